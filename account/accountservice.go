@@ -1,6 +1,7 @@
 package account
 
 import (
+	"path/filepath"
 	"sync"
 
 	"github.com/aergoio/aergo-actor/actor"
@@ -36,7 +37,7 @@ func NewAccountService(cfg *cfg.Config, sdb *state.ChainStateDB) *AccountService
 }
 
 func (as *AccountService) BeforeStart() {
-	as.ks = key.NewStore(as.cfg.DataDir, as.cfg.Account.UnlockTimeout)
+	as.ks = newKeyStore(as.cfg)
 
 	as.accounts = []*types.Account{}
 	addresses, err := as.ks.GetAddresses()
@@ -48,6 +49,20 @@ func (as *AccountService) BeforeStart() {
 	}
 }
 
+// newKeyStore opens the key store of the backend selected in the config.
+func newKeyStore(cfg *cfg.Config) *key.Store {
+	switch cfg.Account.Keystore {
+	case "file":
+		dir := cfg.Account.KeystorePath
+		if dir == "" {
+			dir = filepath.Join(cfg.DataDir, "keystore")
+		}
+		return key.NewFileStore(dir, cfg.Account.UnlockTimeout)
+	default:
+		return key.NewStore(cfg.DataDir, cfg.Account.UnlockTimeout)
+	}
+}
+
 func (as *AccountService) AfterStart() {}
 
 func (as *AccountService) BeforeStop() {
@@ -113,6 +128,37 @@ func (as *AccountService) Receive(context actor.Context) {
 	case *message.ExportAccount:
 		wif, err := as.exportAccount(msg.Account.Address, msg.Pass)
 		context.Respond(&message.ExportAccountRsp{Wif: wif, Err: err})
+	case *message.CreateHDWallet:
+		mnemonic, err := as.ks.CreateHDWallet(msg.Path, msg.Passphrase)
+		rsp := &message.HDWalletRsp{Mnemonic: mnemonic, Err: err}
+		if err == nil {
+			rsp.Path, rsp.Err = as.ks.GetHDPath()
+		}
+		context.Respond(rsp)
+	case *message.ImportHDWallet:
+		err := as.ks.ImportHDWallet(msg.Mnemonic, msg.Path, msg.Passphrase)
+		rsp := &message.HDWalletRsp{Err: err}
+		if err == nil {
+			rsp.Path, rsp.Err = as.ks.GetHDPath()
+		}
+		context.Respond(rsp)
+	case *message.DeriveHDAccount:
+		account, err := as.deriveHDAccount(msg.Index, msg.Passphrase)
+		context.Respond(&message.AccountRsp{Account: account, Err: err})
+	case *message.GetHDAccounts:
+		addresses, err := as.ks.GetHDAddresses()
+		accounts := make([]*types.Account, 0, len(addresses))
+		for _, v := range addresses {
+			accounts = append(accounts, &types.Account{Address: v})
+		}
+		context.Respond(&message.GetHDAccountsRsp{Accounts: &types.AccountList{Accounts: accounts}, Err: err})
+	case *message.UnlockHDAccount:
+		addr, err := as.ks.UnlockHD(msg.Index, msg.Passphrase)
+		var account *types.Account
+		if err == nil {
+			account = &types.Account{Address: addr}
+		}
+		context.Respond(&message.AccountRsp{Account: account, Err: err})
 	case *message.SignTx:
 		var err error
 		actualAddress := msg.Tx.GetBody().GetAccount()
@@ -174,6 +220,26 @@ func (as *AccountService) importAccount(wif []byte, old string, new string) (*ty
 	return account, nil
 }
 
+func (as *AccountService) deriveHDAccount(index uint32, passphrase string) (*types.Account, error) {
+	address, err := as.ks.DeriveHDKey(index, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	// the derived accounts are also saved in the key store
+	addresses, err := as.ks.GetAddresses()
+	if err != nil {
+		return nil, err
+	}
+	accounts := make([]*types.Account, 0, len(addresses))
+	for _, v := range addresses {
+		accounts = append(accounts, &types.Account{Address: v})
+	}
+	as.accountLock.Lock()
+	as.accounts = accounts
+	as.accountLock.Unlock()
+	return &types.Account{Address: address}, nil
+}
+
 func (as *AccountService) exportAccount(address []byte, pass string) ([]byte, error) {
 	wif, err := as.ks.ExportKey(address, pass)
 	if err != nil {
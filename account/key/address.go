@@ -11,8 +11,6 @@ import (
 
 type Address = []byte
 
-var addresses = []byte("ADDRESSES")
-
 func GenerateAddress(pubkey *ecdsa.PublicKey) []byte {
 	if pubkey == nil {
 		return nil
@@ -38,19 +36,12 @@ func (ks *Store) SaveAddress(addr Address) error {
 	ks.RWMutex.Lock()
 	defer ks.RWMutex.Unlock()
 
-	addrs := append(ks.storage.Get(addresses), addr...)
-	ks.storage.Set(addresses, addrs)
-	return nil
+	return ks.storage.AddAddress(addr)
 }
 
 func (ks *Store) GetAddresses() ([]Address, error) {
 	ks.RWMutex.RLock()
 	defer ks.RWMutex.RUnlock()
 
-	b := ks.storage.Get(addresses)
-	var ret []Address
-	for i := 0; i < len(b); i += types.AddressLength {
-		ret = append(ret, b[i:i+types.AddressLength])
-	}
-	return ret, nil
+	return ks.storage.Addresses()
 }
@@ -0,0 +1,319 @@
+package key
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/aergoio/aergo/types"
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/tyler-smith/go-bip39"
+)
+
+const (
+	// AergoCoinType is the BIP44 coin type registered for Aergo in SLIP-0044.
+	AergoCoinType = 441
+	// HardenedKeyStart is the first index of the hardened child keys.
+	HardenedKeyStart = 0x80000000
+)
+
+// DefaultHDPath is the BIP44 path of the external accounts of the first
+// Aergo account. The derivation index is appended to it.
+var DefaultHDPath = "m/44'/" + strconv.Itoa(AergoCoinType) + "'/0'/0"
+
+var (
+	hdWalletMeta = "HDWALLET"
+
+	ErrHDWalletNotFound = errors.New("hd wallet does not exist")
+	ErrHDWalletExist    = errors.New("hd wallet already exists")
+	ErrInvalidMnemonic  = errors.New("invalid mnemonic")
+	ErrInvalidHDPath    = errors.New("invalid hd path")
+)
+
+// hdWallet is the HD wallet of the key store. Only the mnemonic is secret and
+// it is encrypted with the passphrase as the key of a key file. Addresses are
+// the derived accounts in the order of their derivation index.
+type hdWallet struct {
+	Path   string         `json:"path"`
+	Cipher *keyFileCipher `json:"cipher,omitempty"`
+	Kdf    *keyFileKdf    `json:"kdf,omitempty"`
+	// Salt and Mnemonic are the mnemonic encrypted with the salted hash of
+	// the passphrase, which is only read from the older HD wallets.
+	Salt      []byte    `json:"salt,omitempty"`
+	Mnemonic  []byte    `json:"mnemonic,omitempty"`
+	Addresses []Address `json:"addresses"`
+}
+
+// setMnemonic encrypts mnemonic with pass. The scrypt parameters n and p
+// decide the cost of the key derivation.
+func (w *hdWallet) setMnemonic(mnemonic, pass string, n, p int) error {
+	c, kdf, err := scryptEncrypt([]byte(mnemonic), pass, n, p)
+	if err != nil {
+		return err
+	}
+	w.Cipher, w.Kdf = c, kdf
+	w.Salt, w.Mnemonic = nil, nil
+	return nil
+}
+
+// getMnemonic returns the mnemonic decrypted with pass.
+func (w *hdWallet) getMnemonic(pass string) (string, error) {
+	var (
+		mnemonic []byte
+		err      error
+	)
+	if w.Cipher != nil && w.Kdf != nil {
+		mnemonic, err = scryptDecrypt(w.Cipher, w.Kdf, pass)
+	} else {
+		mnemonic, err = decrypt(w.Salt, hashBytes(w.Salt, []byte(pass)), w.Mnemonic)
+	}
+	if err != nil {
+		return "", types.ErrWrongAddressOrPassWord
+	}
+	return string(mnemonic), nil
+}
+
+// scryptCost returns the scrypt parameters to encrypt the mnemonic in s, which
+// are the ones of the key files if s keeps them.
+func scryptCost(s Storage) (n, p int) {
+	if fs, ok := s.(*fileStorage); ok {
+		return fs.scryptN, fs.scryptP
+	}
+	return StandardScryptN, StandardScryptP
+}
+
+// CreateHDWallet generates a mnemonic and makes the HD wallet of the key
+// store from it. The mnemonic is returned to be backed up.
+func (ks *Store) CreateHDWallet(hdPath, pass string) (string, error) {
+	entropy, err := bip39.NewEntropy(256)
+	if err != nil {
+		return "", err
+	}
+	mnemonic, err := bip39.NewMnemonic(entropy)
+	if err != nil {
+		return "", err
+	}
+	if err := ks.ImportHDWallet(mnemonic, hdPath, pass); err != nil {
+		return "", err
+	}
+	return mnemonic, nil
+}
+
+// ImportHDWallet makes the HD wallet of the key store from mnemonic. The
+// accounts are derived along hdPath, or DefaultHDPath if it is empty.
+func (ks *Store) ImportHDWallet(mnemonic, hdPath, pass string) error {
+	if !bip39.IsMnemonicValid(mnemonic) {
+		return ErrInvalidMnemonic
+	}
+	if hdPath == "" {
+		hdPath = DefaultHDPath
+	}
+	if _, err := ParseHDPath(hdPath); err != nil {
+		return err
+	}
+
+	ks.hdLock.Lock()
+	defer ks.hdLock.Unlock()
+
+	if w, err := ks.getHDWallet(); err != nil {
+		return err
+	} else if w != nil {
+		return ErrHDWalletExist
+	}
+	w := &hdWallet{Path: hdPath}
+	n, p := scryptCost(ks.storage)
+	if err := w.setMnemonic(mnemonic, pass, n, p); err != nil {
+		return err
+	}
+	return ks.setHDWallet(w)
+}
+
+// GetHDPath returns the derivation path of the HD wallet.
+func (ks *Store) GetHDPath() (string, error) {
+	w, err := ks.getHDWallet()
+	if err != nil {
+		return "", err
+	}
+	if w == nil {
+		return "", ErrHDWalletNotFound
+	}
+	return w.Path, nil
+}
+
+// GetHDAddresses returns the derived accounts in the order of their index.
+func (ks *Store) GetHDAddresses() ([]Address, error) {
+	w, err := ks.getHDWallet()
+	if err != nil {
+		return nil, err
+	}
+	if w == nil {
+		return nil, ErrHDWalletNotFound
+	}
+	return w.Addresses, nil
+}
+
+// DeriveHDKey derives the accounts of the HD wallet up to index and adds them
+// to the key store, encrypted with pass. It returns the account at index.
+func (ks *Store) DeriveHDKey(index uint32, pass string) (Address, error) {
+	if index >= HardenedKeyStart {
+		return nil, ErrInvalidHDPath
+	}
+
+	ks.hdLock.Lock()
+	defer ks.hdLock.Unlock()
+
+	w, err := ks.getHDWallet()
+	if err != nil {
+		return nil, err
+	}
+	if w == nil {
+		return nil, ErrHDWalletNotFound
+	}
+	if int(index) < len(w.Addresses) {
+		return w.Addresses[index], nil
+	}
+	mnemonic, err := w.getMnemonic(pass)
+	if err != nil {
+		return nil, err
+	}
+	path, err := ParseHDPath(w.Path)
+	if err != nil {
+		return nil, err
+	}
+	seed := bip39.NewSeed(mnemonic, "")
+	known, err := ks.GetAddresses()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := uint32(len(w.Addresses)); i <= index; i++ {
+		privkey, err := DeriveKey(seed, append(path, i))
+		if err != nil {
+			return nil, err
+		}
+		address, err := ks.addKey(privkey, pass)
+		if err != nil {
+			return nil, err
+		}
+		if !containsAddress(known, address) {
+			if err := ks.SaveAddress(address); err != nil {
+				return nil, err
+			}
+		}
+		w.Addresses = append(w.Addresses, address)
+	}
+	if err := ks.setHDWallet(w); err != nil {
+		return nil, err
+	}
+	return w.Addresses[index], nil
+}
+
+// UnlockHD unlocks the derived account at index.
+func (ks *Store) UnlockHD(index uint32, pass string) (Address, error) {
+	addresses, err := ks.GetHDAddresses()
+	if err != nil {
+		return nil, err
+	}
+	if int(index) >= len(addresses) {
+		return nil, errors.New("account is not derived at the index")
+	}
+	return ks.Unlock(addresses[index], pass)
+}
+
+func (ks *Store) getHDWallet() (*hdWallet, error) {
+	data := ks.storage.GetMeta(hdWalletMeta)
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var w hdWallet
+	if err := json.Unmarshal(data, &w); err != nil {
+		return nil, err
+	}
+	return &w, nil
+}
+
+func (ks *Store) setHDWallet(w *hdWallet) error {
+	data, err := json.Marshal(w)
+	if err != nil {
+		return err
+	}
+	return ks.storage.SetMeta(hdWalletMeta, data)
+}
+
+func containsAddress(addresses []Address, addr Address) bool {
+	for _, a := range addresses {
+		if string(a) == string(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseHDPath parses a BIP32 path such as m/44'/441'/0'/0 into the indexes
+// of the child keys.
+func ParseHDPath(hdPath string) ([]uint32, error) {
+	elems := strings.Split(strings.TrimSpace(hdPath), "/")
+	if len(elems) == 0 || elems[0] != "m" {
+		return nil, ErrInvalidHDPath
+	}
+	var path []uint32
+	for _, e := range elems[1:] {
+		hardened := strings.HasSuffix(e, "'")
+		n, err := strconv.ParseUint(strings.TrimSuffix(e, "'"), 10, 32)
+		if err != nil || n >= HardenedKeyStart {
+			return nil, ErrInvalidHDPath
+		}
+		if hardened {
+			n += HardenedKeyStart
+		}
+		path = append(path, uint32(n))
+	}
+	return path, nil
+}
+
+// DeriveKey derives the private key along path from the BIP32 master seed.
+func DeriveKey(seed []byte, path []uint32) (*btcec.PrivateKey, error) {
+	mac := hmac.New(sha512.New, []byte("Bitcoin seed"))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+	key, chainCode := sum[:32], sum[32:]
+
+	n := btcec.S256().N
+	for _, i := range path {
+		var data []byte
+		if i >= HardenedKeyStart {
+			data = append([]byte{0}, key...)
+		} else {
+			_, pub := btcec.PrivKeyFromBytes(btcec.S256(), key)
+			data = pub.SerializeCompressed()
+		}
+		var index [4]byte
+		binary.BigEndian.PutUint32(index[:], i)
+		data = append(data, index[:]...)
+
+		mac := hmac.New(sha512.New, chainCode)
+		mac.Write(data)
+		sum := mac.Sum(nil)
+
+		il := new(big.Int).SetBytes(sum[:32])
+		if il.Cmp(n) >= 0 {
+			return nil, ErrInvalidHDPath
+		}
+		child := il.Add(il, new(big.Int).SetBytes(key))
+		child.Mod(child, n)
+		if child.Sign() == 0 {
+			return nil, ErrInvalidHDPath
+		}
+		b := child.Bytes()
+		key = make([]byte, 32)
+		copy(key[32-len(b):], b)
+		chainCode = sum[32:]
+	}
+	privkey, _ := btcec.PrivKeyFromBytes(btcec.S256(), key)
+	return privkey, nil
+}
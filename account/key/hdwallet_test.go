@@ -0,0 +1,81 @@
+package key
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/aergoio/aergo/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeriveKey(t *testing.T) {
+	// BIP32 test vector 1
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	for _, tc := range []struct {
+		path string
+		key  string
+	}{
+		{"m/0'", "edb2e14f9ee77d26dd93b4ecede8d16ed408ce149b6cd80b0715a2d911a0afea"},
+		{"m/0'/1", "3c6cb8d0f6a264c91ea8b5030fadaa8e538b020f0a387421a12de9319dc93368"},
+		{"m/0'/1/2'", "cbce0d719ecf7431d88e6a89fa1483e02e35092af60c042b1df2ff59fa424dca"},
+	} {
+		path, err := ParseHDPath(tc.path)
+		assert.NoError(t, err)
+		key, err := DeriveKey(seed, path)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.key, hex.EncodeToString(key.Serialize()), tc.path)
+	}
+
+	for _, p := range []string{"", "0/1", "m/x", "m/2147483648"} {
+		_, err := ParseHDPath(p)
+		assert.Equal(t, ErrInvalidHDPath, err, p)
+	}
+}
+
+func TestHDWallet(t *testing.T) {
+	initTest()
+	defer deinitTest()
+	const pass = "hdpass"
+
+	_, err := ks.DeriveHDKey(0, pass)
+	assert.Equal(t, ErrHDWalletNotFound, err)
+
+	mnemonic, err := ks.CreateHDWallet("", pass)
+	assert.NoError(t, err)
+	path, err := ks.GetHDPath()
+	assert.NoError(t, err)
+	assert.Equal(t, "m/44'/441'/0'/0", path)
+	assert.Equal(t, ErrHDWalletExist, ks.ImportHDWallet(mnemonic, "", pass))
+	w, err := ks.getHDWallet()
+	assert.NoError(t, err)
+	assert.Nil(t, w.Mnemonic, "mnemonic is encrypted as a key file")
+	assert.Equal(t, StandardScryptN, w.Kdf.Params.N)
+
+	_, err = ks.DeriveHDKey(0, "wrong")
+	assert.Equal(t, types.ErrWrongAddressOrPassWord, err)
+
+	addr2, err := ks.DeriveHDKey(2, pass)
+	assert.NoError(t, err)
+	hdAddrs, err := ks.GetHDAddresses()
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(hdAddrs))
+	assert.Equal(t, addr2, hdAddrs[2])
+	addrs, err := ks.GetAddresses()
+	assert.NoError(t, err)
+	assert.Equal(t, hdAddrs, addrs)
+
+	unlocked, err := ks.UnlockHD(1, pass)
+	assert.NoError(t, err)
+	assert.Equal(t, hdAddrs[1], unlocked)
+	_, err = ks.UnlockHD(3, pass)
+	assert.Error(t, err)
+
+	// The same accounts are derived from the backed up mnemonic.
+	other := NewStore(testDir+"/other", 0)
+	defer other.CloseStore()
+	assert.Equal(t, ErrInvalidMnemonic, other.ImportHDWallet("abandon abandon", "", pass))
+	assert.NoError(t, other.ImportHDWallet(mnemonic, "", pass))
+	restored, err := other.DeriveHDKey(2, pass)
+	assert.NoError(t, err)
+	assert.Equal(t, addr2, restored)
+}
@@ -0,0 +1,320 @@
+package key
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/aergoio/aergo/types"
+	"github.com/btcsuite/btcd/btcec"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	keyFileVersion   = "1"
+	keyFileExt       = ".json"
+	keyFileCipherAlg = "aes-128-ctr"
+	keyFileKdfAlg    = "scrypt"
+
+	// StandardScryptN and StandardScryptP are the scrypt parameters of the
+	// key files, which take about a second to derive the key.
+	StandardScryptN = 1 << 18
+	StandardScryptP = 1
+
+	scryptR     = 8
+	scryptDKLen = 32
+
+	// the upper limits of the scrypt parameters of a key file to decrypt,
+	// which bound the memory (128*N*R bytes) and the time of the derivation
+	maxScryptN = 1 << 20
+	maxScryptR = scryptR
+	maxScryptP = 4
+)
+
+var (
+	ErrUnsupportedKeyFile = errors.New("unsupported key file")
+	ErrKeyFileAddress     = errors.New("address of key file does not match its key")
+)
+
+// keyFile is the self-describing JSON encoding of an encrypted key.
+type keyFile struct {
+	Address string        `json:"aergo_address"`
+	Version string        `json:"ks_version"`
+	Cipher  keyFileCipher `json:"cipher"`
+	Kdf     keyFileKdf    `json:"kdf"`
+}
+
+type keyFileCipher struct {
+	Algorithm string `json:"algorithm"`
+	Params    struct {
+		IV string `json:"iv"`
+	} `json:"params"`
+	Ciphertext string `json:"ciphertext"`
+}
+
+type keyFileKdf struct {
+	Algorithm string       `json:"algorithm"`
+	Params    scryptParams `json:"params"`
+	Mac       string       `json:"mac"`
+}
+
+type scryptParams struct {
+	DKLen int    `json:"dklen"`
+	N     int    `json:"n"`
+	P     int    `json:"p"`
+	R     int    `json:"r"`
+	Salt  string `json:"salt"`
+}
+
+// EncryptKeyFile encodes key of addr into a key file encrypted with pass.
+// The scrypt parameters n and p decide the cost of the key derivation.
+func EncryptKeyFile(addr Address, key []byte, pass string, n, p int) ([]byte, error) {
+	c, kdf, err := scryptEncrypt(key, pass, n, p)
+	if err != nil {
+		return nil, err
+	}
+	kf := &keyFile{Address: types.EncodeAddress(addr), Version: keyFileVersion, Cipher: *c, Kdf: *kdf}
+	return json.MarshalIndent(kf, "", "  ")
+}
+
+// DecryptKeyFile returns the address and the key of the key file decrypted
+// with pass.
+func DecryptKeyFile(data []byte, pass string) (Address, []byte, error) {
+	kf, err := parseKeyFile(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	addr, err := types.DecodeAddress(kf.Address)
+	if err != nil {
+		return nil, nil, err
+	}
+	key, err := scryptDecrypt(&kf.Cipher, &kf.Kdf, pass)
+	if err != nil {
+		return nil, nil, err
+	}
+	_, pubkey := btcec.PrivKeyFromBytes(btcec.S256(), key)
+	if subtle.ConstantTimeCompare(GenerateAddress(pubkey.ToECDSA()), addr) != 1 {
+		return nil, nil, ErrKeyFileAddress
+	}
+	return addr, key, nil
+}
+
+func parseKeyFile(data []byte) (*keyFile, error) {
+	var kf keyFile
+	if err := json.Unmarshal(data, &kf); err != nil {
+		return nil, err
+	}
+	if kf.Version != keyFileVersion {
+		return nil, ErrUnsupportedKeyFile
+	}
+	if err := checkScrypt(&kf.Cipher, &kf.Kdf); err != nil {
+		return nil, err
+	}
+	return &kf, nil
+}
+
+// scryptEncrypt encrypts data with the key derived from pass by scrypt, as
+// the key of a key file.
+func scryptEncrypt(data []byte, pass string, n, p int) (*keyFileCipher, *keyFileKdf, error) {
+	salt := make([]byte, 32)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, nil, err
+	}
+	iv := make([]byte, aes.BlockSize)
+	if _, err := rand.Read(iv); err != nil {
+		return nil, nil, err
+	}
+	derived, err := scrypt.Key([]byte(pass), salt, n, scryptR, p, scryptDKLen)
+	if err != nil {
+		return nil, nil, err
+	}
+	ciphertext, err := aesCTR(derived[:16], iv, data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	c := &keyFileCipher{Algorithm: keyFileCipherAlg, Ciphertext: hex.EncodeToString(ciphertext)}
+	c.Params.IV = hex.EncodeToString(iv)
+	kdf := &keyFileKdf{
+		Algorithm: keyFileKdfAlg,
+		Params:    scryptParams{DKLen: scryptDKLen, N: n, P: p, R: scryptR, Salt: hex.EncodeToString(salt)},
+		Mac:       hex.EncodeToString(keyFileMac(derived, ciphertext)),
+	}
+	return c, kdf, nil
+}
+
+// scryptDecrypt returns the data encrypted by scryptEncrypt with pass.
+func scryptDecrypt(c *keyFileCipher, kdf *keyFileKdf, pass string) ([]byte, error) {
+	if err := checkScrypt(c, kdf); err != nil {
+		return nil, err
+	}
+	salt, err := hex.DecodeString(kdf.Params.Salt)
+	if err != nil {
+		return nil, err
+	}
+	iv, err := hex.DecodeString(c.Params.IV)
+	if err != nil {
+		return nil, err
+	}
+	ciphertext, err := hex.DecodeString(c.Ciphertext)
+	if err != nil {
+		return nil, err
+	}
+	mac, err := hex.DecodeString(kdf.Mac)
+	if err != nil {
+		return nil, err
+	}
+
+	params := kdf.Params
+	derived, err := scrypt.Key([]byte(pass), salt, params.N, params.R, params.P, params.DKLen)
+	if err != nil {
+		return nil, err
+	}
+	if subtle.ConstantTimeCompare(keyFileMac(derived, ciphertext), mac) != 1 {
+		return nil, types.ErrWrongAddressOrPassWord
+	}
+	return aesCTR(derived[:16], iv, ciphertext)
+}
+
+// checkScrypt checks the algorithms and the scrypt parameters against the
+// upper limits.
+func checkScrypt(c *keyFileCipher, kdf *keyFileKdf) error {
+	if c.Algorithm != keyFileCipherAlg || kdf.Algorithm != keyFileKdfAlg || kdf.Params.DKLen != scryptDKLen {
+		return ErrUnsupportedKeyFile
+	}
+	if p := kdf.Params; p.N > maxScryptN || p.R > maxScryptR || p.P > maxScryptP {
+		return ErrUnsupportedKeyFile
+	}
+	return nil
+}
+
+func keyFileMac(derived, ciphertext []byte) []byte {
+	return hashBytes(derived[16:32], ciphertext)
+}
+
+func aesCTR(key, iv, in []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]byte, len(in))
+	cipher.NewCTR(block, iv).XORKeyStream(out, in)
+	return out, nil
+}
+
+// fileStorage keeps a key file per key in a directory, named after the
+// address of the key. Other data is kept in files with the .meta extension.
+type fileStorage struct {
+	dir     string
+	scryptN int
+	scryptP int
+}
+
+// NewFileStorage opens the directory dir as the storage of key files.
+func NewFileStorage(dir string) Storage {
+	return &fileStorage{dir: dir, scryptN: StandardScryptN, scryptP: StandardScryptP}
+}
+
+func (s *fileStorage) keyPath(addr Address) string {
+	return filepath.Join(s.dir, types.EncodeAddress(addr)+keyFileExt)
+}
+
+func (s *fileStorage) Save(addr Address, pass string, key []byte) error {
+	data, err := EncryptKeyFile(addr, key, pass, s.scryptN, s.scryptP)
+	if err != nil {
+		return err
+	}
+	return s.write(s.keyPath(addr), data)
+}
+
+func (s *fileStorage) Load(addr Address, pass string) ([]byte, error) {
+	data, err := ioutil.ReadFile(s.keyPath(addr))
+	if err != nil {
+		return nil, types.ErrWrongAddressOrPassWord
+	}
+	fileAddr, key, err := DecryptKeyFile(data, pass)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(fileAddr, addr) {
+		return nil, ErrKeyFileAddress
+	}
+	return key, nil
+}
+
+// AddAddress does nothing since the accounts are the key files themselves.
+func (s *fileStorage) AddAddress(addr Address) error {
+	return nil
+}
+
+func (s *fileStorage) Addresses() ([]Address, error) {
+	files, err := ioutil.ReadDir(s.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var ret []Address
+	for _, f := range files {
+		if f.IsDir() || !strings.HasSuffix(f.Name(), keyFileExt) {
+			continue
+		}
+		data, err := ioutil.ReadFile(filepath.Join(s.dir, f.Name()))
+		if err != nil {
+			return nil, err
+		}
+		kf, err := parseKeyFile(data)
+		if err != nil {
+			continue
+		}
+		addr, err := types.DecodeAddress(kf.Address)
+		if err != nil {
+			continue
+		}
+		ret = append(ret, addr)
+	}
+	return ret, nil
+}
+
+func (s *fileStorage) GetMeta(name string) []byte {
+	data, _ := ioutil.ReadFile(filepath.Join(s.dir, strings.ToLower(name)+".meta"))
+	return data
+}
+
+func (s *fileStorage) SetMeta(name string, data []byte) error {
+	return s.write(filepath.Join(s.dir, strings.ToLower(name)+".meta"), data)
+}
+
+// write replaces the file at path with data through a temporary file so that
+// a key file is never left half written.
+func (s *fileStorage) write(path string, data []byte) error {
+	if err := os.MkdirAll(s.dir, 0700); err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(s.dir, ".tmp-")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func (s *fileStorage) Close() {}
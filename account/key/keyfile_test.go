@@ -0,0 +1,127 @@
+package key
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/aergoio/aergo/types"
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/stretchr/testify/assert"
+	"github.com/tyler-smith/go-bip39"
+)
+
+// testScryptN keeps the key derivation of the tests fast.
+const testScryptN = 1 << 12
+
+func TestKeyFileEncryptDecrypt(t *testing.T) {
+	privkey, err := btcec.NewPrivateKey(btcec.S256())
+	assert.NoError(t, err)
+	addr := GenerateAddress(privkey.PubKey().ToECDSA())
+
+	data, err := EncryptKeyFile(addr, privkey.D.Bytes(), "pass", testScryptN, 1)
+	assert.NoError(t, err)
+
+	decAddr, decKey, err := DecryptKeyFile(data, "pass")
+	assert.NoError(t, err)
+	assert.Equal(t, addr, decAddr)
+	assert.Equal(t, privkey.D.Bytes(), decKey)
+
+	_, _, err = DecryptKeyFile(data, "wrong")
+	assert.Equal(t, types.ErrWrongAddressOrPassWord, err)
+
+	_, _, err = DecryptKeyFile([]byte(`{"ks_version":"2"}`), "pass")
+	assert.Equal(t, ErrUnsupportedKeyFile, err)
+
+	// too expensive key derivation
+	data, err = EncryptKeyFile(addr, privkey.D.Bytes(), "pass", testScryptN, maxScryptP+1)
+	assert.NoError(t, err)
+	_, _, err = DecryptKeyFile(data, "pass")
+	assert.Equal(t, ErrUnsupportedKeyFile, err)
+
+	// the key of another address
+	other, err := btcec.NewPrivateKey(btcec.S256())
+	assert.NoError(t, err)
+	data, err = EncryptKeyFile(addr, other.D.Bytes(), "pass", testScryptN, 1)
+	assert.NoError(t, err)
+	_, _, err = DecryptKeyFile(data, "pass")
+	assert.Equal(t, ErrKeyFileAddress, err)
+}
+
+func TestFileStore(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "keystore")
+	defer os.RemoveAll(dir)
+	fks := NewStoreWithStorage(&fileStorage{dir: dir, scryptN: testScryptN, scryptP: 1}, 0)
+	defer fks.CloseStore()
+
+	addr, err := fks.CreateKey("pass")
+	assert.NoError(t, err)
+	assert.NoError(t, fks.SaveAddress(addr))
+
+	addrs, err := fks.GetAddresses()
+	assert.NoError(t, err)
+	assert.Equal(t, []Address{addr}, addrs)
+
+	_, err = os.Stat(path.Join(dir, types.EncodeAddress(addr)+keyFileExt))
+	assert.NoError(t, err)
+
+	_, err = fks.Unlock(addr, "wrong")
+	assert.Error(t, err)
+	_, err = fks.Unlock(addr, "pass")
+	assert.NoError(t, err)
+}
+
+func TestMigrate(t *testing.T) {
+	initTest()
+	defer deinitTest()
+
+	addr1, err := ks.CreateKey("pass")
+	assert.NoError(t, err)
+	assert.NoError(t, ks.SaveAddress(addr1))
+	addr2, err := ks.CreateKey("other")
+	assert.NoError(t, err)
+	assert.NoError(t, ks.SaveAddress(addr2))
+	// an HD wallet of the older version
+	mnemonic, err := bip39.NewMnemonic(make([]byte, 32))
+	assert.NoError(t, err)
+	salt := make([]byte, 32)
+	encrypted, err := encrypt(salt, hashBytes(salt, []byte("pass")), []byte(mnemonic))
+	assert.NoError(t, err)
+	assert.NoError(t, ks.setHDWallet(&hdWallet{Path: DefaultHDPath, Salt: salt, Mnemonic: encrypted}))
+	hdAddr, err := ks.DeriveHDKey(0, "pass")
+	assert.NoError(t, err)
+
+	dir, _ := ioutil.TempDir("", "keystore")
+	defer os.RemoveAll(dir)
+	dst := &fileStorage{dir: dir, scryptN: testScryptN, scryptP: 1}
+
+	migrated, skipped, err := Migrate(ks.storage, dst, "pass")
+	assert.NoError(t, err)
+	assert.Equal(t, []Address{addr1, hdAddr}, migrated)
+	assert.Equal(t, []Address{addr2}, skipped)
+
+	fks := NewStoreWithStorage(dst, 0)
+	defer fks.CloseStore()
+	_, err = fks.Unlock(addr1, "pass")
+	assert.NoError(t, err)
+	hdPath, err := fks.GetHDPath()
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultHDPath, hdPath)
+
+	// The mnemonic is encrypted again as a key file.
+	w, err := fks.getHDWallet()
+	assert.NoError(t, err)
+	assert.Nil(t, w.Mnemonic)
+	assert.Equal(t, testScryptN, w.Kdf.Params.N)
+	_, err = w.getMnemonic("other")
+	assert.Equal(t, types.ErrWrongAddressOrPassWord, err)
+	decrypted, err := w.getMnemonic("pass")
+	assert.NoError(t, err)
+	assert.Equal(t, mnemonic, decrypted)
+	derived, err := fks.DeriveHDKey(1, "pass")
+	assert.NoError(t, err)
+	next, err := ks.DeriveHDKey(1, "pass")
+	assert.NoError(t, err)
+	assert.Equal(t, next, derived)
+}
@@ -1,6 +1,7 @@
 package key
 
 import (
+	"bytes"
 	"encoding/binary"
 
 	"github.com/aergoio/aergo/types"
@@ -54,6 +55,9 @@ func VerifyTx(tx *types.Tx) error {
 func VerifyTxWithAddress(tx *types.Tx, address []byte) error {
 	txBody := tx.Body
 	hash := CalculateHashWithoutSign(txBody)
+	if types.IsMultisigSign(txBody.Sign) {
+		return verifyMultisig(hash, txBody.Sign, address)
+	}
 	sign, err := btcec.ParseSignature(txBody.Sign, btcec.S256())
 	if err != nil {
 		return err
@@ -68,6 +72,103 @@ func VerifyTxWithAddress(tx *types.Tx, address []byte) error {
 	return nil
 }
 
+// verifyMultisig checks that the witness belongs to address and carries
+// valid signatures from at least threshold distinct keys.
+func verifyMultisig(hash, witness, address []byte) error {
+	ms, err := types.ParseMultisigSign(witness)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(ms.Config.Address(), address) {
+		return types.ErrSignNotMatch
+	}
+	valid := 0
+	for _, s := range ms.Signatures {
+		sign, err := btcec.ParseSignature(s.Sign, btcec.S256())
+		if err != nil {
+			return err
+		}
+		pubkey, err := btcec.ParsePubKey(ms.Config.Keys[s.Index], btcec.S256())
+		if err != nil {
+			return err
+		}
+		if !sign.Verify(hash, pubkey) {
+			return types.ErrSignNotMatch
+		}
+		valid++
+	}
+	if valid < ms.Config.Threshold {
+		return types.ErrSignNotMatch
+	}
+	return nil
+}
+
+// AddMultisigSign adds the signature of signer, one of the keys of config,
+// to the multisig witness of tx.
+func AddMultisigSign(tx *types.Tx, config *types.MultisigConfig, signer, sign []byte) error {
+	index := config.KeyIndex(signer)
+	if index < 0 {
+		return types.ErrSignNotMatch
+	}
+	ms := &types.MultisigSign{Config: config}
+	if types.IsMultisigSign(tx.Body.Sign) {
+		var err error
+		if ms, err = types.ParseMultisigSign(tx.Body.Sign); err != nil {
+			return err
+		}
+		if !bytes.Equal(ms.Config.Address(), config.Address()) {
+			return types.ErrTxInvalidMultisig
+		}
+	}
+	ms.AddSignature(index, sign)
+	tx.Body.Sign = ms.Serialize()
+	tx.Hash = tx.CalculateTxHash()
+	return nil
+}
+
+// SignMultisigTx partially signs tx with key, one of the keys of config.
+func SignMultisigTx(tx *types.Tx, key *aergokey, config *types.MultisigConfig) error {
+	sign, err := key.Sign(CalculateHashWithoutSign(tx.Body))
+	if err != nil {
+		return err
+	}
+	return AddMultisigSign(tx, config, GenerateAddress(key.PubKey().ToECDSA()), sign.Serialize())
+}
+
+// CombineMultisigTx merges the signatures of the partially signed copies of
+// the same tx.
+func CombineMultisigTx(txs ...*types.Tx) (*types.Tx, error) {
+	if len(txs) == 0 {
+		return nil, types.ErrTxFormatInvalid
+	}
+	hash := CalculateHashWithoutSign(txs[0].Body)
+	var combined *types.MultisigSign
+	for _, tx := range txs {
+		if !bytes.Equal(hash, CalculateHashWithoutSign(tx.Body)) {
+			return nil, types.ErrTxFormatInvalid
+		}
+		ms, err := types.ParseMultisigSign(tx.Body.Sign)
+		if err != nil {
+			return nil, err
+		}
+		if combined == nil {
+			combined = ms
+			continue
+		}
+		if !bytes.Equal(combined.Config.Address(), ms.Config.Address()) {
+			return nil, types.ErrTxInvalidMultisig
+		}
+		for _, s := range ms.Signatures {
+			combined.AddSignature(s.Index, s.Sign)
+		}
+	}
+	body := *txs[0].Body
+	body.Sign = combined.Serialize()
+	tx := &types.Tx{Body: &body}
+	tx.Hash = tx.CalculateTxHash()
+	return tx, nil
+}
+
 //VerifyTx return result to varify sign
 func (ks *Store) VerifyTx(tx *types.Tx) error {
 	return VerifyTx(tx)
@@ -85,5 +186,7 @@ func CalculateHashWithoutSign(txBody *types.TxBody) []byte {
 	h.Write(txBody.GasPrice)
 	binary.Write(h, binary.LittleEndian, txBody.Type)
 	h.Write(txBody.ChainIdHash)
+	txBody.WriteExpiry(h)
+	txBody.WriteGasTip(h)
 	return h.Sum(nil)
 }
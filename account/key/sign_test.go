@@ -0,0 +1,40 @@
+package key
+
+import (
+	"testing"
+
+	"github.com/aergoio/aergo/types"
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultisigTx(t *testing.T) {
+	var keys []*btcec.PrivateKey
+	config := &types.MultisigConfig{Threshold: 2}
+	for i := 0; i < 3; i++ {
+		key, err := btcec.NewPrivateKey(btcec.S256())
+		assert.NoError(t, err, "could not create private key")
+		keys = append(keys, key)
+		config.Keys = append(config.Keys, GenerateAddress(&key.PublicKey))
+	}
+	addr := config.Address()
+	assert.True(t, types.IsMultisigAddress(addr))
+
+	body := &types.TxBody{Nonce: 1, Account: addr, Recipient: config.Keys[0], Amount: []byte{1}, Type: types.TxType_TRANSFER}
+	tx1 := &types.Tx{Body: body}
+	assert.NoError(t, SignMultisigTx(tx1, keys[0], config))
+	assert.Equal(t, types.ErrSignNotMatch, VerifyTx(tx1), "threshold is not reached")
+
+	body2 := *body
+	tx2 := &types.Tx{Body: &body2}
+	assert.NoError(t, SignMultisigTx(tx2, keys[2], config))
+
+	tx, err := CombineMultisigTx(tx1, tx2)
+	assert.NoError(t, err)
+	assert.NoError(t, VerifyTx(tx))
+	assert.NoError(t, types.NewTransaction(tx).Validate(nil, false))
+
+	other, _ := btcec.NewPrivateKey(btcec.S256())
+	assert.Error(t, SignMultisigTx(tx, other, config), "not a key of the config")
+	assert.Equal(t, types.ErrSignNotMatch, VerifyTxWithAddress(tx, config.Keys[1]))
+}
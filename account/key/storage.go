@@ -0,0 +1,141 @@
+package key
+
+import (
+	"encoding/json"
+
+	"github.com/aergoio/aergo-lib/db"
+	"github.com/aergoio/aergo/types"
+)
+
+// Storage is the backend of the key store, which keeps the keys encrypted
+// with their passphrase.
+type Storage interface {
+	// Save encrypts key with pass and keeps it for addr.
+	Save(addr Address, pass string, key []byte) error
+	// Load returns the key of addr decrypted with pass.
+	Load(addr Address, pass string) ([]byte, error)
+	// AddAddress adds addr to the list of the accounts.
+	AddAddress(addr Address) error
+	// Addresses returns the list of the accounts.
+	Addresses() ([]Address, error)
+	// GetMeta returns the data stored under name, such as the HD wallet.
+	GetMeta(name string) []byte
+	// SetMeta stores data under name.
+	SetMeta(name string, data []byte) error
+	Close()
+}
+
+var addresses = []byte("ADDRESSES")
+
+// levelDBStorage keeps the keys in a LevelDB. A key is indexed by the hash of
+// its address and passphrase, so the same key may be saved with several
+// passphrases.
+type levelDBStorage struct {
+	db db.DB
+}
+
+// NewLevelDBStorage opens the LevelDB storage at dbPath.
+func NewLevelDBStorage(dbPath string) Storage {
+	return &levelDBStorage{db: db.NewDB(db.LevelImpl, dbPath)}
+}
+
+func (s *levelDBStorage) Save(addr Address, pass string, key []byte) error {
+	encryptkey := hashBytes(addr, []byte(pass))
+	encrypted, err := encrypt(addr, encryptkey, key)
+	if err != nil {
+		return err
+	}
+	s.db.Set(hashBytes(addr, encryptkey), encrypted)
+	return nil
+}
+
+func (s *levelDBStorage) Load(addr Address, pass string) ([]byte, error) {
+	encryptkey := hashBytes(addr, []byte(pass))
+	key := s.db.Get(hashBytes(addr, encryptkey))
+	if cap(key) == 0 {
+		return nil, types.ErrWrongAddressOrPassWord
+	}
+	return decrypt(addr, encryptkey, key)
+}
+
+func (s *levelDBStorage) AddAddress(addr Address) error {
+	s.db.Set(addresses, append(s.db.Get(addresses), addr...))
+	return nil
+}
+
+func (s *levelDBStorage) Addresses() ([]Address, error) {
+	b := s.db.Get(addresses)
+	var ret []Address
+	for i := 0; i < len(b); i += types.AddressLength {
+		ret = append(ret, b[i:i+types.AddressLength])
+	}
+	return ret, nil
+}
+
+func (s *levelDBStorage) GetMeta(name string) []byte {
+	return s.db.Get([]byte(name))
+}
+
+func (s *levelDBStorage) SetMeta(name string, data []byte) error {
+	s.db.Set([]byte(name), data)
+	return nil
+}
+
+func (s *levelDBStorage) Close() {
+	s.db.Close()
+}
+
+// Migrate copies the keys of src which are encrypted with pass into dst, and
+// the HD wallet if its mnemonic is encrypted with pass. Keys of src saved
+// with another passphrase are returned as skipped. Everything is encrypted
+// again as dst does.
+func Migrate(src, dst Storage, pass string) (migrated, skipped []Address, err error) {
+	addrs, err := src.Addresses()
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, addr := range addrs {
+		key, err := src.Load(addr, pass)
+		if err != nil {
+			skipped = append(skipped, addr)
+			continue
+		}
+		if err := dst.Save(addr, pass, key); err != nil {
+			return migrated, skipped, err
+		}
+		if err := dst.AddAddress(addr); err != nil {
+			return migrated, skipped, err
+		}
+		migrated = append(migrated, addr)
+	}
+	if len(dst.GetMeta(hdWalletMeta)) == 0 {
+		if err := migrateHDWallet(src, dst, pass); err != nil {
+			return migrated, skipped, err
+		}
+	}
+	return migrated, skipped, nil
+}
+
+func migrateHDWallet(src, dst Storage, pass string) error {
+	data := src.GetMeta(hdWalletMeta)
+	if len(data) == 0 {
+		return nil
+	}
+	var w hdWallet
+	if err := json.Unmarshal(data, &w); err != nil {
+		return err
+	}
+	mnemonic, err := w.getMnemonic(pass)
+	if err != nil {
+		// saved with another passphrase
+		return nil
+	}
+	n, p := scryptCost(dst)
+	if err := w.setMnemonic(mnemonic, pass, n, p); err != nil {
+		return err
+	}
+	if data, err = json.Marshal(&w); err != nil {
+		return err
+	}
+	return dst.SetMeta(hdWalletMeta, data)
+}
@@ -9,7 +9,6 @@ import (
 	"sync"
 	"time"
 
-	"github.com/aergoio/aergo-lib/db"
 	"github.com/aergoio/aergo/types"
 	"github.com/btcsuite/btcd/btcec"
 	sha256 "github.com/minio/sha256-simd"
@@ -28,18 +27,31 @@ type Store struct {
 	timeout      time.Duration
 	unlocked     map[string]*keyPair
 	unlockedLock *sync.Mutex
-	storage      db.DB
+	storage      Storage
+	hdLock       sync.Mutex
 }
 
-// NewStore make new instance of keystore
+// NewStore make new instance of keystore which keeps the keys in the LevelDB
+// under storePath
 func NewStore(storePath string, unlockTimeout uint) *Store {
 	const dbName = "account"
 	dbPath := path.Join(storePath, dbName)
+	return NewStoreWithStorage(NewLevelDBStorage(dbPath), unlockTimeout)
+}
+
+// NewFileStore make new instance of keystore which keeps a key file per key in
+// the directory dir
+func NewFileStore(dir string, unlockTimeout uint) *Store {
+	return NewStoreWithStorage(NewFileStorage(dir), unlockTimeout)
+}
+
+// NewStoreWithStorage make new instance of keystore on storage
+func NewStoreWithStorage(storage Storage, unlockTimeout uint) *Store {
 	return &Store{
 		timeout:      time.Duration(unlockTimeout) * time.Second,
 		unlocked:     map[string]*keyPair{},
 		unlockedLock: &sync.Mutex{},
-		storage:      db.NewDB(db.LevelImpl, dbPath),
+		storage:      storage,
 	}
 }
 func (ks *Store) CloseStore() {
@@ -149,25 +161,27 @@ func (ks *Store) Lock(addr Address, pass string) (Address, error) {
 	return addr, nil
 }
 
-func (ks *Store) getKey(address []byte, pass string) ([]byte, error) {
-	encryptkey := hashBytes(address, []byte(pass))
-	key := ks.storage.Get(hashBytes(address, encryptkey))
-	if cap(key) == 0 {
-		return nil, types.ErrWrongAddressOrPassWord
+// GetPrivKey returns the private key of addr which is decrypted with pass.
+func (ks *Store) GetPrivKey(addr Address, pass string) (*btcec.PrivateKey, error) {
+	key, err := ks.getKey(addr, pass)
+	if key == nil {
+		return nil, err
 	}
-	return decrypt(address, encryptkey, key)
+	pk, _ := btcec.PrivKeyFromBytes(btcec.S256(), key)
+	return pk, nil
+}
+
+func (ks *Store) getKey(address []byte, pass string) ([]byte, error) {
+	return ks.storage.Load(address, pass)
 }
 
 func (ks *Store) addKey(key *btcec.PrivateKey, pass string) (Address, error) {
 	//gen new address
 	address := GenerateAddress(&key.PublicKey)
 	//save pass/address/key
-	encryptkey := hashBytes(address, []byte(pass))
-	encrypted, err := encrypt(address, encryptkey, key.Serialize())
-	if err != nil {
+	if err := ks.storage.Save(address, pass, key.Serialize()); err != nil {
 		return nil, err
 	}
-	ks.storage.Set(hashBytes(address, encryptkey), encrypted)
 	return address, nil
 }
 
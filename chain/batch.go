@@ -0,0 +1,174 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package chain
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+
+	"github.com/aergoio/aergo/consensus"
+	"github.com/aergoio/aergo/contract"
+	"github.com/aergoio/aergo/contract/name"
+	"github.com/aergoio/aergo/state"
+	"github.com/aergoio/aergo/types"
+)
+
+const (
+	batchOpSuccess  = "SUCCESS"
+	batchOpFailed   = "ERROR"
+	batchOpReverted = "REVERTED"
+	batchOpSkipped  = "SKIPPED"
+)
+
+// batchError is the runtime error of a batch tx. Its message is the result of
+// every operation, so that the receipt tells which one failed.
+type batchError struct {
+	results []*types.BatchOpResult
+}
+
+func (e *batchError) Error() string {
+	b, _ := json.Marshal(e.results)
+	return string(b)
+}
+
+func (e *batchError) Runtime() bool {
+	return e != nil
+}
+
+// batchOpError is the runtime error of an operation which is not executed by
+// the VM.
+type batchOpError struct {
+	error
+}
+
+func (e *batchOpError) Runtime() bool {
+	return e != nil
+}
+
+// executeBatchTx executes the operations of a batch tx in order. If one of
+// them fails, the changes of all of them are undone.
+func executeBatchTx(ccc consensus.ChainConsensusCluster, cdb contract.ChainAccessor, bs *state.BlockState,
+	tx types.Transaction, sender *state.V, bi *types.BlockHeaderInfo, preLoadService int) (string, []*types.Event, *big.Int, error) {
+	usedFee := new(big.Int)
+	if !bi.IsFeatureActive(types.FeatureBatchTx) {
+		return "", nil, usedFee, types.ErrTxInvalidType
+	}
+	txBody := tx.GetBody()
+	ops, err := types.ParseBatch(txBody.GetPayload())
+	if err != nil {
+		return "", nil, usedFee, err
+	}
+
+	snapshot := bs.Snapshot()
+	contracts := bs.SnapshotContracts()
+	sqlBatch, err := contract.BeginSQLBatch()
+	if err != nil {
+		return "", nil, usedFee, err
+	}
+	rollback := func() error {
+		if err := sqlBatch.Rollback(); err != nil {
+			return err
+		}
+		if err := bs.RollbackContracts(contracts); err != nil {
+			return err
+		}
+		return bs.Rollback(snapshot)
+	}
+
+	var events []*types.Event
+	results := make([]*types.BatchOpResult, len(ops))
+	for i, op := range ops {
+		var (
+			rv     string
+			evs    []*types.Event
+			fee    *big.Int
+			opBody = op.Body(txBody)
+		)
+		// the operations share the gas limit of the batch
+		gasUsed := contract.GasUsed(usedFee, bs.GasPrice, types.TxType_CALL, bi.Version)
+		if gasLimit := txBody.GetGasLimit(); gasLimit != 0 && gasUsed >= gasLimit {
+			err = &batchOpError{types.ErrNotEnoughGas}
+		} else {
+			if gasLimit != 0 {
+				opBody.GasLimit = gasLimit - gasUsed
+			}
+			rv, evs, fee, err = executeBatchOp(ccc, cdb, bs, tx, opBody, sender, bi, preLoadService)
+		}
+		if fee != nil {
+			usedFee.Add(usedFee, fee)
+		}
+		if err != nil {
+			if rErr := rollback(); rErr != nil {
+				return "", nil, usedFee, rErr
+			}
+			if !contract.IsRuntimeError(err) {
+				return "", nil, usedFee, err
+			}
+			for j := range results {
+				switch {
+				case j < i:
+					results[j].Status = batchOpReverted
+				case j == i:
+					results[j] = &types.BatchOpResult{Status: batchOpFailed, Ret: err.Error()}
+				default:
+					results[j] = &types.BatchOpResult{Status: batchOpSkipped}
+				}
+			}
+			return "", nil, usedFee, &batchError{results}
+		}
+		for _, ev := range evs {
+			ev.EventIdx = int32(len(events))
+			events = append(events, ev)
+		}
+		results[i] = &types.BatchOpResult{Status: batchOpSuccess, Ret: rv}
+	}
+	if err := sqlBatch.Release(); err != nil {
+		return "", nil, usedFee, err
+	}
+	rv, err := json.Marshal(results)
+	if err != nil {
+		return "", nil, usedFee, err
+	}
+	return string(rv), events, usedFee, nil
+}
+
+func executeBatchOp(ccc consensus.ChainConsensusCluster, cdb contract.ChainAccessor, bs *state.BlockState,
+	tx types.Transaction, opBody *types.TxBody, sender *state.V, bi *types.BlockHeaderInfo,
+	preLoadService int) (rv string, events []*types.Event, fee *big.Int, err error) {
+	recipient := name.Resolve(bs, opBody.GetRecipient(), bi)
+	if len(recipient) == 0 {
+		return "", nil, nil, types.ErrTxInvalidRecipient
+	}
+	receiver := sender
+	if !bytes.Equal(recipient, sender.ID()) {
+		if receiver, err = bs.GetAccountStateV(recipient); err != nil {
+			return "", nil, nil, err
+		}
+	}
+
+	switch opBody.GetType() {
+	case types.TxType_TRANSFER, types.TxType_CALL:
+		opTx := &types.Tx{Hash: tx.GetHash(), Body: opBody}
+		rv, events, fee, err = contract.Execute(bs, cdb, opTx, sender, receiver, bi, preLoadService, false)
+		if err == nil {
+			sender.SubBalance(fee)
+		}
+	case types.TxType_GOVERNANCE:
+		events, err = executeGovernanceTx(ccc, bs, opBody, sender, receiver, bi)
+	default:
+		err = types.ErrTxInvalidType
+	}
+	if err != nil {
+		return "", events, fee, err
+	}
+	if receiver != sender {
+		if err = receiver.PutState(); err != nil {
+			return "", events, fee, err
+		}
+	}
+	return rv, events, fee, nil
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/aergoio/aergo/consensus"
 	"github.com/aergoio/aergo/internal/common"
 	"github.com/aergoio/aergo/internal/enc"
+	"github.com/aergoio/aergo/pkg/metrics"
 	"github.com/aergoio/aergo/types"
 	"github.com/gogo/protobuf/proto"
 )
@@ -54,8 +55,16 @@ var (
 	raftConfChangeProgressPrefix = []byte("r_ccstatus.")
 
 	hardforkKey = []byte("hardfork")
+	// featureKey is separated from hardforkKey so that the older nodes
+	// unaware of the features keep reading the hardfork record.
+	featureKey = []byte("hardfork.feature")
+
+	evidenceKey = []byte(chainDBName + ".evidence")
 )
 
+// maxEvidences is the maximum number of double sign evidences kept in chain db. the oldest one is dropped first.
+const maxEvidences = 1000
+
 // ErrNoBlock reports there is no such a block with id (hash or block number).
 type ErrNoBlock struct {
 	id interface{}
@@ -363,6 +372,7 @@ func (cdb *ChainDB) setLatest(newBestBlock *types.Block) (oldLatest types.BlockN
 	newLatest := types.BlockNo(newBestBlock.GetHeader().GetBlockNo())
 	cdb.latest.Store(newLatest)
 	cdb.bestBlock.Store(newBestBlock)
+	metrics.BlockHeight.Set(float64(newLatest))
 
 	logger.Debug().Uint64("old", oldLatest).Uint64("new", newLatest).Msg("update latest block")
 
@@ -785,6 +795,8 @@ func (cdb *ChainDB) IsNew() bool {
 	return true
 }
 
+// Hardfork returns the hardfork versions and the feature schedule recorded in
+// the chain database. The features are keyed by config.FeatureKeyPrefix.
 func (cdb *ChainDB) Hardfork() config.HardforkDbConfig {
 	var c config.HardforkDbConfig
 	data := cdb.store.Get(hardforkKey)
@@ -794,6 +806,15 @@ func (cdb *ChainDB) Hardfork() config.HardforkDbConfig {
 	if err := json.Unmarshal(data, &c); err != nil {
 		return nil
 	}
+	if data := cdb.store.Get(featureKey); len(data) != 0 {
+		var features config.HardforkDbConfig
+		if err := json.Unmarshal(data, &features); err != nil {
+			return nil
+		}
+		for k, bno := range features {
+			c[k] = bno
+		}
+	}
 	return c
 }
 
@@ -802,6 +823,51 @@ func (cdb *ChainDB) WriteHardfork(c *config.HardforkConfig) error {
 	if err != nil {
 		return err
 	}
-	cdb.store.Set(hardforkKey, data)
+	features, err := json.Marshal(c.FeatureSchedule())
+	if err != nil {
+		return err
+	}
+	tx := cdb.store.NewTx()
+	tx.Set(hardforkKey, data)
+	tx.Set(featureKey, features)
+	tx.Commit()
 	return nil
 }
+
+// addEvidence stores evidence of double signing. It returns false if the evidence of same producer and slot was
+// already stored.
+func (cdb *ChainDB) addEvidence(evidence *types.DoubleSignEvidence) (bool, error) {
+	list, err := cdb.getEvidences()
+	if err != nil {
+		return false, err
+	}
+	key := evidence.Key()
+	for _, e := range list {
+		if bytes.Equal(e.Key(), key) {
+			return false, nil
+		}
+	}
+	list = append(list, evidence)
+	if len(list) > maxEvidences {
+		list = list[len(list)-maxEvidences:]
+	}
+	data, err := proto.Marshal(&types.DoubleSignEvidenceList{Evidences: list})
+	if err != nil {
+		return false, err
+	}
+	cdb.store.Set(evidenceKey, data)
+	return true, nil
+}
+
+// getEvidences returns stored evidences of double signing, in the order of detection.
+func (cdb *ChainDB) getEvidences() ([]*types.DoubleSignEvidence, error) {
+	data := cdb.store.Get(evidenceKey)
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var list types.DoubleSignEvidenceList
+	if err := proto.Unmarshal(data, &list); err != nil {
+		return nil, err
+	}
+	return list.Evidences, nil
+}
@@ -12,14 +12,17 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"time"
 
 	"github.com/aergoio/aergo/contract/system"
 
 	"github.com/aergoio/aergo/consensus"
 	"github.com/aergoio/aergo/contract"
+	"github.com/aergoio/aergo/contract/multisig"
 	"github.com/aergoio/aergo/contract/name"
 	"github.com/aergoio/aergo/internal/enc"
 	"github.com/aergoio/aergo/message"
+	"github.com/aergoio/aergo/pkg/metrics"
 	"github.com/aergoio/aergo/state"
 	"github.com/aergoio/aergo/types"
 	"github.com/golang/protobuf/proto"
@@ -34,12 +37,15 @@ var (
 	errBlockStale       = errors.New("produced block becomes stale")
 	errBlockInvalidFork = errors.New("invalid fork occured")
 	errBlockTimestamp   = errors.New("invalid timestamp")
+	errBlockBaseFee     = errors.New("invalid base fee")
 
-	InAddBlock      = make(chan struct{}, 1)
-	SendBlockReward = sendRewardCoinbase
+	InAddBlock                    = make(chan struct{}, 1)
+	SendBlockReward BlockRewardFn = func(bState *state.BlockState, coinbaseAccount []byte, _ *types.BlockHeaderInfo) error {
+		return sendRewardCoinbase(bState, coinbaseAccount)
+	}
 )
 
-type BlockRewardFn = func(*state.BlockState, []byte) error
+type BlockRewardFn = func(*state.BlockState, []byte, *types.BlockHeaderInfo) error
 
 type ErrReorg struct {
 	err error
@@ -332,6 +338,7 @@ func (cp *chainProcessor) execute(block *types.Block) error {
 
 	var err error
 
+	begT := time.Now()
 	err = cp.executeBlock(block)
 	if err != nil {
 		logger.Error().Str("error", err.Error()).Str("hash", block.ID()).
@@ -345,6 +352,7 @@ func (cp *chainProcessor) execute(block *types.Block) error {
 	if _, err = cp.connectToChain(block); err != nil {
 		return err
 	}
+	metrics.BlockConnectTime.Observe(time.Since(begT).Seconds())
 
 	cp.notifyBlockByOther(block)
 
@@ -456,6 +464,10 @@ func (cs *ChainService) addBlockInternal(newBlock *types.Block, usedBstate *stat
 		return err, true
 	}
 
+	// double signing is just recorded and doesn't make the block invalid, since it is not possible to tell which one
+	// of the conflicting blocks is right.
+	cs.equivocation.check(newBlock)
+
 	// handle orphan
 	if cs.isOrphan(newBlock) {
 		if usedBstate != nil {
@@ -590,6 +602,9 @@ func newBlockExecutor(cs *ChainService, bState *state.BlockState, block *types.B
 			state.SetPrevBlockHash(block.GetHeader().GetPrevBlockHash()),
 		)
 		bi = types.NewBlockHeaderInfo(block)
+		if err := cs.validateBaseFee(bState, block, bi); err != nil {
+			return nil, err
+		}
 		exec = NewTxExecutor(cs.ChainConsensus, cs.cdb, bi, contract.ChainService)
 
 		validateSignWait = func() error {
@@ -601,7 +616,11 @@ func newBlockExecutor(cs *ChainService, bState *state.BlockState, block *types.B
 		// executed by the block factory.
 		commitOnly = true
 	}
-	bState.SetGasPrice(system.GetGasPriceFromState(bState))
+	if baseFee := block.GetHeader().GetBaseFee(); len(baseFee) != 0 {
+		bState.SetGasPrice(new(big.Int).SetBytes(baseFee))
+	} else {
+		bState.SetGasPrice(system.GetGasPriceFromState(bState))
+	}
 	bState.Receipts().SetHardFork(cs.cfg.Hardfork, block.BlockNo())
 
 	return &blockExecutor{
@@ -620,6 +639,27 @@ func newBlockExecutor(cs *ChainService, bState *state.BlockState, block *types.B
 	}, nil
 }
 
+// validateBaseFee checks that the base fee of block is the one computed from
+// its previous block.
+func (cs *ChainService) validateBaseFee(bState *state.BlockState, block *types.Block, bi *types.BlockHeaderInfo) error {
+	if !bi.IsFeatureActive(types.FeatureBaseFee) {
+		if bi.BaseFee != nil {
+			return errBlockBaseFee
+		}
+		return nil
+	}
+	prev, err := cs.cdb.GetBlock(block.GetHeader().GetPrevBlockHash())
+	if err != nil {
+		return err
+	}
+	expected := types.NextBaseFee(prev, system.GetGasPriceFromState(bState))
+	if bi.BaseFee == nil || bi.BaseFee.Cmp(expected) != 0 {
+		logger.Error().Str("expected", expected.String()).Str("hash", block.ID()).Msg("invalid base fee")
+		return errBlockBaseFee
+	}
+	return nil
+}
+
 // NewTxExecutor returns a new TxExecFn.
 func NewTxExecutor(ccc consensus.ChainConsensusCluster, cdb contract.ChainAccessor, bi *types.BlockHeaderInfo, preLoadService int) TxExecFn {
 	return func(bState *state.BlockState, tx types.Transaction) error {
@@ -650,6 +690,9 @@ func (e *blockExecutor) execute() error {
 	// Receipt must be committed unconditionally.
 	if !e.commitOnly {
 		defer contract.CloseDatabase()
+		if err := ActivateSystemParams(e.BlockState, e.bi); err != nil {
+			return err
+		}
 		var preLoadTx *types.Tx
 		nCand := len(e.txs)
 		for i, tx := range e.txs {
@@ -672,7 +715,7 @@ func (e *blockExecutor) execute() error {
 		}
 
 		//TODO check result of verifing txs
-		if err := SendBlockReward(e.BlockState, e.coinbaseAcccount); err != nil {
+		if err := SendBlockReward(e.BlockState, e.coinbaseAcccount, e.bi); err != nil {
 			return err
 		}
 
@@ -744,6 +787,13 @@ func (cs *ChainService) executeBlock(bstate *state.BlockState, block *types.Bloc
 		return err
 	}
 
+	// the consensus which changes its membership by block execution
+	if applier, ok := cs.ChainConsensus.(consensus.ConfChangeApplier); ok && ex.BlockState.CCProposal != nil {
+		if err := applier.ApplyConfChange(block, ex.BlockState.CCProposal); err != nil {
+			logger.Error().Err(err).Uint64("no", block.BlockNo()).Msg("failed to apply membership change")
+		}
+	}
+
 	if len(ex.BlockState.Receipts().Get()) != 0 {
 		cs.cdb.writeReceipts(block.BlockHash(), block.BlockNo(), ex.BlockState.Receipts())
 	}
@@ -751,6 +801,7 @@ func (cs *ChainService) executeBlock(bstate *state.BlockState, block *types.Bloc
 	cs.notifyEvents(block, ex.BlockState)
 
 	cs.Update(block)
+	cs.applySystemValues()
 
 	logger.Debug().Uint64("no", block.GetHeader().BlockNo).Msg("end to execute")
 
@@ -887,18 +938,24 @@ func executeTx(
 	if tx.HasVerifedAccount() {
 		account = tx.GetVerifedAccount()
 		tx.RemoveVerifedAccount()
-		resolvedAccount := name.Resolve(bs, txBody.GetAccount())
+		resolvedAccount := name.Resolve(bs, txBody.GetAccount(), bi)
 		if !bytes.Equal(account, resolvedAccount) {
 			return types.ErrSignNotMatch
 		}
 	} else {
-		account = name.Resolve(bs, txBody.GetAccount())
+		account = name.Resolve(bs, txBody.GetAccount(), bi)
 	}
 
 	err := tx.Validate(bi.ChainIdHash(), IsPublic())
 	if err != nil {
 		return err
 	}
+	if err = txBody.ValidateExpiry(bi); err != nil {
+		return err
+	}
+	if err = txBody.ValidateGasTip(bi.Version); err != nil {
+		return err
+	}
 
 	sender, err := bs.GetAccountStateV(account)
 	if err != nil {
@@ -909,11 +966,17 @@ func executeTx(
 	if err != nil {
 		return err
 	}
+	if err = multisig.ValidateSender(bs, sender, bi); err != nil {
+		return err
+	}
 
-	recipient := name.Resolve(bs, txBody.Recipient)
+	recipient := name.Resolve(bs, txBody.Recipient, bi)
 	var receiver *state.V
 	status := "SUCCESS"
-	if len(recipient) > 0 {
+	if txBody.Type == types.TxType_BATCH {
+		// the operations have their own recipients
+		receiver = sender
+	} else if len(recipient) > 0 {
 		receiver, err = bs.GetAccountStateV(recipient)
 		if receiver != nil && txBody.Type == types.TxType_REDEPLOY {
 			status = "RECREATED"
@@ -940,6 +1003,15 @@ func executeTx(
 		if err != nil {
 			logger.Warn().Err(err).Str("txhash", enc.ToString(tx.GetHash())).Msg("governance tx Error")
 		}
+	case types.TxType_BATCH:
+		// the fee of each operation is paid as it is executed
+		rv, events, txFee, err = executeBatchTx(ccc, cdb, bs, tx, sender, bi, preLoadService)
+	case types.TxType_MULTISIG:
+		txFee, events, err = multisig.ExecuteMultisigTx(bs, txBody, sender, receiver, bi)
+		if err != nil {
+			return err
+		}
+		sender.SubBalance(txFee)
 	case types.TxType_FEEDELEGATION:
 		balance := receiver.Balance()
 		var fee *big.Int
@@ -968,10 +1040,23 @@ func executeTx(
 		receiver.SubBalance(txFee)
 	}
 
-	if err != nil {
-		if !contract.IsRuntimeError(err) {
-			return err
+	if err != nil && !contract.IsRuntimeError(err) {
+		return err
+	}
+	gasUsed := contract.GasUsed(txFee, bs.GasPrice, txBody.Type, bi.Version)
+	bpFee := txFee
+	if bi.IsFeatureActive(types.FeatureBaseFee) {
+		// the base fee is burned and only the tip is paid to the block producer
+		payer := sender
+		if txBody.Type == types.TxType_FEEDELEGATION {
+			payer = receiver
 		}
+		bpFee = gasTipFee(payer, txBody, gasUsed)
+		payer.SubBalance(bpFee)
+		txFee = new(big.Int).Add(txFee, bpFee)
+	}
+
+	if err != nil {
 		if txBody.Type != types.TxType_FEEDELEGATION || sender.AccountID() == receiver.AccountID() {
 			sErr := resetAccount(sender, txFee, &txBody.Nonce)
 			if sErr != nil {
@@ -1003,21 +1088,31 @@ func executeTx(
 		}
 		rv = adjustRv(rv)
 	}
-	bs.BpReward.Add(&bs.BpReward, txFee)
+	bs.BpReward.Add(&bs.BpReward, bpFee)
 
 	receipt := types.NewReceipt(receiver.ID(), status, rv)
 	receipt.FeeUsed = txFee.Bytes()
 	receipt.TxHash = tx.GetHash()
 	receipt.Events = events
 	receipt.FeeDelegation = txBody.Type == types.TxType_FEEDELEGATION
-	receipt.GasUsed = contract.GasUsed(txFee, bs.GasPrice, txBody.Type, bi.Version)
+	receipt.GasUsed = gasUsed
 
 	return bs.AddReceipt(receipt)
 }
 
+// gasTipFee returns the tip of the gas used by a tx, which is at most the
+// balance of payer.
+func gasTipFee(payer *state.V, txBody *types.TxBody, gasUsed uint64) *big.Int {
+	tip := new(big.Int).Mul(txBody.GetGasTipBigInt(), new(big.Int).SetUint64(gasUsed))
+	if balance := payer.Balance(); tip.Cmp(balance) > 0 {
+		return balance
+	}
+	return tip
+}
+
 func DecorateBlockRewardFn(fn BlockRewardFn) {
-	SendBlockReward = func(bState *state.BlockState, coinbaseAccount []byte) error {
-		if err := fn(bState, coinbaseAccount); err != nil {
+	SendBlockReward = func(bState *state.BlockState, coinbaseAccount []byte, bi *types.BlockHeaderInfo) error {
+		if err := fn(bState, coinbaseAccount, bi); err != nil {
 			return err
 		}
 
@@ -1025,6 +1120,34 @@ func DecorateBlockRewardFn(fn BlockRewardFn) {
 	}
 }
 
+// ActivateSystemParams applies the system parameters voted by the governance
+// whose activation block has come, and records the activation block of the
+// name expiry. It must be called before the transactions of a block are
+// executed.
+func ActivateSystemParams(bState *state.BlockState, bi *types.BlockHeaderInfo) error {
+	if bi == nil {
+		return nil
+	}
+	if bi.IsFeatureActive(types.FeatureNameExpiry) {
+		if err := name.InitExpiryStart(bState, bi.No); err != nil {
+			return err
+		}
+	}
+	if !bi.IsFeatureActive(types.FeatureGovernance) {
+		return nil
+	}
+	scs, err := bState.GetSystemAccountState()
+	if err != nil {
+		return err
+	}
+	activated, err := system.ActivateParams(scs, bi.No)
+	if err != nil || !activated {
+		return err
+	}
+	logger.Info().Uint64("no", bi.No).Msg("system parameters activated")
+	return bState.StageContractState(scs)
+}
+
 func sendRewardCoinbase(bState *state.BlockState, coinbaseAccount []byte) error {
 	bpReward := &bState.BpReward
 	if bpReward.Cmp(new(big.Int).SetUint64(0)) <= 0 || coinbaseAccount == nil {
@@ -32,7 +32,7 @@ func initTest(t *testing.T, testmode bool) {
 	genesis := types.GetTestGenesis()
 	chainID = genesis.Block().GetHeader().ChainID
 
-	err := sdb.SetGenesis(genesis, nil)
+	err := sdb.SetGenesis(genesis, nil, nil)
 	if err != nil {
 		t.Fatalf("failed init : %s", err.Error())
 	}
@@ -116,7 +116,7 @@ func (core *Core) initGenesis(genesis *types.Genesis, mainnet bool, testmode boo
 			}
 		}
 
-		err := core.sdb.SetGenesis(genesis, InitGenesisBPs)
+		err := core.sdb.SetGenesis(genesis, InitGenesisBPs, contract.InitGenesisContracts)
 		if err != nil {
 			logger.Fatal().Err(err).Msg("cannot set statedb of genesisblock")
 			return nil, err
@@ -180,6 +180,7 @@ type IChainHandler interface {
 	getAccountVote(addr []byte) (*types.AccountVoteInfo, error)
 	getVotes(id string, n uint32) (*types.VoteList, error)
 	getStaking(addr []byte) (*types.Staking, error)
+	getVotingReward(addr []byte) (*types.VotingReward, error)
 	getNameInfo(name string, blockNo types.BlockNo) (*types.NameInfo, error)
 	getEnterpriseConf(key string) (*types.EnterpriseConfig, error)
 	addBlock(newBlock *types.Block, usedBstate *state.BlockState, peerID types.PeerID) error
@@ -200,6 +201,9 @@ type ChainService struct {
 	op        *OrphanPool
 	errBlocks *lru.Cache
 
+	// equivocation is only set if the consensus is dpos
+	equivocation *equivocationDetector
+
 	validator *BlockValidator
 
 	chainWorker   *ChainWorker
@@ -256,6 +260,9 @@ func NewChainService(cfg *cfg.Config) *ChainService {
 		return nil
 	}
 
+	// The constructors of the genesis contracts run by the hardfork config.
+	contract.HardforkConfig = cs.cfg.Hardfork
+
 	// init genesis block
 	if _, err := cs.initGenesis(nil, !cfg.UseTestnet, cfg.EnableTestmode); err != nil {
 		logger.Fatal().Err(err).Msg("failed to create a genesis block")
@@ -269,6 +276,11 @@ func NewChainService(cfg *cfg.Config) *ChainService {
 	}
 
 	if ConsensusName() == consensus.ConsensusName[consensus.ConsensusDPOS] {
+		if cs.equivocation, err = newEquivocationDetector(cs.cdb); err != nil {
+			logger.Fatal().Err(err).Msg("failed to init equivocation detector")
+			return nil
+		}
+
 		top, err := cs.getVotes(types.OpvoteBP.ID(), 1)
 		if err != nil {
 			logger.Debug().Err(err).Msg("failed to get elected BPs")
@@ -288,7 +300,6 @@ func NewChainService(cfg *cfg.Config) *ChainService {
 	contract.TraceBlockNo = cfg.Blockchain.StateTrace
 	contract.SetStateSQLMaxDBSize(cfg.SQL.MaxDbSize)
 	contract.StartLStateFactory()
-	contract.HardforkConfig = cs.cfg.Hardfork
 
 	// For a strict governance transaction validation.
 	types.InitGovernance(cs.ConsensusType(), cs.IsPublic())
@@ -300,6 +311,7 @@ func NewChainService(cfg *cfg.Config) *ChainService {
 		panic("failed to read aergo.system state")
 	}
 	system.InitSystemParams(systemState, len(cs.GetGenesisInfo().BPs))
+	cs.applySystemValues()
 
 	// init Debugger
 	cs.initDebugger()
@@ -434,15 +446,18 @@ func (cs *ChainService) Receive(context actor.Context) {
 		*message.GetTx,
 		*message.GetReceipt,
 		*message.GetABI,
+		*message.GetCode,
 		*message.GetQuery,
 		*message.GetStateQuery,
 		*message.GetElected,
 		*message.GetVote,
 		*message.GetStaking,
+		*message.GetVotingReward,
 		*message.GetNameInfo,
 		*message.GetEnterpriseConf,
 		*message.GetParams,
 		*message.ListEvents,
+		*message.ListEvidences,
 		*message.CheckFeeDelegation:
 		cs.chainWorker.Request(msg, context.Sender())
 
@@ -519,12 +534,24 @@ func (cs *ChainService) getAccountVote(addr []byte) (*types.AccountVoteInfo, err
 	if err != nil {
 		return nil, err
 	}
-	voteInfo, err := system.GetVotes(scs, name.GetAddress(namescs, addr))
+	addr = name.GetAddress(namescs, addr)
+	voteInfo, err := system.GetVotes(scs, addr)
 	if err != nil {
 		return nil, err
 	}
+	info := &types.AccountVoteInfo{Voting: voteInfo}
 
-	return &types.AccountVoteInfo{Voting: voteInfo}, nil
+	delegate, delegated, err := system.GetDelegation(scs, addr)
+	if err != nil {
+		return nil, err
+	}
+	if delegate != nil {
+		info.Delegate = types.EncodeAddress(delegate)
+	}
+	if delegated.Sign() != 0 {
+		info.Delegated = delegated.String()
+	}
+	return info, nil
 }
 
 func (cs *ChainService) getStaking(addr []byte) (*types.Staking, error) {
@@ -547,6 +574,22 @@ func (cs *ChainService) getStaking(addr []byte) (*types.Staking, error) {
 	return staking, nil
 }
 
+func (cs *ChainService) getVotingReward(addr []byte) (*types.VotingReward, error) {
+	if cs.GetType() != consensus.ConsensusDPOS {
+		return nil, ErrNotSupportedConsensus
+	}
+
+	scs, err := cs.sdb.GetStateDB().OpenContractStateAccount(types.ToAccountID([]byte(types.AergoSystem)))
+	if err != nil {
+		return nil, err
+	}
+	namescs, err := cs.sdb.GetStateDB().OpenContractStateAccount(types.ToAccountID([]byte(types.AergoName)))
+	if err != nil {
+		return nil, err
+	}
+	return system.GetVotingReward(scs, name.GetAddress(namescs, addr))
+}
+
 func (cs *ChainService) getNameInfo(qname string, blockNo types.BlockNo) (*types.NameInfo, error) {
 	var stateDB *state.StateDB
 	if blockNo != 0 {
@@ -557,8 +600,9 @@ func (cs *ChainService) getNameInfo(qname string, blockNo types.BlockNo) (*types
 		stateDB = cs.sdb.OpenNewStateDB(block.GetHeader().GetBlocksRootHash())
 	} else {
 		stateDB = cs.sdb.GetStateDB()
+		blockNo = cs.cdb.getBestBlockNo()
 	}
-	return name.GetNameInfo(stateDB, qname)
+	return name.GetNameInfo(stateDB, qname, blockNo)
 }
 
 func (cs *ChainService) getEnterpriseConf(key string) (*types.EnterpriseConfig, error) {
@@ -577,13 +621,36 @@ func (cs *ChainService) getSystemValue(key types.SystemValue) (*big.Int, error)
 	case types.StakingMin:
 		return system.GetStakingMinimum(), nil
 	case types.GasPrice:
+		if best, err := cs.GetBestBlock(); err == nil && cs.cfg.Hardfork.IsFeatureActive(types.FeatureBaseFee, best.BlockNo()+1) {
+			return types.NextBaseFee(best, system.GetGasPrice()), nil
+		}
 		return system.GetGasPrice(), nil
 	case types.NamePrice:
 		return system.GetNamePrice(), nil
+	case types.MaxBlockSize:
+		if size := system.GetMaxBlockSize(); size != nil {
+			return size, nil
+		}
+		return new(big.Int).SetUint64(uint64(defaultMaxBlockBodySize)), nil
+	case types.StakingDelay:
+		return system.GetStakingDelay(), nil
+	case types.UnbondingPeriod:
+		return system.GetUnbondingPeriod(), nil
+	case types.VotingRewardAmount:
+		return system.GetVotingRewardAmount(), nil
 	}
 	return nil, fmt.Errorf("unsupported system value : %s", key)
 }
 
+// applySystemValues makes the chain follow the system values decided by the
+// governance. It must be called whenever the system parameters may have been
+// changed.
+func (cs *ChainService) applySystemValues() {
+	if size, err := cs.GetSystemValue(types.MaxBlockSize); err == nil {
+		applyBlockSizeLimit(size)
+	}
+}
+
 type ChainManager struct {
 	*SubComponent
 	IChainHandler //to use chain APIs
@@ -776,6 +843,22 @@ func (cw *ChainWorker) Receive(context actor.Context) {
 				Err: err,
 			})
 		}
+	case *message.GetCode:
+		address, err := getAddressNameResolved(cw.sdb, msg.Contract)
+		if err != nil {
+			context.Respond(message.GetCodeRsp{Err: err})
+			break
+		}
+		contractState, err := cw.sdb.GetStateDB().OpenContractStateAccount(types.ToAccountID(address))
+		if err != nil {
+			context.Respond(message.GetCodeRsp{Err: err})
+			break
+		}
+		code, err := contractState.GetCode()
+		if err == nil && len(code) == 0 {
+			err = errors.New("cannot find contract")
+		}
+		context.Respond(message.GetCodeRsp{Code: code, Err: err})
 	case *message.GetQuery:
 		runtime.LockOSThread()
 		defer runtime.UnlockOSThread()
@@ -848,6 +931,12 @@ func (cw *ChainWorker) Receive(context actor.Context) {
 			Staking: staking,
 			Err:     err,
 		})
+	case *message.GetVotingReward:
+		reward, err := cw.getVotingReward(msg.Addr)
+		context.Respond(&message.GetVotingRewardRsp{
+			Reward: reward,
+			Err:    err,
+		})
 	case *message.GetNameInfo:
 		owner, err := cw.getNameInfo(msg.Name, msg.BlockNo)
 		context.Respond(&message.GetNameInfoRsp{
@@ -866,6 +955,12 @@ func (cw *ChainWorker) Receive(context actor.Context) {
 			Events: events,
 			Err:    err,
 		})
+	case *message.ListEvidences:
+		evidences, err := cw.cdb.getEvidences()
+		context.Respond(&message.ListEvidencesRsp{
+			Evidences: evidences,
+			Err:       err,
+		})
 	case *message.GetParams:
 		context.Respond(&message.GetParamsRsp{
 			BpCount:      system.GetBpCount(),
@@ -7,6 +7,8 @@ package chain
 
 import (
 	"errors"
+	"math/big"
+	"sync/atomic"
 
 	"github.com/aergoio/aergo/consensus"
 	"github.com/aergoio/aergo/internal/enc"
@@ -23,8 +25,11 @@ var (
 	// maxBlockBodySize is the upper limit of block size.
 	maxBlockBodySize uint32
 	maxBlockSize     uint32
-	pubNet           bool
-	consensusName    string
+	// defaultMaxBlockBodySize is the upper limit of block size used unless
+	// it is decided by the governance.
+	defaultMaxBlockBodySize uint32
+	pubNet                  bool
+	consensusName           string
 
 	Genesis *types.Genesis
 )
@@ -40,7 +45,7 @@ var (
 func Init(maxBlkBodySize uint32, coinbaseAccountStr string, isBp bool, maxAnchorCount int, verifierCount int) error {
 	var err error
 
-	setBlockSizeLimit(maxBlkBodySize)
+	setDefaultBlockSizeLimit(maxBlkBodySize)
 
 	if isBp {
 		if len(coinbaseAccountStr) != 0 {
@@ -70,7 +75,7 @@ func IsPublic() bool {
 func initChainParams(genesis *types.Genesis) {
 	pubNet = genesis.ID.PublicNet
 	if pubNet {
-		setBlockSizeLimit(pubNetMaxBlockBodySize)
+		setDefaultBlockSizeLimit(pubNetMaxBlockBodySize)
 	}
 	if err := setConsensusName(genesis.ConsensusType()); err != nil {
 		logger.Panic().Err(err).Msg("invalid consensus type in genesis block")
@@ -85,21 +90,36 @@ func initChainParams(genesis *types.Genesis) {
 
 // MaxBlockBodySize returns the max block body size.
 func MaxBlockBodySize() uint32 {
-	return maxBlockBodySize
+	return atomic.LoadUint32(&maxBlockBodySize)
 }
 
 // MaxBlockSize returns the max block size.
 func MaxBlockSize() uint32 {
-	return maxBlockSize
+	return atomic.LoadUint32(&maxBlockSize)
 }
 
 func setMaxBlockBodySize(size uint32) {
-	maxBlockBodySize = size
+	atomic.StoreUint32(&maxBlockBodySize, size)
 }
 
 func setBlockSizeLimit(maxBlockBodySize uint32) {
 	setMaxBlockBodySize(maxBlockBodySize)
-	maxBlockSize = MaxBlockBodySize() + types.DefaultMaxHdrSize
+	atomic.StoreUint32(&maxBlockSize, MaxBlockBodySize()+types.DefaultMaxHdrSize)
+}
+
+func setDefaultBlockSizeLimit(maxBlockBodySize uint32) {
+	defaultMaxBlockBodySize = maxBlockBodySize
+	setBlockSizeLimit(maxBlockBodySize)
+}
+
+// applyBlockSizeLimit sets the block size limit to size decided by the
+// governance. The default limit is restored if size is nil.
+func applyBlockSizeLimit(size *big.Int) {
+	if size == nil || !size.IsUint64() || size.Uint64() > uint64(^uint32(0)) {
+		setBlockSizeLimit(defaultMaxBlockBodySize)
+		return
+	}
+	setBlockSizeLimit(uint32(size.Uint64()))
 }
 
 func setConsensusName(val string) error {
@@ -0,0 +1,72 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package chain
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/aergoio/aergo/consensus"
+	"github.com/aergoio/aergo/internal/enc"
+	"github.com/aergoio/aergo/types"
+	lru "github.com/hashicorp/golang-lru"
+)
+
+// dfltEquivocationCacheSize is the number of recently seen (producer, slot) pairs remembered by detector
+const dfltEquivocationCacheSize = 1000
+
+// equivocationDetector watches blocks passing through the chain service, both the blocks connected to chain and the
+// ones going to the orphan pool, and detects that a block producer signed two different blocks for the same slot.
+type equivocationDetector struct {
+	mutex  sync.Mutex
+	cdb    *ChainDB
+	recent *lru.Cache
+}
+
+func newEquivocationDetector(cdb *ChainDB) (*equivocationDetector, error) {
+	recent, err := lru.New(dfltEquivocationCacheSize)
+	if err != nil {
+		return nil, err
+	}
+	return &equivocationDetector{cdb: cdb, recent: recent}, nil
+}
+
+// check remembers the block and returns evidence if the producer of block already signed another block for the same
+// slot. The block must have a verified signature. The evidence is stored to chain db before it is returned.
+func (d *equivocationDetector) check(block *types.Block) *types.DoubleSignEvidence {
+	if d == nil {
+		return nil
+	}
+	header := block.GetHeader()
+	slot := types.DposSlotIndex(header.GetTimestamp(), consensus.BlockIntervalSec)
+	key := fmt.Sprintf("%s/%d", enc.ToString(header.GetPubKey()), slot)
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	prev, found := d.recent.Get(key)
+	if !found {
+		// keep only header, not to hold transactions in memory
+		d.recent.Add(key, &types.Block{Hash: block.BlockHash(), Header: header})
+		return nil
+	}
+	prevBlock := prev.(*types.Block)
+	if bytes.Equal(prevBlock.BlockHash(), block.BlockHash()) {
+		return nil
+	}
+
+	evidence := types.NewDoubleSignEvidence(prevBlock.GetHeader(), header, slot, time.Now().UnixNano())
+	added, err := d.cdb.addEvidence(evidence)
+	if err != nil {
+		logger.Error().Err(err).Str("bp", block.BPID2Str()).Int64("slot", slot).Msg("failed to store double sign evidence")
+	} else if added {
+		logger.Warn().Str("bp", block.BPID2Str()).Int64("slot", slot).Str("first", prevBlock.ID()).
+			Str("second", block.ID()).Uint64("no", block.BlockNo()).Msg("block producer signed two different blocks for the same slot")
+	}
+	return evidence
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/aergoio/aergo/consensus"
 	"github.com/aergoio/aergo/internal/enc"
 	"github.com/aergoio/aergo/message"
+	"github.com/aergoio/aergo/pkg/metrics"
 	"github.com/aergoio/aergo/state"
 	"github.com/aergoio/aergo/types"
 )
@@ -173,9 +174,13 @@ func (cs *ChainService) reorg(topBlock *types.Block, marker *ReorgMarker) error
 		return err
 	}
 
-	cs.stat.updateEvent(ReorgStat, time.Since(begT), reorg.oldBlocks[0], reorg.newBlocks[0], reorg.brStartBlock)
+	elapsed := time.Since(begT)
+	cs.stat.updateEvent(ReorgStat, elapsed, reorg.oldBlocks[0], reorg.newBlocks[0], reorg.brStartBlock)
+	metrics.ReorgCount.Inc()
+	metrics.ReorgTime.Observe(elapsed.Seconds())
 	systemStateDB, err := cs.SDB().GetSystemAccountState()
 	system.InitSystemParams(systemStateDB, system.RESET)
+	cs.applySystemValues()
 	logger.Info().Msg("reorg end")
 
 	return nil
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path"
 	"syscall"
 
 	"github.com/aergoio/aergo/account/key"
@@ -24,8 +25,10 @@ func init() {
 
 	newCmd.Flags().StringVar(&pw, "password", "", "Password")
 	newCmd.Flags().StringVar(&dataDir, "path", "$HOME/.aergo/data", "Path to data directory")
+	newCmd.Flags().StringVar(&keystoreDir, "keystore", "", "Path to key file directory")
 
 	listCmd.Flags().StringVar(&dataDir, "path", "$HOME/.aergo/data", "Path to data directory")
+	listCmd.Flags().StringVar(&keystoreDir, "keystore", "", "Path to key file directory")
 
 	unlockCmd.Flags().StringVar(&address, "address", "", "Address of account")
 	unlockCmd.MarkFlagRequired("address")
@@ -40,11 +43,13 @@ func init() {
 	importCmd.Flags().StringVar(&pw, "password", "", "Password when exporting")
 	importCmd.Flags().StringVar(&to, "newpassword", "", "Password to be reset")
 	importCmd.Flags().StringVar(&dataDir, "path", "$HOME/.aergo/data", "Path to data directory")
+	importCmd.Flags().StringVar(&keystoreDir, "keystore", "", "Path to key file directory")
 
 	exportCmd.Flags().StringVar(&address, "address", "", "Address of account")
 	exportCmd.MarkFlagRequired("address")
 	exportCmd.Flags().StringVar(&pw, "password", "", "Password")
 	exportCmd.Flags().StringVar(&dataDir, "path", "$HOME/.aergo/data", "Path to data directory")
+	exportCmd.Flags().StringVar(&keystoreDir, "keystore", "", "Path to key file directory")
 
 	voteCmd.Flags().StringVar(&address, "address", "", "Account address of voter")
 	voteCmd.MarkFlagRequired("address")
@@ -60,8 +65,41 @@ func init() {
 	unstakeCmd.MarkFlagRequired("address")
 	unstakeCmd.Flags().StringVar(&amount, "amount", "0", "Amount of staking")
 	unstakeCmd.MarkFlagRequired("amount")
+	withdrawCmd.Flags().StringVar(&address, "address", "", "Account address")
+	withdrawCmd.MarkFlagRequired("address")
+	claimRewardCmd.Flags().StringVar(&address, "address", "", "Account address")
+	claimRewardCmd.MarkFlagRequired("address")
+	delegateCmd.Flags().StringVar(&address, "address", "", "Account address")
+	delegateCmd.MarkFlagRequired("address")
+	undelegateCmd.Flags().StringVar(&address, "address", "", "Account address")
+	undelegateCmd.MarkFlagRequired("address")
 
-	accountCmd.AddCommand(newCmd, listCmd, unlockCmd, lockCmd, importCmd, exportCmd, voteCmd, stakeCmd, unstakeCmd)
+	hdNewCmd.Flags().StringVar(&pw, "password", "", "Password")
+	hdNewCmd.Flags().StringVar(&hdPath, "hdpath", key.DefaultHDPath, "BIP44 path of the derived accounts")
+	hdNewCmd.Flags().StringVar(&dataDir, "path", "$HOME/.aergo/data", "Path to data directory")
+	hdNewCmd.Flags().StringVar(&keystoreDir, "keystore", "", "Path to key file directory")
+	hdImportCmd.Flags().StringVar(&mnemonic, "mnemonic", "", "BIP39 mnemonic")
+	hdImportCmd.MarkFlagRequired("mnemonic")
+	hdImportCmd.Flags().StringVar(&pw, "password", "", "Password")
+	hdImportCmd.Flags().StringVar(&hdPath, "hdpath", key.DefaultHDPath, "BIP44 path of the derived accounts")
+	hdImportCmd.Flags().StringVar(&dataDir, "path", "$HOME/.aergo/data", "Path to data directory")
+	hdImportCmd.Flags().StringVar(&keystoreDir, "keystore", "", "Path to key file directory")
+	hdDeriveCmd.Flags().Uint32Var(&hdIndex, "index", 0, "Derivation index of the account")
+	hdDeriveCmd.Flags().StringVar(&pw, "password", "", "Password")
+	hdDeriveCmd.Flags().StringVar(&dataDir, "path", "$HOME/.aergo/data", "Path to data directory")
+	hdDeriveCmd.Flags().StringVar(&keystoreDir, "keystore", "", "Path to key file directory")
+	hdListCmd.Flags().StringVar(&dataDir, "path", "$HOME/.aergo/data", "Path to data directory")
+	hdListCmd.Flags().StringVar(&keystoreDir, "keystore", "", "Path to key file directory")
+	migrateCmd.Flags().StringVar(&dataDir, "path", "$HOME/.aergo/data", "Path to data directory of the LevelDB key store")
+	migrateCmd.Flags().StringVar(&keystoreDir, "keystore", "", "Path to key file directory")
+	migrateCmd.MarkFlagRequired("keystore")
+	migrateCmd.Flags().StringVar(&pw, "password", "", "Password of the accounts to migrate")
+
+	hdUnlockCmd.Flags().Uint32Var(&hdIndex, "index", 0, "Derivation index of the account")
+	hdUnlockCmd.Flags().StringVar(&pw, "password", "", "Password")
+
+	accountCmd.AddCommand(newCmd, listCmd, unlockCmd, lockCmd, importCmd, exportCmd, voteCmd, stakeCmd, unstakeCmd, withdrawCmd, claimRewardCmd, delegateCmd, undelegateCmd,
+		hdNewCmd, hdImportCmd, hdDeriveCmd, hdListCmd, hdUnlockCmd, migrateCmd)
 	rootCmd.AddCommand(accountCmd)
 }
 
@@ -82,11 +120,10 @@ var newCmd = &cobra.Command{
 		}
 		var msg *types.Account
 		var addr []byte
-		if cmd.Flags().Changed("path") == false {
+		if !isLocalStore(cmd) {
 			msg, err = client.CreateAccount(context.Background(), &param)
 		} else {
-			dataEnvPath := os.ExpandEnv(dataDir)
-			ks := key.NewStore(dataEnvPath, 0)
+			ks := newLocalStore()
 			defer ks.CloseStore()
 			addr, err = ks.CreateKey(param.Passphrase)
 			if err != nil {
@@ -114,11 +151,10 @@ var listCmd = &cobra.Command{
 		var err error
 		var msg *types.AccountList
 		var addrs [][]byte
-		if cmd.Flags().Changed("path") == false {
+		if !isLocalStore(cmd) {
 			msg, err = client.GetAccounts(context.Background(), &types.Empty{})
 		} else {
-			dataEnvPath := os.ExpandEnv(dataDir)
-			ks := key.NewStore(dataEnvPath, 0)
+			ks := newLocalStore()
 			defer ks.CloseStore()
 			addrs, err = ks.GetAddresses()
 		}
@@ -210,7 +246,7 @@ var importCmd = &cobra.Command{
 			wif.Newpass = wif.Oldpass
 		}
 
-		if cmd.Flags().Changed("path") == false {
+		if !isLocalStore(cmd) {
 			msg, errRemote := client.ImportAccount(context.Background(), wif)
 			if errRemote != nil {
 				cmd.Printf("Failed: %s\n", errRemote.Error())
@@ -218,8 +254,7 @@ var importCmd = &cobra.Command{
 			}
 			address = msg.GetAddress()
 		} else {
-			dataEnvPath := os.ExpandEnv(dataDir)
-			ks := key.NewStore(dataEnvPath, 0)
+			ks := newLocalStore()
 			defer ks.CloseStore()
 			address, err = ks.ImportKey(importBuf, wif.Oldpass, wif.Newpass)
 			if err != nil {
@@ -241,7 +276,7 @@ var exportCmd = &cobra.Command{
 			return
 		}
 		var result []byte
-		if cmd.Flags().Changed("path") == false {
+		if !isLocalStore(cmd) {
 			msg, err := client.ExportAccount(context.Background(), param)
 			if err != nil {
 				cmd.Printf("Failed: %s\n", err.Error())
@@ -249,8 +284,7 @@ var exportCmd = &cobra.Command{
 			}
 			result = msg.Value
 		} else {
-			dataEnvPath := os.ExpandEnv(dataDir)
-			ks := key.NewStore(dataEnvPath, 0)
+			ks := newLocalStore()
 			defer ks.CloseStore()
 			wif, err := ks.ExportKey(param.Account.Address, param.Passphrase)
 			if err != nil {
@@ -263,6 +297,36 @@ var exportCmd = &cobra.Command{
 	},
 }
 
+var migrateCmd = &cobra.Command{
+	Use:   "migrate [flags]",
+	Short: "Copy accounts of the LevelDB key store into key files",
+	Run: func(cmd *cobra.Command, args []string) {
+		var err error
+		pass := pw
+		if pass == "" {
+			pass, err = getPasswd(cmd, false)
+			if err != nil {
+				cmd.Printf("Failed get password: %s\n", err.Error())
+				return
+			}
+		}
+		src := key.NewLevelDBStorage(path.Join(os.ExpandEnv(dataDir), "account"))
+		defer src.Close()
+		dst := key.NewFileStorage(os.ExpandEnv(keystoreDir))
+		defer dst.Close()
+		migrated, skipped, err := key.Migrate(src, dst, pass)
+		for _, addr := range migrated {
+			cmd.Println("migrated:", types.EncodeAddress(addr))
+		}
+		for _, addr := range skipped {
+			cmd.Println("skipped (other password):", types.EncodeAddress(addr))
+		}
+		if err != nil {
+			cmd.Printf("Failed: %s\n", err.Error())
+		}
+	},
+}
+
 func parsePersonalParam(cmd *cobra.Command) (*types.Personal, error) {
 	var err error
 	param := &types.Personal{Account: &types.Account{}}
@@ -304,8 +368,23 @@ func getPasswd(cmd *cobra.Command, isNew bool) (string, error) {
 	return string(password), err
 }
 
+// isLocalStore reports whether the command uses the key store of the cli,
+// given by --path or --keystore, instead of the one of the node.
+func isLocalStore(cmd *cobra.Command) bool {
+	return cmd.Flags().Changed("path") || cmd.Flags().Changed("keystore")
+}
+
+// newLocalStore opens the key files in --keystore if it is set, or the
+// LevelDB key store in --path.
+func newLocalStore() *key.Store {
+	if keystoreDir != "" {
+		return key.NewFileStore(os.ExpandEnv(keystoreDir), 0)
+	}
+	return key.NewStore(os.ExpandEnv(dataDir), 0)
+}
+
 func preConnectAergo(cmd *cobra.Command, args []string) {
-	if cmd.Flags().Changed("path") == false {
+	if !isLocalStore(cmd) {
 		connectAergo(cmd, args)
 	} else {
 		client = nil
@@ -0,0 +1,82 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"strings"
+
+	"github.com/aergoio/aergo/cmd/aergocli/util"
+	"github.com/aergoio/aergo/types"
+	"github.com/spf13/cobra"
+)
+
+var batchtxCmd = &cobra.Command{
+	Use:   "batchtx --from <address> --ops <json or @file>",
+	Short: "Send transaction executing several operations atomically",
+	Long: `Send transaction executing several operations atomically.
+The operations are executed in order and if one of them fails, none of them is applied.
+	ex) aergocli batchtx --from <address> --ops '[{"type":"TRANSFER","recipient":"<address>","amount":"1000"},
+	    {"type":"CALL","recipient":"<contract address>","payload":{"Name":"inc","Args":[]}}]'`,
+	Args: cobra.MinimumNArgs(0),
+	RunE: execBatchTX,
+}
+
+var batchOps string
+
+func init() {
+	rootCmd.AddCommand(batchtxCmd)
+	batchtxCmd.Flags().StringVar(&from, "from", "", "Sender account address")
+	batchtxCmd.MarkFlagRequired("from")
+	batchtxCmd.Flags().StringVar(&batchOps, "ops", "", "JSON array of operations, or @file to read it from a file")
+	batchtxCmd.MarkFlagRequired("ops")
+	batchtxCmd.Flags().Uint64Var(&nonce, "nonce", 0, "setting nonce manually")
+	batchtxCmd.Flags().Uint64VarP(&gas, "gaslimit", "g", 0, "Gas limit")
+	addExpiryFlags(batchtxCmd)
+	addGasTipFlag(batchtxCmd)
+}
+
+func execBatchTX(cmd *cobra.Command, args []string) error {
+	account, err := types.DecodeAddress(from)
+	if err != nil {
+		return errors.New("Wrong address in --from flag\n" + err.Error())
+	}
+	payload := []byte(batchOps)
+	if strings.HasPrefix(batchOps, "@") {
+		if payload, err = ioutil.ReadFile(batchOps[1:]); err != nil {
+			return errors.New("Failed to read --ops file\n" + err.Error())
+		}
+	}
+	ops, err := types.ParseBatch(payload)
+	if err != nil {
+		return errors.New("Wrong value in --ops flag\n" + err.Error())
+	}
+	if payload, err = types.EncodeBatch(ops); err != nil {
+		return err
+	}
+	tx := &types.Tx{Body: &types.TxBody{
+		Type:     types.TxType_BATCH,
+		Account:  account,
+		Payload:  payload,
+		Nonce:    nonce,
+		GasLimit: gas,
+	}}
+	if err := fillExpiry(tx.GetBody()); err != nil {
+		return err
+	}
+	if err := fillGasTip(tx.GetBody()); err != nil {
+		return err
+	}
+	msg, err := client.SendTX(context.Background(), tx)
+	if err != nil {
+		cmd.Println(err.Error())
+		return nil
+	}
+	cmd.Println(util.JSON(msg))
+	return nil
+}
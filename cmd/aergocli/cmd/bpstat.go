@@ -0,0 +1,34 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package cmd
+
+import (
+	"context"
+
+	"github.com/aergoio/aergo/cmd/aergocli/util"
+	aergorpc "github.com/aergoio/aergo/types"
+	"github.com/spf13/cobra"
+)
+
+var bpStatBlockNo uint64
+
+func init() {
+	rootCmd.AddCommand(bpStatCmd)
+	bpStatCmd.Flags().Uint64Var(&bpStatBlockNo, "blockno", 0, "block number in the election period (default: current period)")
+}
+
+var bpStatCmd = &cobra.Command{
+	Use:   "bpstat",
+	Short: "Print block production statistics of each BP for an election period",
+	Run: func(cmd *cobra.Command, args []string) {
+		msg, err := client.GetBPStats(context.Background(), &aergorpc.BPStatParams{BlockNo: bpStatBlockNo})
+		if err != nil {
+			cmd.Printf("Failed: %s\n", err.Error())
+			return
+		}
+		cmd.Println(util.JSON(msg))
+	},
+}
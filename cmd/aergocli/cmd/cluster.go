@@ -12,6 +12,7 @@ var (
 	nodeidStr   string
 	peerAddress string
 	peerid      string
+	learner     bool
 )
 
 func init() {
@@ -26,11 +27,17 @@ func init() {
 	addCmd.MarkFlagRequired("address")
 	addCmd.Flags().StringVar(&peerid, "peerid", "", "peer id of node to add to the cluster")
 	addCmd.MarkFlagRequired("peerid")
+	addCmd.Flags().BoolVar(&learner, "learner", false, "add node as a learner which replicates blocks without voting")
 
 	removeCmd.Flags().StringVar(&nodeidStr, "nodeid", "", "node id to remove to the cluster")
 	removeCmd.MarkFlagRequired("nodeid")
 
-	clusterCmd.AddCommand(addCmd, removeCmd)
+	promoteCmd.Flags().StringVar(&nodeidStr, "nodeid", "", "node id of learner to promote")
+	promoteCmd.MarkFlagRequired("nodeid")
+
+	transferCmd.Flags().StringVar(&nodeidStr, "nodeid", "", "node id to transfer leadership to (default: the most up-to-date member)")
+
+	clusterCmd.AddCommand(addCmd, removeCmd, promoteCmd, transferCmd)
 	rootCmd.AddCommand(clusterCmd)
 }
 
@@ -53,6 +60,9 @@ var addCmd = &cobra.Command{
 			Type: aergorpc.MembershipChangeType_ADD_MEMBER,
 			Attr: &aergorpc.MemberAttr{Name: nodename, Address: peerAddress, PeerID: []byte(peerIDBytes)},
 		}
+		if learner {
+			changeReq.Type = aergorpc.MembershipChangeType_ADD_LEARNER
+		}
 		reply, err := client.ChangeMembership(context.Background(), changeReq)
 		if err != nil {
 			cmd.Printf("Failed to add member: %s\n", err.Error())
@@ -92,3 +102,57 @@ var removeCmd = &cobra.Command{
 		return
 	},
 }
+
+var promoteCmd = &cobra.Command{
+	Use:   "promote [flags]",
+	Short: "Promote learner with given node id to voting member. This command can only be used for raft consensus.",
+	Run: func(cmd *cobra.Command, args []string) {
+		nodeid, err := strconv.ParseUint(nodeidStr, 16, 64)
+		if err != nil {
+			cmd.Printf("Failed: nodeid flag must be string of hex format\n")
+			return
+		}
+
+		changeReq := &aergorpc.MembershipChange{
+			Type: aergorpc.MembershipChangeType_PROMOTE_LEARNER,
+			Attr: &aergorpc.MemberAttr{ID: nodeid},
+		}
+		reply, err := client.ChangeMembership(context.Background(), changeReq)
+		if err != nil {
+			cmd.Printf("Failed to promote learner: %s\n", err.Error())
+			return
+		}
+
+		cmd.Printf("promoted learner to voting member: %s\n", reply.Attr.ToString())
+	},
+}
+
+var transferCmd = &cobra.Command{
+	Use:   "transfer [flags]",
+	Short: "Transfer leadership to other member before maintenance of leader. This command can only be used for raft consensus.",
+	Run: func(cmd *cobra.Command, args []string) {
+		var (
+			nodeid uint64
+			err    error
+		)
+
+		if len(nodeidStr) > 0 {
+			if nodeid, err = strconv.ParseUint(nodeidStr, 16, 64); err != nil {
+				cmd.Printf("Failed: nodeid flag must be string of hex format\n")
+				return
+			}
+		}
+
+		changeReq := &aergorpc.MembershipChange{
+			Type: aergorpc.MembershipChangeType_TRANSFER_LEADER,
+			Attr: &aergorpc.MemberAttr{ID: nodeid},
+		}
+		reply, err := client.ChangeMembership(context.Background(), changeReq)
+		if err != nil {
+			cmd.Printf("Failed to transfer leadership: %s\n", err.Error())
+			return
+		}
+
+		cmd.Printf("transferred leadership to: %s\n", reply.Attr.ToString())
+	},
+}
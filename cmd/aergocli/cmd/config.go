@@ -21,9 +21,10 @@ func NewCliContext(homePath string, configFilePath string) *CliContext {
 
 // CliConfig is configs for aergo cli.
 type CliConfig struct {
-	Host string     `mapstructure:"host" description:"Target server host. default is localhost"`
-	Port int        `mapstructure:"port" description:"Target server port. default is 7845"`
-	TLS  *TLSConfig `mapstructure:"tls"`
+	Host  string     `mapstructure:"host" description:"Target server host. default is localhost"`
+	Port  int        `mapstructure:"port" description:"Target server port. default is 7845"`
+	Token string     `mapstructure:"token" description:"Bearer token (JWT or API key) for the RPC authentication of the server"`
+	TLS   *TLSConfig `mapstructure:"tls"`
 }
 
 type TLSConfig struct {
@@ -62,6 +63,7 @@ func (ctx *CliContext) GetTemplate() string {
 const configTemplate = `# aergo cli TOML Configuration File (https://github.com/toml-lang/toml)
 host = "{{.Host}}"
 port = "{{.Port}}"
+token = "{{.Token}}"
 
 [tls]
 servername = "{{.TLS.ServerName}}"
@@ -29,6 +29,8 @@ var (
 	feeDelegation bool
 	contractID    string
 	gas           uint64
+	sourceFile    string
+	compilerVer   string
 )
 
 func init() {
@@ -48,8 +50,12 @@ func init() {
 	deployCmd.PersistentFlags().StringVar(&data, "payload", "", "result of compiling a contract")
 	deployCmd.PersistentFlags().StringVar(&amount, "amount", "0", "setting amount")
 	deployCmd.PersistentFlags().StringVarP(&contractID, "redeploy", "r", "", "redeploy the contract")
+	deployCmd.PersistentFlags().StringVar(&sourceFile, "source", "", "record the hash of the source file in the abi")
 	deployCmd.Flags().StringVar(&dataDir, "path", "$HOME/.aergo/data", "Path to account data directory")
+	deployCmd.Flags().StringVar(&keystoreDir, "keystore", "", "Path to key file directory")
 	deployCmd.Flags().StringVar(&pw, "password", "", "Password")
+	addExpiryFlags(deployCmd)
+	addGasTipFlag(deployCmd)
 
 	callCmd := &cobra.Command{
 		Use:   "call [flags] sender contract funcname '[argument...]'",
@@ -64,7 +70,10 @@ func init() {
 	callCmd.PersistentFlags().BoolVar(&gover, "governance", false, "setting type")
 	callCmd.PersistentFlags().BoolVar(&feeDelegation, "delegation", false, "fee dellegation")
 	callCmd.Flags().StringVar(&dataDir, "path", "$HOME/.aergo/data", "Path to account data directory")
+	callCmd.Flags().StringVar(&keystoreDir, "keystore", "", "Path to key file directory")
 	callCmd.Flags().StringVar(&pw, "password", "", "Password")
+	addExpiryFlags(callCmd)
+	addGasTipFlag(callCmd)
 
 	stateQueryCmd := &cobra.Command{
 		Use:   "statequery [flags] contract varname varindex",
@@ -75,6 +84,14 @@ func init() {
 	stateQueryCmd.Flags().StringVar(&stateroot, "root", "", "Query the state at a specified state root")
 	stateQueryCmd.Flags().BoolVar(&compressed, "compressed", false, "Get a compressed proof for the state")
 
+	verifyCmd := &cobra.Command{
+		Use:   "verify [flags] contract srcfile",
+		Short: "Verify that the deployed code of a contract is compiled from the source file",
+		Args:  cobra.ExactArgs(2),
+		Run:   runVerifyCmd,
+	}
+	verifyCmd.Flags().StringVar(&compilerVer, "compiler", "", "version of the compiler of the deployed code")
+
 	contractCmd.AddCommand(
 		deployCmd,
 		callCmd,
@@ -91,6 +108,7 @@ func init() {
 			Run:   runQueryCmd,
 		},
 		stateQueryCmd,
+		verifyCmd,
 	)
 	rootCmd.AddCommand(contractCmd)
 }
@@ -131,7 +149,7 @@ func runDeployCmd(cmd *cobra.Command, args []string) {
 			}
 			deployArgs = []byte(args[3])
 		}
-		payload = luac.NewLuaCodePayload(luac.NewLuaCode(code, abi), deployArgs)
+		code = luac.NewLuaCode(code, abi)
 	} else {
 		if len(args) == 2 {
 			var ci types.CallInfo
@@ -146,8 +164,18 @@ func runDeployCmd(cmd *cobra.Command, args []string) {
 			_, _ = fmt.Fprint(os.Stderr, err)
 			os.Exit(1)
 		}
-		payload = luac.NewLuaCodePayload(luac.LuaCode(code), deployArgs)
 	}
+	if len(sourceFile) > 0 {
+		source, err := ioutil.ReadFile(sourceFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+		code, err = luac.SetSourceHash(luac.LuaCode(code), source)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+	payload = luac.NewLuaCodePayload(luac.LuaCode(code), deployArgs)
 	amountBigInt, ok := new(big.Int).SetString(amount, 10)
 	if !ok {
 		_, _ = fmt.Fprint(os.Stderr, "failed to parse --amount flags")
@@ -173,14 +201,22 @@ func runDeployCmd(cmd *cobra.Command, args []string) {
 			Recipient: contract,
 		},
 	}
+	if err := fillExpiry(tx.Body); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+	if err := fillGasTip(tx.Body); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
 
-	if cmd.Flags().Changed("path") {
+	if isLocalStore(cmd) {
 		var msgs *types.CommitResultList
 		if errStr := fillChainId(tx); errStr != "" {
 			cmd.Printf(errStr)
 			return
 		}
-		if errStr := fillSign(tx, dataDir, pw, creator); errStr != "" {
+		if errStr := fillSign(tx, pw, creator); errStr != "" {
 			cmd.Printf(errStr)
 			return
 		}
@@ -273,6 +309,14 @@ func runCallCmd(cmd *cobra.Command, args []string) {
 			Type:      txType,
 		},
 	}
+	if err := fillExpiry(tx.Body); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+	if err := fillGasTip(tx.Body); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
 
 	if chainIdHash != "" {
 		rawCidHash, err := base58.Decode(chainIdHash)
@@ -300,13 +344,13 @@ func runCallCmd(cmd *cobra.Command, args []string) {
 		return
 	}
 
-	if cmd.Flags().Changed("path") {
+	if isLocalStore(cmd) {
 		var msgs *types.CommitResultList
 		if errStr := fillChainId(tx); errStr != "" {
 			cmd.Printf(errStr)
 			return
 		}
-		if errStr := fillSign(tx, dataDir, pw, caller); errStr != "" {
+		if errStr := fillSign(tx, pw, caller); errStr != "" {
 			cmd.Printf(errStr)
 			return
 		}
@@ -337,6 +381,29 @@ func runGetABICmd(cmd *cobra.Command, args []string) {
 	cmd.Println(util.JSON(abi))
 }
 
+func runVerifyCmd(cmd *cobra.Command, args []string) {
+	contract, err := types.DecodeAddress(args[0])
+	if err != nil {
+		log.Fatal(err)
+	}
+	source, err := ioutil.ReadFile(args[1])
+	if err != nil {
+		log.Fatal(err)
+	}
+	msg, err := client.VerifyContractSource(context.Background(), &types.ContractSource{
+		ContractAddress: contract,
+		Source:          string(source),
+		CompilerVersion: compilerVer,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	cmd.Println(util.JSON(msg))
+	if !msg.GetVerified() {
+		os.Exit(1)
+	}
+}
+
 func runQueryCmd(cmd *cobra.Command, args []string) {
 	contract, err := types.DecodeAddress(args[0])
 	if err != nil {
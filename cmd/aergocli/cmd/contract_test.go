@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aergoio/aergo/cmd/aergocli/util/encoding/json"
+	"github.com/aergoio/aergo/types"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyContractWithMock(t *testing.T) {
+	mock := initMock(t)
+	defer deinitMock()
+
+	dir, err := ioutil.TempDir("", "verify")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	source := "function hello() return 1 end abi.register(hello)"
+	srcFile := filepath.Join(dir, "hello.lua")
+	if err := ioutil.WriteFile(srcFile, []byte(source), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	testContract := "AmgMhLWDzsC7f4PCpQ5HV4K3d2Dd8Hzm8TcRF7WE8UV8Wy2dsfbs"
+	contract, _ := types.DecodeAddress(testContract)
+	mock.EXPECT().VerifyContractSource(
+		gomock.Any(),
+		&types.ContractSource{ContractAddress: contract, Source: source},
+	).Return(
+		&types.SourceVerification{Verified: true, AbiMatch: true},
+		nil,
+	).Times(1)
+
+	output, err := executeCommand(rootCmd, "contract", "verify", testContract, srcFile)
+	assert.NoError(t, err, "should be success")
+	t.Log(output)
+
+	var result map[string]interface{}
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, true, result["verified"])
+}
@@ -0,0 +1,119 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/aergoio/aergo/cmd/aergocli/util"
+	"github.com/aergoio/aergo/types"
+	"github.com/mr-tron/base58/base58"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(evidenceCmd)
+	evidenceCmd.AddCommand(submitEvidenceCmd)
+	submitEvidenceCmd.Flags().StringVar(&address, "address", "", "address of account submitting the evidence")
+	submitEvidenceCmd.MarkFlagRequired("address")
+}
+
+var evidenceCmd = &cobra.Command{
+	Use:   "evidence",
+	Short: "Show evidences of block producers which signed two different blocks for the same slot",
+	Run:   execListEvidences,
+}
+
+var submitEvidenceCmd = &cobra.Command{
+	Use:   "submit <encoded evidence>",
+	Short: "Submit an evidence of double signing to aergo system",
+	Args:  cobra.ExactArgs(1),
+	RunE:  execSubmitEvidence,
+}
+
+type evidenceBlock struct {
+	Hash     string
+	No       uint64
+	PrevHash string
+}
+
+type evidenceItem struct {
+	Producer   string
+	Slot       int64
+	DetectedAt string
+	Blocks     []evidenceBlock
+	Encoded    string
+}
+
+func execListEvidences(cmd *cobra.Command, args []string) {
+	msg, err := client.ListEvidences(context.Background(), &types.Empty{})
+	if err != nil {
+		cmd.Printf("Failed: %s\n", err.Error())
+		return
+	}
+	items := make([]evidenceItem, 0, len(msg.GetEvidences()))
+	for _, e := range msg.GetEvidences() {
+		producer, err := e.Producer()
+		if err != nil {
+			cmd.Printf("Failed: %s\n", err.Error())
+			return
+		}
+		encoded, err := e.Encode()
+		if err != nil {
+			cmd.Printf("Failed: %s\n", err.Error())
+			return
+		}
+		item := evidenceItem{
+			Producer:   types.IDB58Encode(producer),
+			Slot:       e.Slot,
+			DetectedAt: time.Unix(0, e.DetectedAt).String(),
+			Encoded:    encoded,
+		}
+		for _, h := range []*types.BlockHeader{e.GetFirst(), e.GetSecond()} {
+			block := &types.Block{Header: h}
+			item.Blocks = append(item.Blocks, evidenceBlock{
+				Hash:     base58.Encode(block.BlockHash()),
+				No:       h.GetBlockNo(),
+				PrevHash: base58.Encode(h.GetPrevBlockHash()),
+			})
+		}
+		items = append(items, item)
+	}
+	cmd.Println(util.B58JSON(items))
+}
+
+func execSubmitEvidence(cmd *cobra.Command, args []string) error {
+	account, err := types.DecodeAddress(address)
+	if err != nil {
+		return errors.New("Failed to parse --address flag (" + address + ")\n" + err.Error())
+	}
+	if _, err := types.DecodeDoubleSignEvidence(args[0]); err != nil {
+		return errors.New("Failed to decode evidence\n" + err.Error())
+	}
+	payload, err := json.Marshal(types.CallInfo{Name: types.OpsubmitEvidence.Cmd(), Args: []interface{}{args[0]}})
+	if err != nil {
+		cmd.Printf("Failed: %s\n", err.Error())
+		return nil
+	}
+	tx := &types.Tx{
+		Body: &types.TxBody{
+			Account:   account,
+			Recipient: []byte(types.AergoSystem),
+			Payload:   payload,
+			Type:      types.TxType_GOVERNANCE,
+		},
+	}
+	msg, err := client.SendTX(context.Background(), tx)
+	if err != nil {
+		cmd.Println(err.Error())
+		return nil
+	}
+	cmd.Println(util.JSON(msg))
+	return nil
+}
@@ -27,6 +27,7 @@ var nohidden bool
 var showself bool
 var sortFlag string
 var detailed int
+var known bool
 
 const (
 	sortAddr    = "addr"
@@ -47,18 +48,21 @@ func init() {
 	getpeersCmd.Flags().BoolVar(&showself, "self", false, "show self peer info")
 	getpeersCmd.Flags().StringVar(&sortFlag, "sort", "no", "sort peers by address, id or other")
 	getpeersCmd.Flags().IntVar(&detailed, "detail", 0, "detail level")
+	getpeersCmd.Flags().BoolVar(&known, "known", false, "show peers in address book with connection history, including disconnected ones")
 }
 
 func execGetPeers(cmd *cobra.Command, args []string) {
 	sorter := GetSorter(cmd, sortFlag)
-	msg, err := client.GetPeers(context.Background(), &types.PeersParams{NoHidden: nohidden, ShowSelf: showself})
+	msg, err := client.GetPeers(context.Background(), &types.PeersParams{NoHidden: nohidden, ShowSelf: showself, Known: known})
 	if err != nil {
 		cmd.Printf("Failed to get peer from server: %s\n", err.Error())
 		return
 	}
 	// address and peerid should be encoded, respectively
 	sorter.Sort(msg.Peers)
-	if detailed == 0 {
+	if known {
+		cmd.Println(util.KnownPeerListToString(msg))
+	} else if detailed == 0 {
 		cmd.Println(util.PeerListToString(msg))
 	} else if detailed > 0 {
 		// TODO show long fields
@@ -7,6 +7,9 @@ package cmd
 
 import (
 	"context"
+	"fmt"
+	"math/big"
+	"strings"
 
 	"github.com/aergoio/aergo/cmd/aergocli/util"
 	"github.com/aergoio/aergo/types"
@@ -27,6 +30,7 @@ func init() {
 	getstateCmd.Flags().BoolVar(&proof, "proof", false, "Get the proof for the state")
 	getstateCmd.Flags().BoolVar(&compressed, "compressed", false, "Get a compressed proof for the state")
 	getstateCmd.Flags().BoolVar(&staking, "staking", false, "Get the staking info from the address")
+	getstateCmd.Flags().BoolVar(&votingReward, "reward", false, "Get the voting reward accrued to the address")
 	getstateCmd.Flags().StringVar(&unit, "unit", "aergo", "display unit of balance")
 	rootCmd.AddCommand(getstateCmd)
 }
@@ -58,8 +62,35 @@ func execGetState(cmd *cobra.Command, args []string) {
 			cmd.Printf("Failed: %s", err.Error())
 			return
 		}
-		cmd.Printf(`{"account":"%s", "staked":"%s", "when":%d}`+"\n",
-			address, amount, msg.GetWhen())
+		var unbondings []string
+		for _, u := range msg.GetUnbondings() {
+			unbonding, err := util.ConvertUnit(new(big.Int).SetBytes(u.GetAmount()), unit)
+			if err != nil {
+				cmd.Printf("Failed: %s", err.Error())
+				return
+			}
+			unbondings = append(unbondings, fmt.Sprintf(`{"amount":"%s", "release":%d}`, unbonding, u.GetRelease()))
+		}
+		cmd.Printf(`{"account":"%s", "staked":"%s", "when":%d, "unbonding":[%s]}`+"\n",
+			address, amount, msg.GetWhen(), strings.Join(unbondings, ", "))
+
+		return
+	}
+
+	if votingReward {
+		msg, err := client.GetVotingReward(context.Background(),
+			&types.AccountAddress{Value: addr})
+		if err != nil {
+			cmd.Printf("Failed: %s", err.Error())
+			return
+		}
+		accrued, err := util.ConvertUnit(new(big.Int).SetBytes(msg.GetAccrued()), unit)
+		if err != nil {
+			cmd.Printf("Failed: %s", err.Error())
+			return
+		}
+		cmd.Printf(`{"account":"%s", "power":"%s", "accrued":"%s"}`+"\n",
+			address, new(big.Int).SetBytes(msg.GetPower()), accrued)
 
 		return
 	}
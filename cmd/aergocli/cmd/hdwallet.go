@@ -0,0 +1,167 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aergoio/aergo/account/key"
+	"github.com/aergoio/aergo/types"
+	"github.com/spf13/cobra"
+)
+
+var (
+	mnemonic string
+	hdPath   string
+	hdIndex  uint32
+)
+
+func openLocalStore(cmd *cobra.Command) *key.Store {
+	if !isLocalStore(cmd) {
+		return nil
+	}
+	return newLocalStore()
+}
+
+func getHDPassword(cmd *cobra.Command, isNew bool) (string, error) {
+	if pw != "" {
+		return pw, nil
+	}
+	return getPasswd(cmd, isNew)
+}
+
+var hdNewCmd = &cobra.Command{
+	Use:   "hdnew [flags]",
+	Short: "Create a HD wallet from a new mnemonic in the node or cli",
+	Run: func(cmd *cobra.Command, args []string) {
+		pass, err := getHDPassword(cmd, true)
+		if err != nil {
+			cmd.Printf("Failed get password: %s\n", err.Error())
+			return
+		}
+		var wallet *types.HDWallet
+		if ks := openLocalStore(cmd); ks != nil {
+			defer ks.CloseStore()
+			wallet = &types.HDWallet{}
+			wallet.Mnemonic, err = ks.CreateHDWallet(hdPath, pass)
+			if err == nil {
+				wallet.Path, err = ks.GetHDPath()
+			}
+		} else {
+			wallet, err = client.CreateHDWallet(context.Background(),
+				&types.HDWallet{Passphrase: pass, Path: hdPath})
+		}
+		if err != nil {
+			cmd.Printf("Failed: %s\n", err.Error())
+			return
+		}
+		cmd.Println("Write down the mnemonic below. It is the only backup of the derived accounts.")
+		cmd.Println(wallet.GetMnemonic())
+		cmd.Println("path:", wallet.GetPath())
+	},
+}
+
+var hdImportCmd = &cobra.Command{
+	Use:   "hdimport [flags]",
+	Short: "Import a HD wallet from a mnemonic in the node or cli",
+	Run: func(cmd *cobra.Command, args []string) {
+		pass, err := getHDPassword(cmd, true)
+		if err != nil {
+			cmd.Printf("Failed get password: %s\n", err.Error())
+			return
+		}
+		var wallet *types.HDWallet
+		if ks := openLocalStore(cmd); ks != nil {
+			defer ks.CloseStore()
+			wallet = &types.HDWallet{}
+			err = ks.ImportHDWallet(mnemonic, hdPath, pass)
+			if err == nil {
+				wallet.Path, err = ks.GetHDPath()
+			}
+		} else {
+			wallet, err = client.ImportHDWallet(context.Background(),
+				&types.HDWallet{Passphrase: pass, Mnemonic: mnemonic, Path: hdPath})
+		}
+		if err != nil {
+			cmd.Printf("Failed: %s\n", err.Error())
+			return
+		}
+		cmd.Println("path:", wallet.GetPath())
+	},
+}
+
+var hdDeriveCmd = &cobra.Command{
+	Use:   "hdderive [flags]",
+	Short: "Derive the accounts of the HD wallet up to the index",
+	Run: func(cmd *cobra.Command, args []string) {
+		pass, err := getHDPassword(cmd, false)
+		if err != nil {
+			cmd.Printf("Failed get password: %s\n", err.Error())
+			return
+		}
+		var addr []byte
+		if ks := openLocalStore(cmd); ks != nil {
+			defer ks.CloseStore()
+			addr, err = ks.DeriveHDKey(hdIndex, pass)
+		} else {
+			var msg *types.Account
+			msg, err = client.DeriveHDAccount(context.Background(),
+				&types.HDAccount{Passphrase: pass, Index: hdIndex})
+			addr = msg.GetAddress()
+		}
+		if err != nil {
+			cmd.Printf("Failed: %s\n", err.Error())
+			return
+		}
+		cmd.Println(types.EncodeAddress(addr))
+	},
+}
+
+var hdListCmd = &cobra.Command{
+	Use:   "hdlist [flags]",
+	Short: "Get the derived accounts of the HD wallet in the order of their index",
+	Run: func(cmd *cobra.Command, args []string) {
+		var addrs [][]byte
+		var err error
+		if ks := openLocalStore(cmd); ks != nil {
+			defer ks.CloseStore()
+			addrs, err = ks.GetHDAddresses()
+		} else {
+			var msg *types.AccountList
+			msg, err = client.GetHDAccounts(context.Background(), &types.Empty{})
+			for _, a := range msg.GetAccounts() {
+				addrs = append(addrs, a.GetAddress())
+			}
+		}
+		if err != nil {
+			cmd.Printf("Failed: %s\n", err.Error())
+			return
+		}
+		for i, a := range addrs {
+			cmd.Println(fmt.Sprintf("%d: %s", i, types.EncodeAddress(a)))
+		}
+	},
+}
+
+var hdUnlockCmd = &cobra.Command{
+	Use:   "hdunlock [flags]",
+	Short: "Unlock the derived account at the index in the node",
+	Run: func(cmd *cobra.Command, args []string) {
+		pass, err := getHDPassword(cmd, false)
+		if err != nil {
+			cmd.Printf("Failed get password: %s\n", err.Error())
+			return
+		}
+		msg, err := client.UnlockHDAccount(context.Background(),
+			&types.HDAccount{Passphrase: pass, Index: hdIndex})
+		if err != nil {
+			cmd.Printf("Failed: %s\n", err.Error())
+			return
+		}
+		cmd.Println(types.EncodeAddress(msg.GetAddress()))
+	},
+}
@@ -790,3 +790,165 @@ func (mr *MockAergoRPCServiceClientMockRecorder) VerifyTX(arg0, arg1 interface{}
 	varargs := append([]interface{}{arg0, arg1}, arg2...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyTX", reflect.TypeOf((*MockAergoRPCServiceClient)(nil).VerifyTX), varargs...)
 }
+
+// ListEvidences mocks base method
+func (m *MockAergoRPCServiceClient) ListEvidences(arg0 context.Context, arg1 *types.Empty, arg2 ...grpc.CallOption) (*types.DoubleSignEvidenceList, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListEvidences", varargs...)
+	ret0, _ := ret[0].(*types.DoubleSignEvidenceList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEvidences indicates an expected call of ListEvidences
+func (mr *MockAergoRPCServiceClientMockRecorder) ListEvidences(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEvidences", reflect.TypeOf((*MockAergoRPCServiceClient)(nil).ListEvidences), varargs...)
+}
+
+// GetBPStats mocks base method
+func (m *MockAergoRPCServiceClient) GetBPStats(arg0 context.Context, arg1 *types.BPStatParams, arg2 ...grpc.CallOption) (*types.BPStatList, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetBPStats", varargs...)
+	ret0, _ := ret[0].(*types.BPStatList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBPStats indicates an expected call of GetBPStats
+func (mr *MockAergoRPCServiceClientMockRecorder) GetBPStats(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBPStats", reflect.TypeOf((*MockAergoRPCServiceClient)(nil).GetBPStats), varargs...)
+}
+
+// GetVotingReward mocks base method
+func (m *MockAergoRPCServiceClient) GetVotingReward(arg0 context.Context, arg1 *types.AccountAddress, arg2 ...grpc.CallOption) (*types.VotingReward, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetVotingReward", varargs...)
+	ret0, _ := ret[0].(*types.VotingReward)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVotingReward indicates an expected call of GetVotingReward
+func (mr *MockAergoRPCServiceClientMockRecorder) GetVotingReward(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVotingReward", reflect.TypeOf((*MockAergoRPCServiceClient)(nil).GetVotingReward), varargs...)
+}
+
+// CreateHDWallet mocks base method
+func (m *MockAergoRPCServiceClient) CreateHDWallet(arg0 context.Context, arg1 *types.HDWallet, arg2 ...grpc.CallOption) (*types.HDWallet, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateHDWallet", varargs...)
+	ret0, _ := ret[0].(*types.HDWallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateHDWallet indicates an expected call of CreateHDWallet
+func (mr *MockAergoRPCServiceClientMockRecorder) CreateHDWallet(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateHDWallet", reflect.TypeOf((*MockAergoRPCServiceClient)(nil).CreateHDWallet), varargs...)
+}
+
+// ImportHDWallet mocks base method
+func (m *MockAergoRPCServiceClient) ImportHDWallet(arg0 context.Context, arg1 *types.HDWallet, arg2 ...grpc.CallOption) (*types.HDWallet, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ImportHDWallet", varargs...)
+	ret0, _ := ret[0].(*types.HDWallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportHDWallet indicates an expected call of ImportHDWallet
+func (mr *MockAergoRPCServiceClientMockRecorder) ImportHDWallet(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportHDWallet", reflect.TypeOf((*MockAergoRPCServiceClient)(nil).ImportHDWallet), varargs...)
+}
+
+// DeriveHDAccount mocks base method
+func (m *MockAergoRPCServiceClient) DeriveHDAccount(arg0 context.Context, arg1 *types.HDAccount, arg2 ...grpc.CallOption) (*types.Account, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeriveHDAccount", varargs...)
+	ret0, _ := ret[0].(*types.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeriveHDAccount indicates an expected call of DeriveHDAccount
+func (mr *MockAergoRPCServiceClientMockRecorder) DeriveHDAccount(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeriveHDAccount", reflect.TypeOf((*MockAergoRPCServiceClient)(nil).DeriveHDAccount), varargs...)
+}
+
+// GetHDAccounts mocks base method
+func (m *MockAergoRPCServiceClient) GetHDAccounts(arg0 context.Context, arg1 *types.Empty, arg2 ...grpc.CallOption) (*types.AccountList, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetHDAccounts", varargs...)
+	ret0, _ := ret[0].(*types.AccountList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHDAccounts indicates an expected call of GetHDAccounts
+func (mr *MockAergoRPCServiceClientMockRecorder) GetHDAccounts(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHDAccounts", reflect.TypeOf((*MockAergoRPCServiceClient)(nil).GetHDAccounts), varargs...)
+}
+
+// UnlockHDAccount mocks base method
+func (m *MockAergoRPCServiceClient) UnlockHDAccount(arg0 context.Context, arg1 *types.HDAccount, arg2 ...grpc.CallOption) (*types.Account, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UnlockHDAccount", varargs...)
+	ret0, _ := ret[0].(*types.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnlockHDAccount indicates an expected call of UnlockHDAccount
+func (mr *MockAergoRPCServiceClientMockRecorder) UnlockHDAccount(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockHDAccount", reflect.TypeOf((*MockAergoRPCServiceClient)(nil).UnlockHDAccount), varargs...)
+}
+
+// VerifyContractSource mocks base method
+func (m *MockAergoRPCServiceClient) VerifyContractSource(arg0 context.Context, arg1 *types.ContractSource, arg2 ...grpc.CallOption) (*types.SourceVerification, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "VerifyContractSource", varargs...)
+	ret0, _ := ret[0].(*types.SourceVerification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyContractSource indicates an expected call of VerifyContractSource
+func (mr *MockAergoRPCServiceClientMockRecorder) VerifyContractSource(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyContractSource", reflect.TypeOf((*MockAergoRPCServiceClient)(nil).VerifyContractSource), varargs...)
+}
@@ -0,0 +1,177 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package cmd
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aergoio/aergo/account/key"
+	"github.com/aergoio/aergo/cmd/aergocli/util"
+	"github.com/aergoio/aergo/types"
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/mr-tron/base58/base58"
+	"github.com/spf13/cobra"
+)
+
+var multisigConfig string
+
+func init() {
+	rootCmd.AddCommand(multisigCmd)
+
+	multisigCreateCmd.Flags().StringVar(&address, "address", "", "address of the account creating the multisig account")
+	multisigCreateCmd.MarkFlagRequired("address")
+	multisigCreateCmd.Flags().StringVar(&multisigConfig, "config", "", `multisig config json, e.g. {"threshold":2,"keys":["Am...","Am...","Am..."]}`)
+	multisigCreateCmd.MarkFlagRequired("config")
+	multisigCreateCmd.Flags().StringVar(&amount, "amount", "0", "amount sent to the multisig account")
+
+	multisigAddressCmd.Flags().StringVar(&multisigConfig, "config", "", "multisig config json")
+	multisigAddressCmd.MarkFlagRequired("config")
+
+	multisigSignCmd.Flags().StringVar(&jsonTx, "jsontx", "", "transaction json to sign")
+	multisigSignCmd.MarkFlagRequired("jsontx")
+	multisigSignCmd.Flags().StringVar(&multisigConfig, "config", "", "multisig config json")
+	multisigSignCmd.MarkFlagRequired("config")
+	multisigSignCmd.Flags().StringVar(&dataDir, "path", "$HOME/.aergo/data/cli", "path to data directory")
+	multisigSignCmd.Flags().StringVar(&keystoreDir, "keystore", "", "Path to key file directory")
+	multisigSignCmd.Flags().StringVar(&address, "address", "", "address of the key to sign with")
+	multisigSignCmd.Flags().StringVar(&pw, "password", "", "local account password")
+	multisigSignCmd.Flags().StringVar(&privKey, "key", "", "base58 encoded key for sign")
+
+	multisigCombineCmd.Flags().StringVar(&jsonTx, "jsontx", "", "list json of the partially signed transactions")
+	multisigCombineCmd.MarkFlagRequired("jsontx")
+
+	multisigCmd.AddCommand(multisigCreateCmd, multisigAddressCmd, multisigSignCmd, multisigCombineCmd)
+}
+
+var multisigCmd = &cobra.Command{
+	Use:   "multisig",
+	Short: "Multisig account command",
+}
+
+var multisigCreateCmd = &cobra.Command{
+	Use:    "create",
+	Short:  "Create a multisig account",
+	PreRun: preConnectAergo,
+	RunE:   execMultisigCreate,
+}
+
+func execMultisigCreate(cmd *cobra.Command, args []string) error {
+	account, err := types.DecodeAddress(address)
+	if err != nil {
+		return errors.New("Failed to parse --address flag (" + address + ")\n" + err.Error())
+	}
+	config, err := types.ParseMultisigConfig([]byte(multisigConfig))
+	if err != nil {
+		return errors.New("Failed to parse --config flag\n" + err.Error())
+	}
+	amountBigInt, err := util.ParseUnit(amount)
+	if err != nil {
+		return errors.New("Failed to parse --amount flag\n" + err.Error())
+	}
+	payload, err := config.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	tx := &types.Tx{
+		Body: &types.TxBody{
+			Account:   account,
+			Recipient: config.Address(),
+			Amount:    amountBigInt.Bytes(),
+			Payload:   payload,
+			Type:      types.TxType_MULTISIG,
+		},
+	}
+	msg, err := client.SendTX(context.Background(), tx)
+	if err != nil {
+		cmd.Printf("Failed: %s\n", err.Error())
+		return nil
+	}
+	cmd.Println(types.EncodeAddress(config.Address()))
+	cmd.Println(util.JSON(msg))
+	return nil
+}
+
+var multisigAddressCmd = &cobra.Command{
+	Use:   "address",
+	Short: "Print the address of a multisig account",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		config, err := types.ParseMultisigConfig([]byte(multisigConfig))
+		if err != nil {
+			return errors.New("Failed to parse --config flag\n" + err.Error())
+		}
+		cmd.Println(types.EncodeAddress(config.Address()))
+		return nil
+	},
+}
+
+var multisigSignCmd = &cobra.Command{
+	Use:   "sign",
+	Short: "Add a signature to a transaction of a multisig account",
+	Run: func(cmd *cobra.Command, args []string) {
+		config, err := types.ParseMultisigConfig([]byte(multisigConfig))
+		if err != nil {
+			cmd.Printf("Failed: %s\n", err.Error())
+			return
+		}
+		body, err := util.ParseBase58TxBody([]byte(jsonTx))
+		if err != nil {
+			cmd.Printf("Failed: %s\n", err.Error())
+			return
+		}
+		tx := &types.Tx{Body: body}
+		if privKey != "" {
+			rawKey, err := base58.Decode(privKey)
+			if err != nil {
+				cmd.Printf("Failed: %s\n", err.Error())
+				return
+			}
+			signKey, _ := btcec.PrivKeyFromBytes(btcec.S256(), rawKey)
+			err = key.SignMultisigTx(tx, signKey, config)
+		} else {
+			if cmd.Flags().Changed("address") == false {
+				cmd.Print("Error: required flag(s) \"address\" not set")
+				return
+			}
+			var signer []byte
+			signer, err = types.DecodeAddress(address)
+			if err != nil {
+				cmd.Printf("Failed: %s\n", err.Error())
+				return
+			}
+			ks := newLocalStore()
+			defer ks.CloseStore()
+			var sign []byte
+			sign, err = ks.Sign(signer, pw, key.CalculateHashWithoutSign(body))
+			if err == nil {
+				err = key.AddMultisigSign(tx, config, signer, sign)
+			}
+		}
+		if err != nil {
+			cmd.Printf("Failed: %s\n", err.Error())
+			return
+		}
+		cmd.Println(util.TxConvBase58Addr(tx))
+	},
+}
+
+var multisigCombineCmd = &cobra.Command{
+	Use:   "combine",
+	Short: "Combine the signatures of partially signed transactions",
+	Run: func(cmd *cobra.Command, args []string) {
+		txs, err := util.ParseBase58Tx([]byte(jsonTx))
+		if err != nil {
+			cmd.Printf("Failed: %s\n", err.Error())
+			return
+		}
+		tx, err := key.CombineMultisigTx(txs...)
+		if err != nil {
+			cmd.Printf("Failed: %s\n", err.Error())
+			return
+		}
+		cmd.Println(util.TxConvBase58Addr(tx))
+	},
+}
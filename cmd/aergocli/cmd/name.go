@@ -11,6 +11,7 @@ import (
 	"errors"
 	"log"
 	"math/big"
+	"strconv"
 
 	"github.com/aergoio/aergo/cmd/aergocli/util"
 	"github.com/aergoio/aergo/types"
@@ -62,7 +63,51 @@ func init() {
 	ownerCmd.MarkFlagRequired("name")
 	ownerCmd.Flags().Uint64VarP(&blockNo, "blockno", "n", 0, "Block height")
 
-	nameCmd.AddCommand(newCmd, updateCmd, ownerCmd)
+	renewCmd := &cobra.Command{
+		Use:                   "renew",
+		Short:                 "Extend the registration period of account name",
+		RunE:                  execNameRenew,
+		DisableFlagsInUseLine: true,
+	}
+	renewCmd.Flags().StringVar(&from, "from", "", "Sender account address")
+	renewCmd.MarkFlagRequired("from")
+	renewCmd.Flags().StringVar(&name, "name", "", "Name of account to renew")
+	renewCmd.MarkFlagRequired("name")
+	renewCmd.Flags().StringVar(&spending, "amount", "1aergo", "Spending for renew name. at least 1 aergo")
+
+	subnameCmd := &cobra.Command{
+		Use:                   "subname",
+		Short:                 "Set or remove subname (e.g. pay.myname123456) of account name",
+		RunE:                  execNameSubname,
+		DisableFlagsInUseLine: true,
+	}
+	subnameCmd.Flags().StringVar(&from, "from", "", "Sender account address")
+	subnameCmd.MarkFlagRequired("from")
+	subnameCmd.Flags().StringVar(&name, "name", "", "Subname to set")
+	subnameCmd.MarkFlagRequired("name")
+	subnameCmd.Flags().StringVar(&to, "to", "", "Recipient account address. remove subname if empty")
+
+	primaryCmd := &cobra.Command{
+		Use:                   "primary",
+		Short:                 "Set or remove primary name of account address",
+		RunE:                  execNamePrimary,
+		DisableFlagsInUseLine: true,
+	}
+	primaryCmd.Flags().StringVar(&from, "from", "", "Sender account address")
+	primaryCmd.MarkFlagRequired("from")
+	primaryCmd.Flags().StringVar(&name, "name", "", "Name resolved to the sender. remove primary name if empty")
+
+	reverseCmd := &cobra.Command{
+		Use:                   "reverse",
+		Short:                 "Primary name of account address",
+		Run:                   execNameReverse,
+		DisableFlagsInUseLine: true,
+	}
+	reverseCmd.Flags().StringVar(&address, "address", "", "Account address")
+	reverseCmd.MarkFlagRequired("address")
+	reverseCmd.Flags().Uint64VarP(&blockNo, "blockno", "n", 0, "Block height")
+
+	nameCmd.AddCommand(newCmd, updateCmd, ownerCmd, renewCmd, subnameCmd, primaryCmd, reverseCmd)
 }
 
 func execNameNew(cmd *cobra.Command, args []string) error {
@@ -161,13 +206,98 @@ func execNameUpdate(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func execNameRenew(cmd *cobra.Command, args []string) error {
+	account, err := types.DecodeAddress(from)
+	if err != nil {
+		return errors.New("Wrong address in --from flag\n" + err.Error())
+	}
+	if len(name) != types.NameLength {
+		return errors.New("The name must be 12 alphabetic characters\n")
+	}
+	amount, err := util.ParseUnit(spending)
+	if err != nil {
+		return errors.New("Wrong value in --amount flag\n" + err.Error())
+	}
+	return sendNameTx(cmd, account, amount, types.NameRenew, name)
+}
+
+func execNameSubname(cmd *cobra.Command, args []string) error {
+	account, err := types.DecodeAddress(from)
+	if err != nil {
+		return errors.New("Wrong address in --from flag\n" + err.Error())
+	}
+	if _, _, ok := types.ParseSubname(name); !ok {
+		return errors.New("The subname must be <label>.<name> with a label of at most 12 alphabetic characters\n")
+	}
+	if to != "" {
+		if _, err = types.DecodeAddress(to); err != nil {
+			return errors.New("Wrong address in --to flag\n" + err.Error())
+		}
+	}
+	return sendNameTx(cmd, account, big.NewInt(0), types.NameSetSubname, name, to)
+}
+
+func execNamePrimary(cmd *cobra.Command, args []string) error {
+	account, err := types.DecodeAddress(from)
+	if err != nil {
+		return errors.New("Wrong address in --from flag\n" + err.Error())
+	}
+	return sendNameTx(cmd, account, big.NewInt(0), types.NameSetPrimary, name)
+}
+
+func sendNameTx(cmd *cobra.Command, account []byte, amount *big.Int, op string, args ...interface{}) error {
+	payload, err := json.Marshal(&types.CallInfo{Name: op, Args: args})
+	if err != nil {
+		return err
+	}
+	tx := &types.Tx{
+		Body: &types.TxBody{
+			Account:   account,
+			Recipient: []byte(types.AergoName),
+			Amount:    amount.Bytes(),
+			Payload:   payload,
+			GasLimit:  0,
+			Type:      types.TxType_GOVERNANCE,
+		},
+	}
+	msg, err := client.SendTX(context.Background(), tx)
+	if err != nil {
+		cmd.Printf("Failed request to aergo sever\n" + err.Error())
+		return nil
+	}
+	cmd.Println(util.JSON(msg))
+	return nil
+}
+
 func execNameOwner(cmd *cobra.Command, args []string) {
 	msg, err := client.GetNameInfo(context.Background(), &types.Name{Name: name, BlockNo: blockNo})
 	if err != nil {
 		cmd.Println(err.Error())
 		return
 	}
+	printNameInfo(cmd, msg)
+}
+
+func execNameReverse(cmd *cobra.Command, args []string) {
+	if _, err := types.DecodeAddress(address); err != nil || len(address) != types.EncodedAddressLength {
+		cmd.Println("Wrong address in --address flag")
+		return
+	}
+	msg, err := client.GetNameInfo(context.Background(), &types.Name{Name: address, BlockNo: blockNo})
+	if err != nil {
+		cmd.Println(err.Error())
+		return
+	}
+	printNameInfo(cmd, msg)
+}
+
+func printNameInfo(cmd *cobra.Command, msg *types.NameInfo) {
+	expiry := ""
+	if msg.Status != "" {
+		expiry = ",\n  \"ExpireNo\": " + strconv.FormatUint(msg.ExpireNo, 10) + ",\n  " +
+			"\"Status\": \"" + msg.Status + "\""
+	}
 	cmd.Println("{\n \"" + msg.Name.Name + "\": {\n  " +
 		"\"Owner\": \"" + types.EncodeAddress(msg.Owner) + "\",\n  " +
-		"\"Destination\": \"" + types.EncodeAddress(msg.Destination) + "\"\n  }\n}")
+		"\"Destination\": \"" + types.EncodeAddress(msg.Destination) + "\"" + expiry + "\n  }\n}")
 }
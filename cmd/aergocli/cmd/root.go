@@ -31,15 +31,17 @@ var (
 	cfgFile string
 	host    string
 	port    int32
-
-	crtFile   string
-	cacrtFile string
-	svrName   string
-	keyFile   string
-	certPeer  string
-	privKey   string
-	pw        string
-	dataDir   string
+	token   string
+
+	crtFile     string
+	cacrtFile   string
+	svrName     string
+	keyFile     string
+	certPeer    string
+	privKey     string
+	pw          string
+	dataDir     string
+	keystoreDir string
 
 	from   string
 	to     string
@@ -52,7 +54,8 @@ var (
 	proof      bool
 	compressed bool
 
-	staking bool
+	staking      bool
+	votingReward bool
 
 	remote       bool
 	importFormat string
@@ -80,12 +83,14 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&keyFile, "tlskey", "", "client key file for TLS ")
 	rootCmd.PersistentFlags().StringVarP(&host, "host", "H", "localhost", "Host address to aergo server")
 	rootCmd.PersistentFlags().Int32VarP(&port, "port", "p", 7845, "Port number to aergo server")
+	rootCmd.PersistentFlags().StringVar(&token, "token", "", "Bearer token (JWT or API key) for the RPC authentication")
 }
 
 func initConfig() {
 	cliCtx := NewCliContext(home, cfgFile)
 	cliCtx.Vc.BindPFlag("host", rootCmd.PersistentFlags().Lookup("host"))
 	cliCtx.Vc.BindPFlag("port", rootCmd.PersistentFlags().Lookup("port"))
+	cliCtx.Vc.BindPFlag("token", rootCmd.PersistentFlags().Lookup("token"))
 	cliCtx.Vc.BindPFlag("tls.servername", rootCmd.PersistentFlags().Lookup("tlsservername"))
 	cliCtx.Vc.BindPFlag("tls.cacert", rootCmd.PersistentFlags().Lookup("tlscacert"))
 	cliCtx.Vc.BindPFlag("tls.clientcert", rootCmd.PersistentFlags().Lookup("tlscert"))
@@ -139,8 +144,14 @@ func connectAergo(cmd *cobra.Command, args []string) {
 			RootCAs:      certPool,
 		})
 		opts = append(opts, grpc.WithTransportCredentials(creds))
+		if rootConfig.Token != "" {
+			opts = append(opts, grpc.WithPerRPCCredentials(util.NewTokenCredentials(rootConfig.Token, true)))
+		}
 	} else {
 		opts = append(opts, grpc.WithInsecure())
+		if rootConfig.Token != "" {
+			opts = append(opts, grpc.WithPerRPCCredentials(util.NewTokenCredentials(rootConfig.Token, false)))
+		}
 	}
 	var ok bool
 	client, ok = util.GetClient(serverAddr, opts).(*util.ConnClient)
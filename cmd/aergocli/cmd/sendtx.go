@@ -8,6 +8,8 @@ package cmd
 import (
 	"context"
 	"errors"
+	"strconv"
+	"time"
 
 	"github.com/aergoio/aergo/cmd/aergocli/util"
 	"github.com/aergoio/aergo/types"
@@ -23,6 +25,12 @@ var sendtxCmd = &cobra.Command{
 }
 var chainIdHash string
 
+var (
+	validUntilNo uint64
+	validUntil   string
+	gasTip       string
+)
+
 func init() {
 	rootCmd.AddCommand(sendtxCmd)
 	sendtxCmd.Flags().StringVar(&from, "from", "", "Sender account address")
@@ -34,6 +42,49 @@ func init() {
 	sendtxCmd.Flags().Uint64Var(&nonce, "nonce", 0, "setting nonce manually")
 	sendtxCmd.Flags().StringVar(&chainIdHash, "chainidhash", "", "hash value of chain id in the block")
 	sendtxCmd.Flags().Uint64VarP(&gas, "gaslimit", "g", 0, "Gas limit")
+	addExpiryFlags(sendtxCmd)
+	addGasTipFlag(sendtxCmd)
+}
+
+func addExpiryFlags(cmd *cobra.Command) {
+	flags := cmd.PersistentFlags()
+	flags.Uint64Var(&validUntilNo, "validuntilno", 0, "last block number in which the tx can be included")
+	flags.StringVar(&validUntil, "validuntil", "", "last block time at which the tx can be included (unix seconds, RFC3339 or duration from now e.g. 10m)")
+}
+
+func addGasTipFlag(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVar(&gasTip, "tip", "", "tip per gas paid to the block producer in addition to the base fee")
+}
+
+// fillGasTip sets the tip given by --tip.
+func fillGasTip(body *types.TxBody) error {
+	if gasTip == "" {
+		return nil
+	}
+	tip, err := util.ParseUnit(gasTip)
+	if err != nil {
+		return errors.New("Wrong value in --tip flag\n" + err.Error())
+	}
+	body.GasTip = tip.Bytes()
+	return nil
+}
+
+// fillExpiry sets the expiry given by --validuntilno and --validuntil.
+func fillExpiry(body *types.TxBody) error {
+	body.ValidUntilNo = validUntilNo
+	if validUntil == "" {
+		return nil
+	}
+	if sec, err := strconv.ParseInt(validUntil, 10, 64); err == nil {
+		body.ValidUntilTime = sec
+	} else if t, err := time.Parse(time.RFC3339, validUntil); err == nil {
+		body.ValidUntilTime = t.Unix()
+	} else if d, err := time.ParseDuration(validUntil); err == nil {
+		body.ValidUntilTime = time.Now().Add(d).Unix()
+	} else {
+		return errors.New("Wrong value in --validuntil flag")
+	}
+	return nil
 }
 
 func execSendTX(cmd *cobra.Command, args []string) error {
@@ -57,6 +108,12 @@ func execSendTX(cmd *cobra.Command, args []string) error {
 		Nonce:     nonce,
 		GasLimit:  gas,
 	}}
+	if err := fillExpiry(tx.GetBody()); err != nil {
+		return err
+	}
+	if err := fillGasTip(tx.GetBody()); err != nil {
+		return err
+	}
 	if chainIdHash != "" {
 		cid, err := base58.Decode(chainIdHash)
 		if err != nil {
@@ -3,7 +3,6 @@ package cmd
 import (
 	"context"
 	"fmt"
-	"os"
 
 	"github.com/aergoio/aergo/account/key"
 	"github.com/aergoio/aergo/cmd/aergocli/util"
@@ -17,6 +16,7 @@ func init() {
 	rootCmd.AddCommand(signCmd)
 	signCmd.Flags().StringVar(&jsonTx, "jsontx", "", "transaction json to sign")
 	signCmd.Flags().StringVar(&dataDir, "path", "$HOME/.aergo/data/cli", "path to data directory")
+	signCmd.Flags().StringVar(&keystoreDir, "keystore", "", "Path to key file directory")
 	signCmd.Flags().StringVar(&address, "address", "1", "address of account to use for signing")
 	signCmd.Flags().StringVar(&pw, "password", "", "local account password")
 	signCmd.Flags().StringVar(&privKey, "key", "", "base58 encoded key for sign")
@@ -58,7 +58,7 @@ var signCmd = &cobra.Command{
 			}
 			cmd.Println(types.EncodeAddress(key.GenerateAddress(pubkey.ToECDSA())))
 			msg = tx
-		} else if cmd.Flags().Changed("path") == false {
+		} else if !isLocalStore(cmd) {
 			msg, err = client.SignTX(context.Background(), &types.Tx{Body: param})
 		} else {
 			tx := &types.Tx{Body: param}
@@ -72,8 +72,7 @@ var signCmd = &cobra.Command{
 				return
 			}
 
-			dataEnvPath := os.ExpandEnv(dataDir)
-			ks := key.NewStore(dataEnvPath, 0)
+			ks := newLocalStore()
 			defer ks.CloseStore()
 			addr, err := types.DecodeAddress(address)
 			if err != nil {
@@ -134,10 +133,9 @@ var verifyCmd = &cobra.Command{
 	},
 }
 
-func fillSign(tx *types.Tx, dataDir, pw string, account []byte) string {
+func fillSign(tx *types.Tx, pw string, account []byte) string {
 	hash := key.CalculateHashWithoutSign(tx.Body)
-	dataEnvPath := os.ExpandEnv(dataDir)
-	ks := key.NewStore(dataEnvPath, 0)
+	ks := newLocalStore()
 	defer ks.CloseStore()
 	var err error
 	tx.Body.Sign, err = ks.Sign(account, pw, hash)
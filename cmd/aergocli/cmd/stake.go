@@ -35,6 +35,66 @@ func execUnstake(cmd *cobra.Command, args []string) error {
 	return sendStake(cmd, false)
 }
 
+var withdrawCmd = &cobra.Command{
+	Use:   "withdraw",
+	Short: "Withdraw the unstaked balance whose unbonding period has passed",
+	RunE:  execWithdraw,
+}
+
+func execWithdraw(cmd *cobra.Command, args []string) error {
+	account, err := types.DecodeAddress(address)
+	if err != nil {
+		return errors.New("Failed to parse --address flag (" + address + ")\n" + err.Error())
+	}
+	return sendSystemTx(cmd, account, types.CallInfo{Name: types.Opwithdraw.Cmd()}, nil)
+}
+
+var claimRewardCmd = &cobra.Command{
+	Use:   "claimreward",
+	Short: "Claim the voting reward accrued to the account",
+	RunE:  execClaimReward,
+}
+
+func execClaimReward(cmd *cobra.Command, args []string) error {
+	account, err := types.DecodeAddress(address)
+	if err != nil {
+		return errors.New("Failed to parse --address flag (" + address + ")\n" + err.Error())
+	}
+	return sendSystemTx(cmd, account, types.CallInfo{Name: types.OpclaimReward.Cmd()}, nil)
+}
+
+var delegateCmd = &cobra.Command{
+	Use:   "delegate [flags] <delegate>",
+	Short: "Delegate the voting power of the account to another account",
+	Args:  cobra.ExactArgs(1),
+	RunE:  execDelegate,
+}
+
+func execDelegate(cmd *cobra.Command, args []string) error {
+	account, err := types.DecodeAddress(address)
+	if err != nil {
+		return errors.New("Failed to parse --address flag (" + address + ")\n" + err.Error())
+	}
+	if _, err := types.DecodeAddress(args[0]); err != nil {
+		return errors.New("Failed to parse delegate (" + args[0] + ")\n" + err.Error())
+	}
+	return sendSystemTx(cmd, account, types.CallInfo{Name: types.Opdelegate.Cmd(), Args: []interface{}{args[0]}}, nil)
+}
+
+var undelegateCmd = &cobra.Command{
+	Use:   "undelegate",
+	Short: "Revoke the delegation of the voting power of the account",
+	RunE:  execUndelegate,
+}
+
+func execUndelegate(cmd *cobra.Command, args []string) error {
+	account, err := types.DecodeAddress(address)
+	if err != nil {
+		return errors.New("Failed to parse --address flag (" + address + ")\n" + err.Error())
+	}
+	return sendSystemTx(cmd, account, types.CallInfo{Name: types.Opundelegate.Cmd()}, nil)
+}
+
 func sendStake(cmd *cobra.Command, s bool) error {
 	account, err := types.DecodeAddress(address)
 	if err != nil {
@@ -50,6 +110,10 @@ func sendStake(cmd *cobra.Command, s bool) error {
 	if err != nil {
 		return errors.New("Failed to parse --amount flag\n" + err.Error())
 	}
+	return sendSystemTx(cmd, account, ci, amountBigInt.Bytes())
+}
+
+func sendSystemTx(cmd *cobra.Command, account []byte, ci types.CallInfo, amount []byte) error {
 	payload, err := json.Marshal(ci)
 	if err != nil {
 		cmd.Printf("Failed: %s\n", err.Error())
@@ -59,7 +123,7 @@ func sendStake(cmd *cobra.Command, s bool) error {
 		Body: &types.TxBody{
 			Account:   account,
 			Recipient: []byte(types.AergoSystem),
-			Amount:    amountBigInt.Bytes(),
+			Amount:    amount,
 			Payload:   payload,
 			GasLimit:  0,
 			Type:      types.TxType_GOVERNANCE,
@@ -25,6 +25,7 @@ type InOutBlockHeader struct {
 	PubKey           string
 	Sign             string
 	CoinbaseAccount  string
+	BaseFee          string `json:",omitempty"`
 }
 
 type InOutBlockBody struct {
@@ -65,6 +66,15 @@ type LongInOutPeer struct {
 	Certificates []*InOutCert
 }
 
+type KnownInOutPeer struct {
+	InOutPeer
+	LastSuccess time.Time
+	LastFailure time.Time
+	SuccessCnt  int32
+	FailCnt     int32
+	Latency     string
+}
+
 type InOutCert struct {
 	CertVersion uint32
 	ProducerID string
@@ -113,6 +123,13 @@ func FillTxBody(source *InOutTxBody, target *types.TxBody) error {
 		}
 		target.GasPrice = price.Bytes()
 	}
+	if source.GasTip != "" {
+		tip, err := ParseUnit(source.GasTip)
+		if err != nil {
+			return err
+		}
+		target.GasTip = tip.Bytes()
+	}
 	if source.ChainIdHash != "" {
 		target.ChainIdHash, err = base58.Decode(source.ChainIdHash)
 		if err != nil {
@@ -126,6 +143,8 @@ func FillTxBody(source *InOutTxBody, target *types.TxBody) error {
 		}
 	}
 	target.Type = source.Type
+	target.ValidUntilNo = source.ValidUntilNo
+	target.ValidUntilTime = source.ValidUntilTime
 	return nil
 }
 
@@ -205,6 +224,11 @@ func ConvTxEx(tx *types.Tx, payloadType EncodingType) *InOutTx {
 	out.Body.ChainIdHash = base58.Encode(tx.Body.ChainIdHash)
 	out.Body.Sign = base58.Encode(tx.Body.Sign)
 	out.Body.Type = tx.Body.Type
+	if tx.Body.GasTip != nil {
+		out.Body.GasTip = new(big.Int).SetBytes(tx.Body.GasTip).String()
+	}
+	out.Body.ValidUntilNo = tx.Body.ValidUntilNo
+	out.Body.ValidUntilTime = tx.Body.ValidUntilTime
 	return out
 }
 
@@ -233,6 +257,9 @@ func ConvBlock(b *types.Block) *InOutBlock {
 		if b.GetHeader().GetCoinbaseAccount() != nil {
 			out.Header.CoinbaseAccount = types.EncodeAddress(b.GetHeader().GetCoinbaseAccount())
 		}
+		if b.GetHeader().GetBaseFee() != nil {
+			out.Header.BaseFee = new(big.Int).SetBytes(b.GetHeader().GetBaseFee()).String()
+		}
 		if b.Body != nil {
 			for _, tx := range b.Body.Txs {
 				out.Body.Txs = append(out.Body.Txs, ConvTx(tx))
@@ -284,6 +311,20 @@ func ConvPeerLong(p *types.Peer) *LongInOutPeer {
 	return out
 }
 
+func ConvKnownPeer(p *types.Peer) *KnownInOutPeer {
+	out := &KnownInOutPeer{InOutPeer: *ConvPeer(p)}
+	if p.LastSuccess != 0 {
+		out.LastSuccess = time.Unix(0, p.LastSuccess)
+	}
+	if p.LastFailure != 0 {
+		out.LastFailure = time.Unix(0, p.LastFailure)
+	}
+	out.SuccessCnt = p.SuccessCnt
+	out.FailCnt = p.FailCnt
+	out.Latency = time.Duration(p.Latency).String()
+	return out
+}
+
 func ConvBlockchainStatus(in *types.BlockchainStatus) string {
 	out := &InOutBlockchainStatus{}
 	if in == nil {
@@ -338,6 +379,13 @@ func LongPeerListToString(p *types.PeerList) string {
 	}
 	return toString(peers)
 }
+func KnownPeerListToString(p *types.PeerList) string {
+	peers := []*KnownInOutPeer{}
+	for _, peer := range p.GetPeers() {
+		peers = append(peers, ConvKnownPeer(peer))
+	}
+	return toString(peers)
+}
 func toString(out interface{}) string {
 	jsonout, err := json.MarshalIndent(out, "", " ")
 	if err != nil {
@@ -15,16 +15,19 @@ type InOutTx struct {
 }
 
 type InOutTxBody struct {
-	Nonce       uint64       `json:",omitempty"`
-	Account     string       `json:",omitempty"`
-	Recipient   string       `json:",omitempty"`
-	Amount      string       `json:",omitempty"`
-	Payload     string       `json:",omitempty"`
-	GasLimit    uint64       `json:",omitempty"`
-	GasPrice    string       `json:",omitempty"`
-	Type        types.TxType `json:",omitempty"`
-	ChainIdHash string       `json:",omitempty"`
-	Sign        string       `json:",omitempty"`
+	Nonce          uint64       `json:",omitempty"`
+	Account        string       `json:",omitempty"`
+	Recipient      string       `json:",omitempty"`
+	Amount         string       `json:",omitempty"`
+	Payload        string       `json:",omitempty"`
+	GasLimit       uint64       `json:",omitempty"`
+	GasPrice       string       `json:",omitempty"`
+	Type           types.TxType `json:",omitempty"`
+	ChainIdHash    string       `json:",omitempty"`
+	Sign           string       `json:",omitempty"`
+	ValidUntilNo   uint64       `json:",omitempty"`
+	ValidUntilTime int64        `json:",omitempty"`
+	GasTip         string       `json:",omitempty"`
 }
 
 type InOutTxIdx struct {
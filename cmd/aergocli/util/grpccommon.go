@@ -6,6 +6,7 @@
 package util
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/aergoio/aergo/cmd/aergocli/util/encoding/json"
@@ -34,6 +35,27 @@ func GetClient(serverAddr string, opts []grpc.DialOption) interface{} {
 	return connClient
 }
 
+// TokenCredentials sends a bearer token in the authorization header of every
+// call.
+type TokenCredentials struct {
+	token  string
+	secure bool
+}
+
+// NewTokenCredentials returns the credentials of token. secure must be true
+// if the connection uses TLS.
+func NewTokenCredentials(token string, secure bool) *TokenCredentials {
+	return &TokenCredentials{token: token, secure: secure}
+}
+
+func (c *TokenCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + c.token}, nil
+}
+
+func (c *TokenCredentials) RequireTransportSecurity() bool {
+	return c.secure
+}
+
 func (c *ConnClient) Close() {
 	c.conn.Close()
 	c.conn = nil
@@ -0,0 +1,578 @@
+package lint
+
+import (
+	"fmt"
+)
+
+type blockKind int
+
+const (
+	blockChunk blockKind = iota
+	blockFunction
+	blockDo
+	blockLoop
+	blockIf
+	blockRepeat
+)
+
+type scope struct {
+	kind   blockKind
+	locals map[string]bool
+	// opened is set when the do of a loop is reached
+	opened bool
+	// depth is the bracket depth of the enclosing function, restored at
+	// the end of a function
+	depth int
+	// fn is the top-level function of a function block
+	fn string
+}
+
+type issue struct {
+	rule string
+	msg  string
+	tok  token
+}
+
+type viewWrite struct {
+	what string
+	tok  token
+}
+
+// the abi functions registering the functions of a contract
+var registerFuncs = map[string]bool{
+	"register":       true,
+	"register_view":  true,
+	"payable":        true,
+	"fee_delegation": true,
+}
+
+// the functions called by the vm without being registered
+var specialFuncs = map[string]bool{
+	"constructor":      true,
+	"check_delegation": true,
+}
+
+// the library functions whose results differ between nodes; a nil map
+// means all the functions of a library
+var nondeterministic = map[string]map[string]string{
+	"os": {
+		"time":    "system.getTimestamp",
+		"clock":   "system.getTimestamp",
+		"date":    "system.date",
+		"getenv":  "",
+		"tmpname": "",
+	},
+	"io": nil,
+	"math": {
+		"random":     "system.random",
+		"randomseed": "system.random",
+	},
+}
+
+var nondeterministicGlobals = map[string]string{
+	"collectgarbage": "",
+}
+
+// the methods of state variables changing the state
+var stateSetters = map[string]bool{
+	"set":    true,
+	"delete": true,
+	"append": true,
+}
+
+// the library functions changing the state
+var stateChangers = map[string]map[string]bool{
+	"contract": {"send": true, "deploy": true, "event": true, "delegatecall": true, "stake": true, "unstake": true, "vote": true},
+	"db":       {"exec": true},
+	"system":   {"setItem": true},
+}
+
+// the contract calls whose result should be checked
+var contractCalls = map[string]bool{
+	"call":         true,
+	"pcall":        true,
+	"delegatecall": true,
+}
+
+// analyzer walks the tokens of a source once, tracking the local variables
+// of the blocks, and collects the issues of all the rules.
+type analyzer struct {
+	toks   []token
+	scopes []*scope
+	depth  int
+
+	issues []issue
+
+	stateVars   map[string]bool
+	globalFuncs map[string]token
+	localFuncs  map[string]bool
+	registered  map[string]token
+	views       map[string]token
+	refs        map[string]int
+	viewWrites  map[string][]viewWrite
+	// assigned is the name of a global assigned with the next function
+	assigned string
+}
+
+func newAnalyzer(toks []token) *analyzer {
+	return &analyzer{
+		toks:        toks,
+		scopes:      []*scope{{kind: blockChunk, locals: make(map[string]bool)}},
+		stateVars:   make(map[string]bool),
+		globalFuncs: make(map[string]token),
+		localFuncs:  make(map[string]bool),
+		registered:  make(map[string]token),
+		views:       make(map[string]token),
+		refs:        make(map[string]int),
+		viewWrites:  make(map[string][]viewWrite),
+	}
+}
+
+func (a *analyzer) tok(i int) token {
+	if i < 0 || i >= len(a.toks) {
+		return token{kind: tokEOF}
+	}
+	return a.toks[i]
+}
+
+func (a *analyzer) report(rule string, t token, format string, args ...interface{}) {
+	a.issues = append(a.issues, issue{rule: rule, msg: fmt.Sprintf(format, args...), tok: t})
+}
+
+func (a *analyzer) top() *scope {
+	return a.scopes[len(a.scopes)-1]
+}
+
+func (a *analyzer) push(kind blockKind) *scope {
+	s := &scope{kind: kind, locals: make(map[string]bool), fn: a.currentFunc()}
+	a.scopes = append(a.scopes, s)
+	return s
+}
+
+func (a *analyzer) pop() {
+	if len(a.scopes) == 1 {
+		return
+	}
+	s := a.top()
+	a.scopes = a.scopes[:len(a.scopes)-1]
+	if s.kind == blockFunction {
+		a.depth = s.depth
+	}
+}
+
+func (a *analyzer) declare(name string) {
+	a.top().locals[name] = true
+}
+
+func (a *analyzer) isLocal(name string) bool {
+	for i := len(a.scopes) - 1; i >= 0; i-- {
+		if a.scopes[i].locals[name] {
+			return true
+		}
+	}
+	return false
+}
+
+// inFunction returns true if the current block is in a function.
+func (a *analyzer) inFunction() bool {
+	for _, s := range a.scopes {
+		if s.kind == blockFunction {
+			return true
+		}
+	}
+	return false
+}
+
+// currentFunc returns the name of the top-level function enclosing the
+// current block.
+func (a *analyzer) currentFunc() string {
+	return a.top().fn
+}
+
+// isGlobal returns true if the token i is a global name, which is neither a
+// local variable nor a field.
+func (a *analyzer) isGlobal(i int, name string) bool {
+	t := a.tok(i)
+	if t.kind != tokName || (name != "" && t.text != name) {
+		return false
+	}
+	if prev := a.tok(i - 1); prev.is(tokOp, ".") || prev.is(tokOp, ":") || prev.is(tokKeyword, "goto") {
+		return false
+	}
+	return !a.isLocal(t.text)
+}
+
+// statementStart returns true if the token i begins a statement.
+func (a *analyzer) statementStart(i int) bool {
+	if a.depth != 0 {
+		return false
+	}
+	prev := a.tok(i - 1)
+	switch prev.kind {
+	case tokEOF, tokName, tokNumber, tokString:
+		return true
+	case tokKeyword:
+		switch prev.text {
+		case "do", "then", "else", "repeat", "end", "nil", "true", "false", "break":
+			return true
+		}
+	case tokOp:
+		switch prev.text {
+		case ")", "]", "}", ";", "...", "::":
+			return true
+		}
+	}
+	return false
+}
+
+// matching returns the index of the bracket closing the bracket at i.
+func (a *analyzer) matching(i int) int {
+	open := a.tok(i).text
+	closing := map[string]string{"(": ")", "[": "]", "{": "}"}[open]
+	depth := 0
+	for j := i; j < len(a.toks); j++ {
+		t := a.toks[j]
+		if t.kind != tokOp {
+			continue
+		}
+		if t.text == open {
+			depth++
+		} else if t.text == closing {
+			depth--
+			if depth == 0 {
+				return j
+			}
+		}
+	}
+	return len(a.toks) - 1
+}
+
+func (a *analyzer) run() {
+	for i := 0; i < len(a.toks); {
+		i = a.step(i)
+	}
+	a.finish()
+}
+
+// step analyzes the token i and returns the index of the next token.
+func (a *analyzer) step(i int) int {
+	t := a.toks[i]
+	switch t.kind {
+	case tokEOF:
+		return i + 1
+	case tokKeyword:
+		return a.keyword(i)
+	case tokOp:
+		switch t.text {
+		case "(", "[", "{":
+			a.depth++
+		case ")", "]", "}":
+			if a.depth > 0 {
+				a.depth--
+			}
+		}
+		return i + 1
+	case tokName:
+		return a.name(i)
+	}
+	return i + 1
+}
+
+func (a *analyzer) keyword(i int) int {
+	t := a.toks[i]
+	switch t.text {
+	case "local":
+		if a.tok(i+1).is(tokKeyword, "function") {
+			name := a.tok(i + 2)
+			if name.kind == tokName {
+				a.declare(name.text)
+				a.localFuncs[name.text] = true
+			}
+			return a.function(i+1, true)
+		}
+		j := i + 1
+		for a.tok(j).kind == tokName {
+			a.declare(a.tok(j).text)
+			if !a.tok(j+1).is(tokOp, ",") {
+				break
+			}
+			j += 2
+		}
+		return j + 1
+	case "function":
+		return a.function(i, false)
+	case "do":
+		if s := a.top(); s.kind == blockLoop && !s.opened {
+			s.opened = true
+		} else {
+			a.push(blockDo)
+		}
+	case "while":
+		a.push(blockLoop)
+	case "for":
+		a.push(blockLoop)
+		j := i + 1
+		for a.tok(j).kind == tokName {
+			a.declare(a.tok(j).text)
+			if !a.tok(j+1).is(tokOp, ",") {
+				break
+			}
+			j += 2
+		}
+		return j + 1
+	case "if":
+		a.push(blockIf)
+	case "elseif", "else":
+		if s := a.top(); s.kind == blockIf {
+			s.locals = make(map[string]bool)
+		}
+	case "repeat":
+		a.push(blockRepeat)
+	case "until", "end":
+		a.pop()
+	}
+	return i + 1
+}
+
+// function analyzes the header of the function defined at i, and returns
+// the index of the first token of its body.
+func (a *analyzer) function(i int, local bool) int {
+	j := i + 1
+	var path []token
+	method := false
+	for a.tok(j).kind == tokName {
+		path = append(path, a.tok(j))
+		next := a.tok(j + 1)
+		if next.is(tokOp, ".") || next.is(tokOp, ":") {
+			method = method || next.text == ":"
+			j += 2
+			continue
+		}
+		j++
+		break
+	}
+
+	fn := a.currentFunc()
+	if a.assigned != "" {
+		fn, a.assigned = a.assigned, ""
+	}
+	if len(path) == 1 && !local {
+		name := path[0]
+		if a.isLocal(name.text) {
+			// assigns a local variable
+		} else if a.inFunction() {
+			a.report(RuleGlobalWrite, name, "function %s defines the global function %s at run time", fn, name.text)
+		} else {
+			a.globalFuncs[name.text] = name
+			fn = name.text
+		}
+	} else if len(path) == 1 && !a.inFunction() {
+		fn = path[0].text
+	}
+
+	s := a.push(blockFunction)
+	s.depth = a.depth
+	s.fn = fn
+	a.depth = 0
+	if method {
+		a.declare("self")
+	}
+	if !a.tok(j).is(tokOp, "(") {
+		return j
+	}
+	end := a.matching(j)
+	for k := j + 1; k < end; k++ {
+		if p := a.tok(k); p.kind == tokName {
+			a.declare(p.text)
+		}
+	}
+	return end + 1
+}
+
+func (a *analyzer) name(i int) int {
+	t := a.toks[i]
+	if !a.isGlobal(i, "") {
+		// a local variable may be assigned together with globals, like
+		// a, c = 3, 4
+		if a.isLocal(t.text) && a.statementStart(i) {
+			if j, ok := a.assignment(i); ok {
+				return j
+			}
+		}
+		return i + 1
+	}
+	next := a.tok(i + 1)
+
+	if next.is(tokOp, ".") {
+		field := a.tok(i + 2)
+		switch t.text {
+		case "state":
+			if field.is(tokName, "var") {
+				return a.stateVar(i + 3)
+			}
+		case "abi":
+			if registerFuncs[field.text] && a.tok(i+3).is(tokOp, "(") {
+				return a.register(field.text, i+3)
+			}
+		}
+		if fns, ok := nondeterministic[t.text]; ok && field.kind == tokName {
+			if alt, bad := fns[field.text]; bad || fns == nil {
+				a.reportNondeterministic(t, t.text+"."+field.text, alt)
+			}
+		}
+		if stateChangers[t.text][field.text] {
+			a.viewWrite(t.text+"."+field.text, t)
+		}
+		if t.text == "contract" && contractCalls[field.text] && a.statementStart(i) {
+			a.report(RuleUncheckedCall, t, "the result of contract.%s is discarded", field.text)
+		}
+	}
+	if alt, ok := nondeterministicGlobals[t.text]; ok {
+		a.reportNondeterministic(t, t.text, alt)
+	}
+
+	if a.statementStart(i) {
+		if j, ok := a.assignment(i); ok {
+			return j
+		}
+	}
+	a.refs[t.text]++
+
+	if a.stateVars[t.text] {
+		a.stateWrite(i)
+	}
+	return i + 1
+}
+
+func (a *analyzer) reportNondeterministic(t token, name, alt string) {
+	if alt != "" {
+		a.report(RuleNondeterministic, t, "%s is not deterministic; use %s", name, alt)
+	} else {
+		a.report(RuleNondeterministic, t, "%s is not deterministic", name)
+	}
+}
+
+// assignment handles an assignment to the names starting at i.
+func (a *analyzer) assignment(i int) (int, bool) {
+	var targets []token
+	j := i
+	for {
+		t := a.tok(j)
+		if t.kind != tokName {
+			return 0, false
+		}
+		targets = append(targets, t)
+		next := a.tok(j + 1)
+		if next.is(tokOp, "=") {
+			break
+		}
+		if !next.is(tokOp, ",") {
+			return 0, false
+		}
+		j += 2
+	}
+	for _, t := range targets {
+		if a.isLocal(t.text) {
+			continue
+		}
+		if a.stateVars[t.text] {
+			a.report(RuleGlobalWrite, t, "the assignment replaces the state variable %s; use %s:set()", t.text, t.text)
+			continue
+		}
+		if a.inFunction() {
+			a.report(RuleGlobalWrite, t, "function %s writes the global variable %s, which is not saved; declare it with state.var", a.currentFunc(), t.text)
+		} else if len(targets) == 1 && a.tok(j+2).is(tokKeyword, "function") {
+			a.globalFuncs[t.text] = t
+			a.assigned = t.text
+		}
+	}
+	return j + 2, true
+}
+
+// stateWrite checks the changes of the state variable at i.
+func (a *analyzer) stateWrite(i int) {
+	t := a.toks[i]
+	next := a.tok(i + 1)
+	switch {
+	case next.is(tokOp, ":") && stateSetters[a.tok(i+2).text]:
+		a.viewWrite(t.text+":"+a.tok(i+2).text, t)
+	case next.is(tokOp, "["):
+		if a.tok(a.matching(i+1)+1).is(tokOp, "=") {
+			a.viewWrite(t.text+"[]", t)
+		}
+	}
+}
+
+func (a *analyzer) viewWrite(what string, t token) {
+	if fn := a.currentFunc(); fn != "" {
+		a.viewWrites[fn] = append(a.viewWrites[fn], viewWrite{what: what, tok: t})
+	}
+}
+
+// stateVar collects the names declared by state.var, whose arguments start
+// at i.
+func (a *analyzer) stateVar(i int) int {
+	if a.tok(i).is(tokOp, "(") {
+		i++
+	}
+	if !a.tok(i).is(tokOp, "{") {
+		return i
+	}
+	end := a.matching(i)
+	depth := 0
+	for k := i; k < end; k++ {
+		t := a.toks[k]
+		if t.kind == tokOp {
+			switch t.text {
+			case "(", "[", "{":
+				depth++
+			case ")", "]", "}":
+				depth--
+			}
+			continue
+		}
+		if depth == 1 && t.kind == tokName && a.tok(k+1).is(tokOp, "=") {
+			a.stateVars[t.text] = true
+		}
+	}
+	return i
+}
+
+// register collects the functions registered by abi.<kind>, whose
+// arguments start at i.
+func (a *analyzer) register(kind string, i int) int {
+	end := a.matching(i)
+	for k := i + 1; k < end; k++ {
+		t := a.toks[k]
+		if t.kind != tokName {
+			continue
+		}
+		if _, ok := a.registered[t.text]; !ok {
+			a.registered[t.text] = t
+		}
+		if kind == "register_view" {
+			a.views[t.text] = t
+		}
+	}
+	return end + 1
+}
+
+func (a *analyzer) finish() {
+	for name, t := range a.globalFuncs {
+		if _, ok := a.registered[name]; ok || specialFuncs[name] || a.refs[name] > 0 {
+			continue
+		}
+		a.report(RuleMissingRegister, t, "function %s is neither registered nor called; register it with abi.register if it is public", name)
+	}
+	for name, t := range a.registered {
+		if _, ok := a.globalFuncs[name]; ok || a.localFuncs[name] {
+			continue
+		}
+		a.report(RuleUnknownRegister, t, "function %s is registered but not defined", name)
+	}
+	for name := range a.views {
+		for _, w := range a.viewWrites[name] {
+			a.report(RuleViewWrite, w.tok, "view function %s changes the state by %s", name, w.what)
+		}
+	}
+}
@@ -0,0 +1,241 @@
+package lint
+
+import (
+	"fmt"
+	"strings"
+)
+
+type tokenKind int
+
+const (
+	tokName tokenKind = iota
+	tokKeyword
+	tokNumber
+	tokString
+	tokOp
+	tokEOF
+)
+
+var keywords = map[string]bool{
+	"and": true, "break": true, "do": true, "else": true, "elseif": true,
+	"end": true, "false": true, "for": true, "function": true, "goto": true,
+	"if": true, "in": true, "local": true, "nil": true, "not": true,
+	"or": true, "repeat": true, "return": true, "then": true, "true": true,
+	"until": true, "while": true,
+}
+
+// the operators are matched longest first
+var operators = []string{
+	"...", "..", "==", "~=", "<=", ">=", "::",
+	"+", "-", "*", "/", "%", "^", "#", "<", ">", "=",
+	"(", ")", "{", "}", "[", "]", ";", ":", ",", ".",
+}
+
+type token struct {
+	kind tokenKind
+	text string
+	line int
+	col  int
+}
+
+func (t token) is(kind tokenKind, text string) bool {
+	return t.kind == kind && t.text == text
+}
+
+type comment struct {
+	text string
+	line int
+	// alone is true if no token precedes the comment on its line
+	alone bool
+}
+
+// lexer splits a lua source into tokens and comments.
+type lexer struct {
+	src      string
+	pos      int
+	line     int
+	col      int
+	lastLine int
+
+	tokens   []token
+	comments []comment
+}
+
+func tokenize(src string) ([]token, []comment, error) {
+	l := &lexer{src: src, line: 1, col: 1}
+	// skip a shebang line
+	if strings.HasPrefix(src, "#") {
+		for l.pos < len(l.src) && l.src[l.pos] != '\n' {
+			l.advance(1)
+		}
+	}
+	for {
+		l.skipSpace()
+		if l.pos >= len(l.src) {
+			break
+		}
+		if err := l.next(); err != nil {
+			return nil, nil, err
+		}
+	}
+	l.tokens = append(l.tokens, token{kind: tokEOF, line: l.line, col: l.col})
+	return l.tokens, l.comments, nil
+}
+
+func (l *lexer) advance(n int) {
+	for i := 0; i < n && l.pos < len(l.src); i++ {
+		if l.src[l.pos] == '\n' {
+			l.line++
+			l.col = 1
+		} else {
+			l.col++
+		}
+		l.pos++
+	}
+}
+
+func (l *lexer) skipSpace() {
+	for l.pos < len(l.src) {
+		switch l.src[l.pos] {
+		case ' ', '\t', '\r', '\n', '\f', '\v':
+			l.advance(1)
+		default:
+			return
+		}
+	}
+}
+
+func (l *lexer) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("%d:%d: %s", l.line, l.col, fmt.Sprintf(format, args...))
+}
+
+func (l *lexer) emit(kind tokenKind, text string, line, col int) {
+	l.tokens = append(l.tokens, token{kind: kind, text: text, line: line, col: col})
+	l.lastLine = line
+}
+
+func (l *lexer) next() error {
+	line, col := l.line, l.col
+	c := l.src[l.pos]
+	rest := l.src[l.pos:]
+
+	switch {
+	case strings.HasPrefix(rest, "--"):
+		l.advance(2)
+		start := l.pos
+		var text string
+		if level := l.longBracketLevel(); level >= 0 {
+			body, err := l.longBracket(level)
+			if err != nil {
+				return err
+			}
+			text = body
+		} else {
+			for l.pos < len(l.src) && l.src[l.pos] != '\n' {
+				l.advance(1)
+			}
+			text = l.src[start:l.pos]
+		}
+		l.comments = append(l.comments, comment{
+			text:  strings.TrimSpace(text),
+			line:  line,
+			alone: l.lastLine != line,
+		})
+	case isLetter(c):
+		start := l.pos
+		for l.pos < len(l.src) && (isLetter(l.src[l.pos]) || isDigit(l.src[l.pos])) {
+			l.advance(1)
+		}
+		word := l.src[start:l.pos]
+		if keywords[word] {
+			l.emit(tokKeyword, word, line, col)
+		} else {
+			l.emit(tokName, word, line, col)
+		}
+	case isDigit(c) || (c == '.' && len(rest) > 1 && isDigit(rest[1])):
+		start := l.pos
+		exponent := "eE"
+		if strings.HasPrefix(rest, "0x") || strings.HasPrefix(rest, "0X") {
+			exponent = "pP"
+		}
+		for l.pos < len(l.src) {
+			ch := l.src[l.pos]
+			signed := (ch == '+' || ch == '-') && strings.IndexByte(exponent, l.src[l.pos-1]) >= 0
+			if !signed && !isLetter(ch) && !isDigit(ch) && ch != '.' {
+				break
+			}
+			l.advance(1)
+		}
+		l.emit(tokNumber, l.src[start:l.pos], line, col)
+	case c == '"' || c == '\'':
+		start := l.pos
+		l.advance(1)
+		for {
+			if l.pos >= len(l.src) || l.src[l.pos] == '\n' {
+				return l.errorf("unfinished string")
+			}
+			ch := l.src[l.pos]
+			if ch == '\\' {
+				l.advance(2)
+				continue
+			}
+			l.advance(1)
+			if ch == c {
+				break
+			}
+		}
+		l.emit(tokString, l.src[start:l.pos], line, col)
+	case c == '[' && l.longBracketLevel() >= 0:
+		body, err := l.longBracket(l.longBracketLevel())
+		if err != nil {
+			return err
+		}
+		l.emit(tokString, body, line, col)
+	default:
+		for _, op := range operators {
+			if strings.HasPrefix(rest, op) {
+				l.advance(len(op))
+				l.emit(tokOp, op, line, col)
+				return nil
+			}
+		}
+		return l.errorf("unexpected symbol %q", c)
+	}
+	return nil
+}
+
+// longBracketLevel returns the level of a long bracket at the current
+// position, or -1 if there is none.
+func (l *lexer) longBracketLevel() int {
+	if l.pos >= len(l.src) || l.src[l.pos] != '[' {
+		return -1
+	}
+	i := l.pos + 1
+	for i < len(l.src) && l.src[i] == '=' {
+		i++
+	}
+	if i < len(l.src) && l.src[i] == '[' {
+		return i - l.pos - 1
+	}
+	return -1
+}
+
+func (l *lexer) longBracket(level int) (string, error) {
+	l.advance(level + 2)
+	closing := "]" + strings.Repeat("=", level) + "]"
+	end := strings.Index(l.src[l.pos:], closing)
+	if end < 0 {
+		return "", l.errorf("unfinished long string or comment")
+	}
+	body := l.src[l.pos : l.pos+end]
+	l.advance(end + len(closing))
+	return body, nil
+}
+
+func isLetter(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,251 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+// Package lint finds common bugs of lua contracts without running them.
+package lint
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+)
+
+// Severity is the level of a finding. The findings of a rule with the
+// severity SeverityOff are not reported.
+type Severity int
+
+const (
+	SeverityOff Severity = iota
+	SeverityInfo
+	SeverityWarning
+	SeverityError
+)
+
+var severityNames = []string{"off", "info", "warning", "error"}
+
+func (s Severity) String() string {
+	if s < 0 || int(s) >= len(severityNames) {
+		return fmt.Sprintf("Severity(%d)", int(s))
+	}
+	return severityNames[s]
+}
+
+// ParseSeverity parses the name of a severity.
+func ParseSeverity(name string) (Severity, error) {
+	for i, n := range severityNames {
+		if strings.EqualFold(n, name) {
+			return Severity(i), nil
+		}
+	}
+	return SeverityOff, fmt.Errorf("unknown severity %q; valid values are %s", name, strings.Join(severityNames, ", "))
+}
+
+// Rule is a check of the linter.
+type Rule struct {
+	ID          string
+	Description string
+	Severity    Severity
+}
+
+const (
+	RuleGlobalWrite      = "global-write"
+	RuleNondeterministic = "nondeterministic"
+	RuleUncheckedCall    = "unchecked-call"
+	RuleMissingRegister  = "missing-register"
+	RuleUnknownRegister  = "unknown-register"
+	RuleViewWrite        = "view-write"
+)
+
+// Rules lists the rules with their default severity.
+var Rules = []Rule{
+	{RuleGlobalWrite, "a function writes a global variable, which is not saved in the state; use state.var", SeverityError},
+	{RuleNondeterministic, "a function uses a library whose result differs between nodes", SeverityError},
+	{RuleUncheckedCall, "the result of a contract call is discarded", SeverityWarning},
+	{RuleMissingRegister, "a global function is neither registered by abi nor called", SeverityWarning},
+	{RuleUnknownRegister, "abi registers a function which is not defined", SeverityError},
+	{RuleViewWrite, "a function registered as view changes the state", SeverityError},
+}
+
+func findRule(id string) (Rule, bool) {
+	for _, r := range Rules {
+		if r.ID == id {
+			return r, true
+		}
+	}
+	return Rule{}, false
+}
+
+// Config sets the severity of the rules. The rules not in Severities have
+// their default severity.
+type Config struct {
+	Severities map[string]Severity
+}
+
+// SetSeverity parses an option of the form <rule>=<severity>.
+func (c *Config) SetSeverity(option string) error {
+	kv := strings.SplitN(option, "=", 2)
+	if len(kv) != 2 {
+		return fmt.Errorf("invalid rule severity %q; use <rule>=<severity>", option)
+	}
+	id := strings.TrimSpace(kv[0])
+	if _, ok := findRule(id); !ok {
+		return fmt.Errorf("unknown lint rule %q", id)
+	}
+	s, err := ParseSeverity(strings.TrimSpace(kv[1]))
+	if err != nil {
+		return err
+	}
+	if c.Severities == nil {
+		c.Severities = make(map[string]Severity)
+	}
+	c.Severities[id] = s
+	return nil
+}
+
+func (c *Config) severity(r Rule) Severity {
+	if s, ok := c.Severities[r.ID]; ok {
+		return s
+	}
+	return r.Severity
+}
+
+// Finding is a problem found in a source file.
+type Finding struct {
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"-"`
+	Message  string   `json:"message"`
+	File     string   `json:"file"`
+	Line     int      `json:"line"`
+	Column   int      `json:"column"`
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%s:%d:%d: %s: %s [%s]", f.File, f.Line, f.Column, f.Severity, f.Message, f.Rule)
+}
+
+// HasErrors returns true if a finding has the severity SeverityError.
+func HasErrors(findings []Finding) bool {
+	for _, f := range findings {
+		if f.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// LintFile lints the lua source file srcFileName.
+func LintFile(srcFileName string, cfg *Config) ([]Finding, error) {
+	src, err := ioutil.ReadFile(srcFileName)
+	if err != nil {
+		return nil, err
+	}
+	return Lint(srcFileName, string(src), cfg)
+}
+
+// Lint lints a lua source. fileName is only used in the findings.
+//
+// A finding is suppressed by a comment "lint:ignore [rule,...]" on its line,
+// or alone on the line before it, and by a comment "lint:ignore-file
+// [rule,...]" anywhere in the source. All the rules are suppressed if no rule
+// is given.
+func Lint(fileName, src string, cfg *Config) ([]Finding, error) {
+	if cfg == nil {
+		cfg = &Config{}
+	}
+	tokens, comments, err := tokenize(src)
+	if err != nil {
+		return nil, fmt.Errorf("%s:%v", fileName, err)
+	}
+	a := newAnalyzer(tokens)
+	a.run()
+
+	sup := newSuppressions(comments)
+	var findings []Finding
+	for _, is := range a.issues {
+		r, _ := findRule(is.rule)
+		s := cfg.severity(r)
+		if s == SeverityOff || sup.suppressed(is.rule, is.tok.line) {
+			continue
+		}
+		findings = append(findings, Finding{
+			Rule:     is.rule,
+			Severity: s,
+			Message:  is.msg,
+			File:     fileName,
+			Line:     is.tok.line,
+			Column:   is.tok.col,
+		})
+	}
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].Line != findings[j].Line {
+			return findings[i].Line < findings[j].Line
+		}
+		return findings[i].Column < findings[j].Column
+	})
+	return findings, nil
+}
+
+const (
+	ignoreDirective     = "lint:ignore"
+	ignoreFileDirective = "lint:ignore-file"
+)
+
+// suppressions holds the rules suppressed by the comments. An empty rule
+// matches all the rules.
+type suppressions struct {
+	file  map[string]bool
+	lines map[int]map[string]bool
+}
+
+func newSuppressions(comments []comment) *suppressions {
+	s := &suppressions{
+		file:  make(map[string]bool),
+		lines: make(map[int]map[string]bool),
+	}
+	for _, c := range comments {
+		var target map[string]bool
+		var args string
+		switch {
+		case strings.HasPrefix(c.text, ignoreFileDirective):
+			target = s.file
+			args = c.text[len(ignoreFileDirective):]
+		case strings.HasPrefix(c.text, ignoreDirective):
+			line := c.line
+			if c.alone {
+				line++
+			}
+			if s.lines[line] == nil {
+				s.lines[line] = make(map[string]bool)
+			}
+			target = s.lines[line]
+			args = c.text[len(ignoreDirective):]
+		default:
+			continue
+		}
+		if args != "" && args[0] != ' ' && args[0] != '\t' {
+			// another directive such as lint:ignored
+			continue
+		}
+		rules := strings.FieldsFunc(args, func(r rune) bool {
+			return r == ',' || r == ' ' || r == '\t'
+		})
+		if len(rules) == 0 {
+			target[""] = true
+		}
+		for _, r := range rules {
+			target[r] = true
+		}
+	}
+	return s
+}
+
+func (s *suppressions) suppressed(rule string, line int) bool {
+	if s.file[""] || s.file[rule] {
+		return true
+	}
+	l := s.lines[line]
+	return l[""] || l[rule]
+}
@@ -0,0 +1,180 @@
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testContract = `
+state.var {
+	Owner = state.value(),
+	Balances = state.map(),
+}
+
+local counter = 0
+MAX = 10
+
+function constructor()
+	Owner:set(system.getSender())
+end
+
+local function check(v)
+	return v < MAX
+end
+
+function transfer(to, amount)
+	count = counter + 1
+	Balances[to] = amount
+	contract.call(to, "notify", amount)
+	local ok = contract.pcall(to, "notify")
+	assert(ok and check(amount))
+end
+
+function balanceOf(addr)
+	Balances[addr] = Balances[addr] or 0
+	return Balances[addr]
+end
+
+function seed()
+	return os.time() + math.random(10)
+end
+
+function helper()
+end
+
+abi.register(transfer, seed, burn)
+abi.register_view(balanceOf)
+`
+
+func findingsByRule(findings []Finding) map[string][]Finding {
+	m := make(map[string][]Finding)
+	for _, f := range findings {
+		m[f.Rule] = append(m[f.Rule], f)
+	}
+	return m
+}
+
+func TestLint(t *testing.T) {
+	findings, err := Lint("test.lua", testContract, nil)
+	assert.NoError(t, err)
+	m := findingsByRule(findings)
+
+	if assert.Len(t, m[RuleGlobalWrite], 1) {
+		assert.Equal(t, 19, m[RuleGlobalWrite][0].Line)
+		assert.Contains(t, m[RuleGlobalWrite][0].Message, "count")
+	}
+	if assert.Len(t, m[RuleUncheckedCall], 1) {
+		assert.Equal(t, 21, m[RuleUncheckedCall][0].Line)
+	}
+	if assert.Len(t, m[RuleViewWrite], 1) {
+		assert.Equal(t, 27, m[RuleViewWrite][0].Line)
+	}
+	assert.Len(t, m[RuleNondeterministic], 2)
+	if assert.Len(t, m[RuleMissingRegister], 1) {
+		assert.Contains(t, m[RuleMissingRegister][0].Message, "helper")
+	}
+	if assert.Len(t, m[RuleUnknownRegister], 1) {
+		assert.Contains(t, m[RuleUnknownRegister][0].Message, "burn")
+	}
+	assert.True(t, HasErrors(findings))
+}
+
+func TestLintSeverity(t *testing.T) {
+	cfg := &Config{}
+	assert.NoError(t, cfg.SetSeverity("global-write=off"))
+	assert.NoError(t, cfg.SetSeverity("unchecked-call=error"))
+	assert.Error(t, cfg.SetSeverity("no-such-rule=error"))
+	assert.Error(t, cfg.SetSeverity("view-write=fatal"))
+	assert.Error(t, cfg.SetSeverity("view-write"))
+
+	findings, err := Lint("test.lua", testContract, cfg)
+	assert.NoError(t, err)
+	m := findingsByRule(findings)
+	assert.Empty(t, m[RuleGlobalWrite])
+	if assert.Len(t, m[RuleUncheckedCall], 1) {
+		assert.Equal(t, SeverityError, m[RuleUncheckedCall][0].Severity)
+	}
+}
+
+func TestLintSuppression(t *testing.T) {
+	src := `
+-- lint:ignore-file missing-register
+function a()
+	x = 1 -- lint:ignore global-write
+	-- lint:ignore
+	y = os.time()
+	z = 1 -- lint:ignore unchecked-call
+end
+`
+	findings, err := Lint("test.lua", src, nil)
+	assert.NoError(t, err)
+	if assert.Len(t, findings, 1) {
+		assert.Equal(t, RuleGlobalWrite, findings[0].Rule)
+		assert.Equal(t, 7, findings[0].Line)
+	}
+}
+
+func TestLintScopes(t *testing.T) {
+	src := `
+function f(a, ...)
+	local b, c = 1, 2
+	for i, v in ipairs(a) do
+		b = i + v
+	end
+	if b then
+		local d
+		d = 1
+	else
+		d = 2
+	end
+	local t = { e = 1 }
+	t.e = 2
+	b, h = 3, 4
+	return function(g) g = c end
+end
+abi.register(f)
+`
+	findings, err := Lint("test.lua", src, nil)
+	assert.NoError(t, err)
+	if assert.Len(t, findings, 2) {
+		assert.Equal(t, RuleGlobalWrite, findings[0].Rule)
+		assert.Equal(t, 11, findings[0].Line)
+		assert.Equal(t, RuleGlobalWrite, findings[1].Rule)
+		assert.Equal(t, 15, findings[1].Line)
+		assert.Contains(t, findings[1].Message, "variable h")
+	}
+}
+
+func TestLintSyntaxError(t *testing.T) {
+	_, err := Lint("test.lua", "s = 'unfinished\n", nil)
+	assert.Error(t, err)
+}
+
+func TestWrite(t *testing.T) {
+	findings, err := Lint("test.lua", testContract, nil)
+	assert.NoError(t, err)
+
+	var buf bytes.Buffer
+	assert.NoError(t, Write(&buf, FormatJSON, findings))
+	var out []map[string]interface{}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &out))
+	if assert.Len(t, out, len(findings)) {
+		assert.Equal(t, findings[0].Rule, out[0]["rule"])
+		assert.Equal(t, findings[0].Severity.String(), out[0]["severity"])
+	}
+
+	buf.Reset()
+	assert.NoError(t, Write(&buf, FormatSARIF, findings))
+	var log sarifLog
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &log))
+	assert.Equal(t, sarifVersion, log.Version)
+	if assert.Len(t, log.Runs, 1) {
+		assert.Len(t, log.Runs[0].Tool.Driver.Rules, len(Rules))
+		assert.Len(t, log.Runs[0].Results, len(findings))
+	}
+
+	assert.Error(t, Write(&buf, "xml", findings))
+}
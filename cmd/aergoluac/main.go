@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/aergoio/aergo/cmd/aergoluac/lint"
 	"github.com/aergoio/aergo/cmd/aergoluac/util"
 	"github.com/spf13/cobra"
 )
@@ -19,13 +20,17 @@ var (
 	abiFile string
 	payload bool
 	version bool
+
+	lintMode   bool
+	lintFormat string
+	lintRules  []string
 )
 
 var githash = "No git hash provided"
 
 func init() {
 	rootCmd = &cobra.Command{
-		Use:   "aergoluac --payload srcfile\n  aergoluac --abi abifile srcfile bcfile",
+		Use:   "aergoluac --payload srcfile\n  aergoluac --abi abifile srcfile bcfile\n  aergoluac --lint [--lint-format text|json|sarif] [--lint-rule rule=severity] srcfile...",
 		Short: "Compile a lua contract",
 		Long:  "Compile a lua contract. This command makes a bytecode file and a ABI file or prints a payload data.",
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -33,8 +38,12 @@ func init() {
 
 			if version {
 				cmd.Printf("Aergoluac %s\n", githash)
+				cmd.Printf("Compiler %s\n", util.CompilerVersion())
 				return nil
 			}
+			if lintMode {
+				return runLint(args)
+			}
 			if payload {
 				if len(args) == 0 {
 					err = util.DumpFromStdin()
@@ -55,9 +64,42 @@ func init() {
 	}
 	rootCmd.PersistentFlags().StringVarP(&abiFile, "abi", "a", "", "abi filename")
 	rootCmd.PersistentFlags().BoolVar(&payload, "payload", false, "print the compilation result consisting of bytecode and abi")
+	rootCmd.PersistentFlags().BoolVar(&lintMode, "lint", false, "check the source files for common contract bugs")
+	rootCmd.PersistentFlags().StringVar(&lintFormat, "lint-format", lint.FormatText, "output format of the lint findings: text, json or sarif")
+	rootCmd.PersistentFlags().StringSliceVar(&lintRules, "lint-rule", nil, "severity of a lint rule as <rule>=<off|info|warning|error>")
 	rootCmd.PersistentFlags().BoolVar(&version, "version", false, "print the version number of aergoluac")
 }
 
+// runLint prints the findings of the source files, and exits with the
+// status 1 if a finding is an error.
+func runLint(args []string) error {
+	if len(args) == 0 {
+		return errors.New("1 or more arguments required: <srcfile>...")
+	}
+	cfg := &lint.Config{}
+	for _, r := range lintRules {
+		if err := cfg.SetSeverity(r); err != nil {
+			return err
+		}
+	}
+	var findings []lint.Finding
+	for _, src := range args {
+		f, err := lint.LintFile(src, cfg)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			os.Exit(1)
+		}
+		findings = append(findings, f...)
+	}
+	if err := lint.Write(os.Stdout, lintFormat, findings); err != nil {
+		return err
+	}
+	if lint.HasErrors(findings) {
+		os.Exit(1)
+	}
+	return nil
+}
+
 func main() {
 	if err := rootCmd.Execute(); err != nil {
 		os.Exit(1)
@@ -118,3 +118,7 @@ const char *vm_stringdump(lua_State *L)
 	return NULL;
 }
 
+const char *luac_version()
+{
+	return LUAJIT_VERSION;
+}
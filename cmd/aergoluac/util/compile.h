@@ -9,5 +9,6 @@ const char *vm_compile(lua_State *L, const char *code, const char *byte, const c
 const char *vm_loadfile(lua_State *L, const char *filename);
 const char *vm_loadstring(lua_State *L, const char *source);
 const char *vm_stringdump(lua_State *L);
+const char *luac_version();
 
 #endif /* _COMPILE_H */
\ No newline at end of file
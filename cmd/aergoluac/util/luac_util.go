@@ -39,6 +39,17 @@ func CloseLState(L *C.lua_State) {
 	}
 }
 
+// BuildVersion is the version of the aergo source the compiler is built from.
+// It is set by the linker like the githash of the commands.
+var BuildVersion = "unknown"
+
+// CompilerVersion returns the version of the lua compiler, which must be the
+// same to reproduce the bytecode of a contract. It consists of the build
+// version and the version of LuaJIT since both of them decide the bytecode.
+func CompilerVersion() string {
+	return BuildVersion + " " + C.GoString(C.luac_version())
+}
+
 func Compile(L *C.lua_State, code string) (LuaCode, error) {
 	cStr := C.CString(code)
 	defer C.free(unsafe.Pointer(cStr))
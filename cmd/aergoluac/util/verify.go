@@ -0,0 +1,167 @@
+package util
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/aergoio/aergo/types"
+)
+
+const (
+	// the header of a luajit bytecode dump: ESC 'L' 'J' version flags
+	// [name length, name]
+	bcDumpHead      = "\x1bLJ"
+	bcDumpFlagStrip = 0x02
+
+	// abiSourceHashKey is the key of the source hash in the ABI of a
+	// deployed contract.
+	abiSourceHashKey = "source_hash"
+)
+
+// SourceHash returns the hash of a contract source stored in its ABI.
+func SourceHash(source []byte) string {
+	h := sha256.Sum256(source)
+	return hex.EncodeToString(h[:])
+}
+
+// SetSourceHash returns the code whose ABI records the hash of source.
+func SetSourceHash(code LuaCode, source []byte) (LuaCode, error) {
+	if !code.IsValidFormat() {
+		return nil, errors.New("invalid contract code")
+	}
+	abi := make(map[string]interface{})
+	if err := json.Unmarshal(code.ABI(), &abi); err != nil {
+		return nil, fmt.Errorf("invalid abi: %v", err)
+	}
+	abi[abiSourceHashKey] = SourceHash(source)
+	rawAbi, err := json.Marshal(abi)
+	if err != nil {
+		return nil, err
+	}
+	return NewLuaCode(code.ByteCode(), rawAbi), nil
+}
+
+// splitChunkName splits a bytecode dump into its chunk name and the rest of
+// the dump. The chunk name depends on how the source is loaded, e.g. the
+// file name, while the rest only depends on the source and the compiler.
+func splitChunkName(byteCode []byte) (string, []byte, error) {
+	if len(byteCode) < len(bcDumpHead)+1 || !bytes.HasPrefix(byteCode, []byte(bcDumpHead)) {
+		return "", nil, errors.New("invalid bytecode header")
+	}
+	// the version is kept in the rest to be compared
+	rest := byteCode[len(bcDumpHead)+1:]
+	flags, n := readUleb128(rest)
+	if n == 0 {
+		return "", nil, errors.New("invalid bytecode header")
+	}
+	if flags&bcDumpFlagStrip != 0 {
+		return "", byteCode, nil
+	}
+	nameLen, m := readUleb128(rest[n:])
+	if m == 0 || uint64(len(rest)-n-m) < nameLen {
+		return "", nil, errors.New("invalid bytecode header")
+	}
+	name := rest[n+m : n+m+int(nameLen)]
+
+	head := byteCode[:len(bcDumpHead)+1+n]
+	body := rest[n+m+int(nameLen):]
+	stripped := make([]byte, 0, len(head)+len(body))
+	stripped = append(append(stripped, head...), body...)
+	return string(name), stripped, nil
+}
+
+func readUleb128(b []byte) (uint64, int) {
+	var v uint64
+	for i, c := range b {
+		if i == 10 {
+			break
+		}
+		v |= uint64(c&0x7f) << (7 * uint(i))
+		if c&0x80 == 0 {
+			return v, i + 1
+		}
+	}
+	return 0, 0
+}
+
+// equalABI compares two ABIs without their source hash.
+func equalABI(a, b []byte) (bool, string) {
+	var ma, mb map[string]interface{}
+	if err := json.Unmarshal(a, &ma); err != nil {
+		return false, ""
+	}
+	if err := json.Unmarshal(b, &mb); err != nil {
+		return false, ""
+	}
+	hash, _ := ma[abiSourceHashKey].(string)
+	delete(ma, abiSourceHashKey)
+	delete(mb, abiSourceHashKey)
+	return reflect.DeepEqual(ma, mb), hash
+}
+
+// VerifySource compiles source and compares the result with the deployed
+// code of a contract. The chunk names of the bytecodes are ignored, so the
+// code compiled from a file of any name is verified.
+func VerifySource(deployed LuaCode, source string) *types.SourceVerification {
+	v := &types.SourceVerification{CompilerVersion: CompilerVersion()}
+	if !deployed.IsValidFormat() {
+		v.Reason = "invalid deployed code"
+		return v
+	}
+	deployedName, deployedBody, err := splitChunkName(deployed.ByteCode())
+	if err != nil {
+		v.Reason = fmt.Sprintf("deployed code: %v", err)
+		return v
+	}
+	v.DeployedHash = hash(deployedBody)
+
+	L := NewLState()
+	if L == nil {
+		v.Reason = "failed to create a lua state"
+		return v
+	}
+	defer CloseLState(L)
+	compiled, err := Compile(L, source)
+	if err != nil {
+		v.Reason = fmt.Sprintf("compile error: %v", err)
+		return v
+	}
+	_, compiledBody, err := splitChunkName(compiled.ByteCode())
+	if err != nil {
+		v.Reason = fmt.Sprintf("compiled code: %v", err)
+		return v
+	}
+	v.CompiledHash = hash(compiledBody)
+
+	// a file name is prefixed by @, and a string chunk is named after its
+	// content
+	if len(deployedName) > 0 && (deployedName[0] == '@' || deployedName[0] == '=') {
+		v.ChunkName = deployedName
+	}
+	v.AbiMatch, v.SourceHash = equalABI(deployed.ABI(), compiled.ABI())
+	if v.SourceHash != "" {
+		v.SourceHashMatch = v.SourceHash == SourceHash([]byte(source))
+	}
+
+	switch {
+	case !bytes.Equal(v.DeployedHash, v.CompiledHash):
+		v.Reason = "bytecode mismatch"
+	case !v.AbiMatch:
+		v.Reason = "abi mismatch"
+	case v.SourceHash != "" && !v.SourceHashMatch:
+		v.Reason = "source hash mismatch"
+	default:
+		v.Verified = true
+	}
+	return v
+}
+
+func hash(b []byte) []byte {
+	h := sha256.Sum256(b)
+	return h[:]
+}
@@ -0,0 +1,178 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+
+	"github.com/aergoio/aergo/account/key"
+	"github.com/aergoio/aergo/consensus/signer"
+	"github.com/aergoio/aergo/p2p/p2putil"
+	"github.com/aergoio/aergo/types"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/spf13/cobra"
+	"golang.org/x/crypto/ssh/terminal"
+	"google.golang.org/grpc"
+)
+
+var (
+	rootCmd   *cobra.Command
+	importCmd *cobra.Command
+
+	keystore      string
+	address       string
+	password      string
+	listen        string
+	stateFile     string
+	keyFile       string
+	blockInterval int64
+	version       bool
+)
+
+var githash = "No git hash provided"
+
+func init() {
+	rootCmd = &cobra.Command{
+		Use:   "aergosigner --keystore dir --address addr --listen unix:///path/to/socket",
+		Short: "Sign blocks on behalf of a block producer",
+		Long: "Sign blocks on behalf of a block producer. aergosvr requests signatures with the remotesigner option of " +
+			"consensus. The signer never signs two blocks for the same slot or for a lower height.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if version {
+				cmd.Printf("Aergosigner %s\n", githash)
+				return nil
+			}
+			return serve(cmd)
+		},
+	}
+	rootCmd.PersistentFlags().StringVar(&keystore, "keystore", ".", "path to the directory of keystore")
+	rootCmd.PersistentFlags().StringVar(&password, "password", "", "password of the key (prompted if empty)")
+	rootCmd.Flags().StringVar(&address, "address", "", "address of the block producer key in keystore")
+	rootCmd.Flags().StringVar(&listen, "listen", "unix:///tmp/aergosigner.sock", "address to listen on (unix:///path/to/socket or host:port)")
+	rootCmd.Flags().StringVar(&stateFile, "state", "", "file to keep the last signed height and slot (default: signer.json in keystore)")
+	rootCmd.Flags().Int64Var(&blockInterval, "blockinterval", 1, "block interval (sec) of the chain to check the slot of requests. 0 disables the check")
+	rootCmd.Flags().BoolVar(&version, "version", false, "print the version number of aergosigner")
+
+	importCmd = &cobra.Command{
+		Use:   "import --keyfile bp.key",
+		Short: "Import a p2p key file of block producer into keystore",
+		Args:  cobra.NoArgs,
+		RunE:  importKey,
+	}
+	importCmd.Flags().StringVar(&keyFile, "keyfile", "", "path to the p2p key file")
+	importCmd.MarkFlagRequired("keyfile")
+	rootCmd.AddCommand(importCmd)
+}
+
+func main() {
+	if err := rootCmd.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+func getPassword(cmd *cobra.Command) (string, error) {
+	if len(password) > 0 {
+		return password, nil
+	}
+	cmd.Print("Enter Password: ")
+	pass, err := terminal.ReadPassword(int(syscall.Stdin))
+	cmd.Println("")
+	return string(pass), err
+}
+
+func importKey(cmd *cobra.Command, args []string) error {
+	privKey, _, err := p2putil.LoadKeyFile(keyFile)
+	if err != nil {
+		return err
+	}
+	raw, err := privKey.Raw()
+	if err != nil {
+		return err
+	}
+	pass, err := getPassword(cmd)
+	if err != nil {
+		return err
+	}
+	encrypted, err := key.EncryptKey(raw, pass)
+	if err != nil {
+		return err
+	}
+
+	ks := key.NewStore(keystore, 0)
+	defer ks.CloseStore()
+
+	addr, err := ks.ImportKey(encrypted, pass, pass)
+	if err != nil {
+		return err
+	}
+	id, _ := types.IDFromPrivateKey(privKey)
+	cmd.Printf("address: %s\nbp id: %s\n", types.EncodeAddress(addr), types.IDB58Encode(id))
+
+	return nil
+}
+
+func loadKey(cmd *cobra.Command) (crypto.PrivKey, error) {
+	if len(address) == 0 {
+		return nil, errors.New("--address is required")
+	}
+	addr, err := types.DecodeAddress(address)
+	if err != nil {
+		return nil, err
+	}
+	pass, err := getPassword(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	ks := key.NewStore(keystore, 0)
+	defer ks.CloseStore()
+
+	pk, err := ks.GetPrivKey(addr, pass)
+	if err != nil {
+		return nil, err
+	}
+	return (*crypto.Secp256k1PrivateKey)(pk), nil
+}
+
+func serve(cmd *cobra.Command) error {
+	privKey, err := loadKey(cmd)
+	if err != nil {
+		return err
+	}
+	if len(stateFile) == 0 {
+		stateFile = filepath.Join(keystore, "signer.json")
+	}
+	s, err := signer.NewServer(privKey, stateFile, blockInterval)
+	if err != nil {
+		return err
+	}
+
+	l, err := signer.Listen(listen)
+	if err != nil {
+		return err
+	}
+
+	server := grpc.NewServer()
+	types.RegisterBlockSignerServiceServer(server, s)
+
+	go func() {
+		interrupt := make(chan os.Signal, 1)
+		signal.Notify(interrupt, syscall.SIGINT, syscall.SIGTERM)
+		<-interrupt
+		server.GracefulStop()
+	}()
+
+	id, _ := types.IDFromPrivateKey(privKey)
+	fmt.Fprintf(os.Stderr, "aergosigner for %s listening on %s\n", types.IDB58Encode(id), listen)
+
+	return server.Serve(l)
+}
@@ -11,6 +11,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/aergoio/aergo/p2p/p2pkey"
 
@@ -24,6 +25,7 @@ import (
 	"github.com/aergoio/aergo/mempool"
 	"github.com/aergoio/aergo/p2p"
 	"github.com/aergoio/aergo/pkg/component"
+	"github.com/aergoio/aergo/pkg/metrics"
 	polarisclient "github.com/aergoio/aergo/polaris/client"
 	"github.com/aergoio/aergo/rpc"
 	"github.com/aergoio/aergo/syncer"
@@ -190,6 +192,17 @@ func rootRun(cmd *cobra.Command, args []string) {
 		consensus.Start(consensusSvc)
 	}
 
+	if len(cfg.Monitor.MetricsAddr) > 0 {
+		if err := metrics.Register(metrics.NewCollector(compMng, 3*time.Second)); err != nil {
+			svrlog.Error().Err(err).Msg("Failed to register component metrics.")
+		}
+		svrlog.Info().Str("addr", cfg.Monitor.MetricsAddr).Msg("Serve prometheus metrics")
+		go func() {
+			err := metrics.NewServer(cfg.Monitor.MetricsAddr).ListenAndServe()
+			svrlog.Info().Err(err).Msg("Metrics Server stopped")
+		}()
+	}
+
 	var interrupt = common.HandleKillSig(func() {
 		consensus.Stop(consensusSvc)
 		compMng.Stop()
@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"github.com/aergoio/aergo/consensus/impl"
 	"os"
+	"path/filepath"
 
 	"github.com/aergoio/aergo/chain"
+	"github.com/aergoio/aergo/contract"
 	"github.com/aergoio/aergo/internal/enc"
 	"github.com/aergoio/aergo/types"
 	"github.com/spf13/cobra"
@@ -50,6 +52,10 @@ var initGenesis = &cobra.Command{
 				return
 			}
 
+			if err := genesis.Validate(); err != nil {
+				fmt.Printf(" %s (error:%s)\n", jsonGenesis, err)
+				return
+			}
 			if err := impl.ValidateGenesis(genesis); err != nil {
 				fmt.Printf(" %s (error:%s)\n", jsonGenesis, err)
 				return
@@ -65,6 +71,7 @@ var initGenesis = &cobra.Command{
 		}
 
 		if core != nil {
+			contract.HardforkConfig = cfg.Hardfork
 			err := core.InitGenesisBlock(genesis, !testNet)
 			if err != nil {
 				fmt.Printf("fail to init genesis block data (error:%s)\n", err)
@@ -88,6 +95,10 @@ func getGenesis(path string) *types.Genesis {
 		fmt.Printf("fail to deserialize %s (error:%s)\n", jsonGenesis, err)
 		return nil
 	}
+	if err := genesis.ReadContractFiles(filepath.Dir(jsonGenesis)); err != nil {
+		fmt.Printf("fail to read contract files of %s (error:%s)\n", jsonGenesis, err)
+		return nil
+	}
 	return genesis
 }
 
@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"sort"
 	"strings"
 
@@ -10,6 +11,7 @@ import (
 	"github.com/aergoio/aergo/cmd/brick/context"
 	"github.com/aergoio/aergo/cmd/brick/exec"
 	prompt "github.com/c-bata/go-prompt"
+	"github.com/mattn/go-colorable"
 )
 
 var logger = log.NewLogger("brick")
@@ -79,6 +81,51 @@ func completerBroker(d prompt.Document) []prompt.Suggest {
 	return prompt.FilterHasPrefix(s, d.GetWordBeforeCursor(), true)
 }
 
+// runTests runs the test scenarios and returns the exit code, which is
+// non-zero if any scenario fails.
+func runTests(path, junitPath string) int {
+	stdOut := colorable.NewColorableStdout()
+
+	cases, err := exec.RunTests(path)
+	if err != nil {
+		fmt.Fprintf(stdOut, "\x1B[31;1m%s\x1B[0m\n", err.Error())
+		return 1
+	}
+
+	failed := 0
+	for _, tc := range cases {
+		if tc.Failed() {
+			failed++
+			fmt.Fprintf(stdOut, "\x1B[31;1mFAIL\x1B[0m %s (%.3fs)\n", tc.Name, tc.Duration.Seconds())
+			for _, failure := range tc.Failures {
+				fmt.Fprintf(stdOut, "\t%s\n", failure)
+			}
+		} else {
+			fmt.Fprintf(stdOut, "\x1B[32;1mPASS\x1B[0m %s (%.3fs)\n", tc.Name, tc.Duration.Seconds())
+		}
+	}
+
+	if junitPath != "" {
+		f, err := os.Create(junitPath)
+		if err != nil {
+			fmt.Fprintf(stdOut, "\x1B[31;1mfail to create a junit report %s: %s\x1B[0m\n", junitPath, err.Error())
+			return 1
+		}
+		defer f.Close()
+		if err := exec.WriteJUnit(f, filepath.Base(filepath.Clean(path)), cases); err != nil {
+			fmt.Fprintf(stdOut, "\x1B[31;1mfail to write a junit report %s: %s\x1B[0m\n", junitPath, err.Error())
+			return 1
+		}
+	}
+
+	if failed != 0 {
+		fmt.Fprintf(stdOut, "\x1B[31;1mTest is failed: %d of %d scenarios\x1B[0m\n", failed, len(cases))
+		return 1
+	}
+	fmt.Fprintf(stdOut, "\x1B[32;1mTest is successfully finished: %d scenarios\x1B[0m\n", len(cases))
+	return 0
+}
+
 func main() {
 	if len(os.Args) <= 1 {
 		// cli mode
@@ -90,6 +137,13 @@ func main() {
 			prompt.OptionTitle("Aergo Brick: Dummy Virtual Machine"),
 		)
 		p.Run()
+	} else if len(os.Args) > 2 && os.Args[2] == "-t" {
+		// run test scenarios
+		junitPath := ""
+		if len(os.Args) > 3 {
+			junitPath = os.Args[3]
+		}
+		os.Exit(runTests(os.Args[1], junitPath))
 	} else {
 		// call batch executor
 		cmd := "batch"
@@ -102,7 +156,8 @@ func main() {
 			} else if os.Args[2] == "-w" {
 				exec.EnableWatch()
 			} else {
-				fmt.Println("Invalid Parameter. Usage: brick filename [-v|-w]\n\t-v\tverbose mode\n\t-w\twatch mode")
+				fmt.Println("Invalid Parameter. Usage: brick filename [-v|-w]\n\t-v\tverbose mode\n\t-w\twatch mode\n" +
+					"       brick path -t [junit_xml_path]\n\t-t\ttest mode")
 				os.Exit(1)
 			}
 		}
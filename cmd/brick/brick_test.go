@@ -0,0 +1,63 @@
+package main
+
+import (
+	"encoding/xml"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRunTests(t *testing.T) {
+	dir, err := ioutil.TempDir("", "brick")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	scenarios := map[string]string{
+		"pass.brick": "inject bj 100\ngetstate bj `100`\n",
+		"fail.brick": "inject bj 100\ngetstate bj `200`\n",
+	}
+	for name, s := range scenarios {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(s), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	junitPath := filepath.Join(dir, "report.xml")
+	if code := runTests(dir, junitPath); code != 1 {
+		t.Errorf("runTests() = %d, want 1", code)
+	}
+	data, err := ioutil.ReadFile(junitPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var suite struct {
+		Tests    int `xml:"tests,attr"`
+		Failures int `xml:"failures,attr"`
+		Cases    []struct {
+			Name    string `xml:"name,attr"`
+			Failure *struct {
+				Text string `xml:",chardata"`
+			} `xml:"failure"`
+		} `xml:"testcase"`
+	}
+	if err := xml.Unmarshal(data, &suite); err != nil {
+		t.Fatalf("invalid junit report: %v\n%s", err, data)
+	}
+	if suite.Tests != 2 || suite.Failures != 1 || len(suite.Cases) != 2 {
+		t.Fatalf("tests = %d, failures = %d, cases = %d, want 2, 1, 2", suite.Tests, suite.Failures, len(suite.Cases))
+	}
+	// the scenarios run in the order of their file names
+	if c := suite.Cases[0]; c.Name != "fail" || c.Failure == nil || len(c.Failure.Text) == 0 {
+		t.Errorf("the failing scenario is not reported: %s", data)
+	}
+	if c := suite.Cases[1]; c.Name != "pass" || c.Failure != nil {
+		t.Errorf("the passing scenario is reported as failed: %s", data)
+	}
+
+	if code := runTests(filepath.Join(dir, "pass.brick"), ""); code != 0 {
+		t.Errorf("runTests() of the passing scenario = %d, want 0", code)
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"strconv"
 
 	"github.com/aergoio/aergo/contract"
+	"github.com/aergoio/aergo/types"
 )
 
 var CurrentCtx *context
@@ -27,6 +28,10 @@ type context struct {
 }
 
 func Reset() {
+	// release the previous chain to remove its temporary data
+	if CurrentCtx != nil {
+		CurrentCtx.chain.Release()
+	}
 	chain, err := contract.LoadDummyChain(contract.OnPubNet)
 	if err != nil {
 		panic(err)
@@ -36,6 +41,25 @@ func Reset() {
 	}
 }
 
+// Fork replaces the current chain with a fork of the state of a node at the
+// block blockNo. src is the data directory of the node or its snapshot file.
+func Fork(src string, blockNo types.BlockNo) error {
+	// release the previous chain first, which may lock the same data directory
+	if CurrentCtx != nil {
+		CurrentCtx.chain.Release()
+		CurrentCtx = nil
+	}
+	chain, err := contract.LoadForkedDummyChain(src, blockNo)
+	if err != nil {
+		Reset()
+		return err
+	}
+	CurrentCtx = &context{
+		chain: chain,
+	}
+	return nil
+}
+
 func LivePrefix() (string, bool) {
 	height := strconv.FormatUint(CurrentCtx.chain.BestBlockNo(), 10)
 	ret := height + "> "
@@ -10,7 +10,11 @@ var (
 	ExpectedSymbol     = "<expected>"
 	ExpectedErrSymbol  = "<expected_err>"
 	FunctionSymbol     = "<function>"
+	EventSymbol        = "<event>"
+	StatusSymbol       = "<status>"
 	CommandSymbol      = "[command]"
+	BlockNoSymbol      = "[block_no]"
+	AddressSymbol      = "<address>"
 )
 
 // reprenestation and description map of all symbols
@@ -25,4 +29,8 @@ func init() {
 	Symbols[ExpectedSymbol] = "expected result"
 	Symbols[ExpectedErrSymbol] = "expected error"
 	Symbols[FunctionSymbol] = "smart contract function name"
+	Symbols[EventSymbol] = "smart contract event name"
+	Symbols[StatusSymbol] = "status of a receipt"
+	Symbols[BlockNoSymbol] = "block number, the latest if omitted"
+	Symbols[AddressSymbol] = "existing account address to impersonate"
 }
@@ -101,6 +101,7 @@ func (c *callContract) Run(args string) (string, uint64, []*types.Event, error)
 	if err != nil {
 		return "", 0, nil, err
 	}
+	lastTxHash = callTx.Hash()
 
 	if expectedError != "" {
 		Index(context.ExpectedErrSymbol, expectedError)
@@ -16,6 +16,10 @@ var logger = log.NewLogger("brick")
 
 var storedCmdLine = ""
 
+// lastTxHash is the hash of the last deploy or call tx, whose receipt is
+// checked by the expect commands.
+var lastTxHash []byte
+
 type Executor interface {
 	Command() string
 	Syntax() string
@@ -0,0 +1,91 @@
+package exec
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/aergoio/aergo/cmd/brick/context"
+	"github.com/aergoio/aergo/types"
+)
+
+func init() {
+	registerExec(&expectEvent{})
+}
+
+type expectEvent struct{}
+
+func (c *expectEvent) Command() string {
+	return "expect-event"
+}
+
+func (c *expectEvent) Syntax() string {
+	return fmt.Sprintf("%s %s %s", context.ContractSymbol, context.EventSymbol, context.ExpectedSymbol)
+}
+
+func (c *expectEvent) Usage() string {
+	return "expect-event <contract_name> <event_name> `[expected_json_args]`"
+}
+
+func (c *expectEvent) Describe() string {
+	return "check that the last tx emitted an event"
+}
+
+func (c *expectEvent) Validate(args string) error {
+	if context.Get() == nil {
+		return fmt.Errorf("load chain first")
+	}
+
+	_, _, _, err := c.parse(args)
+
+	return err
+}
+
+func (c *expectEvent) parse(args string) (string, string, interface{}, error) {
+	splitArgs := context.SplitSpaceAndAccent(args, false)
+	if len(splitArgs) < 2 {
+		return "", "", nil, fmt.Errorf("need at least 2 arguments. usage: %s", c.Usage())
+	} else if len(splitArgs) > 3 {
+		return "", "", nil, fmt.Errorf("too many arguments. usage: %s", c.Usage())
+	}
+
+	var expectedArgs interface{}
+	if len(splitArgs) == 3 {
+		if err := json.Unmarshal([]byte(splitArgs[2].Text), &expectedArgs); err != nil {
+			return "", "", nil, fmt.Errorf("fail to parse json args %s: %s", splitArgs[2].Text, err.Error())
+		}
+	}
+
+	return splitArgs[0].Text, splitArgs[1].Text, expectedArgs, nil
+}
+
+func (c *expectEvent) Run(args string) (string, uint64, []*types.Event, error) {
+	contractName, eventName, expectedArgs, _ := c.parse(args)
+
+	if lastTxHash == nil {
+		return "", 0, nil, fmt.Errorf("no tx to check")
+	}
+
+	address := context.Get().StrToAddress(contractName)
+	var found []string
+	for _, event := range context.Get().GetEvents(lastTxHash) {
+		if types.EncodeAddress(event.GetContractAddress()) != address || event.GetEventName() != eventName {
+			continue
+		}
+		if expectedArgs == nil {
+			return "event compare successfully", 0, nil, nil
+		}
+		var eventArgs interface{}
+		if err := json.Unmarshal([]byte(event.GetJsonArgs()), &eventArgs); err == nil &&
+			reflect.DeepEqual(expectedArgs, eventArgs) {
+			return "event compare successfully", 0, nil, nil
+		}
+		found = append(found, event.GetJsonArgs())
+	}
+
+	if len(found) == 0 {
+		return "", 0, nil, fmt.Errorf("event %s of %s not found", eventName, contractName)
+	}
+	expected, _ := json.Marshal(expectedArgs)
+	return "", 0, nil, fmt.Errorf("event args compare fail. Expected: %s, Actual: %s", expected, found)
+}
@@ -0,0 +1,76 @@
+package exec
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aergoio/aergo/cmd/brick/context"
+	"github.com/aergoio/aergo/types"
+)
+
+func init() {
+	registerExec(&expectGas{})
+}
+
+type expectGas struct{}
+
+func (c *expectGas) Command() string {
+	return "expect-gas"
+}
+
+func (c *expectGas) Syntax() string {
+	return context.ExpectedSymbol
+}
+
+func (c *expectGas) Usage() string {
+	return "expect-gas <gas_used>|<=<max_gas_used>"
+}
+
+func (c *expectGas) Describe() string {
+	return "check the gas used by the last tx"
+}
+
+func (c *expectGas) Validate(args string) error {
+	if context.Get() == nil {
+		return fmt.Errorf("load chain first")
+	}
+
+	_, _, err := c.parse(args)
+
+	return err
+}
+
+// parse returns the expected gas and whether it's an upper bound.
+func (c *expectGas) parse(args string) (uint64, bool, error) {
+	splitArgs := context.SplitSpaceAndAccent(args, false)
+	if len(splitArgs) != 1 {
+		return 0, false, fmt.Errorf("need 1 argument. usage: %s", c.Usage())
+	}
+
+	text := splitArgs[0].Text
+	atMost := strings.HasPrefix(text, "<=")
+	gas, err := strconv.ParseUint(strings.TrimPrefix(text, "<="), 10, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("fail to parse number %s: %s", text, err.Error())
+	}
+
+	return gas, atMost, nil
+}
+
+func (c *expectGas) Run(args string) (string, uint64, []*types.Event, error) {
+	gas, atMost, _ := c.parse(args)
+
+	if lastTxHash == nil {
+		return "", 0, nil, fmt.Errorf("no tx to check")
+	}
+
+	gasUsed := context.Get().GetReceipt(lastTxHash).GetGasUsed()
+	if gasUsed == gas || (atMost && gasUsed < gas) {
+		return "gas compare successfully", 0, nil, nil
+	}
+	if atMost {
+		return "", 0, nil, fmt.Errorf("gas compare fail. Expected: <= %d, Actual: %d", gas, gasUsed)
+	}
+	return "", 0, nil, fmt.Errorf("gas compare fail. Expected: %d, Actual: %d", gas, gasUsed)
+}
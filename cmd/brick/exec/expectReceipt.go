@@ -0,0 +1,99 @@
+package exec
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/aergoio/aergo/cmd/brick/context"
+	"github.com/aergoio/aergo/types"
+)
+
+func init() {
+	registerExec(&expectReceipt{})
+}
+
+type expectReceipt struct{}
+
+func (c *expectReceipt) Command() string {
+	return "expect-receipt"
+}
+
+func (c *expectReceipt) Syntax() string {
+	return fmt.Sprintf("%s %s", context.StatusSymbol, context.ExpectedSymbol)
+}
+
+func (c *expectReceipt) Usage() string {
+	return "expect-receipt <SUCCESS|CREATED|ERROR> `[expected_result]`"
+}
+
+func (c *expectReceipt) Describe() string {
+	return "check the status and the result of the last tx"
+}
+
+func (c *expectReceipt) Validate(args string) error {
+	if context.Get() == nil {
+		return fmt.Errorf("load chain first")
+	}
+
+	_, _, err := c.parse(args)
+
+	return err
+}
+
+func (c *expectReceipt) parse(args string) (string, string, error) {
+	splitArgs := context.SplitSpaceAndAccent(args, false)
+	if len(splitArgs) < 1 {
+		return "", "", fmt.Errorf("need at least 1 argument. usage: %s", c.Usage())
+	} else if len(splitArgs) > 2 {
+		return "", "", fmt.Errorf("too many arguments. usage: %s", c.Usage())
+	}
+
+	status := strings.ToUpper(splitArgs[0].Text)
+	switch status {
+	case "SUCCESS", "CREATED", "ERROR":
+	default:
+		return "", "", fmt.Errorf("invalid status %s. usage: %s", splitArgs[0].Text, c.Usage())
+	}
+
+	expectedResult := ""
+	if len(splitArgs) == 2 {
+		expectedResult = splitArgs[1].Text
+	}
+
+	return status, expectedResult, nil
+}
+
+func (c *expectReceipt) Run(args string) (string, uint64, []*types.Event, error) {
+	status, expectedResult, _ := c.parse(args)
+
+	if lastTxHash == nil {
+		return "", 0, nil, fmt.Errorf("no tx to check")
+	}
+
+	receipt := context.Get().GetReceipt(lastTxHash)
+	if receipt.GetStatus() != status {
+		return "", 0, nil, fmt.Errorf("receipt status compare fail. Expected: %s, Actual: %s (%s)",
+			status, receipt.GetStatus(), receipt.GetRet())
+	}
+	if expectedResult != "" && !resultMatches(status, expectedResult, receipt.GetRet()) {
+		return "", 0, nil, fmt.Errorf("receipt result compare fail. Expected: %s, Actual: %s",
+			expectedResult, receipt.GetRet())
+	}
+
+	return "receipt compare successfully", 0, nil, nil
+}
+
+// resultMatches compares the result of a receipt. An error message matches
+// if it contains the expected one, and a json result is compared by value.
+func resultMatches(status, expected, actual string) bool {
+	if status == "ERROR" {
+		return strings.Contains(actual, expected)
+	}
+	var e, a interface{}
+	if json.Unmarshal([]byte(expected), &e) != nil || json.Unmarshal([]byte(actual), &a) != nil {
+		return expected == actual
+	}
+	return reflect.DeepEqual(e, a)
+}
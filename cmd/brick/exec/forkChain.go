@@ -0,0 +1,68 @@
+package exec
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/aergoio/aergo/cmd/brick/context"
+	"github.com/aergoio/aergo/contract"
+	"github.com/aergoio/aergo/types"
+)
+
+func init() {
+	registerExec(&forkChain{})
+}
+
+type forkChain struct{}
+
+func (c *forkChain) Command() string {
+	return "fork"
+}
+
+func (c *forkChain) Syntax() string {
+	return fmt.Sprintf("%s %s", context.PathSymbol, context.BlockNoSymbol)
+}
+
+func (c *forkChain) Usage() string {
+	return "fork <data_dir|snapshot_file> [block_no]"
+}
+
+func (c *forkChain) Describe() string {
+	return "replace the dummy chain with a fork of the state of a stopped node at a given block, the latest if omitted"
+}
+
+func (c *forkChain) Validate(args string) error {
+	_, _, err := c.parse(args)
+	return err
+}
+
+func (c *forkChain) parse(args string) (string, types.BlockNo, error) {
+	splitArgs := context.SplitSpaceAndAccent(args, false)
+	if len(splitArgs) < 1 {
+		return "", 0, fmt.Errorf("need at least 1 argument. usage: %s", c.Usage())
+	}
+
+	blockNo := contract.ForkLatest
+	if len(splitArgs) > 1 {
+		no, err := strconv.ParseUint(splitArgs[1].Text, 10, 64)
+		if err != nil {
+			return "", 0, fmt.Errorf("fail to parse block number %s", splitArgs[1].Text)
+		}
+		blockNo = types.BlockNo(no)
+	}
+
+	return splitArgs[0].Text, blockNo, nil
+}
+
+func (c *forkChain) Run(args string) (string, uint64, []*types.Event, error) {
+	src, blockNo, _ := c.parse(args)
+
+	if err := context.Fork(src, blockNo); err != nil {
+		return "", 0, nil, err
+	}
+
+	resetContractInfoInterface()
+	lastTxHash = nil
+
+	return fmt.Sprintf("fork a chain at block %d successfully", context.Get().BestBlockNo()), 0, nil, nil
+}
@@ -3,6 +3,7 @@ package exec
 import (
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/aergoio/aergo/cmd/brick/context"
 	"github.com/aergoio/aergo/types"
@@ -19,15 +20,15 @@ func (c *forward) Command() string {
 }
 
 func (c *forward) Syntax() string {
-	return context.AmountSymbol
+	return fmt.Sprintf("%s %s", context.AmountSymbol, context.AmountSymbol)
 }
 
 func (c *forward) Usage() string {
-	return "forward [height_to_skip]"
+	return "forward [height_to_skip] [seconds_to_skip]"
 }
 
 func (c *forward) Describe() string {
-	return "fast forward blocks n times (default = 1)"
+	return "fast forward blocks n times (default = 1) and the clock by seconds (default = 0)"
 }
 
 func (c *forward) Validate(args string) error {
@@ -36,31 +37,41 @@ func (c *forward) Validate(args string) error {
 		return fmt.Errorf("load chain first")
 	}
 
-	_, err := c.parse(args)
+	_, _, err := c.parse(args)
 
 	return err
 }
 
-func (c *forward) parse(args string) (int, error) {
+func (c *forward) parse(args string) (int, time.Duration, error) {
 	splitArgs := context.SplitSpaceAndAccent(args, false)
 	if len(splitArgs) == 0 {
 		height, _ := strconv.Atoi("1")
-		return height, nil
-	} else if len(splitArgs) > 1 {
-		return 0, fmt.Errorf("need 1 or 0 arguments. usage: %s", c.Usage())
+		return height, 0, nil
+	} else if len(splitArgs) > 2 {
+		return 0, 0, fmt.Errorf("need 2, 1 or 0 arguments. usage: %s", c.Usage())
 	}
 
 	amount, err := strconv.Atoi(splitArgs[0].Text)
 	if err != nil {
-		return 0, fmt.Errorf("fail to parse number %s: %s", splitArgs[0].Text, err.Error())
+		return 0, 0, fmt.Errorf("fail to parse number %s: %s", splitArgs[0].Text, err.Error())
 	}
 
-	return amount, nil
+	var shift time.Duration
+	if len(splitArgs) == 2 {
+		seconds, err := strconv.ParseUint(splitArgs[1].Text, 10, 32)
+		if err != nil {
+			return 0, 0, fmt.Errorf("fail to parse number %s: %s", splitArgs[1].Text, err.Error())
+		}
+		shift = time.Duration(seconds) * time.Second
+	}
+
+	return amount, shift, nil
 }
 
 func (c *forward) Run(args string) (string, uint64, []*types.Event, error) {
-	amount, _ := c.parse(args)
+	amount, shift, _ := c.parse(args)
 
+	context.Get().ShiftTime(shift)
 	for i := 0; i < amount; i++ {
 		if err := context.Get().ConnectBlock(); err != nil {
 			return "", 0, nil, err
@@ -5,7 +5,6 @@ import (
 	"math/big"
 
 	"github.com/aergoio/aergo/cmd/brick/context"
-	"github.com/aergoio/aergo/contract"
 	"github.com/aergoio/aergo/types"
 )
 
@@ -66,7 +65,7 @@ func (c *getStateAccount) Run(args string) (string, uint64, []*types.Event, erro
 		return "", 0, nil, err
 	}
 	if expectedResult == "" {
-		return fmt.Sprintf("%s = %d", contract.StrToAddress(accountName), new(big.Int).SetBytes(state.GetBalance())), 0, nil, nil
+		return fmt.Sprintf("%s = %d", context.Get().StrToAddress(accountName), new(big.Int).SetBytes(state.GetBalance())), 0, nil, nil
 	} else {
 		strRet := fmt.Sprintf("%d", new(big.Int).SetBytes(state.GetBalance()))
 		if expectedResult == strRet {
@@ -0,0 +1,67 @@
+package exec
+
+import (
+	"fmt"
+
+	"github.com/aergoio/aergo/cmd/brick/context"
+	"github.com/aergoio/aergo/types"
+)
+
+func init() {
+	registerExec(&impersonate{})
+}
+
+type impersonate struct{}
+
+func (c *impersonate) Command() string {
+	return "impersonate"
+}
+
+func (c *impersonate) Syntax() string {
+	return fmt.Sprintf("%s %s", context.AccountSymbol, context.AddressSymbol)
+}
+
+func (c *impersonate) Usage() string {
+	return "impersonate <account_name> <address>"
+}
+
+func (c *impersonate) Describe() string {
+	return "send the txs of an account name from an existing address without its key"
+}
+
+func (c *impersonate) Validate(args string) error {
+
+	// is chain is loaded?
+	if context.Get() == nil {
+		return fmt.Errorf("load chain first")
+	}
+
+	_, _, err := c.parse(args)
+
+	return err
+}
+
+func (c *impersonate) parse(args string) (string, string, error) {
+	splitArgs := context.SplitSpaceAndAccent(args, false)
+	if len(splitArgs) < 2 {
+		return "", "", fmt.Errorf("need 2 arguments. usage: %s", c.Usage())
+	}
+
+	if _, err := types.DecodeAddress(splitArgs[1].Text); err != nil {
+		return "", "", fmt.Errorf("invalid address %s: %v", splitArgs[1].Text, err)
+	}
+
+	return splitArgs[0].Text, splitArgs[1].Text, nil
+}
+
+func (c *impersonate) Run(args string) (string, uint64, []*types.Event, error) {
+	accountName, address, _ := c.parse(args)
+
+	if err := context.Get().Impersonate(accountName, address); err != nil {
+		return "", 0, nil, err
+	}
+
+	Index(context.AccountSymbol, accountName)
+
+	return fmt.Sprintf("impersonate %s as %s successfully", address, accountName), 0, nil, nil
+}
@@ -37,6 +37,7 @@ func (c *resetChain) Run(args string) (string, uint64, []*types.Event, error) {
 	context.Reset()
 
 	resetContractInfoInterface()
+	lastTxHash = nil
 
 	return "reset a dummy chain successfully", 0, nil, nil
 }
@@ -0,0 +1,175 @@
+package exec
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aergoio/aergo/cmd/brick/context"
+	"github.com/rs/zerolog"
+)
+
+// TestCase is the result of a brick scenario run by RunTests.
+type TestCase struct {
+	Name     string
+	Path     string
+	Duration time.Duration
+	Failures []string
+}
+
+// Failed returns true if any command of the scenario failed.
+func (tc *TestCase) Failed() bool {
+	return len(tc.Failures) != 0
+}
+
+// RunTests runs every .brick scenario in a directory, or a single scenario
+// file. Each scenario starts from a fresh dummy chain and keeps running after
+// a failed command so that all the failures are reported.
+func RunTests(path string) ([]*TestCase, error) {
+	files, err := testFiles(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// set highest log level to turn off verbose
+	if false == verboseBatch {
+		zerolog.SetGlobalLevel(zerolog.ErrorLevel)
+		defer zerolog.SetGlobalLevel(zerolog.DebugLevel)
+	}
+
+	var cases []*TestCase
+	for _, file := range files {
+		cases = append(cases, runTest(file))
+	}
+	return cases, nil
+}
+
+func testFiles(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+	files, err := filepath.Glob(filepath.Join(path, "*.brick"))
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no brick scenario in %s", path)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+func runTest(path string) *TestCase {
+	tc := &TestCase{
+		Name: strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)),
+		Path: path,
+	}
+	start := time.Now()
+
+	context.Reset()
+	resetContractInfoInterface()
+	lastTxHash = nil
+
+	b := GetExecutor("batch").(*batch)
+	cmdLines, err := b.readBatchFile(path)
+	if err != nil {
+		tc.Failures = append(tc.Failures, err.Error())
+		tc.Duration = time.Since(start)
+		return tc
+	}
+
+	// a nested batch must not reset the error count of the scenario
+	b.level++
+	defer func() {
+		b.level--
+		batchErrorCount = 0
+	}()
+
+	for i, line := range cmdLines {
+		cmd, _ := context.ParseFirstWord(line)
+		if len(cmd) == 0 || context.Comment == cmd {
+			continue
+		}
+
+		errCount := batchErrorCount
+		Broker(line)
+
+		if letBatchKnowErr != nil {
+			tc.Failures = append(tc.Failures, fmt.Sprintf("%s:%d %s: %s", path, i+1, line, letBatchKnowErr.Error()))
+			letBatchKnowErr = nil
+		} else if batchErrorCount != errCount {
+			tc.Failures = append(tc.Failures, fmt.Sprintf("%s:%d %s: %d errors", path, i+1, line, batchErrorCount-errCount))
+		}
+	}
+
+	tc.Duration = time.Since(start)
+	return tc
+}
+
+type junitTestSuite struct {
+	XMLName  xml.Name        `xml:"testsuite"`
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Time     string          `xml:"time,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+func junitTime(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
+
+// WriteJUnit writes the results of the scenarios as a JUnit XML test suite.
+func WriteJUnit(w io.Writer, suite string, cases []*TestCase) error {
+	s := junitTestSuite{Name: suite, Tests: len(cases)}
+	var total time.Duration
+	for _, tc := range cases {
+		c := junitTestCase{
+			Name:      tc.Name,
+			ClassName: suite,
+			Time:      junitTime(tc.Duration),
+		}
+		if tc.Failed() {
+			s.Failures++
+			c.Failure = &junitFailure{
+				Message: fmt.Sprintf("%d commands failed", len(tc.Failures)),
+				Text:    strings.Join(tc.Failures, "\n"),
+			}
+		}
+		total += tc.Duration
+		s.Cases = append(s.Cases, c)
+	}
+	s.Time = junitTime(total)
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(s); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -31,7 +31,7 @@ func (c *undoCommit) Describe() string {
 
 func (c *undoCommit) Validate(args string) error {
 
-	if context.Get().BestBlockNo() == 0 {
+	if context.Get().BestBlockNo() == context.Get().BaseBlockNo() {
 		return fmt.Errorf("There are no txs to undo")
 	}
 	return nil
@@ -42,5 +42,7 @@ func (c *undoCommit) Run(args string) (string, uint64, []*types.Event, error) {
 	if err != nil {
 		return "", 0, nil, err
 	}
+	// the receipt of the undone tx is no longer checked
+	lastTxHash = nil
 	return "Undo, Succesfully", 0, nil, nil
 }
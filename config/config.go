@@ -76,20 +76,23 @@ func (ctx *ServerContext) GetDefaultRPCConfig() *RPCConfig {
 
 func (ctx *ServerContext) GetDefaultP2PConfig() *P2PConfig {
 	return &P2PConfig{
-		NetProtocolAddr: "",
-		NetProtocolPort: 7846,
-		NPBindAddr:      "",
-		NPBindPort:      -1,
-		NPEnableTLS:     false,
-		NPCert:          "",
-		NPKey:           "",
-		NPAddPeers:      nil,
-		NPDiscoverPeers: true,
-		NPMaxPeers:      100,
-		NPPeerPool:      100,
-		NPUsePolaris:    true,
-		NPExposeSelf:    true,
-		PeerRole:        "",
+		NetProtocolAddr:   "",
+		NetProtocolPort:   7846,
+		NPBindAddr:        "",
+		NPBindPort:        -1,
+		NPEnableTLS:       false,
+		NPCert:            "",
+		NPKey:             "",
+		NPAddPeers:        nil,
+		NPDiscoverPeers:   true,
+		NPMaxPeers:        100,
+		NPPeerPool:        100,
+		NPMaxInBandwidth:  0,
+		NPMaxOutBandwidth: 0,
+		NPRequestRates:    nil,
+		NPUsePolaris:      true,
+		NPExposeSelf:      true,
+		PeerRole:          "",
 	}
 }
 
@@ -132,6 +135,7 @@ func (ctx *ServerContext) GetDefaultMempoolConfig() *MempoolConfig {
 func (ctx *ServerContext) GetDefaultConsensusConfig() *ConsensusConfig {
 	return &ConsensusConfig{
 		BlockInterval: 1,
+		BpLeaseMissed: 3,
 	}
 }
 
@@ -139,12 +143,14 @@ func (ctx *ServerContext) GetDefaultMonitorConfig() *MonitorConfig {
 	return &MonitorConfig{
 		ServerProtocol: "",
 		ServerEndpoint: "",
+		MetricsAddr:    "",
 	}
 }
 
 func (ctx *ServerContext) GetDefaultAccountConfig() *AccountConfig {
 	return &AccountConfig{
 		UnlockTimeout: 60,
+		Keystore:      "leveldb",
 	}
 }
 
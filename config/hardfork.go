@@ -1,4 +1,4 @@
-//go:generate go run ./hardfork_gen/main.go hardfork.json hardfork_gen.go
+//go:generate go run ./hardfork_gen/main.go hardfork.json hardfork_gen.go ../types/feature_gen.go
 
 /**
  *  @file
@@ -9,7 +9,10 @@ package config
 
 import (
 	"fmt"
+	"math"
+	"reflect"
 	"strconv"
+	"strings"
 
 	"github.com/aergoio/aergo/types"
 )
@@ -34,8 +37,70 @@ func isFork(forkBlkNo, currBlkNo types.BlockNo) bool {
 	return forkBlkNo <= currBlkNo
 }
 
-func checkOlderNode(maxVer uint64, latest types.BlockNo, dbCfg HardforkDbConfig) error {
+// FeatureKeyPrefix prefixes the features in a HardforkDbConfig, which
+// otherwise holds the block numbers of the hardfork versions.
+const FeatureKeyPrefix = "feature:"
+
+type featureError struct {
+	feature string
+	cdb     uint64
+}
+
+func (e *featureError) Error() string {
+	return fmt.Sprintf(
+		"the feature %q scheduled at block %d in the chain is unknown to the node",
+		e.feature, e.cdb,
+	)
+}
+
+// IsFeatureActive reports whether the feature is active in the block h.
+func (c *HardforkConfig) IsFeatureActive(f types.Feature, h types.BlockNo) bool {
+	return f.ActiveAt(c.Version(h))
+}
+
+// ForkNo returns the block number of the hardfork version, or math.MaxUint64
+// if the version is unknown.
+func (c *HardforkConfig) ForkNo(version int32) types.BlockNo {
+	v := reflect.ValueOf(*c)
+	i := int(version) - 2
+	if i < 0 || i >= v.NumField() {
+		return math.MaxUint64
+	}
+	return v.Field(i).Uint()
+}
+
+// FeatureSchedule returns the block numbers from which the features known to
+// the node are active. The keys are prefixed with FeatureKeyPrefix.
+func (c *HardforkConfig) FeatureSchedule() HardforkDbConfig {
+	schedule := make(HardforkDbConfig)
+	for _, f := range types.Features() {
+		schedule[FeatureKeyPrefix+string(f)] = c.ForkNo(f.Version())
+	}
+	return schedule
+}
+
+// checkOlderNode checks the versions and the features recorded in the chain
+// database against the ones known to the node.
+func (c *HardforkConfig) checkOlderNode(maxVer uint64, latest types.BlockNo, dbCfg HardforkDbConfig) error {
 	for k, bno := range dbCfg {
+		if strings.HasPrefix(k, FeatureKeyPrefix) {
+			// Unlike a version, a feature unknown to the node is refused
+			// as soon as it's scheduled since the node can't validate the
+			// blocks from the activation.
+			f := types.Feature(strings.TrimPrefix(k, FeatureKeyPrefix))
+			if f.Version() == 0 {
+				if bno != math.MaxUint64 {
+					return &featureError{string(f), bno}
+				}
+				continue
+			}
+			// A known feature may have been moved to another version by
+			// the node, which changes its activation block.
+			if nodeNo := c.ForkNo(f.Version()); nodeNo != bno && (isFork(bno, latest) || isFork(nodeNo, latest)) {
+				return newForkError(k, latest, nodeNo, bno)
+			}
+			continue
+		}
 		ver, err := strconv.ParseUint(k[1:], 10, 64)
 		if err != nil {
 			return err
@@ -48,3 +113,13 @@ func checkOlderNode(maxVer uint64, latest types.BlockNo, dbCfg HardforkDbConfig)
 	}
 	return nil
 }
+
+// forkNo returns the block number of the hardfork version recorded in the
+// chain database. A version which is missing was unknown to the node that
+// wrote the record, so it's regarded as not scheduled yet.
+func (c HardforkDbConfig) forkNo(version string) types.BlockNo {
+	if bno, exist := c[version]; exist {
+		return bno
+	}
+	return math.MaxUint64
+}
@@ -6,23 +6,27 @@
 package main
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"go/format"
 	"io/ioutil"
 	"os"
+	"regexp"
+	"strings"
 	"text/template"
 
 	"github.com/aergoio/aergo/types"
 )
 
-var tpl = `// Code generated by go run main.go {{.Input}} {{.Output}}; DO NOT EDIT.
+var tpl = `// Code generated by go run main.go {{.Input}} {{.Output}} {{.FeatureOutput}}; DO NOT EDIT.
 
 package {{.Package}}
 
 import (
 	"fmt"
 	"reflect"
-	
+
 	"github.com/aergoio/aergo/types"
 )
 
@@ -67,11 +71,11 @@ func (c *HardforkConfig) CheckCompatibility(dbCfg HardforkDbConfig, h types.Bloc
 		return err
 	}
 {{- range .Hardforks}}
-	if (isFork(c.V{{.Version}}, h) || isFork(dbCfg["V{{.Version}}"], h)) && c.V{{.Version}} != dbCfg["V{{.Version}}"] {
-		return newForkError("V{{.Version}}", h, c.V{{.Version}}, dbCfg["V{{.Version}}"])
+	if (isFork(c.V{{.Version}}, h) || isFork(dbCfg.forkNo("V{{.Version}}"), h)) && c.V{{.Version}} != dbCfg.forkNo("V{{.Version}}") {
+		return newForkError("V{{.Version}}", h, c.V{{.Version}}, dbCfg.forkNo("V{{.Version}}"))
 	}
 {{- end}}
-	return checkOlderNode({{.MaxVersion}}, h, dbCfg)
+	return c.checkOlderNode({{.MaxVersion}}, h, dbCfg)
 }
 
 func (c *HardforkConfig) Version(h types.BlockNo) int32 {
@@ -98,24 +102,79 @@ func (c *HardforkConfig) validate() error {
 }
 `
 
+var featureTpl = `// Code generated by go run main.go {{.Input}} {{.Output}} {{.FeatureOutput}}; DO NOT EDIT.
+
+package types
+
+const (
+{{- range .Hardforks}}
+{{- range .Features}}
+	// {{featureConst .Name}} (V{{$.VersionOf .Name}}): {{.Description}}.
+	{{featureConst .Name}} Feature = "{{.Name}}"
+{{- end}}
+{{- end}}
+)
+
+var featureVersions = map[Feature]int32{
+{{- range .Hardforks}}{{$v := .Version}}
+{{- range .Features}}
+	{{featureConst .Name}}: {{$v}},
+{{- end}}
+{{- end}}
+}
+
+var features = []Feature{
+{{- range .Hardforks}}
+{{- range .Features}}
+	{{featureConst .Name}},
+{{- end}}
+{{- end}}
+}
+`
+
 const versionStartNo = uint64(2)
 
+var featureNameRegexp = regexp.MustCompile(`^[a-z][a-zA-Z0-9]*$`)
+
+type featureElem struct {
+	Name        string
+	Description string
+}
+
 type hardforkElem struct {
 	Version                      uint64
 	MainNetHeight, TestNetHeight types.BlockNo
+	Features                     []featureElem
 }
 
 type hardforkData struct {
-	Hardforks  []hardforkElem
-	MaxVersion uint64
-	Package    string
-	Input      string
-	Output     string
+	Hardforks     []hardforkElem
+	MaxVersion    uint64
+	Package       string
+	Input         string
+	Output        string
+	FeatureOutput string
+}
+
+// VersionOf returns the hardfork version activating the feature.
+func (d *hardforkData) VersionOf(name string) uint64 {
+	for _, hf := range d.Hardforks {
+		for _, f := range hf.Features {
+			if f.Name == name {
+				return hf.Version
+			}
+		}
+	}
+	return 0
+}
+
+func featureConst(name string) string {
+	return "Feature" + strings.ToUpper(name[:1]) + name[1:]
 }
 
 func main() {
-	if len(os.Args) != 3 {
-		panic("Usage: go run main.go <input-file> <output-file>")
+	if len(os.Args) != 4 {
+		panic("Usage: go run main.go <input-file> <output-file> <feature-output-file>")
 	}
 	b, err := ioutil.ReadFile(os.Args[1])
 	if err != nil {
@@ -133,21 +192,33 @@ func main() {
 	if err != nil {
 		panic(err)
 	}
-	tt := template.Must(template.New("hadfork").Parse(tpl))
-	f, err := os.OpenFile(os.Args[2], os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
+	bindVal.Input = os.Args[1]
+	bindVal.Output = os.Args[2]
+	bindVal.FeatureOutput = os.Args[3]
+	funcs := template.FuncMap{"featureConst": featureConst}
+	generate(template.Must(template.New("hadfork").Parse(tpl)), bindVal.Output, bindVal)
+	generate(template.Must(template.New("feature").Funcs(funcs).Parse(featureTpl)), bindVal.FeatureOutput, bindVal)
+}
+
+func generate(tt *template.Template, output string, bindVal *hardforkData) {
+	var buf bytes.Buffer
+	if err := tt.Execute(&buf, bindVal); err != nil {
+		panic(err)
+	}
+	src, err := format.Source(buf.Bytes())
 	if err != nil {
 		panic(err)
 	}
-	bindVal.Input = os.Args[1]
-	bindVal.Output = os.Args[2]
-	tt.Execute(f, bindVal)
-	f.Close()
+	if err := ioutil.WriteFile(output, src, 0644); err != nil {
+		panic(err)
+	}
 }
 
 func validate(v *hardforkData) error {
 	v.Package = os.Getenv("GOPACKAGE")
 	v.MaxVersion = uint64(len(v.Hardforks) + 1)
 	MainNetMax, TestNetMax := types.BlockNo(0), types.BlockNo(0)
+	names := make(map[string]struct{})
 	for i := versionStartNo; i <= v.MaxVersion; i++ {
 		hf := v.Hardforks[i-versionStartNo]
 		if i != hf.Version {
@@ -161,6 +232,15 @@ func validate(v *hardforkData) error {
 			return fmt.Errorf("version %d, testnet block number %d is too low", i, hf.TestNetHeight)
 		}
 		TestNetMax = hf.TestNetHeight
+		for _, f := range hf.Features {
+			if !featureNameRegexp.MatchString(f.Name) {
+				return fmt.Errorf("version %d, invalid feature name %q", i, f.Name)
+			}
+			if _, exist := names[f.Name]; exist {
+				return fmt.Errorf("version %d, duplicate feature %q", i, f.Name)
+			}
+			names[f.Name] = struct{}{}
+		}
 	}
 	return nil
 }
@@ -13,16 +13,17 @@ func TestValidate(t *testing.T) {
 			&hardforkData{
 				[]hardforkElem{
 					{
-						2, 100, 100,
+						2, 100, 100, nil,
 					},
 					{
-						2, 200, 200,
+						2, 200, 200, nil,
 					},
 				},
 				3,
 				"test",
 				"",
 				"",
+				"",
 			},
 			"version 3 expected, but got 2",
 		},
@@ -31,16 +32,17 @@ func TestValidate(t *testing.T) {
 			&hardforkData{
 				[]hardforkElem{
 					{
-						2, 200, 100,
+						2, 200, 100, nil,
 					},
 					{
-						3, 100, 200,
+						3, 100, 200, nil,
 					},
 				},
 				3,
 				"test",
 				"",
 				"",
+				"",
 			},
 			"version 3, mainnet block number 100 is too low",
 		},
@@ -49,16 +51,17 @@ func TestValidate(t *testing.T) {
 			&hardforkData{
 				[]hardforkElem{
 					{
-						2, 200, 200,
+						2, 200, 200, nil,
 					},
 					{
-						3, 200, 100,
+						3, 200, 100, nil,
 					},
 				},
 				3,
 				"test",
 				"",
 				"",
+				"",
 			},
 			"version 3, testnet block number 100 is too low",
 		},
@@ -67,19 +70,55 @@ func TestValidate(t *testing.T) {
 			&hardforkData{
 				[]hardforkElem{
 					{
-						2, 100, 100,
+						2, 100, 100, nil,
 					},
 					{
-						3, 100, 100,
+						3, 100, 100, nil,
 					},
 				},
 				3,
 				"test",
 				"",
 				"",
+				"",
 			},
 			"",
 		},
+		{
+			"invalid feature name",
+			&hardforkData{
+				[]hardforkElem{
+					{
+						2, 100, 100, []featureElem{{"ReceiptV2", ""}},
+					},
+				},
+				2,
+				"test",
+				"",
+				"",
+				"",
+			},
+			`version 2, invalid feature name "ReceiptV2"`,
+		},
+		{
+			"dup feature",
+			&hardforkData{
+				[]hardforkElem{
+					{
+						2, 100, 100, []featureElem{{"receiptV2", ""}},
+					},
+					{
+						3, 200, 200, []featureElem{{"receiptV2", ""}},
+					},
+				},
+				3,
+				"test",
+				"",
+				"",
+				"",
+			},
+			`version 3, duplicate feature "receiptV2"`,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
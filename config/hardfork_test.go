@@ -9,8 +9,10 @@ import (
 	"bytes"
 	"encoding/json"
 	"log"
+	"math"
 	"testing"
 
+	"github.com/aergoio/aergo/types"
 	"github.com/spf13/viper"
 )
 
@@ -72,7 +74,9 @@ v2 = "9223"
 func TestCompatibility(t *testing.T) {
 	cfg := readConfig(`
 [hardfork]
-v2 = "9223"`,
+v2 = "9223"
+v3 = "9300"
+v4 = "9400"`,
 	)
 	dbCfg, _ := readDbConfig(`
 {
@@ -87,7 +91,9 @@ v2 = "9223"`,
 	dbCfg, _ = readDbConfig(`
 {
 	"V2": 9223,
-	"V3": 10000
+	"V3": 9300,
+	"V4": 9400,
+	"V5": 10000
 }`,
 	)
 	err = cfg.CheckCompatibility(dbCfg, 10)
@@ -98,7 +104,9 @@ v2 = "9223"`,
 	dbCfg, _ = readDbConfig(`
 {
 	"V2": 9223,
-	"V3": 10000
+	"V3": 9300,
+	"V4": 9400,
+	"V5": 10000
 }`,
 	)
 	err = cfg.CheckCompatibility(dbCfg, 9500)
@@ -109,7 +117,9 @@ v2 = "9223"`,
 	dbCfg, _ = readDbConfig(`
 {
 	"V2": 9221,
-	"V3": 10000
+	"V3": 9300,
+	"V4": 9400,
+	"V5": 10000
 }`,
 	)
 	err = cfg.CheckCompatibility(dbCfg, 9500)
@@ -120,28 +130,34 @@ v2 = "9223"`,
 	dbCfg, _ = readDbConfig(`
 {
 	"V2": 9223,
-	"V3": 10000
+	"V3": 9300,
+	"V4": 9400,
+	"V5": 10000
 }`,
 	)
 	err = cfg.CheckCompatibility(dbCfg, 10000)
 	if err == nil {
-		t.Error(`the expected error: the fork "V3" is incompatible: latest block(10000), node(0), and chain(10000)`)
+		t.Error(`the expected error: the fork "V5" is incompatible: latest block(10000), node(0), and chain(10000)`)
 	}
 
 	dbCfg, _ = readDbConfig(`
 {
 	"V2": 9223,
-	"V3": 10000
+	"V3": 9300,
+	"V4": 9400,
+	"V5": 10000
 }`,
 	)
 	err = cfg.CheckCompatibility(dbCfg, 10001)
 	if err == nil {
-		t.Error(`the expected error: the fork "V3" is incompatible: latest block(10000), node(0), and chain(10000)`)
+		t.Error(`the expected error: the fork "V5" is incompatible: latest block(10000), node(0), and chain(10000)`)
 	}
 
 	dbCfg, _ = readDbConfig(`
 {
 	"V2": 9223,
+	"V3": 9300,
+	"V4": 9400,
 	"VV": 10000
 }`,
 	)
@@ -152,13 +168,29 @@ v2 = "9223"`,
 	if _, ok := err.(*forkError); ok {
 		t.Error(err)
 	}
+
+	// V3 is missing in the chain written by an older node
+	dbCfg, _ = readDbConfig(`
+{
+	"V2": 9223
+}`,
+	)
+	err = cfg.CheckCompatibility(dbCfg, 9299)
+	if err != nil {
+		t.Error(err)
+	}
+	err = cfg.CheckCompatibility(dbCfg, 9300)
+	if err == nil {
+		t.Error(`the expected error: the fork "V3" is incompatible: latest block(9300), node(9300), and chain(18446744073709551615)`)
+	}
 }
 
 func TestVersion(t *testing.T) {
 	cfg := readConfig(`
 [hardfork]
 v2 = "9223"
-v3 = "10000"`,
+v3 = "10000"
+v4 = "20000"`,
 	)
 	tests := []struct {
 		name string
@@ -185,13 +217,16 @@ v3 = "10000"`,
 			9322,
 			2,
 		},
-		/*
-			{
-				"greater v3",
-				19322,
-				3,
-			},
-		*/
+		{
+			"greater v3",
+			19322,
+			3,
+		},
+		{
+			"equal v4",
+			20000,
+			4,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -202,6 +237,110 @@ v3 = "10000"`,
 	}
 }
 
+func TestIsFeatureActive(t *testing.T) {
+	cfg := readConfig(`
+[hardfork]
+v2 = "9223"
+v3 = "10000"
+v4 = "20000"`,
+	)
+	tests := []struct {
+		name    string
+		feature types.Feature
+		h       uint64
+		want    bool
+	}{
+		{"before v2", types.FeatureReceiptV2, 9222, false},
+		{"at v2", types.FeatureReceiptV2, 9223, true},
+		{"v3 feature at v2", types.FeatureUnbonding, 9999, false},
+		{"v3 feature at v3", types.FeatureUnbonding, 10000, true},
+		{"v2 feature at v4", types.FeatureDaoVote, 20000, true},
+		{"unknown", types.Feature("unknown"), 20000, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cfg.IsFeatureActive(tt.feature, tt.h); got != tt.want {
+				t.Errorf("IsFeatureActive() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFeatureSchedule(t *testing.T) {
+	cfg := readConfig(`
+[hardfork]
+v2 = "9223"
+v3 = "10000"
+v4 = "18446744073709551615"`,
+	)
+	schedule := cfg.FeatureSchedule()
+	if len(schedule) != len(types.Features()) {
+		t.Errorf("len(FeatureSchedule()) = %d, want %d", len(schedule), len(types.Features()))
+	}
+	if bno := schedule[FeatureKeyPrefix+string(types.FeatureEvidence)]; bno != 10000 {
+		t.Errorf("evidence = %d, want %d", bno, 10000)
+	}
+	if bno := schedule[FeatureKeyPrefix+string(types.FeatureBaseFee)]; bno != math.MaxUint64 {
+		t.Errorf("baseFee = %d, want %d", bno, uint64(math.MaxUint64))
+	}
+	if err := cfg.CheckCompatibility(schedule, 10); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCompatibilityFeature(t *testing.T) {
+	cfg := readConfig(`
+[hardfork]
+v2 = "9223"
+v3 = "9300"
+v4 = "9400"`,
+	)
+	// a feature unknown to the node but not scheduled yet
+	dbCfg, _ := readDbConfig(`
+{
+	"V2": 9223,
+	"V3": 9300,
+	"V4": 9400,
+	"feature:receiptV2": 9223,
+	"feature:luaCryptoExt": 18446744073709551615
+}`,
+	)
+	if err := cfg.CheckCompatibility(dbCfg, 10); err != nil {
+		t.Error(err)
+	}
+
+	// a feature unknown to the node is refused even before the activation
+	dbCfg, _ = readDbConfig(`
+{
+	"V2": 9223,
+	"V3": 9300,
+	"V4": 9400,
+	"feature:luaCryptoExt": 10000
+}`,
+	)
+	err := cfg.CheckCompatibility(dbCfg, 10)
+	if _, ok := err.(*featureError); !ok {
+		t.Errorf(`the expected error: the feature "luaCryptoExt" scheduled at block 10000 in the chain is unknown to the node, got %v`, err)
+	}
+
+	// a known feature activated at another block in the chain
+	dbCfg, _ = readDbConfig(`
+{
+	"V2": 9223,
+	"V3": 9300,
+	"V4": 9400,
+	"feature:baseFee": 9300
+}`,
+	)
+	if err := cfg.CheckCompatibility(dbCfg, 10); err != nil {
+		t.Error(err)
+	}
+	err = cfg.CheckCompatibility(dbCfg, 9350)
+	if _, ok := err.(*forkError); !ok {
+		t.Errorf(`the expected error: the fork "feature:baseFee" is incompatible: latest block(9350), node(9400), and chain(9300), got %v`, err)
+	}
+}
+
 func readConfig(c string) *HardforkConfig {
 	v := viper.New()
 	v.SetConfigType("toml")
@@ -51,6 +51,11 @@ type RPCConfig struct {
 	NSKey       string `mapstructure:"nskey" description:"Private Key file for RPC or REST API"`
 	NSCACert    string `mapstructure:"nscacert" description:"CA Certificate file for RPC or REST API"`
 	NSAllowCORS bool   `mapstructure:"nsallowcors" description:"Allow CORS to RPC or REST API"`
+	// JSON-RPC 2.0 gateway over HTTP and WebSocket
+	NSEnableJSONRPC  bool     `mapstructure:"nsjsonrpc" description:"Enable JSON-RPC 2.0 gateway at /jsonrpc of the RPC service port (only without TLS)"`
+	NSJSONRPCOrigins []string `mapstructure:"nsjsonrpcorigins" description:"Origins allowed to call the JSON-RPC gateway from a browser, * for any. Only the same origin is allowed if empty"`
+	// Bearer token (JWT or API key) authentication
+	NSAuthPolicy string `mapstructure:"nsauthpolicy" description:"Policy file of the bearer token authentication for RPC. Disabled if empty"`
 }
 
 // P2PConfig defines configurations for p2p service
@@ -69,6 +74,10 @@ type P2PConfig struct {
 	NPMaxPeers      int      `mapstructure:"npmaxpeers" description:"Maximum number of remote peers to keep"`
 	NPPeerPool      int      `mapstructure:"nppeerpool" description:"Max peer pool size"`
 
+	NPMaxInBandwidth  int64    `mapstructure:"npmaxinbandwidth" description:"Maximum inbound bandwidth of all peers in bytes per second. 0 means unlimited"`
+	NPMaxOutBandwidth int64    `mapstructure:"npmaxoutbandwidth" description:"Maximum outbound bandwidth of all peers in bytes per second. 0 means unlimited"`
+	NPRequestRates    []string `mapstructure:"npreqrates" description:"Maximum number of requests per second from each remote peer, in form of <subprotocol name>=<rate> (e.g. GetBlocksRequest=10)"`
+
 	NPExposeSelf   bool     `mapstructure:"npexposeself" description:"Whether to request expose self to polaris and other connected node"`
 	NPUsePolaris   bool     `mapstructure:"npusepolaris" description:"Whether to connect and get node list from polaris"`
 	NPAddPolarises []string `mapstructure:"npaddpolarises" description:"Add addresses of polarises if default polaris is not sufficient"`
@@ -118,6 +127,9 @@ type MempoolConfig struct {
 type ConsensusConfig struct {
 	EnableBp      bool        `mapstructure:"enablebp" description:"enable block production"`
 	BlockInterval int64       `mapstructure:"blockinterval" description:"block production interval (sec)"`
+	RemoteSigner  string      `mapstructure:"remotesigner" description:"address of remote block signer (unix:///path/to/socket or host:port). blocks are signed with p2p key if empty"`
+	BpLease       string      `mapstructure:"bplease" description:"path to the lease file shared by active and standby instances of the same BP (dpos only). hot-standby is disabled if empty"`
+	BpLeaseMissed uint        `mapstructure:"bpleasemissed" description:"number of BP slots missed by the active instance before the standby one takes over"`
 	Raft          *RaftConfig `mapstructure:"raft"`
 }
 
@@ -145,11 +157,14 @@ type RaftBPConfig struct {
 type MonitorConfig struct {
 	ServerProtocol string `mapstructure:"protocol" description:"Protocol is one of next: http, https or kafka"`
 	ServerEndpoint string `mapstructure:"endpoint" description:"Endpoint to send"`
+	MetricsAddr    string `mapstructure:"metricsaddr" description:"Address to serve prometheus metrics at /metrics. Disabled if empty"`
 }
 
 // Account defines configurations for account service
 type AccountConfig struct {
-	UnlockTimeout uint `mapstructure:"unlocktimeout" description:"lock automatically after timeout (sec)"`
+	UnlockTimeout uint   `mapstructure:"unlocktimeout" description:"lock automatically after timeout (sec)"`
+	Keystore      string `mapstructure:"keystore" description:"backend of the key store: leveldb or file"`
+	KeystorePath  string `mapstructure:"keystorepath" description:"directory of the key files of the file key store (default: <datadir>/keystore)"`
 }
 
 type SQLConfig struct {
@@ -188,6 +203,11 @@ nscert = "{{.RPC.NSCert}}"
 nskey = "{{.RPC.NSKey}}"
 nscacert = "{{.RPC.NSCACert}}"
 nsallowcors = {{.RPC.NSAllowCORS}}
+nsjsonrpc = {{.RPC.NSEnableJSONRPC}}
+nsjsonrpcorigins = [{{range .RPC.NSJSONRPCOrigins}}
+"{{.}}", {{end}}
+]
+nsauthpolicy = "{{.RPC.NSAuthPolicy}}"
 
 [p2p]
 # Set address and port to which the inbound peers connect, and don't set loopback address or private network unless used in local network 
@@ -206,6 +226,13 @@ npaddpeers = [{{range .P2P.NPAddPeers}}
 npdiscoverpeers = true
 npmaxpeers = "{{.P2P.NPMaxPeers}}"
 nppeerpool = "{{.P2P.NPPeerPool}}"
+# Set limits of bandwidth in bytes per second. 0 means unlimited
+npmaxinbandwidth = {{.P2P.NPMaxInBandwidth}}
+npmaxoutbandwidth = {{.P2P.NPMaxOutBandwidth}}
+# Set limits of incoming requests per second from each peer, in form of "<subprotocol name>=<rate>"
+npreqrates = [{{range .P2P.NPRequestRates}}
+"{{.}}", {{end}}
+]
 npexposeself = true
 npusepolaris = {{.P2P.NPUsePolaris}}
 npaddpolarises = [{{range .P2P.NPAddPolarises}}
@@ -236,13 +263,19 @@ dumpfilepath = "{{.Mempool.DumpFilePath}}"
 [consensus]
 enablebp = {{.Consensus.EnableBp}}
 blockinterval = {{.Consensus.BlockInterval}}
+# remotesigner = "unix:///path/to/aergosigner.sock"
+# bplease = "/path/to/shared/bp.lease"
+bpleasemissed = {{.Consensus.BpLeaseMissed}}
 
 [monitor]
 protocol = "{{.Monitor.ServerProtocol}}"
 endpoint = "{{.Monitor.ServerEndpoint}}"
+metricsaddr = "{{.Monitor.MetricsAddr}}"
 
 [account]
 unlocktimeout = "{{.Account.UnlockTimeout}}"
+keystore = "{{.Account.Keystore}}"
+keystorepath = "{{.Account.KeystorePath}}"
 
 [auth]
 enablelocalconf = "{{.Auth.EnableLocalConf}}"
@@ -1,6 +1,7 @@
 package chain
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"time"
@@ -9,6 +10,7 @@ import (
 	"github.com/aergoio/aergo/p2p/p2putil"
 
 	"github.com/aergoio/aergo/chain"
+	"github.com/aergoio/aergo/contract"
 	"github.com/aergoio/aergo/message"
 	"github.com/aergoio/aergo/pkg/component"
 	"github.com/aergoio/aergo/state"
@@ -72,6 +74,14 @@ func GenerateBlock(
 	txOp TxOp,
 	skipEmpty bool,
 ) (*types.Block, error) {
+	if bi.IsFeatureActive(types.FeatureBaseFee) {
+		// the first block from the version starts from the gas price decided by
+		// the governance
+		if bi.BaseFee == nil {
+			bi.BaseFee = bState.GasPrice
+		}
+		bState.SetGasPrice(bi.BaseFee)
+	}
 	transactions, err := GatherTXs(hs, bState, bi, txOp, MaxBlockBodySize())
 	if err != nil {
 		return nil, err
@@ -98,6 +108,50 @@ func GenerateBlock(
 	return block, nil
 }
 
+// ExecuteBlock executes the transactions of block, which is produced by another
+// node, on bState and checks that the resulting state and receipts match the
+// header of block. Unlike GatherTXs, it fails if any transaction fails. bState
+// must start from the state of the previous block.
+func ExecuteBlock(bState *state.BlockState, bi *types.BlockHeaderInfo, block *types.Block, txOp TxOp) error {
+	if err := LockChain(); err != nil {
+		return ErrBestBlock
+	}
+	defer UnlockChain()
+
+	if err := chain.ActivateSystemParams(bState, bi); err != nil {
+		return err
+	}
+	defer contract.CloseDatabase()
+
+	op := NewCompTxOp(txOp)
+	for _, tx := range block.GetBody().GetTxs() {
+		if err := op.Apply(bState, types.NewTransaction(tx)); err != nil {
+			return err
+		}
+	}
+
+	if err := chain.SendBlockReward(bState, block.GetHeader().GetCoinbaseAccount(), bi); err != nil {
+		return err
+	}
+
+	if err := contract.SaveRecoveryPoint(bState); err != nil {
+		return err
+	}
+
+	if err := bState.Update(); err != nil {
+		return err
+	}
+
+	if !bytes.Equal(block.GetHeader().GetBlocksRootHash(), bState.GetRoot()) {
+		return chain.ErrorBlockVerifyStateRoot
+	}
+	if !bytes.Equal(block.GetHeader().GetReceiptsRootHash(), bState.Receipts().MerkleRoot()) {
+		return chain.ErrorBlockVerifyReceiptRoot
+	}
+
+	return nil
+}
+
 // ConnectBlock send an AddBlock request to the chain service.
 func ConnectBlock(hs component.ICompSyncRequester, block *types.Block, blockState *state.BlockState, timeout time.Duration) error {
 	// blockState does not include a valid BlockHash since it is constructed
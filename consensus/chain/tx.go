@@ -115,6 +115,10 @@ func GatherTXs(hs component.ICompSyncRequester, bState *state.BlockState, bi *ty
 	}
 	defer UnlockChain()
 
+	if err := chain.ActivateSystemParams(bState, bi); err != nil {
+		return nil, err
+	}
+
 	txIn := FetchTXs(hs, maxBlockBodySize)
 	nCand = len(txIn)
 
@@ -174,7 +178,7 @@ func GatherTXs(hs component.ICompSyncRequester, bState *state.BlockState, bi *ty
 
 	// Warning: This line must be run even with 0 gathered TXs, since the
 	// function below includes voting reward as well as BP reward.
-	if err := chain.SendBlockReward(bState, chain.CoinbaseAccount); err != nil {
+	if err := chain.SendBlockReward(bState, chain.CoinbaseAccount, bi); err != nil {
 		return nil, err
 	}
 
@@ -79,8 +79,13 @@ type ConsensusAccessor interface {
 	ClusterInfo([]byte) *types.GetClusterInfoResponse
 	ConfChange(req *types.MembershipChange) (*Member, error)
 	ConfChangeInfo(requestID uint64) (*types.ConfChangeProgress, error)
+	// BPStats returns the block production statistics of the election period including blockNo. It is only valid if
+	// chain is dpos consensus
+	BPStats(blockNo types.BlockNo) (*types.BPStatList, error)
 	// RaftAccessor returns AergoRaftAccessor. It is only valid if chain is raft consensus
 	RaftAccessor() AergoRaftAccessor
+	// BFTAccessor returns BFTAccessor. It is only valid if chain is bft consensus
+	BFTAccessor() BFTAccessor
 }
 
 // ChainDB is a reader interface for the ChainDB.
@@ -107,16 +112,36 @@ type AergoRaftAccessor interface {
 	GetMemberByPeerID(peerID types.PeerID) *Member
 }
 
+// BFTAccessor is interface to deliver the vote messages received from remote validators to bft consensus.
+type BFTAccessor interface {
+	Process(peerID types.PeerID, m *types.BftMessage) error
+}
+
+// DummyBFTAccessor returns error if bft message comes.
+type DummyBFTAccessor struct {
+}
+
+func (DummyBFTAccessor) Process(peerID types.PeerID, m *types.BftMessage) error {
+	return IllegalArgumentError
+}
+
+// ConfChangeApplier is implemented by the consensus which changes its membership when the block containing the
+// membership change transaction is executed.
+type ConfChangeApplier interface {
+	ApplyConfChange(block *types.Block, ccPropose *ConfChangePropose) error
+}
+
 type ConsensusType int
 
 const (
 	ConsensusDPOS ConsensusType = iota
 	ConsensusRAFT
 	ConsensusSBP
+	ConsensusBFT
 )
 
-var ConsensusName = []string{"dpos", "raft", "sbp"}
-var ConsensusTypes = map[string]ConsensusType{"dpos": ConsensusDPOS, "raft": ConsensusRAFT, "sbp": ConsensusSBP}
+var ConsensusName = []string{"dpos", "raft", "sbp", "bft"}
+var ConsensusTypes = map[string]ConsensusType{"dpos": ConsensusDPOS, "raft": ConsensusRAFT, "sbp": ConsensusSBP, "bft": ConsensusBFT}
 
 var CurConsensusType ConsensusType
 
@@ -139,6 +164,10 @@ func UseDpos() bool {
 	return CurConsensusType == ConsensusDPOS
 }
 
+func UseBFT() bool {
+	return CurConsensusType == ConsensusBFT
+}
+
 // ChainConsensus includes chainstatus and validation API.
 type ChainConsensus interface {
 	ChainConsensusCluster
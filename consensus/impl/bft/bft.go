@@ -0,0 +1,1012 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package bft
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/aergoio/aergo-lib/log"
+	"github.com/aergoio/aergo/account/key"
+	bc "github.com/aergoio/aergo/chain"
+	"github.com/aergoio/aergo/config"
+	"github.com/aergoio/aergo/consensus"
+	"github.com/aergoio/aergo/consensus/chain"
+	"github.com/aergoio/aergo/contract"
+	"github.com/aergoio/aergo/contract/name"
+	"github.com/aergoio/aergo/contract/system"
+	"github.com/aergoio/aergo/internal/common"
+	"github.com/aergoio/aergo/internal/enc"
+	"github.com/aergoio/aergo/message"
+	"github.com/aergoio/aergo/p2p/p2pkey"
+	"github.com/aergoio/aergo/pkg/component"
+	"github.com/aergoio/aergo/state"
+	"github.com/aergoio/aergo/types"
+	"github.com/aergoio/etcd/raft/raftpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/libp2p/go-libp2p-core/crypto"
+)
+
+const (
+	bftTick        = 100 * time.Millisecond
+	msgQueueMax    = 1000
+	connectTimeout = 10 * time.Second
+
+	// maxCommitsPerRequest is the maximum number of the commit certificates
+	// sent for a request of a syncing validator.
+	maxCommitsPerRequest = 1000
+)
+
+var (
+	logger *log.Logger
+
+	validatorsKey   = []byte("bft.validators")
+	commitKeyPrefix = "bft.commit."
+
+	ErrInvalidConsensusName         = errors.New("invalid consensus name")
+	ErrEmptyValidators              = errors.New("no validators in genesis")
+	ErrRemoteSignerNotSupported     = errors.New("remote signer is not supported in bft consensus")
+	ErrInvalidPrevBlock             = errors.New("block is not the child of the best block")
+	ErrInvalidProposer              = errors.New("block is not produced by the proposer of its round")
+	ErrInvalidBftHeader             = errors.New("invalid bft header of block")
+	ErrBlockNotCertified            = errors.New("no commit certificate of block")
+	ErrInvalidBaseFee               = errors.New("invalid base fee of block")
+	ErrMsgQueueFull                 = errors.New("bft message queue is full")
+	ErrNotSupportedMembershipChange = errors.New("membership change type not supported in bft consensus")
+	ErrDuplicatedValidator          = errors.New("validator of the same name, peer id or member id already exists")
+	ErrNoSuchValidator              = errors.New("no validator of the member id")
+	ErrLastValidator                = errors.New("can't remove the last validator")
+)
+
+func init() {
+	logger = log.NewLogger("bft")
+}
+
+type txExec struct {
+	execTx bc.TxExecFn
+}
+
+func newTxExec(ccc consensus.ChainConsensusCluster, cdb consensus.ChainDB, bi *types.BlockHeaderInfo) chain.TxOp {
+	// Block hash not determined yet
+	return &txExec{
+		execTx: bc.NewTxExecutor(ccc, contract.ChainAccessor(cdb), bi, contract.BlockFactory),
+	}
+}
+
+func (te *txExec) Apply(bState *state.BlockState, tx types.Transaction) error {
+	return te.execTx(bState, tx)
+}
+
+type bftMsg struct {
+	peerID types.PeerID
+	msg    *types.BftMessage
+}
+
+type decision struct {
+	block  *types.Block
+	commit *types.BftCommit
+}
+
+type bftStatus struct {
+	Height   types.BlockNo
+	Round    int32
+	Step     string
+	Proposer string
+}
+
+// BFT implements a tendermint style bft consensus among the validators, which
+// are the members governed by the changeCluster transactions of the enterprise
+// contract. A block is decided through the propose, prevote and precommit
+// steps and the commit certificate of a block, which includes the precommits
+// of at least 2f+1 validators, is stored with the block and included in the
+// header of the next block.
+//
+// The validators execute a proposed block and check its header before voting.
+// A block is connected only if its commit certificate is known, so a syncing
+// validator requests the certificates of the missing blocks before syncing
+// them. The messages are not relayed, so the validators must be connected with
+// each other, e.g. as designated peers.
+type BFT struct {
+	*component.ComponentHub
+	consensus.ChainDB
+	sync.RWMutex
+
+	id          types.PeerID
+	privKey     crypto.PrivKey
+	bv          types.BlockVersionner
+	sdb         *state.ChainStateDB
+	chainID     []byte
+	chainIDHash []byte
+	timeouts    timeoutConfig
+
+	jobQueue chan interface{}
+	msgC     chan *bftMsg
+	quit     chan interface{}
+
+	// followings are accessed only by the consensus loop
+	st        *bftState
+	genStates map[string]*state.BlockState
+	decided   *decision
+	syncing   int32
+
+	// followings are guarded by the mutex since they are also accessed by the
+	// chain service
+	history        validatorHistory
+	historyChanged bool
+	certs          map[string]*types.BftCommit
+	unsaved        []*types.BftCommit
+	lastNo         types.BlockNo
+	status         bftStatus
+}
+
+// GetName returns the name of the consensus.
+func GetName() string {
+	return consensus.ConsensusName[consensus.ConsensusBFT]
+}
+
+// GetConstructor build and returns consensus.Constructor from New function.
+func GetConstructor(cfg *config.Config, hub *component.ComponentHub, cdb consensus.ChainDB,
+	sdb *state.ChainStateDB) consensus.Constructor {
+	return func() (consensus.Consensus, error) {
+		return New(cfg, hub, cdb, sdb)
+	}
+}
+
+// New returns a new BFT.
+func New(cfg *config.Config, hub *component.ComponentHub, cdb consensus.ChainDB,
+	sdb *state.ChainStateDB) (*BFT, error) {
+	if len(cfg.Consensus.RemoteSigner) > 0 {
+		return nil, ErrRemoteSignerNotSupported
+	}
+
+	genesis := cdb.GetGenesisInfo()
+	chainID, err := genesis.ID.Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	bft := &BFT{
+		ComponentHub: hub,
+		ChainDB:      cdb,
+		id:           p2pkey.NodeID(),
+		privKey:      p2pkey.NodePrivKey(),
+		bv:           cfg.Hardfork,
+		sdb:          sdb,
+		chainID:      chainID,
+		chainIDHash:  common.Hasher(chainID),
+		timeouts:     newTimeoutConfig(consensus.BlockInterval),
+		jobQueue:     make(chan interface{}, 1),
+		msgC:         make(chan *bftMsg, msgQueueMax),
+		quit:         make(chan interface{}),
+		certs:        make(map[string]*types.BftCommit),
+	}
+
+	if err := bft.loadValidators(genesis); err != nil {
+		return nil, err
+	}
+
+	if best, err := cdb.GetBestBlock(); err == nil && best != nil {
+		bft.lastNo = best.BlockNo()
+	}
+
+	if cfg.Consensus.EnableBp {
+		bft.st = newState(bft.id, bft.privKey, bft.chainIDHash, bft, bft.timeouts)
+	}
+
+	return bft, nil
+}
+
+func (bft *BFT) loadValidators(genesis *types.Genesis) error {
+	if data := bft.ChainDB.Get(validatorsKey); len(data) != 0 {
+		history, err := decodeValidatorHistory(data)
+		if err != nil {
+			return err
+		}
+		bft.history = history
+		return nil
+	}
+
+	mbrs, err := parseValidators(genesis.EnterpriseBPs)
+	if err != nil {
+		return err
+	}
+	for i, m := range mbrs {
+		mbrs[i] = &consensus.NewMember(m.Name, m.Address, types.PeerID(m.PeerID), bft.chainID, genesis.Timestamp).MemberAttr
+	}
+
+	bft.history = bft.history.add(1, mbrs)
+	bft.historyChanged = true
+
+	return nil
+}
+
+func parseValidators(bps []types.EnterpriseBP) ([]*types.MemberAttr, error) {
+	if len(bps) == 0 {
+		return nil, ErrEmptyValidators
+	}
+
+	mbrs := make([]*types.MemberAttr, len(bps))
+	for i, bp := range bps {
+		if _, err := types.ParseMultiaddr(bp.Address); err != nil {
+			return nil, err
+		}
+
+		peerID, err := types.IDB58Decode(bp.PeerID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid peerID of validator[%d]:%s", i, bp.PeerID)
+		}
+
+		mbrs[i] = &types.MemberAttr{Name: bp.Name, Address: bp.Address, PeerID: []byte(peerID)}
+	}
+
+	return mbrs, nil
+}
+
+func (bft *BFT) validatorsAt(blockNo types.BlockNo) *validatorSet {
+	bft.RLock()
+	defer bft.RUnlock()
+
+	return bft.history.at(blockNo)
+}
+
+func commitKey(hash []byte) []byte {
+	return []byte(commitKeyPrefix + enc.ToString(hash))
+}
+
+// addCert keeps c until the block of c is connected.
+func (bft *BFT) addCert(c *types.BftCommit) {
+	bft.Lock()
+	defer bft.Unlock()
+
+	if c.BlockNo > bft.lastNo {
+		bft.certs[string(c.BlockHash)] = c
+	}
+}
+
+// GetCommit returns the commit certificate of the block hash.
+func (bft *BFT) GetCommit(hash []byte) *types.BftCommit {
+	bft.RLock()
+	defer bft.RUnlock()
+
+	if c, ok := bft.certs[string(hash)]; ok {
+		return c
+	}
+	for _, c := range bft.unsaved {
+		if bytes.Equal(c.BlockHash, hash) {
+			return c
+		}
+	}
+
+	data := bft.ChainDB.Get(commitKey(hash))
+	if len(data) == 0 {
+		return nil
+	}
+
+	c := &types.BftCommit{}
+	if err := proto.Unmarshal(data, c); err != nil {
+		logger.Error().Err(err).Str("hash", enc.ToString(hash)).Msg("failed to decode commit certificate")
+		return nil
+	}
+	return c
+}
+
+func decodeHeader(block *types.Block) (*types.BftHeader, error) {
+	hdr := &types.BftHeader{}
+	if err := proto.Unmarshal(block.GetHeader().GetConsensus(), hdr); err != nil {
+		return nil, ErrInvalidBftHeader
+	}
+	return hdr, nil
+}
+
+// verifyHeader checks that block is produced by the proposer of its round on
+// top of parent, and that the commit certificate of parent is included.
+func (bft *BFT) verifyHeader(block *types.Block, parent *types.Block) error {
+	if parent == nil || block.BlockNo() != parent.BlockNo()+1 ||
+		!bytes.Equal(block.GetHeader().GetPrevBlockHash(), parent.BlockHash()) {
+		return ErrInvalidPrevBlock
+	}
+
+	hdr, err := decodeHeader(block)
+	if err != nil {
+		return err
+	}
+
+	bpID, err := block.BPID()
+	if err != nil {
+		return err
+	}
+	if !types.IsSamePeerID(bpID, bft.validatorsAt(block.BlockNo()).proposer(block.BlockNo(), hdr.Round)) {
+		return ErrInvalidProposer
+	}
+
+	if valid, err := block.VerifySign(); err != nil {
+		return err
+	} else if !valid {
+		return types.ErrInvalidBlockSign
+	}
+
+	// the genesis block has no commit certificate
+	if parent.BlockNo() == 0 {
+		return nil
+	}
+
+	c := hdr.LastCommit
+	if c == nil || c.BlockNo != parent.BlockNo() || !bytes.Equal(c.BlockHash, parent.BlockHash()) {
+		return ErrInvalidCommit
+	}
+	return verifyCommit(bft.chainIDHash, c, bft.validatorsAt(parent.BlockNo()))
+}
+
+// Ticker returns a time.Ticker for the main consensus loop.
+func (bft *BFT) Ticker() *time.Ticker {
+	return time.NewTicker(bftTick)
+}
+
+// QueueJob send a timer event to jq.
+func (bft *BFT) QueueJob(now time.Time, jq chan<- interface{}) {
+	select {
+	case jq <- now:
+	default:
+	}
+}
+
+// BlockFactory returns bft itself.
+func (bft *BFT) BlockFactory() consensus.BlockFactory {
+	return bft
+}
+
+// QuitChan returns the channel from which consensus-related goroutines check
+// when shutdown is initiated.
+func (bft *BFT) QuitChan() chan interface{} {
+	return bft.quit
+}
+
+// JobQueue returns the queue for the timer events.
+func (bft *BFT) JobQueue() chan<- interface{} {
+	return bft.jobQueue
+}
+
+// Start runs the consensus loop.
+func (bft *BFT) Start() {
+	defer logger.Info().Msg("shutdown initiated. stop the service")
+
+	for {
+		select {
+		case e := <-bft.jobQueue:
+			if now, ok := e.(time.Time); ok {
+				bft.onTick(now)
+			}
+		case m := <-bft.msgC:
+			bft.onMessage(m.peerID, m.msg)
+		case <-bft.quit:
+			return
+		}
+	}
+}
+
+// Process queues the message received from peerID to the consensus loop.
+func (bft *BFT) Process(peerID types.PeerID, m *types.BftMessage) error {
+	select {
+	case bft.msgC <- &bftMsg{peerID: peerID, msg: m}:
+		return nil
+	default:
+		return ErrMsgQueueFull
+	}
+}
+
+func (bft *BFT) onTick(now time.Time) {
+	if bft.decided != nil {
+		bft.connectDecided()
+		return
+	}
+
+	if bft.st == nil {
+		return
+	}
+
+	best, err := bft.GetBestBlock()
+	if err != nil {
+		return
+	}
+
+	next := best.BlockNo() + 1
+	if bft.st.vs == nil || bft.st.height < next {
+		// the next height starts after the block interval
+		if now.Before(time.Unix(0, best.GetHeader().GetTimestamp()).Add(consensus.BlockInterval)) {
+			return
+		}
+
+		vs := bft.validatorsAt(next)
+		if !vs.has(bft.id) {
+			return
+		}
+
+		bft.genStates = make(map[string]*state.BlockState)
+		bft.st.startHeight(next, vs, now)
+	} else {
+		bft.st.onTick(now)
+	}
+
+	bft.updateStatus()
+}
+
+func (bft *BFT) onMessage(peerID types.PeerID, m *types.BftMessage) {
+	if m.Commit != nil {
+		bft.onCommit(peerID, m.Commit)
+		return
+	}
+	if m.CommitFrom != 0 {
+		bft.onCommitRequest(peerID, m.CommitFrom)
+		return
+	}
+
+	if bft.st == nil {
+		return
+	}
+
+	if err := bft.st.onMessage(m, time.Now()); err != nil {
+		logger.Debug().Err(err).Str("peer", types.IDB58Encode(peerID)).Msg("failed to process bft message")
+	}
+
+	bft.updateStatus()
+}
+
+// onCommit handles the commit certificate decided by the other validators. If
+// the block isn't proposed to this node, the block is synchronized from peerID.
+func (bft *BFT) onCommit(peerID types.PeerID, c *types.BftCommit) {
+	if err := verifyCommit(bft.chainIDHash, c, bft.validatorsAt(c.BlockNo)); err != nil {
+		logger.Debug().Err(err).Str("peer", types.IDB58Encode(peerID)).Uint64("no", c.BlockNo).Msg("invalid commit certificate")
+		return
+	}
+
+	bft.addCert(c)
+
+	if bft.st != nil && bft.st.onCommit(c) {
+		return
+	}
+
+	if best, err := bft.GetBestBlock(); err == nil && c.BlockNo > best.BlockNo() && bft.decided == nil {
+		bft.requestSync(peerID, c)
+	}
+}
+
+func (bft *BFT) requestSync(peerID types.PeerID, c *types.BftCommit) {
+	if !atomic.CompareAndSwapInt32(&bft.syncing, 0, 1) {
+		return
+	}
+
+	// The blocks between the best block and the decided one are connected
+	// only with their commit certificates.
+	if best, err := bft.GetBestBlock(); err == nil && c.BlockNo > best.BlockNo()+1 {
+		bft.Tell(message.P2PSvc, &message.SendBFT{
+			ToWhom: []types.PeerID{peerID},
+			Body:   &types.BftMessage{CommitFrom: best.BlockNo() + 1},
+		})
+	}
+
+	go func() {
+		defer atomic.StoreInt32(&bft.syncing, 0)
+
+		if err := chain.SyncChain(bft.ComponentHub, c.BlockHash, c.BlockNo, peerID); err != nil {
+			logger.Error().Err(err).Uint64("no", c.BlockNo).Msg("failed to sync decided block")
+		}
+	}()
+}
+
+// onCommitRequest sends the commit certificates of the blocks from the block
+// number to peerID.
+func (bft *BFT) onCommitRequest(peerID types.PeerID, from types.BlockNo) {
+	best, err := bft.GetBestBlock()
+	if err != nil {
+		return
+	}
+
+	for no := from; no <= best.BlockNo() && no < from+maxCommitsPerRequest; no++ {
+		hash, err := bft.GetHashByNo(no)
+		if err != nil {
+			return
+		}
+		c := bft.GetCommit(hash)
+		if c == nil {
+			return
+		}
+		bft.Tell(message.P2PSvc, &message.SendBFT{ToWhom: []types.PeerID{peerID}, Body: &types.BftMessage{Commit: c}})
+	}
+}
+
+func (bft *BFT) updateStatus() {
+	bft.Lock()
+	defer bft.Unlock()
+
+	if bft.st == nil || bft.st.vs == nil {
+		return
+	}
+
+	bft.status = bftStatus{
+		Height:   bft.st.height,
+		Round:    bft.st.round,
+		Step:     bft.st.step.String(),
+		Proposer: types.IDB58Encode(bft.st.vs.proposer(bft.st.height, bft.st.round)),
+	}
+}
+
+// broadcast implements executor.
+func (bft *BFT) broadcast(m *types.BftMessage) {
+	bft.Tell(message.P2PSvc, &message.SendBFT{Body: m})
+}
+
+// propose implements executor.
+func (bft *BFT) propose(height types.BlockNo, round int32) (*types.Block, error) {
+	best, err := bft.GetBestBlock()
+	if err != nil {
+		return nil, err
+	}
+	if best.BlockNo()+1 != height {
+		return nil, ErrInvalidPrevBlock
+	}
+
+	hdr := &types.BftHeader{Round: round}
+	if best.BlockNo() > 0 {
+		if hdr.LastCommit = bft.GetCommit(best.BlockHash()); hdr.LastCommit == nil {
+			return nil, ErrBlockNotCertified
+		}
+	}
+	consensusHeader, err := proto.Marshal(hdr)
+	if err != nil {
+		return nil, err
+	}
+
+	bi := types.NewBlockHeaderInfoFromPrevBlock(best, time.Now().UnixNano(), bft.bv)
+	blockState := bft.sdb.NewBlockState(
+		best.GetHeader().GetBlocksRootHash(),
+		state.SetPrevBlockHash(best.BlockHash()),
+		state.SetGasPrice(system.GetGasPrice()),
+	)
+	blockState.SetConsensus(consensusHeader)
+	blockState.Receipts().SetHardFork(bft.bv, bi.No)
+
+	txOp := chain.NewCompTxOp(
+		chain.TxOpFn(func(bState *state.BlockState, txIn types.Transaction) error {
+			select {
+			case <-bft.quit:
+				return chain.ErrQuit
+			default:
+				return nil
+			}
+		}),
+		newTxExec(bft, bft.ChainDB, bi),
+	)
+
+	block, err := chain.GenerateBlock(bft, bi, blockState, txOp, false)
+	if err != nil {
+		return nil, err
+	}
+	if err := block.Sign(bft.privKey); err != nil {
+		return nil, err
+	}
+
+	bft.genStates[string(block.BlockHash())] = blockState
+
+	return block, nil
+}
+
+// validate implements executor.
+func (bft *BFT) validate(block *types.Block) error {
+	best, err := bft.GetBestBlock()
+	if err != nil {
+		return err
+	}
+	if err := bft.verifyHeader(block, best); err != nil {
+		return err
+	}
+
+	if !bytes.Equal(block.GetHeader().GetTxsRootHash(), types.CalculateTxsRootHash(block.GetBody().GetTxs())) {
+		return bc.ErrorBlockVerifyTxRoot
+	}
+
+	// the block proposed by this node is already executed
+	if _, ok := bft.genStates[string(block.BlockHash())]; ok {
+		return nil
+	}
+	return bft.execute(block, best)
+}
+
+// execute executes the transactions of block proposed by another validator on
+// top of parent and checks the result against the header of block. The block
+// state is discarded, so the block is executed again by the chain service when
+// it is decided.
+func (bft *BFT) execute(block *types.Block, parent *types.Block) error {
+	bi := types.NewBlockHeaderInfo(block)
+	blockState := bft.sdb.NewBlockState(
+		parent.GetHeader().GetBlocksRootHash(),
+		state.SetPrevBlockHash(parent.BlockHash()),
+	)
+
+	gasPrice := system.GetGasPriceFromState(blockState)
+	if bi.IsFeatureActive(types.FeatureBaseFee) {
+		if bi.BaseFee == nil || bi.BaseFee.Cmp(types.NextBaseFee(parent, gasPrice)) != 0 {
+			return ErrInvalidBaseFee
+		}
+		gasPrice = bi.BaseFee
+	} else if bi.BaseFee != nil {
+		return ErrInvalidBaseFee
+	}
+	blockState.SetGasPrice(gasPrice)
+	blockState.Receipts().SetHardFork(bft.bv, bi.No)
+
+	txOp := chain.NewCompTxOp(
+		chain.TxOpFn(func(bState *state.BlockState, txIn types.Transaction) error {
+			select {
+			case <-bft.quit:
+				return chain.ErrQuit
+			default:
+				return verifyTxSign(bState, txIn.GetTx())
+			}
+		}),
+		newTxExec(bft, bft.ChainDB, bi),
+	)
+
+	return chain.ExecuteBlock(blockState, bi, block, txOp)
+}
+
+func verifyTxSign(bState *state.BlockState, tx *types.Tx) error {
+	if !tx.NeedNameVerify() {
+		return key.VerifyTx(tx)
+	}
+
+	scs, err := bState.OpenContractStateAccount(types.ToAccountID([]byte(types.AergoName)))
+	if err != nil {
+		return err
+	}
+	return key.VerifyTxWithAddress(tx, name.GetOwner(scs, tx.GetBody().GetAccount()))
+}
+
+// commit implements executor.
+func (bft *BFT) commit(block *types.Block, c *types.BftCommit) {
+	bft.addCert(c)
+	bft.decided = &decision{block: block, commit: c}
+	bft.connectDecided()
+}
+
+// connectDecided connects the decided block and broadcasts its commit
+// certificate so that the validators which haven't decided yet can sync it.
+func (bft *BFT) connectDecided() {
+	d := bft.decided
+
+	if best, err := bft.GetBestBlock(); err == nil && best.BlockNo() >= d.block.BlockNo() {
+		bft.decided = nil
+		return
+	}
+
+	// the block state of the proposer is used only once since it may be
+	// modified by the failed attempt
+	blockState := bft.genStates[string(d.block.BlockHash())]
+	bft.genStates = nil
+
+	if err := chain.ConnectBlock(bft, d.block, blockState, connectTimeout); err != nil {
+		return
+	}
+
+	logger.Info().Uint64("no", d.block.BlockNo()).Str("hash", d.block.ID()).
+		Str("TrieRoot", enc.ToString(d.block.GetHeader().GetBlocksRootHash())).Msg("block connected")
+
+	bft.decided = nil
+	bft.broadcast(&types.BftMessage{Commit: d.commit})
+}
+
+func (bft *BFT) GetType() consensus.ConsensusType {
+	return consensus.ConsensusBFT
+}
+
+// IsTransactionValid checks the onsensus level validity of a transaction
+func (bft *BFT) IsTransactionValid(tx *types.Tx) bool {
+	// BFT has no tx valid check.
+	return true
+}
+
+// VerifyTimestamp checks the validity of the block timestamp.
+func (bft *BFT) VerifyTimestamp(*types.Block) bool {
+	// BFT don't need to check timestamp.
+	return true
+}
+
+// VerifySign checks the consensus level validity of a block.
+func (bft *BFT) VerifySign(block *types.Block) error {
+	valid, err := block.VerifySign()
+	if !valid || err != nil {
+		return &consensus.ErrorConsensus{Msg: "bad block signature", Err: err}
+	}
+	return nil
+}
+
+// IsBlockValid checks the consensus level validity of a block. A block is
+// valid only if its commit certificate is known.
+func (bft *BFT) IsBlockValid(block *types.Block, bestBlock *types.Block) error {
+	if block.BlockNo() == 0 {
+		return nil
+	}
+
+	if err := bft.verifyHeader(block, bestBlock); err != nil {
+		return &consensus.ErrorConsensus{Msg: "invalid bft block", Err: err}
+	}
+
+	if bft.GetCommit(block.BlockHash()) == nil {
+		return &consensus.ErrorConsensus{Msg: "invalid bft block", Err: ErrBlockNotCertified}
+	}
+
+	return nil
+}
+
+// Update keeps the commit certificates of block and its parent to save.
+func (bft *BFT) Update(block *types.Block) {
+	bft.Lock()
+	defer bft.Unlock()
+
+	if hdr, err := decodeHeader(block); err == nil && hdr.LastCommit != nil {
+		bft.unsaved = append(bft.unsaved, hdr.LastCommit)
+	}
+
+	hash := string(block.BlockHash())
+	if c, ok := bft.certs[hash]; ok {
+		bft.unsaved = append(bft.unsaved, c)
+	}
+
+	for h, c := range bft.certs {
+		if c.BlockNo <= block.BlockNo() {
+			delete(bft.certs, h)
+		}
+	}
+
+	bft.lastNo = block.BlockNo()
+}
+
+// Save saves the validator history and the commit certificates.
+func (bft *BFT) Save(tx consensus.TxWriter) error {
+	bft.Lock()
+	defer bft.Unlock()
+
+	if bft.historyChanged {
+		data, err := bft.history.encode()
+		if err != nil {
+			return err
+		}
+		tx.Set(validatorsKey, data)
+		bft.historyChanged = false
+	}
+
+	for _, c := range bft.unsaved {
+		data, err := proto.Marshal(c)
+		if err != nil {
+			return err
+		}
+		tx.Set(commitKey(c.BlockHash), data)
+	}
+	bft.unsaved = nil
+
+	return nil
+}
+
+// NeedReorganization reports whether reorganization is needed or not. The
+// decided block is final in BFT.
+func (bft *BFT) NeedReorganization(rootNo types.BlockNo) bool {
+	return false
+}
+
+func (bft *BFT) NeedNotify() bool {
+	return true
+}
+
+func (bft *BFT) HasWAL() bool {
+	return false
+}
+
+func (bft *BFT) IsForkEnable() bool {
+	return false
+}
+
+func (bft *BFT) IsConnectedBlock(block *types.Block) bool {
+	_, err := bft.ChainDB.GetBlock(block.BlockHash())
+	if err == nil {
+		return true
+	}
+
+	return false
+}
+
+// Info returns the current consensus status of bft in JSON.
+func (bft *BFT) Info() string {
+	info := consensus.NewInfo(GetName())
+
+	bft.RLock()
+	defer bft.RUnlock()
+
+	if b, err := json.Marshal(bft.status); err == nil {
+		m := json.RawMessage(b)
+		info.Status = &m
+	}
+
+	return info.AsJSON()
+}
+
+func (bft *BFT) ConsensusInfo() *types.ConsensusInfo {
+	cons := &types.ConsensusInfo{Type: GetName()}
+
+	bft.RLock()
+	b, err := json.Marshal(bft.status)
+	bft.RUnlock()
+	if err == nil {
+		cons.Info = string(b)
+	}
+
+	type ValidatorInfo struct {
+		Name   string
+		ID     string
+		PeerID string
+		Addr   string
+	}
+
+	best, err := bft.GetBestBlock()
+	if err != nil {
+		return cons
+	}
+
+	for _, m := range bft.validatorsAt(best.BlockNo() + 1).members {
+		v := &ValidatorInfo{Name: m.Name, ID: fmt.Sprintf("%x", m.ID), PeerID: types.IDB58Encode(types.PeerID(m.PeerID)), Addr: m.Address}
+		if b, err := json.Marshal(v); err == nil {
+			cons.Bps = append(cons.Bps, string(b))
+		}
+	}
+
+	return cons
+}
+
+func (bft *BFT) ClusterInfo(bestBlockHash []byte) *types.GetClusterInfoResponse {
+	best, err := bft.GetBestBlock()
+	if err != nil {
+		return &types.GetClusterInfoResponse{Error: err.Error()}
+	}
+
+	return &types.GetClusterInfoResponse{
+		ChainID:     bft.chainID,
+		MbrAttrs:    bft.validatorsAt(best.BlockNo() + 1).members,
+		BestBlockNo: best.BlockNo(),
+	}
+}
+
+var dummyRaft consensus.DummyRaftAccessor
+
+func (bft *BFT) RaftAccessor() consensus.AergoRaftAccessor {
+	return &dummyRaft
+}
+
+func (bft *BFT) BFTAccessor() consensus.BFTAccessor {
+	return bft
+}
+
+// ConfChange returns error since the validators are changed only by the
+// changeCluster transaction of the enterprise contract.
+func (bft *BFT) ConfChange(req *types.MembershipChange) (*consensus.Member, error) {
+	return nil, consensus.ErrNotSupportedMethod
+}
+
+func (bft *BFT) ConfChangeInfo(requestID uint64) (*types.ConfChangeProgress, error) {
+	return nil, consensus.ErrNotSupportedMethod
+}
+
+func (bft *BFT) BPStats(blockNo types.BlockNo) (*types.BPStatList, error) {
+	return nil, consensus.ErrNotSupportedMethod
+}
+
+// MakeConfChangeProposal validates the membership change request against the
+// validators of the block including the request. The change is applied from
+// the next block by ApplyConfChange.
+func (bft *BFT) MakeConfChangeProposal(req *types.MembershipChange) (*consensus.ConfChangePropose, error) {
+	var (
+		vs   = bft.validatorsAt(req.GetRequestID())
+		attr = req.GetAttr()
+		cc   = &raftpb.ConfChange{ID: req.GetRequestID()}
+		m    *types.MemberAttr
+	)
+
+	switch req.Type {
+	case types.MembershipChangeType_ADD_MEMBER:
+		if len(attr.GetName()) == 0 || len(attr.GetAddress()) == 0 || len(attr.GetPeerID()) == 0 {
+			return nil, consensus.ErrInvalidMemberAttr
+		}
+
+		m = &consensus.NewMember(attr.Name, attr.Address, types.PeerID(attr.PeerID), bft.chainID, int64(req.GetRequestID())).MemberAttr
+		for _, v := range vs.members {
+			if v.Name == m.Name || bytes.Equal(v.PeerID, m.PeerID) || v.ID == m.ID {
+				return nil, ErrDuplicatedValidator
+			}
+		}
+		cc.Type = raftpb.ConfChangeAddNode
+
+	case types.MembershipChangeType_REMOVE_MEMBER:
+		if m = vs.getByID(attr.GetID()); m == nil {
+			return nil, ErrNoSuchValidator
+		}
+		if vs.size() == 1 {
+			return nil, ErrLastValidator
+		}
+		cc.Type = raftpb.ConfChangeRemoveNode
+
+	default:
+		return nil, ErrNotSupportedMembershipChange
+	}
+
+	data, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	cc.NodeID = m.ID
+	cc.Context = data
+
+	logger.Info().Str("request", req.ToString()).Msg("make proposal of validator change")
+
+	return &consensus.ConfChangePropose{Ctx: context.Background(), Cc: cc}, nil
+}
+
+// ApplyConfChange changes the validators from the block next to block.
+func (bft *BFT) ApplyConfChange(block *types.Block, ccPropose *consensus.ConfChangePropose) error {
+	cc := ccPropose.Cc
+	if cc == nil {
+		return nil
+	}
+
+	m := &types.MemberAttr{}
+	if err := json.Unmarshal(cc.Context, m); err != nil {
+		return err
+	}
+
+	bft.Lock()
+	defer bft.Unlock()
+
+	from := block.BlockNo() + 1
+	cur := bft.history.at(block.BlockNo()).members
+
+	var mbrs []*types.MemberAttr
+	switch cc.Type {
+	case raftpb.ConfChangeAddNode:
+		mbrs = append(append(mbrs, cur...), m)
+	case raftpb.ConfChangeRemoveNode:
+		for _, v := range cur {
+			if v.ID != m.ID {
+				mbrs = append(mbrs, v)
+			}
+		}
+	default:
+		return ErrNotSupportedMembershipChange
+	}
+
+	bft.history = bft.history.add(from, mbrs)
+	bft.historyChanged = true
+
+	logger.Info().Uint64("from", from).Int("validators", len(mbrs)).Str("member", m.ToString()).
+		Str("type", cc.Type.String()).Msg("validators changed")
+
+	return nil
+}
+
+func ValidateGenesis(genesis *types.Genesis) error {
+	if strings.ToLower(genesis.ConsensusType()) != GetName() {
+		return ErrInvalidConsensusName
+	}
+
+	if _, err := parseValidators(genesis.EnterpriseBPs); err != nil {
+		logger.Error().Err(err).Msg("failed to parse validator list of Genesis block")
+		return err
+	}
+
+	return nil
+}
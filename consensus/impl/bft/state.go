@@ -0,0 +1,459 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package bft
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/aergoio/aergo/internal/enc"
+	"github.com/aergoio/aergo/types"
+	"github.com/libp2p/go-libp2p-core/crypto"
+)
+
+type step int
+
+const (
+	stepPropose step = iota
+	stepPrevote
+	stepPrecommit
+	stepCommit
+)
+
+var stepName = map[step]string{
+	stepPropose:   "propose",
+	stepPrevote:   "prevote",
+	stepPrecommit: "precommit",
+	stepCommit:    "commit",
+}
+
+func (s step) String() string {
+	return stepName[s]
+}
+
+const (
+	noRound = int32(-1)
+
+	// maxPendingMsg is the maximum number of the buffered messages of the next
+	// height.
+	maxPendingMsg = 1000
+)
+
+// executor is the interface through which the state machine interacts with
+// the network and the chain.
+type executor interface {
+	// broadcast sends m to the other validators.
+	broadcast(m *types.BftMessage)
+	// propose returns a new block for round at height. It is called only if
+	// this validator is the proposer of the round.
+	propose(height types.BlockNo, round int32) (*types.Block, error)
+	// validate checks the validity of the proposed block.
+	validate(block *types.Block) error
+	// commit is called when the block is decided by c.
+	commit(block *types.Block, c *types.BftCommit)
+}
+
+// timeoutConfig holds the timeouts of each step. The timeouts increase by
+// delta as the round goes on so that the validators eventually synchronize.
+type timeoutConfig struct {
+	propose   time.Duration
+	prevote   time.Duration
+	precommit time.Duration
+	delta     time.Duration
+}
+
+func newTimeoutConfig(blockInterval time.Duration) timeoutConfig {
+	return timeoutConfig{
+		propose:   blockInterval,
+		prevote:   blockInterval / 2,
+		precommit: blockInterval / 2,
+		delta:     blockInterval / 2,
+	}
+}
+
+func (tc timeoutConfig) of(s step, round int32) time.Duration {
+	var base time.Duration
+	switch s {
+	case stepPropose:
+		base = tc.propose
+	case stepPrevote:
+		base = tc.prevote
+	default:
+		base = tc.precommit
+	}
+	return base + tc.delta*time.Duration(round)
+}
+
+// bftState is the tendermint style consensus state machine of a validator. It
+// decides a block for each height through the propose, prevote and precommit
+// steps of rounds. It is not thread-safe: all the methods must be called by a
+// single goroutine.
+type bftState struct {
+	id          types.PeerID
+	privKey     crypto.PrivKey
+	chainIDHash []byte
+	exec        executor
+	timeouts    timeoutConfig
+
+	vs       *validatorSet
+	height   types.BlockNo
+	round    int32
+	step     step
+	deadline time.Time
+
+	lockedRound int32
+	lockedBlock *types.Block
+	validRound  int32
+	validBlock  *types.Block
+
+	proposals  map[int32]*types.BftProposal
+	prevotes   map[int32]*voteSet
+	precommits map[int32]*voteSet
+	// validUpdated keeps the rounds in which the valid block is updated.
+	validUpdated map[int32]bool
+	validity     map[string]error
+
+	// pending is the messages of the next height which arrived early.
+	pending []*types.BftMessage
+}
+
+func newState(id types.PeerID, privKey crypto.PrivKey, chainIDHash []byte, exec executor, timeouts timeoutConfig) *bftState {
+	return &bftState{
+		id:          id,
+		privKey:     privKey,
+		chainIDHash: chainIDHash,
+		exec:        exec,
+		timeouts:    timeouts,
+		step:        stepCommit,
+	}
+}
+
+// startHeight starts the consensus for height among vs.
+func (s *bftState) startHeight(height types.BlockNo, vs *validatorSet, now time.Time) {
+	s.vs = vs
+	s.height = height
+	s.lockedRound, s.lockedBlock = noRound, nil
+	s.validRound, s.validBlock = noRound, nil
+	s.proposals = make(map[int32]*types.BftProposal)
+	s.prevotes = make(map[int32]*voteSet)
+	s.precommits = make(map[int32]*voteSet)
+	s.validUpdated = make(map[int32]bool)
+	s.validity = make(map[string]error)
+
+	logger.Debug().Uint64("height", height).Int("validators", vs.size()).Msg("start new height")
+
+	s.startRound(0, now)
+	s.process(now)
+
+	pending := s.pending
+	s.pending = nil
+	for _, m := range pending {
+		if err := s.onMessage(m, now); err != nil {
+			logger.Debug().Err(err).Msg("failed to process pending bft message")
+		}
+	}
+}
+
+func (s *bftState) startRound(round int32, now time.Time) {
+	s.round = round
+	s.step = stepPropose
+	s.deadline = now.Add(s.timeouts.of(stepPropose, round))
+
+	if types.IsSamePeerID(s.vs.proposer(s.height, round), s.id) {
+		s.propose()
+	}
+}
+
+func (s *bftState) propose() {
+	block := s.validBlock
+	if block == nil {
+		var err error
+		if block, err = s.exec.propose(s.height, s.round); err != nil {
+			logger.Error().Err(err).Uint64("height", s.height).Int32("round", s.round).Msg("failed to make block to propose")
+			return
+		}
+	}
+
+	p := &types.BftProposal{Block: block, Round: s.round, ValidRound: s.validRound}
+	if err := signProposal(s.chainIDHash, p, s.privKey); err != nil {
+		logger.Error().Err(err).Msg("failed to sign proposal")
+		return
+	}
+
+	logger.Info().Uint64("height", s.height).Int32("round", s.round).Str("hash", block.ID()).Msg("propose block")
+
+	s.proposals[s.round] = p
+	s.exec.broadcast(&types.BftMessage{Proposal: p})
+}
+
+func (s *bftState) castVote(voteType types.BftVoteType, hash []byte) {
+	v, err := newVote(s.chainIDHash, voteType, s.height, s.round, hash, s.id, s.privKey)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to sign vote")
+		return
+	}
+
+	s.addVote(v)
+	s.exec.broadcast(&types.BftMessage{Vote: v})
+}
+
+func (s *bftState) addVote(v *types.BftVote) (bool, error) {
+	votes := s.prevotes
+	if v.Type == types.BftVoteType_PRECOMMIT {
+		votes = s.precommits
+	}
+
+	set, ok := votes[v.Round]
+	if !ok {
+		set = newVoteSet()
+		votes[v.Round] = set
+	}
+
+	return set.add(v)
+}
+
+// onMessage handles a message received from the other validators.
+func (s *bftState) onMessage(m *types.BftMessage, now time.Time) error {
+	switch {
+	case m.Proposal != nil:
+		return s.onProposal(m, now)
+	case m.Vote != nil:
+		return s.onVote(m, now)
+	}
+	return nil
+}
+
+// isFuture buffers m if it is the message of the next height and reports
+// whether m must not be processed now.
+func (s *bftState) isFuture(m *types.BftMessage, height types.BlockNo) bool {
+	if s.vs != nil && height <= s.height {
+		return height < s.height || s.step == stepCommit
+	}
+	if (s.vs == nil || height == s.height+1) && len(s.pending) < maxPendingMsg {
+		s.pending = append(s.pending, m)
+	}
+	return true
+}
+
+func (s *bftState) onProposal(m *types.BftMessage, now time.Time) error {
+	p := m.Proposal
+	if p.Block == nil {
+		return ErrInvalidProposal
+	}
+	if s.isFuture(m, p.Block.BlockNo()) {
+		return nil
+	}
+
+	if err := verifyProposal(s.chainIDHash, p, s.vs); err != nil {
+		return err
+	}
+	if _, exist := s.proposals[p.Round]; exist {
+		return nil
+	}
+	s.proposals[p.Round] = p
+
+	s.process(now)
+
+	return nil
+}
+
+func (s *bftState) onVote(m *types.BftMessage, now time.Time) error {
+	v := m.Vote
+	if s.isFuture(m, v.BlockNo) {
+		return nil
+	}
+
+	if err := verifyVote(s.chainIDHash, v, s.vs); err != nil {
+		return err
+	}
+	if added, err := s.addVote(v); err != nil || !added {
+		return err
+	}
+
+	s.process(now)
+
+	return nil
+}
+
+// onCommit decides the block of c if the block has been proposed. It reports
+// whether the block is decided.
+func (s *bftState) onCommit(c *types.BftCommit) bool {
+	if s.vs == nil || s.step == stepCommit || c.BlockNo != s.height {
+		return false
+	}
+
+	for _, p := range s.proposals {
+		if bytes.Equal(p.Block.BlockHash(), c.BlockHash) {
+			s.decide(p.Block, c)
+			return true
+		}
+	}
+
+	return false
+}
+
+// onTick handles the timeout of the current step.
+func (s *bftState) onTick(now time.Time) {
+	if s.vs == nil || s.step == stepCommit || now.Before(s.deadline) {
+		return
+	}
+
+	logger.Debug().Uint64("height", s.height).Int32("round", s.round).Str("step", s.step.String()).Msg("timeout")
+
+	switch s.step {
+	case stepPropose:
+		s.castVote(types.BftVoteType_PREVOTE, nil)
+		s.enterStep(stepPrevote, now)
+	case stepPrevote:
+		s.castVote(types.BftVoteType_PRECOMMIT, nil)
+		s.enterStep(stepPrecommit, now)
+	case stepPrecommit:
+		s.startRound(s.round+1, now)
+	}
+
+	s.process(now)
+}
+
+func (s *bftState) enterStep(st step, now time.Time) {
+	s.step = st
+	s.deadline = now.Add(s.timeouts.of(st, s.round))
+}
+
+func (s *bftState) isValid(block *types.Block) bool {
+	key := string(block.BlockHash())
+	err, checked := s.validity[key]
+	if !checked {
+		err = s.exec.validate(block)
+		if err != nil {
+			logger.Info().Err(err).Str("hash", block.ID()).Msg("invalid block proposed")
+		}
+		s.validity[key] = err
+	}
+	return err == nil
+}
+
+func (s *bftState) count(votes map[int32]*voteSet, round int32, hash []byte) int {
+	if set, ok := votes[round]; ok {
+		return set.count(hash)
+	}
+	return 0
+}
+
+func (s *bftState) isLockedOn(hash []byte) bool {
+	return s.lockedBlock != nil && bytes.Equal(s.lockedBlock.BlockHash(), hash)
+}
+
+// process applies the rules of the consensus until the state doesn't change.
+func (s *bftState) process(now time.Time) {
+	for s.step != stepCommit && s.apply(now) {
+	}
+}
+
+func (s *bftState) apply(now time.Time) bool {
+	quorum := s.vs.quorum()
+
+	// decide the block which has 2f+1 precommits of any round
+	for round, p := range s.proposals {
+		hash := p.Block.BlockHash()
+		if s.count(s.precommits, round, hash) >= quorum && s.isValid(p.Block) {
+			s.decide(p.Block, &types.BftCommit{BlockNo: s.height, Round: round, BlockHash: hash,
+				Votes: s.precommits[round].votesFor(hash)})
+			return false
+		}
+	}
+
+	// skip to the higher round which f+1 validators are in
+	for round := range s.votedRounds() {
+		if round > s.round && s.votersOf(round) >= s.vs.minority() {
+			logger.Debug().Uint64("height", s.height).Int32("round", round).Msg("skip to higher round")
+			s.startRound(round, now)
+			return true
+		}
+	}
+
+	p := s.proposals[s.round]
+
+	if s.step == stepPropose && p != nil {
+		hash := p.Block.BlockHash()
+		vr := p.ValidRound
+		if vr == noRound {
+			if s.isValid(p.Block) && (s.lockedRound == noRound || s.isLockedOn(hash)) {
+				s.castVote(types.BftVoteType_PREVOTE, hash)
+			} else {
+				s.castVote(types.BftVoteType_PREVOTE, nil)
+			}
+			s.enterStep(stepPrevote, now)
+			return true
+		} else if s.count(s.prevotes, vr, hash) >= quorum {
+			if s.isValid(p.Block) && (s.lockedRound <= vr || s.isLockedOn(hash)) {
+				s.castVote(types.BftVoteType_PREVOTE, hash)
+			} else {
+				s.castVote(types.BftVoteType_PREVOTE, nil)
+			}
+			s.enterStep(stepPrevote, now)
+			return true
+		}
+	}
+
+	if s.step >= stepPrevote && p != nil && !s.validUpdated[s.round] {
+		hash := p.Block.BlockHash()
+		if s.count(s.prevotes, s.round, hash) >= quorum && s.isValid(p.Block) {
+			s.validUpdated[s.round] = true
+			if s.step == stepPrevote {
+				s.lockedRound, s.lockedBlock = s.round, p.Block
+				s.castVote(types.BftVoteType_PRECOMMIT, hash)
+				s.enterStep(stepPrecommit, now)
+			}
+			s.validRound, s.validBlock = s.round, p.Block
+			return true
+		}
+	}
+
+	if s.step == stepPrevote && s.count(s.prevotes, s.round, nil) >= quorum {
+		s.castVote(types.BftVoteType_PRECOMMIT, nil)
+		s.enterStep(stepPrecommit, now)
+		return true
+	}
+
+	if s.step == stepPrecommit && s.count(s.precommits, s.round, nil) >= quorum {
+		s.startRound(s.round+1, now)
+		return true
+	}
+
+	return false
+}
+
+func (s *bftState) votedRounds() map[int32]bool {
+	rounds := make(map[int32]bool)
+	for r := range s.prevotes {
+		rounds[r] = true
+	}
+	for r := range s.precommits {
+		rounds[r] = true
+	}
+	return rounds
+}
+
+// votersOf returns the number of the validators which voted in round.
+func (s *bftState) votersOf(round int32) int {
+	voters := make(map[types.PeerID]bool)
+	for _, votes := range []map[int32]*voteSet{s.prevotes, s.precommits} {
+		if set, ok := votes[round]; ok {
+			for id := range set.votes {
+				voters[id] = true
+			}
+		}
+	}
+	return len(voters)
+}
+
+func (s *bftState) decide(block *types.Block, c *types.BftCommit) {
+	logger.Info().Uint64("height", s.height).Int32("round", c.Round).Str("hash", enc.ToString(c.BlockHash)).
+		Int("votes", len(c.Votes)).Msg("block decided")
+
+	s.step = stepCommit
+	s.exec.commit(block, c)
+}
@@ -0,0 +1,243 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package bft
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/aergoio/aergo/internal/common"
+	"github.com/aergoio/aergo/types"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+var testChainIDHash = common.Hasher([]byte("bft test chain"))
+
+type testMsg struct {
+	from types.PeerID
+	m    *types.BftMessage
+}
+
+type testNet struct {
+	states    map[types.PeerID]*bftState
+	queue     []testMsg
+	silent    map[types.PeerID]bool
+	committed map[types.PeerID]*types.BftCommit
+}
+
+type testExec struct {
+	net     *testNet
+	id      types.PeerID
+	privKey crypto.PrivKey
+}
+
+func (e *testExec) broadcast(m *types.BftMessage) {
+	e.net.queue = append(e.net.queue, testMsg{from: e.id, m: m})
+}
+
+func (e *testExec) propose(height types.BlockNo, round int32) (*types.Block, error) {
+	block := &types.Block{Header: &types.BlockHeader{BlockNo: height, Timestamp: int64(round)}, Body: &types.BlockBody{}}
+	if err := block.Sign(e.privKey); err != nil {
+		return nil, err
+	}
+	return block, nil
+}
+
+func (e *testExec) validate(block *types.Block) error {
+	return nil
+}
+
+func (e *testExec) commit(block *types.Block, c *types.BftCommit) {
+	e.net.committed[e.id] = c
+}
+
+func newTestNet(t *testing.T, n int) (*testNet, *validatorSet) {
+	net := &testNet{
+		states:    make(map[types.PeerID]*bftState),
+		silent:    make(map[types.PeerID]bool),
+		committed: make(map[types.PeerID]*types.BftCommit),
+	}
+
+	var mbrs []*types.MemberAttr
+	for i := 0; i < n; i++ {
+		privKey, _, err := crypto.GenerateKeyPair(crypto.Secp256k1, 256)
+		assert.NoError(t, err)
+		id, err := types.IDFromPrivateKey(privKey)
+		assert.NoError(t, err)
+
+		mbrs = append(mbrs, &types.MemberAttr{ID: uint64(i + 1), PeerID: []byte(id)})
+		net.states[id] = newState(id, privKey, testChainIDHash, &testExec{net: net, id: id, privKey: privKey}, newTimeoutConfig(time.Second))
+	}
+
+	return net, newValidatorSet(mbrs)
+}
+
+// deliver sends the queued messages to the validators until no message is left.
+func (net *testNet) deliver(now time.Time) {
+	for len(net.queue) > 0 {
+		msg := net.queue[0]
+		net.queue = net.queue[1:]
+
+		if net.silent[msg.from] {
+			continue
+		}
+		for id, s := range net.states {
+			if id == msg.from || net.silent[id] {
+				continue
+			}
+			s.onMessage(msg.m, now)
+		}
+	}
+}
+
+func (net *testNet) start(height types.BlockNo, vs *validatorSet, now time.Time) {
+	for id, s := range net.states {
+		if !net.silent[id] {
+			s.startHeight(height, vs, now)
+		}
+	}
+	net.deliver(now)
+}
+
+func (net *testNet) tick(now time.Time) {
+	for id, s := range net.states {
+		if !net.silent[id] {
+			s.onTick(now)
+		}
+	}
+	net.deliver(now)
+}
+
+func (net *testNet) checkCommitted(t *testing.T, vs *validatorSet, n int, round int32) {
+	assert.Equal(t, n, len(net.committed))
+
+	var hash []byte
+	for _, c := range net.committed {
+		assert.Equal(t, round, c.Round)
+		assert.NoError(t, verifyCommit(testChainIDHash, c, vs))
+		if hash == nil {
+			hash = c.BlockHash
+		}
+		assert.True(t, bytes.Equal(hash, c.BlockHash))
+	}
+}
+
+func TestValidatorSet(t *testing.T) {
+	for _, tc := range []struct {
+		n, quorum, minority int
+	}{
+		{1, 1, 1}, {3, 3, 1}, {4, 3, 2}, {7, 5, 3},
+	} {
+		mbrs := make([]*types.MemberAttr, tc.n)
+		for i := range mbrs {
+			mbrs[i] = &types.MemberAttr{ID: uint64(i), PeerID: []byte{byte(tc.n - i)}}
+		}
+		vs := newValidatorSet(mbrs)
+
+		assert.Equal(t, tc.quorum, vs.quorum())
+		assert.Equal(t, tc.minority, vs.minority())
+		assert.Equal(t, types.PeerID([]byte{1}), vs.proposer(0, 0))
+		assert.Equal(t, vs.proposer(1, 0), vs.proposer(0, 1))
+	}
+
+	m1 := &types.MemberAttr{ID: 1, PeerID: []byte(types.RandomPeerID())}
+	m2 := &types.MemberAttr{ID: 2, PeerID: []byte(types.RandomPeerID())}
+
+	var h validatorHistory
+	h = h.add(1, []*types.MemberAttr{m1})
+	h = h.add(10, []*types.MemberAttr{m1, m2})
+
+	assert.Equal(t, 1, h.at(9).size())
+	assert.Equal(t, 2, h.at(10).size())
+
+	data, err := h.encode()
+	assert.NoError(t, err)
+	decoded, err := decodeValidatorHistory(data)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, decoded.at(11).size())
+}
+
+func TestStateCommit(t *testing.T) {
+	net, vs := newTestNet(t, 4)
+	now := time.Now()
+
+	net.start(1, vs, now)
+	net.checkCommitted(t, vs, 4, 0)
+}
+
+func TestStateSilentProposer(t *testing.T) {
+	net, vs := newTestNet(t, 4)
+	now := time.Now()
+
+	net.silent[vs.proposer(1, 0)] = true
+	net.start(1, vs, now)
+	assert.Empty(t, net.committed)
+
+	// timeout of propose: nil prevotes and nil precommits lead to the next round
+	now = now.Add(time.Second)
+	net.tick(now)
+
+	net.checkCommitted(t, vs, 3, 1)
+}
+
+func TestStateNoQuorum(t *testing.T) {
+	net, vs := newTestNet(t, 4)
+	now := time.Now()
+
+	net.silent[vs.proposer(1, 1)] = true
+	net.silent[vs.proposer(1, 2)] = true
+	net.start(1, vs, now)
+
+	for i := 0; i < 10; i++ {
+		now = now.Add(10 * time.Second)
+		net.tick(now)
+	}
+
+	assert.Empty(t, net.committed)
+}
+
+func TestVerifyCommit(t *testing.T) {
+	net, vs := newTestNet(t, 4)
+	net.start(1, vs, time.Now())
+
+	var c *types.BftCommit
+	for _, c = range net.committed {
+		break
+	}
+	assert.NoError(t, verifyCommit(testChainIDHash, c, vs))
+
+	lack := *c
+	lack.Votes = c.Votes[:vs.quorum()-1]
+	assert.Equal(t, ErrNotEnoughCommitVote, verifyCommit(testChainIDHash, &lack, vs))
+
+	forged := *c
+	forged.BlockHash = []byte("forged")
+	assert.Equal(t, ErrInvalidCommit, verifyCommit(testChainIDHash, &forged, vs))
+
+	_, others := newTestNet(t, 4)
+	assert.Equal(t, ErrNotValidator, verifyCommit(testChainIDHash, c, others))
+
+	// The votes of another chain of the same validators
+	otherChain := common.Hasher([]byte("other chain"))
+	assert.Equal(t, ErrInvalidVoteSign, verifyCommit(otherChain, c, vs))
+}
+
+func TestVerifyProposalOfOtherChain(t *testing.T) {
+	net, vs := newTestNet(t, 4)
+	net.start(1, vs, time.Now())
+
+	var p *types.BftProposal
+	for _, s := range net.states {
+		if p = s.proposals[0]; p != nil {
+			break
+		}
+	}
+	assert.NotNil(t, p)
+	assert.NoError(t, verifyProposal(testChainIDHash, p, vs))
+	assert.Equal(t, ErrInvalidVoteSign, verifyProposal(common.Hasher([]byte("other chain")), p, vs))
+}
@@ -0,0 +1,125 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package bft
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+
+	"github.com/aergoio/aergo/types"
+)
+
+// validatorSet is the set of the members which take part in the bft consensus
+// at a specific height. The members are sorted by their peer ID so that every
+// validator agrees on the proposer of each round.
+type validatorSet struct {
+	members []*types.MemberAttr
+	index   map[types.PeerID]int
+}
+
+func newValidatorSet(mbrs []*types.MemberAttr) *validatorSet {
+	members := make([]*types.MemberAttr, len(mbrs))
+	copy(members, mbrs)
+
+	sort.Slice(members, func(i, j int) bool {
+		return bytes.Compare(members[i].PeerID, members[j].PeerID) < 0
+	})
+
+	index := make(map[types.PeerID]int, len(members))
+	for i, m := range members {
+		index[types.PeerID(m.PeerID)] = i
+	}
+
+	return &validatorSet{members: members, index: index}
+}
+
+// size returns the number of validators.
+func (vs *validatorSet) size() int {
+	return len(vs.members)
+}
+
+// quorum returns the minimum number of votes (2f+1) to decide.
+func (vs *validatorSet) quorum() int {
+	return vs.size()*2/3 + 1
+}
+
+// minority returns the minimum number of votes (f+1) which include at least
+// one honest validator.
+func (vs *validatorSet) minority() int {
+	return vs.size() - vs.quorum() + 1
+}
+
+// has reports whether peerID is a validator.
+func (vs *validatorSet) has(peerID types.PeerID) bool {
+	_, ok := vs.index[peerID]
+	return ok
+}
+
+// proposer returns the validator which proposes a block of round at height.
+func (vs *validatorSet) proposer(height types.BlockNo, round int32) types.PeerID {
+	if vs.size() == 0 {
+		return types.PeerID("")
+	}
+	return types.PeerID(vs.members[(height+uint64(round))%uint64(vs.size())].PeerID)
+}
+
+func (vs *validatorSet) getByID(id uint64) *types.MemberAttr {
+	for _, m := range vs.members {
+		if m.ID == id {
+			return m
+		}
+	}
+	return nil
+}
+
+func (vs *validatorSet) getByPeerID(peerID types.PeerID) *types.MemberAttr {
+	if i, ok := vs.index[peerID]; ok {
+		return vs.members[i]
+	}
+	return nil
+}
+
+// validatorEpoch is the validator set which is valid from the block From.
+type validatorEpoch struct {
+	From    types.BlockNo       `json:"from"`
+	Members []*types.MemberAttr `json:"members"`
+}
+
+// validatorHistory is the list of the validator sets ordered by the block
+// number from which each one is applied.
+type validatorHistory []*validatorEpoch
+
+func decodeValidatorHistory(data []byte) (validatorHistory, error) {
+	var h validatorHistory
+	if err := json.Unmarshal(data, &h); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+func (h validatorHistory) encode() ([]byte, error) {
+	return json.Marshal(h)
+}
+
+// at returns the validator set of the block blockNo.
+func (h validatorHistory) at(blockNo types.BlockNo) *validatorSet {
+	for i := len(h) - 1; i >= 0; i-- {
+		if h[i].From <= blockNo {
+			return newValidatorSet(h[i].Members)
+		}
+	}
+	return newValidatorSet(nil)
+}
+
+// add appends the validator set which is valid from the block from. The sets
+// which were added for the blocks at or after from are replaced.
+func (h validatorHistory) add(from types.BlockNo, mbrs []*types.MemberAttr) validatorHistory {
+	for len(h) > 0 && h[len(h)-1].From >= from {
+		h = h[:len(h)-1]
+	}
+	return append(h, &validatorEpoch{From: from, Members: mbrs})
+}
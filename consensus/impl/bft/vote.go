@@ -0,0 +1,192 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package bft
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"sort"
+
+	"github.com/aergoio/aergo/types"
+	"github.com/libp2p/go-libp2p-core/crypto"
+)
+
+var (
+	ErrNotValidator        = errors.New("not a validator")
+	ErrInvalidVoteSign     = errors.New("invalid signature of vote")
+	ErrInvalidProposal     = errors.New("invalid proposal")
+	ErrInvalidCommit       = errors.New("invalid commit certificate")
+	ErrConflictingVote     = errors.New("conflicting vote of the same validator")
+	ErrNotEnoughCommitVote = errors.New("not enough votes in commit certificate")
+)
+
+// voteBytes returns the signed bytes of v. The hash of the chain id is included
+// so that a vote can't be replayed on another chain of the same validators.
+func voteBytes(chainIDHash []byte, v *types.BftVote) []byte {
+	var buf bytes.Buffer
+
+	buf.Write(chainIDHash)
+	binary.Write(&buf, binary.LittleEndian, int32(v.Type))
+	binary.Write(&buf, binary.LittleEndian, v.BlockNo)
+	binary.Write(&buf, binary.LittleEndian, v.Round)
+	buf.Write(v.BlockHash)
+
+	return buf.Bytes()
+}
+
+// proposalBytes returns the signed bytes of p, which include the hash of the
+// chain id as the votes.
+func proposalBytes(chainIDHash []byte, p *types.BftProposal) []byte {
+	var buf bytes.Buffer
+
+	buf.Write(chainIDHash)
+	buf.Write(p.Block.BlockHash())
+	binary.Write(&buf, binary.LittleEndian, p.Round)
+	binary.Write(&buf, binary.LittleEndian, p.ValidRound)
+
+	return buf.Bytes()
+}
+
+func newVote(chainIDHash []byte, voteType types.BftVoteType, blockNo types.BlockNo, round int32, hash []byte,
+	id types.PeerID, privKey crypto.PrivKey) (*types.BftVote, error) {
+	v := &types.BftVote{Type: voteType, BlockNo: blockNo, Round: round, BlockHash: hash, Validator: []byte(id)}
+
+	sig, err := privKey.Sign(voteBytes(chainIDHash, v))
+	if err != nil {
+		return nil, err
+	}
+	v.Sign = sig
+
+	return v, nil
+}
+
+func verifySign(peerID types.PeerID, msg []byte, sig []byte) error {
+	pubKey, err := peerID.ExtractPublicKey()
+	if err != nil {
+		return err
+	}
+
+	valid, err := pubKey.Verify(msg, sig)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		return ErrInvalidVoteSign
+	}
+
+	return nil
+}
+
+// verifyVote checks that v is signed by one of the validators in vs for the
+// chain of chainIDHash.
+func verifyVote(chainIDHash []byte, v *types.BftVote, vs *validatorSet) error {
+	peerID := types.PeerID(v.Validator)
+	if !vs.has(peerID) {
+		return ErrNotValidator
+	}
+	return verifySign(peerID, voteBytes(chainIDHash, v), v.Sign)
+}
+
+func signProposal(chainIDHash []byte, p *types.BftProposal, privKey crypto.PrivKey) error {
+	sig, err := privKey.Sign(proposalBytes(chainIDHash, p))
+	if err != nil {
+		return err
+	}
+	p.Sign = sig
+
+	return nil
+}
+
+// verifyProposal checks that p is signed by the proposer of its round for the
+// chain of chainIDHash. The block may be produced by another validator if it
+// is re-proposed as the valid block of a previous round.
+func verifyProposal(chainIDHash []byte, p *types.BftProposal, vs *validatorSet) error {
+	if p.Block == nil || p.Block.Header == nil || p.ValidRound >= p.Round {
+		return ErrInvalidProposal
+	}
+
+	return verifySign(vs.proposer(p.Block.BlockNo(), p.Round), proposalBytes(chainIDHash, p), p.Sign)
+}
+
+// verifyCommit checks that c includes the precommits of at least 2f+1
+// validators in vs for its block on the chain of chainIDHash.
+func verifyCommit(chainIDHash []byte, c *types.BftCommit, vs *validatorSet) error {
+	if c == nil || len(c.BlockHash) == 0 {
+		return ErrInvalidCommit
+	}
+
+	voted := make(map[types.PeerID]bool)
+	for _, v := range c.Votes {
+		if v.Type != types.BftVoteType_PRECOMMIT || v.BlockNo != c.BlockNo || v.Round != c.Round ||
+			!bytes.Equal(v.BlockHash, c.BlockHash) {
+			return ErrInvalidCommit
+		}
+		if err := verifyVote(chainIDHash, v, vs); err != nil {
+			return err
+		}
+		voted[types.PeerID(v.Validator)] = true
+	}
+
+	if len(voted) < vs.quorum() {
+		return ErrNotEnoughCommitVote
+	}
+
+	return nil
+}
+
+// voteSet is the votes of the same type casted in a round.
+type voteSet struct {
+	votes  map[types.PeerID]*types.BftVote
+	counts map[string]int
+}
+
+func newVoteSet() *voteSet {
+	return &voteSet{
+		votes:  make(map[types.PeerID]*types.BftVote),
+		counts: make(map[string]int),
+	}
+}
+
+// add adds v and reports whether it is a new vote.
+func (s *voteSet) add(v *types.BftVote) (bool, error) {
+	peerID := types.PeerID(v.Validator)
+	if old, ok := s.votes[peerID]; ok {
+		if !bytes.Equal(old.BlockHash, v.BlockHash) {
+			return false, ErrConflictingVote
+		}
+		return false, nil
+	}
+
+	s.votes[peerID] = v
+	s.counts[string(v.BlockHash)]++
+
+	return true, nil
+}
+
+// size returns the number of votes in s.
+func (s *voteSet) size() int {
+	return len(s.votes)
+}
+
+// count returns the number of votes for hash.
+func (s *voteSet) count(hash []byte) int {
+	return s.counts[string(hash)]
+}
+
+// votesFor returns the votes for hash.
+func (s *voteSet) votesFor(hash []byte) []*types.BftVote {
+	var votes []*types.BftVote
+	for _, v := range s.votes {
+		if bytes.Equal(v.BlockHash, hash) {
+			votes = append(votes, v)
+		}
+	}
+	sort.Slice(votes, func(i, j int) bool {
+		return bytes.Compare(votes[i].Validator, votes[j].Validator) < 0
+	})
+	return votes
+}
@@ -11,8 +11,6 @@ import (
 	"runtime/debug"
 	"time"
 
-	"github.com/aergoio/aergo/p2p/p2pkey"
-
 	"github.com/aergoio/aergo-lib/log"
 	bc "github.com/aergoio/aergo/chain"
 	"github.com/aergoio/aergo/consensus/chain"
@@ -23,7 +21,6 @@ import (
 	"github.com/aergoio/aergo/state"
 	"github.com/aergoio/aergo/types"
 	"github.com/davecgh/go-spew/spew"
-	"github.com/libp2p/go-libp2p-core/crypto"
 )
 
 const (
@@ -55,7 +52,7 @@ type BlockFactory struct {
 	quit             <-chan interface{}
 	maxBlockBodySize uint32
 	ID               string
-	privKey          crypto.PrivKey
+	signer           types.BlockSigner
 	txOp             chain.TxOp
 	sdb              *state.ChainStateDB
 	bv               types.BlockVersionner
@@ -67,6 +64,7 @@ func NewBlockFactory(
 	sdb *state.ChainStateDB,
 	quitC <-chan interface{},
 	bv types.BlockVersionner,
+	signer types.BlockSigner,
 ) *BlockFactory {
 	bf := &BlockFactory{
 		ComponentHub:     hub,
@@ -75,8 +73,8 @@ func NewBlockFactory(
 		bpTimeoutC:       make(chan struct{}, 1),
 		maxBlockBodySize: chain.MaxBlockBodySize(),
 		quit:             quitC,
-		ID:               p2pkey.NodeSID(),
-		privKey:          p2pkey.NodePrivKey(),
+		ID:               enc.ToString([]byte(localBpID)),
+		signer:           signer,
 		sdb:              sdb,
 		bv:               bv,
 	}
@@ -236,7 +234,7 @@ func (bf *BlockFactory) generateBlock(bpi *bpInfo, lpbNo types.BlockNo) (block *
 
 	block.SetConfirms(block.BlockNo() - lpbNo)
 
-	if err = block.Sign(bf.privKey); err != nil {
+	if err = block.SignWith(bf.signer, bpi.slot.Index()); err != nil {
 		return nil, nil, err
 	}
 
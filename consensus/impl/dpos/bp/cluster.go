@@ -453,6 +453,21 @@ func (sn *Snapshots) gc(blockNo types.BlockNo) {
 	}
 }
 
+// PeriodRefBlockNo returns the block number at which the BP cluster producing
+// the block blockNo is updated. The blocks in (ref, ref+period] belong to the
+// same election period.
+func PeriodRefBlockNo(blockNo types.BlockNo) types.BlockNo {
+	if blockNo == 0 {
+		return 0
+	}
+	return (blockNo - 1) / getElectionPeriod() * getElectionPeriod()
+}
+
+// ElectionPeriod returns the number of blocks in an election period.
+func ElectionPeriod() types.BlockNo {
+	return getElectionPeriod()
+}
+
 func getElectionPeriod() types.BlockNo {
 	return electionPeriod
 }
@@ -0,0 +1,310 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package dpos
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/aergoio/aergo/consensus"
+	"github.com/aergoio/aergo/consensus/impl/dpos/bp"
+	"github.com/aergoio/aergo/internal/common"
+	"github.com/aergoio/aergo/types"
+)
+
+const bpStatPrefix = "dpos.BpStat"
+
+// BpStatJournalKey is the key when the undo journal of the BP statistics is
+// put into the chain DB.
+var BpStatJournalKey = []byte("dpos.BpStatJournal")
+
+type bpIndexer interface {
+	Size() uint16
+	BpIndex2ID(bpIdx bp.Index) (types.PeerID, bool)
+}
+
+// bpCounter is the block production statistics of a BP during an election
+// period.
+type bpCounter struct {
+	Assigned     uint64 // the slots assigned to the BP on the main chain
+	Produced     uint64
+	Missed       uint64
+	Orphaned     uint64 // the blocks rolled back by a reorganization
+	LatencySum   int64  // milli-seconds
+	LatencyCount uint64
+}
+
+func (c *bpCounter) avgLatency() int64 {
+	if c.LatencyCount == 0 {
+		return 0
+	}
+	return c.LatencySum / int64(c.LatencyCount)
+}
+
+// periodStat is a map from BP ID to its counter.
+type periodStat map[string]*bpCounter
+
+func (ps periodStat) counter(id string) *bpCounter {
+	c, exist := ps[id]
+	if !exist {
+		c = &bpCounter{}
+		ps[id] = c
+	}
+	return c
+}
+
+// statEntry records the counters increased by a block connection in order to
+// undo them upon a reorganization.
+type statEntry struct {
+	BlockNo  types.BlockNo
+	Period   types.BlockNo
+	Producer string
+	Missed   map[string]uint64
+}
+
+// bpStats tracks the per-BP block production statistics for each election
+// period. It is not thread safe; it is guarded by the lock of Status.
+type bpStats struct {
+	bpc     bpIndexer
+	cdb     consensus.ChainDB
+	periods map[types.BlockNo]periodStat
+	dirty   map[types.BlockNo]bool
+	journal []*statEntry
+}
+
+func newBpStats(bpc bpIndexer, cdb consensus.ChainDB) *bpStats {
+	bs := &bpStats{
+		bpc:     bpc,
+		cdb:     cdb,
+		periods: make(map[types.BlockNo]periodStat),
+		dirty:   make(map[types.BlockNo]bool),
+	}
+
+	if value := cdb.Get(BpStatJournalKey); len(value) != 0 {
+		if err := common.GobDecode(value, &bs.journal); err != nil {
+			logger.Error().Err(err).Msg("failed to decode BP statistics journal. ignored")
+			bs.journal = nil
+		}
+	}
+
+	return bs
+}
+
+func bpStatKey(ref types.BlockNo) []byte {
+	return []byte(fmt.Sprintf("%v.%v", bpStatPrefix, ref))
+}
+
+// period returns the statistics of the election period corresponding to ref.
+func (bs *bpStats) period(ref types.BlockNo) periodStat {
+	if ps, exist := bs.periods[ref]; exist {
+		return ps
+	}
+
+	ps := bs.load(ref)
+	bs.periods[ref] = ps
+
+	return ps
+}
+
+func (bs *bpStats) load(ref types.BlockNo) periodStat {
+	ps := make(periodStat)
+	if value := bs.cdb.Get(bpStatKey(ref)); len(value) != 0 {
+		if err := common.GobDecode(value, &ps); err != nil {
+			logger.Error().Err(err).Uint64("period", ref).Msg("failed to decode BP statistics. ignored")
+			return make(periodStat)
+		}
+	}
+	return ps
+}
+
+// connect counts the block connected to prev. The slots between them are
+// counted as missed by the BPs which the slots are assigned to.
+func (bs *bpStats) connect(block, prev *types.Block, now time.Time) {
+	if bs == nil {
+		return
+	}
+
+	id, err := block.BPID()
+	if err != nil {
+		return
+	}
+
+	var (
+		ref      = bp.PeriodRefBlockNo(block.BlockNo())
+		ps       = bs.period(ref)
+		curIdx   = types.DposSlotIndex(block.GetHeader().GetTimestamp(), consensus.BlockIntervalSec)
+		producer = id.Pretty()
+		e        = &statEntry{BlockNo: block.BlockNo(), Period: ref, Producer: producer}
+	)
+
+	// The genesis block is not produced in any slot.
+	if prev != nil && prev.BlockNo() > 0 {
+		prevIdx := types.DposSlotIndex(prev.GetHeader().GetTimestamp(), consensus.BlockIntervalSec)
+		e.Missed = bs.missedSlots(prevIdx, curIdx)
+		for mid, n := range e.Missed {
+			c := ps.counter(mid)
+			c.Assigned += n
+			c.Missed += n
+		}
+	}
+
+	c := ps.counter(producer)
+	c.Assigned++
+	c.Produced++
+
+	// The latency is meaningless for the blocks received during a sync.
+	intervalMs := consensus.BlockIntervalSec * 1000
+	latency := now.UnixNano()/int64(time.Millisecond) - (curIdx-1)*intervalMs
+	if latency >= 0 && latency < int64(bs.bpc.Size())*intervalMs {
+		c.LatencySum += latency
+		c.LatencyCount++
+	}
+
+	bs.journal = append(bs.journal, e)
+	bs.dirty[ref] = true
+}
+
+// missedSlots returns the number of slots which each BP missed between the
+// slot prevIdx and curIdx.
+func (bs *bpStats) missedSlots(prevIdx, curIdx int64) map[string]uint64 {
+	size := int64(bs.bpc.Size())
+	gap := curIdx - prevIdx - 1
+	if size == 0 || gap <= 0 {
+		return nil
+	}
+
+	missed := make(map[string]uint64)
+	rounds, rem := gap/size, gap%size
+	for i := int64(0); i < size; i++ {
+		idx := (prevIdx + 1 + i) % size
+		n := uint64(rounds)
+		if i < rem {
+			n++
+		}
+		if n == 0 {
+			continue
+		}
+		if id, exist := bs.bpc.BpIndex2ID(bp.Index(idx)); exist {
+			missed[id.Pretty()] += n
+		}
+	}
+
+	return missed
+}
+
+// rollback undoes the counters of the blocks above blockNo. Their producers
+// are charged with orphaned blocks.
+func (bs *bpStats) rollback(blockNo types.BlockNo) {
+	if bs == nil {
+		return
+	}
+
+	for len(bs.journal) > 0 {
+		e := bs.journal[len(bs.journal)-1]
+		if e.BlockNo <= blockNo {
+			break
+		}
+
+		ps := bs.period(e.Period)
+		c := ps.counter(e.Producer)
+		c.Assigned--
+		c.Produced--
+		c.Orphaned++
+		for mid, n := range e.Missed {
+			c := ps.counter(mid)
+			c.Assigned -= n
+			c.Missed -= n
+		}
+		bs.dirty[e.Period] = true
+
+		bs.journal = bs.journal[:len(bs.journal)-1]
+	}
+}
+
+// trim removes the journal entries which cannot be rolled back any more.
+func (bs *bpStats) trim(libNo types.BlockNo) {
+	if bs == nil {
+		return
+	}
+
+	i := 0
+	for i < len(bs.journal) && bs.journal[i].BlockNo <= libNo {
+		i++
+	}
+	bs.journal = bs.journal[i:]
+}
+
+func (bs *bpStats) save(tx consensus.TxWriter) error {
+	if bs == nil {
+		return nil
+	}
+
+	for ref := range bs.dirty {
+		b, err := common.GobEncode(bs.periods[ref])
+		if err != nil {
+			return err
+		}
+		tx.Set(bpStatKey(ref), b)
+	}
+
+	b, err := common.GobEncode(bs.journal)
+	if err != nil {
+		return err
+	}
+	tx.Set(BpStatJournalKey, b)
+
+	bs.dirty = make(map[types.BlockNo]bool)
+	bs.gc()
+
+	return nil
+}
+
+// gc removes from memory the periods which are not referred by the journal.
+// They are reloaded from DB upon request.
+func (bs *bpStats) gc() {
+	if len(bs.journal) == 0 {
+		return
+	}
+
+	oldest := bs.journal[0].Period
+	for ref := range bs.periods {
+		if ref < oldest {
+			delete(bs.periods, ref)
+		}
+	}
+}
+
+// list returns the statistics of the election period including blockNo.
+func (bs *bpStats) list(blockNo types.BlockNo) *types.BPStatList {
+	ref := bp.PeriodRefBlockNo(blockNo)
+
+	ps, exist := bs.periods[ref]
+	if !exist {
+		ps = bs.load(ref)
+	}
+
+	l := &types.BPStatList{
+		StartNo: ref + 1,
+		EndNo:   ref + bp.ElectionPeriod(),
+		Stats:   make([]*types.BPStat, 0, len(ps)),
+	}
+	for id, c := range ps {
+		l.Stats = append(l.Stats, &types.BPStat{
+			PeerID:     id,
+			Assigned:   c.Assigned,
+			Produced:   c.Produced,
+			Missed:     c.Missed,
+			Orphaned:   c.Orphaned,
+			AvgLatency: c.avgLatency(),
+		})
+	}
+	sort.Slice(l.Stats, func(i, j int) bool {
+		return l.Stats[i].PeerID < l.Stats[j].PeerID
+	})
+
+	return l
+}
@@ -0,0 +1,111 @@
+package dpos
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aergoio/aergo-lib/db"
+	"github.com/aergoio/aergo/consensus"
+	"github.com/aergoio/aergo/consensus/impl/dpos/bp"
+	"github.com/aergoio/aergo/types"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+type testBpIndexer struct {
+	ids []types.PeerID
+}
+
+func (c *testBpIndexer) Size() uint16 {
+	return uint16(len(c.ids))
+}
+
+func (c *testBpIndexer) BpIndex2ID(bpIdx bp.Index) (types.PeerID, bool) {
+	if int(bpIdx) >= len(c.ids) {
+		return types.PeerID(""), false
+	}
+	return c.ids[bpIdx], true
+}
+
+type testStatDB struct {
+	consensus.ChainDB
+	kv map[string][]byte
+}
+
+func (cdb *testStatDB) Get(key []byte) []byte {
+	return cdb.kv[string(key)]
+}
+
+func (cdb *testStatDB) Set(key, value []byte) {
+	cdb.kv[string(key)] = value
+}
+
+func (cdb *testStatDB) NewTx() db.Transaction {
+	return nil
+}
+
+func TestBpStats(t *testing.T) {
+	const clusterSize = 3
+
+	var (
+		keys  = make([]crypto.PrivKey, clusterSize)
+		bpc   = &testBpIndexer{}
+		cdb   = &testStatDB{kv: make(map[string][]byte)}
+		slot0 = time.Now().Unix() / consensus.BlockIntervalSec
+	)
+	for i := range keys {
+		keys[i], _, _ = crypto.GenerateKeyPair(crypto.Secp256k1, 256)
+		id, err := types.IDFromPrivateKey(keys[i])
+		assert.Nil(t, err)
+		bpc.ids = append(bpc.ids, id)
+	}
+
+	// newSlotBlock returns a block produced at the slot index idx by the BP
+	// assigned to it.
+	newSlotBlock := func(no types.BlockNo, idx int64) *types.Block {
+		ts := (idx-1)*consensus.BlockIntervalSec*int64(time.Second) + int64(time.Millisecond)
+		block := types.NewBlock(&types.BlockHeaderInfo{No: no, Ts: ts}, nil, nil, nil, nil, nil)
+		assert.Nil(t, block.Sign(keys[idx%clusterSize]))
+		return block
+	}
+	counter := func(bs *bpStats, idx int64) *bpCounter {
+		return bs.period(0).counter(bpc.ids[idx%clusterSize].Pretty())
+	}
+
+	bs := newBpStats(bpc, cdb)
+
+	b1 := newSlotBlock(1, slot0)
+	bs.connect(b1, nil, time.Now())
+	// The slots slot0+1 and slot0+2 are missed.
+	b2 := newSlotBlock(2, slot0+3)
+	bs.connect(b2, b1, time.Now())
+
+	assert.Equal(t, uint64(2), counter(bs, slot0).Produced)
+	assert.Equal(t, uint64(1), counter(bs, slot0+1).Missed)
+	assert.Equal(t, uint64(1), counter(bs, slot0+2).Missed)
+	assert.Equal(t, uint64(1), counter(bs, slot0+2).Assigned)
+
+	// Reorganization to b1: b2 is orphaned.
+	bs.rollback(1)
+	assert.Equal(t, uint64(1), counter(bs, slot0).Produced)
+	assert.Equal(t, uint64(1), counter(bs, slot0).Orphaned)
+	assert.Equal(t, uint64(0), counter(bs, slot0+1).Missed)
+	assert.Equal(t, uint64(0), counter(bs, slot0+2).Assigned)
+
+	b2 = newSlotBlock(2, slot0+1)
+	bs.connect(b2, b1, time.Now())
+	assert.Equal(t, uint64(1), counter(bs, slot0+1).Produced)
+
+	assert.Nil(t, bs.save(cdb))
+	bs.trim(2)
+	assert.Empty(t, bs.journal)
+
+	// The statistics are restored from DB.
+	l := newBpStats(bpc, cdb).list(2)
+	assert.Equal(t, types.BlockNo(1), l.StartNo)
+	assert.Equal(t, bp.ElectionPeriod(), l.EndNo)
+	assert.Len(t, l.Stats, clusterSize)
+	for _, s := range l.Stats {
+		assert.Equal(t, s.Assigned, s.Produced+s.Missed)
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/aergoio/aergo/consensus"
 	"github.com/aergoio/aergo/consensus/impl/dpos/bp"
 	"github.com/aergoio/aergo/consensus/impl/dpos/slot"
+	"github.com/aergoio/aergo/consensus/signer"
 	"github.com/aergoio/aergo/contract/system"
 	"github.com/aergoio/aergo/p2p/p2pkey"
 	"github.com/aergoio/aergo/pkg/component"
@@ -33,6 +34,10 @@ var (
 	majorityCount           uint16
 	initialBpElectionPeriod types.BlockNo
 
+	// localBpID is the ID of the block producer for which this node
+	// produces blocks. It differs from the node ID if a remote signer is used.
+	localBpID types.PeerID
+
 	lastJob = &lastSlot{}
 )
 
@@ -58,9 +63,10 @@ type DPoS struct {
 	*Status
 	consensus.ChainDB
 	*component.ComponentHub
-	bpc  *bp.Cluster
-	bf   *BlockFactory
-	quit chan interface{}
+	bpc   *bp.Cluster
+	bf    *BlockFactory
+	lease *bpLease
+	quit  chan interface{}
 }
 
 // Status shows DPoS consensus's current status
@@ -98,6 +104,15 @@ func New(cfg *config.Config, hub *component.ComponentHub, cdb consensus.ChainDB,
 
 	chain.DecorateBlockRewardFn(sendVotingReward)
 
+	blockSigner := signer.NewLocal(p2pkey.NodePrivKey())
+	if cfg.Consensus.EnableBp {
+		var err error
+		if blockSigner, err = signer.New(cfg.Consensus, p2pkey.NodePrivKey()); err != nil {
+			return nil, err
+		}
+	}
+	localBpID = signer.ID(blockSigner)
+
 	bpc, err := bp.NewCluster(cdb)
 	if err != nil {
 		return nil, err
@@ -109,21 +124,30 @@ func New(cfg *config.Config, hub *component.ComponentHub, cdb consensus.ChainDB,
 		return nil, err
 	}
 
+	lease, err := newBpLease(cfg.Consensus.BpLease, cfg.Consensus.BpLeaseMissed)
+	if err != nil {
+		return nil, err
+	}
+
 	Init(bpc.Size())
 
 	quitC := make(chan interface{})
 
+	status := NewStatus(bpc, cdb, sdb, cfg.Blockchain.ForceResetHeight)
+	status.stats = newBpStats(bpc, cdb)
+
 	return &DPoS{
-		Status:       NewStatus(bpc, cdb, sdb, cfg.Blockchain.ForceResetHeight),
+		Status:       status,
 		ComponentHub: hub,
 		ChainDB:      cdb,
 		bpc:          bpc,
-		bf:           NewBlockFactory(hub, sdb, quitC, cfg.Hardfork),
+		bf:           NewBlockFactory(hub, sdb, quitC, cfg.Hardfork, blockSigner),
+		lease:        lease,
 		quit:         quitC,
 	}, nil
 }
 
-func sendVotingReward(bState *state.BlockState, dummy []byte) error {
+func sendVotingReward(bState *state.BlockState, dummy []byte, bi *types.BlockHeaderInfo) error {
 	vrSeed := func(stateRoot []byte) int64 {
 		return int64(binary.LittleEndian.Uint64(stateRoot))
 	}
@@ -142,11 +166,15 @@ func sendVotingReward(bState *state.BlockState, dummy []byte) error {
 		return nil
 	}
 
-	reward := system.GetVotingRewardAmount()
+	reward := system.GetVotingRewardFromState(bState)
 	if vaultBalance.Cmp(reward) < 0 {
 		reward = new(big.Int).Set(vaultBalance)
 	}
 
+	if bi != nil && bi.IsFeatureActive(types.FeatureVotingReward) {
+		return distributeVotingReward(bState, vs, reward)
+	}
+
 	addr, err := system.PickVotingRewardWinner(vrSeed(bState.PrevBlockHash()))
 	if err != nil {
 		logger.Debug().Err(err).Msg("no voting reward winner")
@@ -185,6 +213,41 @@ func sendVotingReward(bState *state.BlockState, dummy []byte) error {
 	return nil
 }
 
+// distributeVotingReward moves reward from the vault to the system account,
+// where it's shared by all the voters in proportion to their voting power.
+func distributeVotingReward(bState *state.BlockState, vs *types.State, reward *big.Int) error {
+	sysID := types.ToAccountID([]byte(types.AergoSystem))
+	ss, err := bState.GetAccountState(sysID)
+	if err != nil {
+		return err
+	}
+	scs, err := bState.OpenContractState(sysID, ss)
+	if err != nil {
+		return err
+	}
+
+	if err := system.DistributeVotingReward(scs, reward); err != nil {
+		logger.Debug().Err(err).Msg("skip voting reward")
+		return nil
+	}
+	if err := bState.StageContractState(scs); err != nil {
+		return err
+	}
+
+	ss.Balance = new(big.Int).Add(ss.GetBalanceBigInt(), reward).Bytes()
+	if err := bState.PutState(sysID, ss); err != nil {
+		return err
+	}
+	vs.Balance = new(big.Int).Sub(vs.GetBalanceBigInt(), reward).Bytes()
+	if err := bState.PutState(types.ToAccountID([]byte(types.AergoVault)), vs); err != nil {
+		return err
+	}
+
+	logger.Debug().Str("amount", reward.String()).Msg("voting reward distributed")
+
+	return nil
+}
+
 func InitVPR(sdb *state.StateDB) error {
 	s, err := sdb.OpenContractStateAccount(types.ToAccountID([]byte(types.AergoSystem)))
 	if err != nil {
@@ -246,7 +309,7 @@ func (dpos *DPoS) QuitChan() chan interface{} {
 }
 
 func (dpos *DPoS) bpid() types.PeerID {
-	return p2pkey.NodeID()
+	return localBpID
 }
 
 // VerifyTimestamp checks the validity of the block timestamp.
@@ -326,6 +389,14 @@ func (dpos *DPoS) getBpInfo(now time.Time) *bpInfo {
 		return nil
 	}
 
+	// Only the lease holder produces blocks when the BP runs in the
+	// active/standby mode.
+	round := consensus.BlockInterval * time.Duration(dpos.bpc.Size())
+	if !dpos.lease.acquire(now, round) {
+		lastJob.set(s)
+		return nil
+	}
+
 	return &bpInfo{
 		ChainDB:   dpos.ChainDB,
 		bestBlock: block,
@@ -347,6 +418,14 @@ func (dpos *DPoS) ConsensusInfo() *types.ConsensusInfo {
 
 	})
 
+	type lpbInfo struct {
+		BPID      string
+		Height    types.BlockNo
+		Hash      string
+		Timestamp string
+	}
+	var lpb *lpbInfo
+
 	if dpos.done {
 		var lpbNo types.BlockNo
 
@@ -356,40 +435,66 @@ func (dpos *DPoS) ConsensusInfo() *types.ConsensusInfo {
 
 		if lpbNo > 0 {
 			if block, err := dpos.GetBlockByNo(lpbNo); err == nil {
-				type lpbInfo struct {
-					BPID      string
-					Height    types.BlockNo
-					Hash      string
-					Timestamp string
-				}
-				s := struct {
-					NodeID              string
-					RecentBlockProduced lpbInfo
-				}{
-					NodeID: dpos.bf.ID,
-					RecentBlockProduced: lpbInfo{
-						BPID:      block.BPID2Str(),
-						Height:    lpbNo,
-						Hash:      block.ID(),
-						Timestamp: block.Localtime().String(),
-					},
-				}
-				if m, err := json.Marshal(s); err == nil {
-					ci.Info = string(m)
+				lpb = &lpbInfo{
+					BPID:      block.BPID2Str(),
+					Height:    lpbNo,
+					Hash:      block.ID(),
+					Timestamp: block.Localtime().String(),
 				}
 			}
 		}
 	}
 
+	// Role is given only in the active/standby mode.
+	if role := dpos.lease.getRole(); lpb != nil || len(role) > 0 {
+		s := struct {
+			NodeID              string
+			Role                string   `json:",omitempty"`
+			RecentBlockProduced *lpbInfo `json:",omitempty"`
+		}{
+			NodeID:              dpos.bf.ID,
+			Role:                role,
+			RecentBlockProduced: lpb,
+		}
+		if m, err := json.Marshal(s); err == nil {
+			ci.Info = string(m)
+		}
+	}
+
 	return ci
 }
 
+// BPStats returns the block production statistics of each BP for the election
+// period including blockNo. The current period is used if blockNo is 0.
+func (dpos *DPoS) BPStats(blockNo types.BlockNo) (*types.BPStatList, error) {
+	dpos.RLock()
+	defer dpos.RUnlock()
+
+	if blockNo == 0 {
+		if dpos.bestBlock != nil {
+			blockNo = dpos.bestBlock.BlockNo()
+		} else if best, err := dpos.GetBestBlock(); err == nil {
+			blockNo = best.BlockNo()
+		} else {
+			return nil, err
+		}
+	}
+
+	return dpos.stats.list(blockNo), nil
+}
+
 var dummyRaft consensus.DummyRaftAccessor
 
 func (dpos *DPoS) RaftAccessor() consensus.AergoRaftAccessor {
 	return &dummyRaft
 }
 
+var dummyBFT consensus.DummyBFTAccessor
+
+func (dpos *DPoS) BFTAccessor() consensus.BFTAccessor {
+	return &dummyBFT
+}
+
 func isBpTiming(block *types.Block, s *slot.Slot) bool {
 	blockSlot := slot.NewFromUnixNano(block.Header.Timestamp)
 	// The block corresponding to the current slot has already been generated.
@@ -0,0 +1,161 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package dpos
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	roleActive  = "active"
+	roleStandby = "standby"
+)
+
+// leaseInfo is the content of the lease file.
+type leaseInfo struct {
+	Holder string
+	Expire int64 // UNIX time in ns
+}
+
+// bpLease coordinates the block production of the BP instances sharing the
+// same BP identity (active/standby). Only the holder of the lease produces
+// blocks. The active instance renews the lease at each slot of the BP. The
+// standby one takes over when the lease expires, that is, the active instance
+// has missed the configured number of the BP slots.
+type bpLease struct {
+	sync.RWMutex
+	path      string
+	holder    string
+	maxMissed int64
+	role      string
+	current   leaseInfo
+}
+
+var errNoFileLock = errors.New("bplease is not supported on this platform: no file lock")
+
+func newBpLease(path string, maxMissed uint) (*bpLease, error) {
+	if len(path) == 0 {
+		return nil, nil
+	}
+	if !fileLockSupported {
+		return nil, errNoFileLock
+	}
+
+	if maxMissed == 0 {
+		maxMissed = 1
+	}
+
+	host, _ := os.Hostname()
+	return &bpLease{
+		path:      path,
+		holder:    fmt.Sprintf("%s/%d/%x", host, os.Getpid(), time.Now().UnixNano()),
+		maxMissed: int64(maxMissed),
+		role:      roleStandby,
+	}, nil
+}
+
+// acquire reports whether this instance holds the lease at now. The lease is
+// acquired or renewed if it is held by this instance or expired. round is the
+// interval between the slots of the BP.
+func (l *bpLease) acquire(now time.Time, round time.Duration) bool {
+	if l == nil {
+		return true
+	}
+
+	l.Lock()
+	defer l.Unlock()
+
+	acquired, err := l.tryAcquire(now, round)
+	if err != nil {
+		logger.Error().Err(err).Str("path", l.path).Msg("failed to access BP lease. skip block production")
+		return false
+	}
+
+	role := roleStandby
+	if acquired {
+		role = roleActive
+	}
+	if role != l.role {
+		logger.Info().Str("role", role).Str("holder", l.current.Holder).Msg("BP role changed")
+		l.role = role
+	}
+
+	return acquired
+}
+
+func (l *bpLease) tryAcquire(now time.Time, round time.Duration) (bool, error) {
+	f, err := os.OpenFile(l.path, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	if err := lockFile(f); err != nil {
+		return false, err
+	}
+	defer unlockFile(f)
+
+	var cur leaseInfo
+	if data, err := readAll(f); err != nil {
+		return false, err
+	} else if len(data) != 0 {
+		if err := json.Unmarshal(data, &cur); err != nil {
+			return false, fmt.Errorf("invalid lease file: %s", err.Error())
+		}
+	}
+
+	if cur.Holder != l.holder && now.UnixNano() < cur.Expire {
+		l.current = cur
+		return false, nil
+	}
+
+	next := leaseInfo{
+		Holder: l.holder,
+		Expire: now.Add(time.Duration(l.maxMissed) * round).UnixNano(),
+	}
+	data, err := json.Marshal(next)
+	if err != nil {
+		return false, err
+	}
+	if err := f.Truncate(0); err != nil {
+		return false, err
+	}
+	if _, err := f.WriteAt(data, 0); err != nil {
+		return false, err
+	}
+	if err := f.Sync(); err != nil {
+		return false, err
+	}
+	l.current = next
+
+	return true, nil
+}
+
+func readAll(f *os.File) ([]byte, error) {
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	return ioutil.ReadAll(f)
+}
+
+// getRole returns the current role of this instance. It is empty if the
+// hot-standby is disabled.
+func (l *bpLease) getRole() string {
+	if l == nil {
+		return ""
+	}
+
+	l.RLock()
+	defer l.RUnlock()
+	return l.role
+}
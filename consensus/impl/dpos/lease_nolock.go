@@ -0,0 +1,17 @@
+// +build windows solaris
+
+package dpos
+
+import "os"
+
+// fileLockSupported is false since the advisory file lock isn't available on
+// this platform. The lease file can't be shared safely by the BP instances.
+const fileLockSupported = false
+
+func lockFile(f *os.File) error {
+	return nil
+}
+
+func unlockFile(f *os.File) error {
+	return nil
+}
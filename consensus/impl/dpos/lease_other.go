@@ -0,0 +1,18 @@
+// +build !windows,!solaris
+
+package dpos
+
+import (
+	"os"
+	"syscall"
+)
+
+const fileLockSupported = true
+
+func lockFile(f *os.File) error {
+	return syscall.Flock(int(f.Fd()), syscall.LOCK_EX)
+}
+
+func unlockFile(f *os.File) error {
+	return syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+}
@@ -0,0 +1,51 @@
+package dpos
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBpLease(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bplease")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	const round = 3 * time.Second
+	path := filepath.Join(dir, "bp.lease")
+	active, err := newBpLease(path, 2)
+	assert.Nil(t, err)
+	standby, err := newBpLease(path, 2)
+	assert.Nil(t, err)
+	now := time.Now()
+
+	assert.Equal(t, roleStandby, active.getRole())
+	assert.True(t, active.acquire(now, round))
+	assert.Equal(t, roleActive, active.getRole())
+
+	// The standby one can't produce while the active one renews the lease.
+	assert.False(t, standby.acquire(now.Add(round), round))
+	assert.True(t, active.acquire(now.Add(round), round))
+	assert.False(t, standby.acquire(now.Add(2*round), round))
+	assert.Equal(t, roleStandby, standby.getRole())
+
+	// The active one misses 2 slots of the BP.
+	assert.True(t, standby.acquire(now.Add(3*round), round))
+	assert.Equal(t, roleActive, standby.getRole())
+
+	// The old active one turns into standby.
+	assert.False(t, active.acquire(now.Add(4*round), round))
+	assert.Equal(t, roleStandby, active.getRole())
+
+	// No lease
+	var none *bpLease
+	assert.True(t, none.acquire(now, round))
+	assert.Equal(t, "", none.getRole())
+	none, err = newBpLease("", 2)
+	assert.Nil(t, err)
+	assert.Nil(t, none)
+}
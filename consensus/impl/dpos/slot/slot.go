@@ -45,6 +45,11 @@ func (s *Slot) UnixNano() int64 {
 	return s.timeNs
 }
 
+// Index returns the index of s. It is the same as types.DposSlotIndex.
+func (s *Slot) Index() int64 {
+	return s.nextIndex
+}
+
 // Time returns a Slot corresponting to the given time.
 func Time(t time.Time) *Slot {
 	return fromUnixNs(t.UnixNano())
@@ -3,9 +3,11 @@ package dpos
 import (
 	"encoding/json"
 	"sync"
+	"time"
 
 	"github.com/aergoio/aergo/consensus"
 	"github.com/aergoio/aergo/consensus/impl/dpos/bp"
+	"github.com/aergoio/aergo/pkg/metrics"
 	"github.com/aergoio/aergo/state"
 	"github.com/aergoio/aergo/types"
 )
@@ -19,6 +21,7 @@ type Status struct {
 	bestBlock *types.Block
 	libState  *libStatus
 	bps       *bp.Snapshots
+	stats     *bpStats
 	sdb       *state.ChainStateDB
 }
 
@@ -69,6 +72,7 @@ func (s *Status) Update(block *types.Block) {
 	curBestID := s.bestBlock.ID()
 	if curBestID == block.PrevID() {
 		s.libState.addConfirmInfo(block)
+		s.stats.connect(block, s.bestBlock, time.Now())
 
 		logger.Debug().
 			Str("block hash", block.ID()).
@@ -96,6 +100,10 @@ func (s *Status) Update(block *types.Block) {
 			logger.Fatal().Err(err).Msg("failed to rollback DPoS status")
 		}
 
+		// Rollback BP statistics. The blocks above the branch root are
+		// orphaned.
+		s.stats.rollback(block.BlockNo())
+
 		// Rollback BP list. -- BP list is alos affected by a fork.
 		s.bps.UpdateCluster(block.BlockNo())
 
@@ -110,6 +118,8 @@ func (s *Status) Update(block *types.Block) {
 	}
 
 	s.libState.gc()
+	s.stats.trim(s.libState.libNo())
+	metrics.LibHeight.Set(float64(s.libState.libNo()))
 
 	s.bestBlock = block
 }
@@ -168,6 +178,10 @@ func (s *Status) Save(tx consensus.TxWriter) error {
 		return err
 	}
 
+	if err := s.stats.save(tx); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -9,6 +9,7 @@ import (
 	"github.com/aergoio/aergo/chain"
 	"github.com/aergoio/aergo/config"
 	"github.com/aergoio/aergo/consensus"
+	"github.com/aergoio/aergo/consensus/impl/bft"
 	"github.com/aergoio/aergo/consensus/impl/dpos"
 	"github.com/aergoio/aergo/consensus/impl/raftv2"
 	"github.com/aergoio/aergo/consensus/impl/sbp"
@@ -56,6 +57,7 @@ func newConsensus(cfg *config.Config, hub *component.ComponentHub,
 		dpos.GetName():   dpos.GetConstructor(cfg, hub, cdb, sdb),              // DPoS
 		sbp.GetName():    sbp.GetConstructor(cfg, hub, cdb, sdb),               // Simple BP
 		raftv2.GetName(): raftv2.GetConstructor(cfg, hub, cs.WalDB(), sdb, pa), // Raft BP
+		bft.GetName():    bft.GetConstructor(cfg, hub, cdb, sdb),               // BFT
 	}
 
 	consensus.SetCurConsensus(cdb.GetGenesisInfo().ConsensusType())
@@ -71,6 +73,7 @@ func ValidateGenesis(genesis *types.Genesis) error {
 		dpos.GetName():   dpos.ValidateGenesis,   // DPoS
 		sbp.GetName():    sbp.ValidateGenesis,    // Simple BP
 		raftv2.GetName(): raftv2.ValidateGenesis, // Raft BP
+		bft.GetName():    bft.ValidateGenesis,    // BFT
 	}
 
 	return validators[name](genesis)
@@ -2,6 +2,7 @@ package raftv2
 
 import (
 	"bytes"
+	"context"
 	"encoding/binary"
 	"encoding/json"
 	"errors"
@@ -16,6 +17,7 @@ import (
 	"github.com/aergoio/aergo/config"
 	"github.com/aergoio/aergo/consensus"
 	"github.com/aergoio/aergo/consensus/chain"
+	"github.com/aergoio/aergo/consensus/signer"
 	"github.com/aergoio/aergo/contract"
 	"github.com/aergoio/aergo/contract/system"
 	"github.com/aergoio/aergo/internal/enc"
@@ -24,7 +26,6 @@ import (
 	"github.com/aergoio/aergo/pkg/component"
 	"github.com/aergoio/aergo/state"
 	"github.com/aergoio/aergo/types"
-	"github.com/libp2p/go-libp2p-core/crypto"
 )
 
 var (
@@ -129,7 +130,7 @@ type BlockFactory struct {
 
 	maxBlockBodySize uint32
 	ID               string
-	privKey          crypto.PrivKey
+	signer           types.BlockSigner
 	txOp             chain.TxOp
 	sdb              *state.ChainStateDB
 	prevBlock        *types.Block // best block of last job
@@ -167,7 +168,7 @@ func New(cfg *config.Config, hub *component.ComponentHub, cdb consensus.ChainWAL
 		quit:             make(chan interface{}),
 		maxBlockBodySize: chain.MaxBlockBodySize(),
 		ID:               p2pkey.NodeSID(),
-		privKey:          p2pkey.NodePrivKey(),
+		signer:           signer.NewLocal(p2pkey.NodePrivKey()),
 		sdb:              sdb,
 		bv:               cfg.Hardfork,
 	}
@@ -175,6 +176,11 @@ func New(cfg *config.Config, hub *component.ComponentHub, cdb consensus.ChainWAL
 	if cfg.Consensus.EnableBp {
 		Init(cfg.Consensus.Raft)
 
+		var err error
+		if bf.signer, err = signer.New(cfg.Consensus, p2pkey.NodePrivKey()); err != nil {
+			return bf, err
+		}
+
 		if err := bf.newRaftServer(cfg); err != nil {
 			logger.Error().Err(err).Msg("failed to init raft server")
 			return bf, err
@@ -524,7 +530,8 @@ func (bf *BlockFactory) generateBlock(work *Work) (*types.Block, *state.BlockSta
 		return nil, nil, err
 	}
 
-	if err = block.Sign(bf.privKey); err != nil {
+	// raft has no slot. The remote signer checks only the block height.
+	if err = block.SignWith(bf.signer, 0); err != nil {
 		logger.Error().Err(err).Msg("failed to sign in block")
 		return nil, nil, err
 	}
@@ -587,6 +594,12 @@ func (bf *BlockFactory) connect(block *types.Block) error {
 		return err
 	}
 
+	if blockState != nil && blockState.CCProposal != nil && blockState.CCProposal.Transferee != consensus.InvalidMemberID {
+		if _, err := bf.bpc.TransferLeadership(blockState.CCProposal.Transferee, true); err != nil {
+			logger.Error().Err(err).Msg("failed to transfer leadership")
+		}
+	}
+
 	return nil
 }
 
@@ -698,6 +711,13 @@ func (bf *BlockFactory) ConfChange(req *types.MembershipChange) (*consensus.Memb
 
 	req.RequestID = binary.LittleEndian.Uint64(best.GetHash()[0:8])
 
+	if req.Type == types.MembershipChangeType_TRANSFER_LEADER {
+		if member, err = bf.bpc.TransferLeadership(req.GetAttr().GetID(), false); err != nil {
+			return nil, ErrorMembershipChange{err}
+		}
+		return member, nil
+	}
+
 	if member, err = bf.bpc.ChangeMembership(req, false); err != nil {
 		return nil, ErrorMembershipChange{err}
 	}
@@ -709,6 +729,12 @@ func (bf *BlockFactory) RaftAccessor() consensus.AergoRaftAccessor {
 	return bf.rhw
 }
 
+var dummyBFT consensus.DummyBFTAccessor
+
+func (bf *BlockFactory) BFTAccessor() consensus.BFTAccessor {
+	return &dummyBFT
+}
+
 func (bf *BlockFactory) MakeConfChangeProposal(req *types.MembershipChange) (*consensus.ConfChangePropose, error) {
 	var (
 		proposal *consensus.ConfChangePropose
@@ -731,6 +757,18 @@ func (bf *BlockFactory) MakeConfChangeProposal(req *types.MembershipChange) (*co
 
 	logger.Info().Str("request", req.ToString()).Msg("make proposal of cluster conf change")
 
+	// leadership is transferred after the block including the request is connected
+	if req.Type == types.MembershipChangeType_TRANSFER_LEADER {
+		var transferee *consensus.Member
+
+		if transferee, err = cl.getTransferee(req.GetAttr().GetID()); err != nil {
+			logger.Error().Err(err).Uint64("requestID", req.GetRequestID()).Msg("failed to make proposal for leadership transfer")
+			return nil, err
+		}
+
+		return &consensus.ConfChangePropose{Ctx: context.Background(), Transferee: transferee.ID}, nil
+	}
+
 	if proposal, err = cl.makeProposal(req, true); err != nil {
 		logger.Error().Uint64("requestID", req.GetRequestID()).Msg("failed to make proposal for conf change")
 		return nil, err
@@ -814,6 +852,11 @@ func (bf *BlockFactory) ConfChangeInfo(requestID uint64) (*types.ConfChangeProgr
 	return bf.GetConfChangeProgress(requestID)
 }
 
+// BPStats is not supported by raft consensus
+func (bf *BlockFactory) BPStats(blockNo types.BlockNo) (*types.BPStatList, error) {
+	return nil, consensus.ErrNotSupportedMethod
+}
+
 func (bf *BlockFactory) checkBpTimeout() error {
 	select {
 	case <-bf.bpTimeoutC:
@@ -862,7 +905,7 @@ func (rop *RaftOperator) propose(block *types.Block, blockState *state.BlockStat
 
 	logger.Info().Msg("block proposed by blockfactory")
 
-	if blockState.CCProposal != nil {
+	if blockState.CCProposal != nil && blockState.CCProposal.Cc != nil {
 		if err := rop.ProposeConfChange(blockState.CCProposal); err != nil {
 			logger.Error().Err(err).Msg("failed to change membership")
 			return ErrorMembershipChange{err}
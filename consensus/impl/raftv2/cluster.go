@@ -23,7 +23,8 @@ import (
 )
 
 var (
-	MaxConfChangeTimeOut = time.Second * 100
+	MaxConfChangeTimeOut     = time.Second * 100
+	MaxTransferLeaderTimeOut = time.Second * 10
 
 	ErrClusterHasNoMember   = errors.New("cluster has no member")
 	ErrNotExistRaftMember   = errors.New("not exist member of raft cluster")
@@ -40,6 +41,11 @@ var (
 	ErrNotExitRaftProgress      = errors.New("progress of this node doesn't exist")
 	ErrUnhealtyNodeExist        = errors.New("can't add some node if unhealthy nodes exist")
 	ErrRemoveHealthyNode        = errors.New("remove of a healthy node may cause the cluster to hang")
+	ErrNotRaftLearner           = errors.New("member is not a learner")
+	ErrLearnerNotCaughtUp       = errors.New("learner has not caught up with the leader. try again later")
+	ErrInvalidTransferee        = errors.New("leadership can be transferred only to a voting member other than the leader")
+	ErrNoTransferee             = errors.New("no healthy voting member to transfer leadership")
+	ErrTransferLeaderTimeOut    = errors.New("timeouted leadership transfer")
 )
 
 const (
@@ -327,12 +333,20 @@ func (cl *Cluster) ResetMembers() {
 func (cl *Cluster) isMatch(confstate *raftpb.ConfState) bool {
 	var matched int
 
-	if len(cl.AppliedMembers().MapByID) != len(confstate.Nodes) {
+	if len(cl.AppliedMembers().MapByID) != len(confstate.Nodes)+len(confstate.Learners) {
 		return false
 	}
 
 	for _, confID := range confstate.Nodes {
-		if _, ok := cl.AppliedMembers().MapByID[confID]; !ok {
+		if m, ok := cl.AppliedMembers().MapByID[confID]; !ok || m.IsLearner {
+			return false
+		}
+
+		matched++
+	}
+
+	for _, confID := range confstate.Learners {
+		if m, ok := cl.AppliedMembers().MapByID[confID]; !ok || !m.IsLearner {
 			return false
 		}
 
@@ -452,6 +466,35 @@ func (cl *Cluster) addMember(member *consensus.Member, applied bool) error {
 	return nil
 }
 
+// isLearner returns true if the applied member of id is a learner.
+func (cl *Cluster) isLearner(id uint64) bool {
+	cl.Lock()
+	defer cl.Unlock()
+
+	m := cl.AppliedMembers().getMember(id)
+	return m != nil && m.IsLearner
+}
+
+// promoteMember changes the learner to a voting member.
+func (cl *Cluster) promoteMember(member *consensus.Member) error {
+	logger.Info().Str("member", member.ToString()).Msg("member promote")
+
+	cl.Lock()
+	defer cl.Unlock()
+
+	m := cl.AppliedMembers().getMember(member.ID)
+	if m == nil {
+		return ErrNotExistRaftMember
+	}
+	m.IsLearner = false
+
+	if m = cl.members.getMember(member.ID); m != nil {
+		m.IsLearner = false
+	}
+
+	return nil
+}
+
 func (cl *Cluster) removeMember(member *consensus.Member) error {
 	logger.Info().Str("member", member.ToString()).Msg("member remove")
 
@@ -801,10 +844,11 @@ func (cl *Cluster) toConsensusInfo() *types.ConsensusInfo {
 	}
 
 	type PeerInfo struct {
-		Name   string
-		RaftID string
-		PeerID string
-		Addr   string
+		Name    string
+		RaftID  string
+		PeerID  string
+		Addr    string
+		Learner bool `json:",omitempty"`
 	}
 
 	b, err := json.Marshal(cl.getRaftInfo(true))
@@ -824,7 +868,7 @@ func (cl *Cluster) toConsensusInfo() *types.ConsensusInfo {
 		bps := make([]string, cl.Size)
 
 		for id, m := range cl.Members().MapByID {
-			bp := &PeerInfo{Name: m.Name, RaftID: EtcdIDToString(m.ID), PeerID: m.GetPeerID().Pretty(), Addr: m.Address}
+			bp := &PeerInfo{Name: m.Name, RaftID: EtcdIDToString(m.ID), PeerID: m.GetPeerID().Pretty(), Addr: m.Address, Learner: m.IsLearner}
 			b, err = json.Marshal(bp)
 			if err != nil {
 				logger.Error().Err(err).Str("raftid", EtcdIDToString(id)).Msg("failed to marshalEntryData raft consensus bp")
@@ -848,6 +892,37 @@ func (cl *Cluster) NewMemberFromAddReq(req *types.MembershipChange) (*consensus.
 	return consensus.NewMember(req.Attr.Name, req.Attr.Address, types.PeerID(req.Attr.PeerID), cl.chainID, time.Now().UnixNano()), nil
 }
 
+func (cl *Cluster) NewMemberFromAddLearnerReq(req *types.MembershipChange) (*consensus.Member, error) {
+	member, err := cl.NewMemberFromAddReq(req)
+	if err != nil {
+		return nil, err
+	}
+
+	member.IsLearner = true
+
+	return member, nil
+}
+
+// NewMemberFromPromoteReq returns the voting member to which the learner of the request is promoted.
+func (cl *Cluster) NewMemberFromPromoteReq(req *types.MembershipChange) (*consensus.Member, error) {
+	if req.Attr.ID == consensus.InvalidMemberID {
+		return nil, consensus.ErrInvalidMemberID
+	}
+
+	learner := cl.AppliedMembers().getMember(req.Attr.ID)
+	if learner == nil {
+		return nil, ErrNotExistRaftMember
+	}
+	if !learner.IsLearner {
+		return nil, ErrNotRaftLearner
+	}
+
+	member := consensus.NewMember(learner.Name, learner.Address, learner.GetPeerID(), cl.chainID, 0)
+	member.SetMemberID(learner.ID)
+
+	return member, nil
+}
+
 func (cl *Cluster) NewMemberFromRemoveReq(req *types.MembershipChange) (*consensus.Member, error) {
 	if req.Attr.ID == consensus.InvalidMemberID {
 		return nil, consensus.ErrInvalidMemberID
@@ -916,6 +991,12 @@ func (cl *Cluster) makeProposal(req *types.MembershipChange, nowait bool) (*cons
 	case types.MembershipChangeType_REMOVE_MEMBER:
 		member, err = cl.NewMemberFromRemoveReq(req)
 
+	case types.MembershipChangeType_ADD_LEARNER:
+		member, err = cl.NewMemberFromAddLearnerReq(req)
+
+	case types.MembershipChangeType_PROMOTE_LEARNER:
+		member, err = cl.NewMemberFromPromoteReq(req)
+
 	default:
 		return nil, ErrInvalidMembershipReqType
 	}
@@ -998,6 +1079,108 @@ func (cl *Cluster) recvConfChangeReply(replyC chan *consensus.ConfChangeReply) (
 	}
 }
 
+// TransferLeadership transfers the leadership of this node to the member of the given ID. If id is
+// InvalidMemberID, the most up-to-date healthy voting member is chosen. Unless nowait is set, it waits until
+// the transferee becomes the leader.
+func (cl *Cluster) TransferLeadership(id uint64, nowait bool) (*consensus.Member, error) {
+	var (
+		transferee *consensus.Member
+		err        error
+	)
+
+	cl.Lock()
+	transferee, err = cl.getTransferee(id)
+	cl.Unlock()
+
+	if err != nil {
+		logger.Error().Err(err).Str("id", EtcdIDToString(id)).Msg("failed to get transferee of leadership")
+		return nil, err
+	}
+
+	if err = cl.transferLeadership(transferee.ID); err != nil {
+		return nil, err
+	}
+
+	if nowait {
+		return transferee, nil
+	}
+
+	timeout := time.After(MaxTransferLeaderTimeOut)
+	ticker := time.NewTicker(time.Millisecond * 100)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if cl.rs.GetLeader() == transferee.ID {
+				logger.Info().Str("leader", transferee.ToString()).Msg("leadership transferred")
+				return transferee, nil
+			}
+		case <-timeout:
+			logger.Warn().Str("transferee", transferee.ToString()).Msg("leadership transfer is time-out")
+			return nil, ErrTransferLeaderTimeOut
+		}
+	}
+}
+
+func (cl *Cluster) transferLeadership(transferee uint64) error {
+	node := cl.rs.getNodeSync()
+	if node == nil {
+		return ErrRaftStatusEmpty
+	}
+
+	logger.Info().Str("from", EtcdIDToString(cl.rs.ID())).Str("to", EtcdIDToString(transferee)).Msg("transfer leadership")
+
+	ctx, cancel := context.WithTimeout(context.Background(), MaxTransferLeaderTimeOut)
+	defer cancel()
+
+	node.TransferLeadership(ctx, cl.rs.ID(), transferee)
+
+	return nil
+}
+
+// getTransferee returns the voting member to which the leadership of this node can be transferred.
+func (cl *Cluster) getTransferee(id uint64) (*consensus.Member, error) {
+	if !cl.rs.IsLeader() {
+		return nil, ErrNotRaftLeader
+	}
+
+	cp, err := cl.rs.GetClusterProgress()
+	if err != nil {
+		return nil, err
+	}
+
+	if id == consensus.InvalidMemberID {
+		var best *MemberProgress
+
+		for _, mp := range cp.MemberProgresses {
+			if mp.MemberID == cl.NodeID() || mp.IsLearner || mp.Status != MemberProgressStateHealthy {
+				continue
+			}
+
+			if best == nil || mp.progress.Match > best.progress.Match {
+				best = mp
+			}
+		}
+
+		if best == nil {
+			return nil, ErrNoTransferee
+		}
+		id = best.MemberID
+	}
+
+	m := cl.AppliedMembers().getMember(id)
+	if m == nil {
+		return nil, ErrNotExistRaftMember
+	}
+
+	if m.IsLearner || m.ID == cl.NodeID() {
+		return nil, ErrInvalidTransferee
+	}
+
+	return m, nil
+}
+
 func (cl *Cluster) AfterConfChange(cc *raftpb.ConfChange, member *consensus.Member, err error) {
 	cl.Lock()
 	defer cl.Unlock()
@@ -1073,7 +1256,7 @@ func (cl *Cluster) isEnableChangeMembership(cc *raftpb.ConfChange) error {
 		var healthy int
 
 		for _, mp := range cp.MemberProgresses {
-			if mp.Status == MemberProgressStateHealthy {
+			if !mp.IsLearner && mp.Status == MemberProgressStateHealthy {
 				healthy++
 			}
 		}
@@ -1098,12 +1281,25 @@ func (cl *Cluster) isEnableChangeMembership(cc *raftpb.ConfChange) error {
 	switch {
 	case cc.Type == raftpb.ConfChangeAddNode:
 		for _, mp := range cp.MemberProgresses {
-			if mp.Status != MemberProgressStateHealthy {
+			if mp.Status == MemberProgressStateHealthy {
+				continue
+			}
+
+			// the learner to promote must have caught up with the leader
+			if mp.MemberID == cc.NodeID {
+				logger.Error().Str("learner", mp.ToString()).Msg("learner is not ready to be promoted")
+				return ErrLearnerNotCaughtUp
+			}
+
+			// learners don't affect the quorum
+			if !mp.IsLearner {
 				logger.Error().Uint64("slowgap", MaxSlowNodeGap).Str("unhealthy member", mp.ToString()).Msg("exist unhealthy member in cluster. If you want add some node, fix the unhealthy node and try again")
 				return ErrUnhealtyNodeExist
 			}
 		}
 
+		return nil
+	case cc.Type == raftpb.ConfChangeAddLearnerNode:
 		return nil
 	case cc.Type == raftpb.ConfChangeRemoveNode:
 		mp, ok := cp.MemberProgresses[cc.NodeID]
@@ -1112,6 +1308,10 @@ func (cl *Cluster) isEnableChangeMembership(cc *raftpb.ConfChange) error {
 			return ErrNotExitRaftProgress
 		}
 
+		if mp.IsLearner {
+			return nil
+		}
+
 		if mp.Status != MemberProgressStateHealthy {
 			logger.Warn().Uint64("memberid", mp.MemberID).Msg("try to remove slow node")
 			return nil
@@ -1149,7 +1349,17 @@ func (cl *Cluster) validateChangeMembership(cc *raftpb.ConfChange, member *conse
 	}
 
 	switch cc.Type {
-	case raftpb.ConfChangeAddNode:
+	case raftpb.ConfChangeAddNode, raftpb.ConfChangeAddLearnerNode:
+		if m := appliedMembers.getMember(member.ID); m != nil && m.IsLearner && cc.Type == raftpb.ConfChangeAddNode {
+			if !m.IsCompatible(member) {
+				return ErrInvalidMember
+			}
+
+			// promotion of learner
+			member.IsLearner = false
+			return nil
+		}
+
 		if !member.IsValid() {
 			logger.Error().Str("member", member.ToString()).Msg("member has invalid fields")
 			return ErrInvalidMember
@@ -1186,6 +1396,10 @@ func (cl *Cluster) makeConfChange(reqID uint64, reqType types.MembershipChangeTy
 		changeType = raftpb.ConfChangeAddNode
 	case types.MembershipChangeType_REMOVE_MEMBER:
 		changeType = raftpb.ConfChangeRemoveNode
+	case types.MembershipChangeType_ADD_LEARNER:
+		changeType = raftpb.ConfChangeAddLearnerNode
+	case types.MembershipChangeType_PROMOTE_LEARNER:
+		changeType = raftpb.ConfChangeAddNode
 	default:
 		return nil, ErrInvalidMembershipReqType
 	}
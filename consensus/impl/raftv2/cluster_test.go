@@ -7,6 +7,7 @@ import (
 	"github.com/aergoio/aergo/config"
 	"github.com/aergoio/aergo/consensus"
 	"github.com/aergoio/aergo/types"
+	"github.com/aergoio/etcd/raft/raftpb"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -208,3 +209,48 @@ func TestClusterEqual(t *testing.T) {
 
 	assert.False(t, cl.isAllMembersEqual(testMbrs, nil))
 }
+
+func TestClusterLearner(t *testing.T) {
+	cl := NewCluster([]byte("test"), nil, "testm1", testPeerIDs[0], 0, nil)
+	for _, m := range testMbrs[0:2] {
+		err := cl.addMember(m, true)
+		assert.NoError(t, err)
+	}
+
+	req := &types.MembershipChange{
+		Type: types.MembershipChangeType_ADD_LEARNER,
+		Attr: &types.MemberAttr{Name: "testm3", Address: "/ip4/127.0.0.1/tcp/13003", PeerID: []byte(testPeerIDs[2])},
+	}
+	proposal, err := cl.makeProposal(req, true)
+	assert.NoError(t, err)
+	assert.Equal(t, raftpb.ConfChangeAddLearnerNode, proposal.Cc.Type)
+
+	// apply learner
+	learner := *testMbrs[2]
+	learner.IsLearner = true
+	assert.NoError(t, cl.addMember(&learner, true))
+	assert.True(t, cl.isLearner(learner.ID))
+	assert.True(t, cl.isMatch(&raftpb.ConfState{Nodes: []uint64{1, 2}, Learners: []uint64{3}}))
+	assert.False(t, cl.isMatch(&raftpb.ConfState{Nodes: []uint64{1, 2, 3}}))
+
+	// only learner can be promoted
+	req = &types.MembershipChange{Type: types.MembershipChangeType_PROMOTE_LEARNER, Attr: &types.MemberAttr{ID: 2}}
+	_, err = cl.makeProposal(req, true)
+	assert.Equal(t, ErrNotRaftLearner, err)
+
+	req = &types.MembershipChange{Type: types.MembershipChangeType_PROMOTE_LEARNER, Attr: &types.MemberAttr{ID: learner.ID}}
+	proposal, err = cl.makeProposal(req, true)
+	assert.NoError(t, err)
+	assert.Equal(t, raftpb.ConfChangeAddNode, proposal.Cc.Type)
+	assert.Equal(t, learner.ID, proposal.Cc.NodeID)
+
+	var member consensus.Member
+	assert.NoError(t, json.Unmarshal(proposal.Cc.Context, &member))
+	assert.False(t, member.IsLearner)
+	assert.Equal(t, learner.Name, member.Name)
+
+	// apply promotion
+	assert.NoError(t, cl.promoteMember(&member))
+	assert.False(t, cl.isLearner(learner.ID))
+	assert.True(t, cl.isMatch(&raftpb.ConfState{Nodes: []uint64{1, 2, 3}}))
+}
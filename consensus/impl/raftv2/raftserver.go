@@ -1149,7 +1149,14 @@ func (rs *raftServer) applyConfChange(ent *raftpb.Entry) bool {
 	logger.Info().Uint64("requestID", cc.ID).Str("type", cc.Type.String()).Str("member", member.ToString()).Msg("publish conf change entry")
 
 	switch cc.Type {
-	case raftpb.ConfChangeAddNode:
+	case raftpb.ConfChangeAddNode, raftpb.ConfChangeAddLearnerNode:
+		if cc.Type == raftpb.ConfChangeAddNode && rs.cluster.isLearner(member.ID) {
+			if err := rs.cluster.promoteMember(member); err != nil {
+				logger.Fatal().Str("member", member.ToString()).Msg("failed to promote learner")
+			}
+			break
+		}
+
 		if err := rs.cluster.addMember(member, true); err != nil {
 			logger.Fatal().Str("member", member.ToString()).Msg("failed to add member to cluster")
 		}
@@ -1382,18 +1389,20 @@ type MemberProgress struct {
 	MemberID      uint64
 	Status        MemberProgressState
 	LogDifference uint64
+	IsLearner     bool
 
 	progress raftlib.Progress
 }
 
 type ClusterProgress struct {
-	N int
+	N        int // number of voting members
+	Learners int
 
 	MemberProgresses map[uint64]*MemberProgress
 }
 
 func (cp *ClusterProgress) ToString() string {
-	buf := fmt.Sprintf("{ Total: %d, Members[", cp.N)
+	buf := fmt.Sprintf("{ Total: %d, Learners: %d, Members[", cp.N, cp.Learners)
 
 	for _, mp := range cp.MemberProgresses {
 		buf = buf + mp.ToString()
@@ -1405,7 +1414,7 @@ func (cp *ClusterProgress) ToString() string {
 }
 
 func (cp *MemberProgress) ToString() string {
-	return fmt.Sprintf("{ id: %x, Staus: \"%s\", LogDifference: %d }", cp.MemberID, MemberProgressStateNames[cp.Status], cp.LogDifference)
+	return fmt.Sprintf("{ id: %x, Staus: \"%s\", LogDifference: %d, Learner: %t }", cp.MemberID, MemberProgressStateNames[cp.Status], cp.LogDifference, cp.IsLearner)
 }
 
 func (rs *raftServer) GetLastIndex() (uint64, error) {
@@ -1469,9 +1478,14 @@ func (rs *raftServer) GetClusterProgress() (*ClusterProgress, error) {
 	}
 
 	prog.MemberProgresses = make(map[uint64]*MemberProgress)
-	prog.N = n
 	for id, nodeProgress := range status.Progress {
-		prog.MemberProgresses[id] = &MemberProgress{MemberID: id, Status: getProgressState(&nodeProgress, lastIdx, rs.cluster.NodeID(), id), LogDifference: lastIdx - nodeProgress.Match, progress: nodeProgress}
+		prog.MemberProgresses[id] = &MemberProgress{MemberID: id, Status: getProgressState(&nodeProgress, lastIdx, rs.cluster.NodeID(), id), LogDifference: lastIdx - nodeProgress.Match, IsLearner: nodeProgress.IsLearner, progress: nodeProgress}
+
+		if nodeProgress.IsLearner {
+			prog.Learners++
+		} else {
+			prog.N++
+		}
 	}
 
 	return &prog, nil
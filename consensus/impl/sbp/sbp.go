@@ -231,6 +231,12 @@ func (s *SimpleBlockFactory) RaftAccessor() consensus.AergoRaftAccessor {
 	return &dummyRaft
 }
 
+var dummyBFT consensus.DummyBFTAccessor
+
+func (s *SimpleBlockFactory) BFTAccessor() consensus.BFTAccessor {
+	return &dummyBFT
+}
+
 func (s *SimpleBlockFactory) NeedNotify() bool {
 	return true
 }
@@ -260,6 +266,10 @@ func (s *SimpleBlockFactory) ConfChangeInfo(requestID uint64) (*types.ConfChange
 	return nil, consensus.ErrNotSupportedMethod
 }
 
+func (s *SimpleBlockFactory) BPStats(blockNo types.BlockNo) (*types.BPStatList, error) {
+	return nil, consensus.ErrNotSupportedMethod
+}
+
 func (s *SimpleBlockFactory) MakeConfChangeProposal(req *types.MembershipChange) (*consensus.ConfChangePropose, error) {
 	return nil, consensus.ErrNotSupportedMethod
 }
@@ -29,6 +29,9 @@ type ConfChangePropose struct {
 	Ctx context.Context
 	Cc  *raftpb.ConfChange
 
+	// Transferee is the member ID to which the leadership is transferred. Cc is nil if it is set.
+	Transferee uint64
+
 	ReplyC chan *ConfChangeReply
 }
 
@@ -282,7 +285,7 @@ func NewMember(name string, address string, peerID types.PeerID, chainID []byte,
 }
 
 func (m *Member) Clone() *Member {
-	newM := Member{MemberAttr: types.MemberAttr{ID: m.ID, Name: m.Name, Address: m.Address}}
+	newM := Member{MemberAttr: types.MemberAttr{ID: m.ID, Name: m.Name, Address: m.Address, IsLearner: m.IsLearner}}
 
 	copy(newM.PeerID, m.PeerID)
 
@@ -330,6 +333,7 @@ func (m *Member) Equal(other *Member) bool {
 		bytes.Equal(m.PeerID, other.PeerID) &&
 		m.Name == other.Name &&
 		m.Address == other.Address &&
+		m.IsLearner == other.IsLearner &&
 		bytes.Equal([]byte(m.PeerID), []byte(other.PeerID))
 }
 
@@ -0,0 +1,109 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package signer
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aergoio/aergo/types"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"google.golang.org/grpc"
+)
+
+const (
+	unixScheme = "unix://"
+
+	// signTimeout is the maximum time to wait for a signature. It must be
+	// short enough not to miss the slot.
+	signTimeout = 500 * time.Millisecond
+	dialTimeout = 3 * time.Second
+)
+
+type remoteSigner struct {
+	addr   string
+	conn   *grpc.ClientConn
+	client types.BlockSignerServiceClient
+	pubKey crypto.PubKey
+}
+
+// NewRemote returns a block signer which requests signatures to the signer
+// process listening on addr. addr is either unix:///path/to/socket or
+// host:port.
+func NewRemote(addr string) (types.BlockSigner, error) {
+	conn, err := Dial(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &remoteSigner{
+		addr:   addr,
+		conn:   conn,
+		client: types.NewBlockSignerServiceClient(conn),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), dialTimeout)
+	defer cancel()
+
+	rsp, err := s.client.GetPubKey(ctx, &types.Empty{})
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to get public key from remote signer %s: %s", addr, err.Error())
+	}
+	if s.pubKey, err = crypto.UnmarshalPublicKey(rsp.GetValue()); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("invalid public key from remote signer %s: %s", addr, err.Error())
+	}
+
+	logger.Info().Str("addr", addr).Str("bp", types.IDB58Encode(ID(s))).Msg("remote block signer connected")
+
+	return s, nil
+}
+
+// Dial connects to the signer process listening on addr.
+func Dial(addr string) (*grpc.ClientConn, error) {
+	opts := []grpc.DialOption{grpc.WithInsecure()}
+	if strings.HasPrefix(addr, unixScheme) {
+		opts = append(opts, grpc.WithDialer(func(path string, timeout time.Duration) (net.Conn, error) {
+			return net.DialTimeout("unix", path, timeout)
+		}))
+		addr = strings.TrimPrefix(addr, unixScheme)
+	}
+	return grpc.Dial(addr, opts...)
+}
+
+// Listen announces on addr for a signer process. addr is in the same format as
+// NewRemote.
+func Listen(addr string) (net.Listener, error) {
+	if strings.HasPrefix(addr, unixScheme) {
+		path := strings.TrimPrefix(addr, unixScheme)
+		// remove the socket file left by the previous run
+		if fi, err := os.Stat(path); err == nil && fi.Mode()&os.ModeSocket != 0 {
+			os.Remove(path)
+		}
+		return net.Listen("unix", path)
+	}
+	return net.Listen("tcp", addr)
+}
+
+func (s *remoteSigner) PubKey() crypto.PubKey {
+	return s.pubKey
+}
+
+func (s *remoteSigner) SignBlockHeader(header *types.BlockHeader, slot int64) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), signTimeout)
+	defer cancel()
+
+	rsp, err := s.client.SignBlock(ctx, &types.BlockSignRequest{Header: header, Slot: slot})
+	if err != nil {
+		return nil, fmt.Errorf("remote signer %s: %s", s.addr, err.Error())
+	}
+	return rsp.GetValue(), nil
+}
@@ -0,0 +1,156 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package signer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+
+	"github.com/aergoio/aergo/types"
+	"github.com/libp2p/go-libp2p-core/crypto"
+)
+
+var (
+	ErrNoHeader       = errors.New("no block header to sign")
+	ErrPubKeyMismatch = errors.New("public key of block header is not the one of signer")
+	ErrSlotMismatch   = errors.New("slot is inconsistent with block timestamp")
+)
+
+// watermark is the highest block which the signer has ever signed.
+type watermark struct {
+	Height types.BlockNo
+	Slot   int64
+}
+
+// Server is the signer process side of the remote block signer. It never
+// signs a block for a height or a slot which is not higher than the last one
+// it has signed. The slot is derived from the block timestamp, not taken from
+// the request. The last signed height and slot are persisted to a file before
+// the signature is returned, so that the rule is kept across restarts.
+type Server struct {
+	mutex            sync.Mutex
+	privKey          crypto.PrivKey
+	pubKey           []byte
+	statePath        string
+	blockIntervalSec int64
+	last             watermark
+}
+
+// NewServer returns a new Server signing with privKey. The watermark is loaded
+// from and saved to statePath. If blockIntervalSec is positive, the slot of
+// each block is derived from its timestamp. Otherwise (e.g. raft) only the
+// height is checked.
+func NewServer(privKey crypto.PrivKey, statePath string, blockIntervalSec int64) (*Server, error) {
+	pubKey, err := privKey.GetPublic().Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Server{
+		privKey:          privKey,
+		pubKey:           pubKey,
+		statePath:        statePath,
+		blockIntervalSec: blockIntervalSec,
+	}
+
+	if data, err := ioutil.ReadFile(statePath); err == nil {
+		if err := json.Unmarshal(data, &s.last); err != nil {
+			return nil, fmt.Errorf("invalid signer state file %s: %s", statePath, err.Error())
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// GetPubKey returns the public key of the signer.
+func (s *Server) GetPubKey(ctx context.Context, in *types.Empty) (*types.SingleBytes, error) {
+	return &types.SingleBytes{Value: s.pubKey}, nil
+}
+
+// SignBlock returns the signature of the requested block header.
+func (s *Server) SignBlock(ctx context.Context, in *types.BlockSignRequest) (*types.SingleBytes, error) {
+	header := in.GetHeader()
+	if header == nil {
+		return nil, ErrNoHeader
+	}
+	if !bytes.Equal(header.GetPubKey(), s.pubKey) {
+		return nil, ErrPubKeyMismatch
+	}
+	var slot int64
+	if s.blockIntervalSec > 0 {
+		slot = types.DposSlotIndex(header.GetTimestamp(), s.blockIntervalSec)
+		if in.GetSlot() != 0 && in.GetSlot() != slot {
+			return nil, ErrSlotMismatch
+		}
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	next := watermark{Height: header.GetBlockNo(), Slot: slot}
+	if err := s.check(next); err != nil {
+		logger.Warn().Err(err).Uint64("no", next.Height).Int64("slot", next.Slot).Msg("refused to sign block")
+		return nil, err
+	}
+
+	// Persist first. A crash after signing must not allow another signature
+	// for the same slot.
+	if err := s.save(next); err != nil {
+		return nil, err
+	}
+	s.last = next
+
+	sig, err := signHeader(s.privKey, header)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info().Uint64("no", next.Height).Int64("slot", next.Slot).Msg("block signed")
+
+	return &types.SingleBytes{Value: sig}, nil
+}
+
+func (s *Server) check(next watermark) error {
+	if next.Height <= s.last.Height {
+		return fmt.Errorf("height %d is not higher than the last signed one %d", next.Height, s.last.Height)
+	}
+	if s.blockIntervalSec > 0 && next.Slot <= s.last.Slot {
+		return fmt.Errorf("slot %d is not higher than the last signed one %d", next.Slot, s.last.Slot)
+	}
+	return nil
+}
+
+func (s *Server) save(wm watermark) error {
+	data, err := json.Marshal(wm)
+	if err != nil {
+		return err
+	}
+
+	tmp := s.statePath + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err = f.Write(data); err == nil {
+		err = f.Sync()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, s.statePath)
+}
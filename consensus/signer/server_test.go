@@ -0,0 +1,86 @@
+package signer
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aergoio/aergo/types"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer_SignBlock(t *testing.T) {
+	dir, err := ioutil.TempDir("", "signer")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	statePath := filepath.Join(dir, "state.json")
+
+	privKey, _, _ := crypto.GenerateKeyPair(crypto.Secp256k1, 256)
+	otherKey, _, _ := crypto.GenerateKeyPair(crypto.Secp256k1, 256)
+	pubKey, _ := privKey.GetPublic().Bytes()
+
+	s, err := NewServer(privKey, statePath, 1)
+	assert.Nil(t, err)
+
+	base := time.Unix(1000, 0).UnixNano() + int64(time.Millisecond)
+	request := func(no types.BlockNo, slot int64) *types.BlockSignRequest {
+		ts := base + (slot-1001)*int64(time.Second)
+		return &types.BlockSignRequest{
+			Header: &types.BlockHeader{BlockNo: no, Timestamp: ts, PubKey: pubKey},
+			Slot:   slot,
+		}
+	}
+	sign := func(s *Server, in *types.BlockSignRequest) error {
+		_, err := s.SignBlock(context.Background(), in)
+		return err
+	}
+
+	assert.Nil(t, sign(s, request(10, 1001)))
+	// same slot
+	assert.NotNil(t, sign(s, request(11, 1001)))
+	// lower height
+	assert.NotNil(t, sign(s, request(9, 1002)))
+	// same height for a later slot
+	assert.NotNil(t, sign(s, request(10, 1002)))
+	// the slot is derived from the timestamp even if none is requested
+	in := request(11, 1001)
+	in.Slot = 0
+	assert.NotNil(t, sign(s, in))
+	in = request(11, 1002)
+	in.Slot = 0
+	assert.Nil(t, sign(s, in))
+
+	// slot inconsistent with timestamp
+	in = request(12, 1003)
+	in.Slot = 1004
+	assert.Equal(t, ErrSlotMismatch, sign(s, in))
+
+	// public key of another BP
+	in = request(12, 1003)
+	in.Header.PubKey, _ = otherKey.GetPublic().Bytes()
+	assert.Equal(t, ErrPubKeyMismatch, sign(s, in))
+
+	// The watermark survives restart.
+	s, err = NewServer(privKey, statePath, 1)
+	assert.Nil(t, err)
+	assert.NotNil(t, sign(s, request(11, 1003)))
+
+	in = request(12, 1003)
+	rsp, err := s.SignBlock(context.Background(), in)
+	assert.Nil(t, err)
+	in.Header.Sign = rsp.GetValue()
+	valid, err := (&types.Block{Header: in.Header}).VerifySign()
+	assert.Nil(t, err)
+	assert.True(t, valid)
+
+	// Without the block interval, only the height is checked.
+	s, err = NewServer(privKey, filepath.Join(dir, "raft.json"), 0)
+	assert.Nil(t, err)
+	assert.Nil(t, sign(s, request(10, 0)))
+	assert.NotNil(t, sign(s, request(10, 0)))
+	assert.Nil(t, sign(s, request(11, 0)))
+}
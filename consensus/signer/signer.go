@@ -0,0 +1,61 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+// Package signer provides the block signers of block producers. A block is
+// signed either in-process with the p2p key of the node or by a remote signer
+// process which keeps the key of the block producer apart from aergosvr.
+package signer
+
+import (
+	"github.com/aergoio/aergo-lib/log"
+	"github.com/aergoio/aergo/config"
+	"github.com/aergoio/aergo/types"
+	"github.com/libp2p/go-libp2p-core/crypto"
+)
+
+var logger = log.NewLogger("signer")
+
+// New returns the block signer configured by cfg. privKey is used if no remote
+// signer is configured.
+func New(cfg *config.ConsensusConfig, privKey crypto.PrivKey) (types.BlockSigner, error) {
+	if cfg != nil && len(cfg.RemoteSigner) > 0 {
+		return NewRemote(cfg.RemoteSigner)
+	}
+	return NewLocal(privKey), nil
+}
+
+// ID returns the block producer ID corresponding to s.
+func ID(s types.BlockSigner) types.PeerID {
+	id, err := types.IDFromPublicKey(s.PubKey())
+	if err != nil {
+		return types.PeerID("")
+	}
+	return id
+}
+
+type localSigner struct {
+	privKey crypto.PrivKey
+}
+
+// NewLocal returns a block signer which signs in-process with privKey.
+func NewLocal(privKey crypto.PrivKey) types.BlockSigner {
+	return &localSigner{privKey: privKey}
+}
+
+func (s *localSigner) PubKey() crypto.PubKey {
+	return s.privKey.GetPublic()
+}
+
+func (s *localSigner) SignBlockHeader(header *types.BlockHeader, slot int64) ([]byte, error) {
+	return signHeader(s.privKey, header)
+}
+
+func signHeader(privKey crypto.PrivKey, header *types.BlockHeader) ([]byte, error) {
+	block := &types.Block{Header: header}
+	if err := block.Sign(privKey); err != nil {
+		return nil, err
+	}
+	return block.Header.Sign, nil
+}
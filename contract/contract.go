@@ -3,13 +3,17 @@ package contract
 import "C"
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"math"
 	"math/big"
 	"strconv"
+	"time"
 
+	luacUtil "github.com/aergoio/aergo/cmd/aergoluac/util"
 	"github.com/aergoio/aergo/config"
 	"github.com/aergoio/aergo/fee"
+	"github.com/aergoio/aergo/pkg/metrics"
 	"github.com/aergoio/aergo/state"
 	"github.com/aergoio/aergo/types"
 	"github.com/minio/sha256-simd"
@@ -126,7 +130,7 @@ func Execute(
 		replyCh := preLoadInfos[preLoadService].replyCh
 		for {
 			var preload *loadedReply
-			if HardforkConfig.IsV2Fork(bi.No) {
+			if HardforkConfig.IsFeatureActive(types.FeatureLuaVMV2, bi.No) {
 				if preLoadService == BlockFactory {
 					select {
 					case preload = <-replyCh:
@@ -160,6 +164,7 @@ func Execute(
 	}
 
 	var ctrFee *big.Int
+	vmBegT := time.Now()
 	if ex != nil {
 		rv, events, ctrFee, err = PreCall(ex, bs, sender, contractState, receiver.RP(), gasLimit)
 	} else {
@@ -176,6 +181,7 @@ func Execute(
 		}
 	}
 
+	metrics.VMExecutionTime.Observe(time.Since(vmBegT).Seconds())
 	usedFee.Add(usedFee, ctrFee)
 
 	if err != nil {
@@ -202,8 +208,62 @@ func Execute(
 	return rv, events, usedFee, nil
 }
 
+// InitGenesisContracts calls the constructors of the genesis contracts with
+// constructor arguments. Their code is already set by the state of the
+// genesis block. The SQL databases written by the constructors are committed
+// and their recovery points are recorded in bs, which is applied right after.
+func InitGenesisContracts(bs *state.BlockState, genesis *types.Genesis) (err error) {
+	if HardforkConfig == nil {
+		return errors.New("hardfork config is not set")
+	}
+	defer func() {
+		if err != nil {
+			CloseDatabase()
+		}
+	}()
+	bi := types.NewBlockHeaderInfo(genesis.Block())
+	for _, c := range genesis.Contracts {
+		if len(c.Args) == 0 {
+			continue
+		}
+		StartLStateFactory()
+
+		id, err := c.ID()
+		if err != nil {
+			return err
+		}
+		code, err := c.LuaCode()
+		if err != nil {
+			return err
+		}
+		receiver, err := bs.GetAccountStateV(id)
+		if err != nil {
+			return err
+		}
+		contractState, err := bs.OpenContractState(receiver.AccountID(), receiver.State())
+		if err != nil {
+			return err
+		}
+		ctx := newVmContext(bs, nil, receiver, receiver, contractState, id, nil, bi, "", true, false,
+			receiver.RP(), ChainService, big.NewInt(0), math.MaxUint64, false)
+		payload := luacUtil.NewLuaCodePayload(luacUtil.LuaCode(code), c.Args)
+		// Unlike a deploy tx, invalid constructor arguments and arguments to
+		// a contract without a constructor fail the genesis.
+		if _, _, _, err = create(contractState, payload, id, ctx); err != nil {
+			return fmt.Errorf("constructor of genesis contract %s failed: %s", c.Address, err.Error())
+		}
+		if err = bs.StageContractState(contractState); err != nil {
+			return err
+		}
+		if err = receiver.PutState(); err != nil {
+			return err
+		}
+	}
+	return SaveRecoveryPoint(bs)
+}
+
 func txFee(payloadSize int, GasPrice *big.Int, version int32) *big.Int {
-	if version < 2 {
+	if !types.FeatureGasFee.ActiveAt(version) {
 		return fee.PayloadTxFee(payloadSize)
 	}
 	txGas := fee.TxGas(payloadSize)
@@ -299,7 +359,7 @@ func checkRedeploy(sender, receiver *state.V, contractState *state.ContractState
 }
 
 func useGas(version int32) bool {
-	return version >= 2 && PubNet
+	return types.FeatureGasFee.ActiveAt(version) && PubNet
 }
 
 func SetBPTimeout(timeout <-chan struct{}) {
@@ -307,7 +367,7 @@ func SetBPTimeout(timeout <-chan struct{}) {
 }
 
 func GasUsed(txFee, gasPrice *big.Int, txType types.TxType, version int32) uint64 {
-	if fee.IsZeroFee() || txType == types.TxType_GOVERNANCE || version < 2 {
+	if fee.IsZeroFee() || txType == types.TxType_GOVERNANCE || !types.FeatureGasFee.ActiveAt(version) {
 		return 0
 	}
 	return new(big.Int).Div(txFee, gasPrice).Uint64()
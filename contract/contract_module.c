@@ -413,6 +413,9 @@ static int governance(lua_State *L, char type) {
             luaL_error(L, "invalid input");
         }
     }
+    else if (type == 'W') {
+        arg = "";
+    }
     else {
 	    arg = lua_util_get_json_from_stack (L, 1, lua_gettop(L), false);
 	    if (arg == NULL)
@@ -441,6 +444,10 @@ static int moduleUnstake(lua_State *L) {
     return governance(L, 'U');
 }
 
+static int moduleWithdraw(lua_State *L) {
+    return governance(L, 'W');
+}
+
 static int moduleVote(lua_State *L) {
     return governance(L, 'V');
 }
@@ -489,6 +496,7 @@ static const luaL_Reg contract_lib[] = {
 	{"event", moduleEvent},
 	{"stake", moduleStake},
 	{"unstake", moduleUnstake},
+	{"withdraw", moduleWithdraw},
 	{"vote", moduleVote},
 	{"voteDao", moduleVoteDao},
 	{NULL, NULL}
@@ -10,8 +10,11 @@ import (
 type CcArgument map[string]interface{}
 
 const (
-	CmdMembershipAdd    = "add"
-	CmdMembershipRemove = "remove"
+	CmdMembershipAdd        = "add"
+	CmdMembershipRemove     = "remove"
+	CmdMembershipAddLearner = "addlearner"
+	CmdMembershipPromote    = "promote"
+	CmdLeadershipTransfer   = "transfer"
 
 	CCCommand         = "command"
 	MemberAttrName    = "name"
@@ -105,8 +108,11 @@ func (cc CcArgument) parse() (*types.MembershipChange, error) {
 	}
 
 	switch cmd {
-	case CmdMembershipAdd:
+	case CmdMembershipAdd, CmdMembershipAddLearner:
 		mChange.Type = types.MembershipChangeType_ADD_MEMBER
+		if cmd == CmdMembershipAddLearner {
+			mChange.Type = types.MembershipChangeType_ADD_LEARNER
+		}
 
 		if name, err = cc.get(MemberAttrName); err != nil {
 			return nil, err
@@ -129,15 +135,32 @@ func (cc CcArgument) parse() (*types.MembershipChange, error) {
 			return nil, fmt.Errorf("invalid ChangeCluster argument: %s", err.Error())
 		}
 
-	case CmdMembershipRemove:
+	case CmdMembershipRemove, CmdMembershipPromote:
 		mChange.Type = types.MembershipChangeType_REMOVE_MEMBER
+		if cmd == CmdMembershipPromote {
+			mChange.Type = types.MembershipChangeType_PROMOTE_LEARNER
+		}
 
 		if idStr, err = cc.get(MemberAttrID); err != nil {
 			return nil, err
 		}
 
-		if id, err = strconv.ParseUint(idStr, 16, 64); err != nil {
-			return nil, fmt.Errorf("invalid ChangeCluster argument: invalid id %s. ID must be a string in hexadecial format(ex:dd44cf1a06727dc5)", idStr)
+		if id, err = parseMemberID(idStr); err != nil {
+			return nil, err
+		}
+
+	case CmdLeadershipTransfer:
+		mChange.Type = types.MembershipChangeType_TRANSFER_LEADER
+
+		// without id, the leader chooses the transferee
+		if _, exist := cc[MemberAttrID]; exist {
+			if idStr, err = cc.get(MemberAttrID); err != nil {
+				return nil, err
+			}
+
+			if id, err = parseMemberID(idStr); err != nil {
+				return nil, err
+			}
 		}
 
 	default:
@@ -148,3 +171,12 @@ func (cc CcArgument) parse() (*types.MembershipChange, error) {
 
 	return &mChange, nil
 }
+
+func parseMemberID(idStr string) (uint64, error) {
+	id, err := strconv.ParseUint(idStr, 16, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid ChangeCluster argument: invalid id %s. ID must be a string in hexadecial format(ex:dd44cf1a06727dc5)", idStr)
+	}
+
+	return id, nil
+}
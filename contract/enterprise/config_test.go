@@ -18,7 +18,7 @@ func initTest(t *testing.T) (*state.ContractState, *state.V, *state.V) {
 	cdb.Init(string(db.BadgerImpl), "test", nil, false)
 	genesis := types.GetTestGenesis()
 	sdb = cdb.OpenNewStateDB(cdb.GetRoot())
-	err := cdb.SetGenesis(genesis, nil)
+	err := cdb.SetGenesis(genesis, nil, nil)
 	if err != nil {
 		t.Fatalf("failed init : %s", err.Error())
 	}
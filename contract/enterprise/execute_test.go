@@ -217,6 +217,30 @@ func TestEnterpriseChangeCluster(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, bs.CCProposal)
 
+	bs = state.NewBlockState(&state.StateDB{})
+	tx.Payload = []byte(`{"name":"changeCluster", "args":[{"command" : "addlearner", "name": "aergonew", "address": "/ip4/127.0.0.1/tcp/11001", "peerid":"16Uiu2HAmAAtqye6QQbeG9EZnrWJbGK8Xw74cZxpnGGEAZAB3zJ8B"}]}`)
+	_, err = ExecuteEnterpriseTx(bs, ccc, scs, tx, sender, receiver, testBlockNo)
+	assert.NoError(t, err)
+	assert.NotNil(t, bs.CCProposal)
+
+	bs = state.NewBlockState(&state.StateDB{})
+	tx.Payload = []byte(`{"name":"changeCluster", "args":[{"command" : "promote", "id": "1234"}]}`)
+	_, err = ExecuteEnterpriseTx(bs, ccc, scs, tx, sender, receiver, testBlockNo)
+	assert.NoError(t, err)
+	assert.NotNil(t, bs.CCProposal)
+
+	bs = state.NewBlockState(&state.StateDB{})
+	tx.Payload = []byte(`{"name":"changeCluster", "args":[{"command" : "transfer"}]}`)
+	_, err = ExecuteEnterpriseTx(bs, ccc, scs, tx, sender, receiver, testBlockNo)
+	assert.NoError(t, err)
+	assert.NotNil(t, bs.CCProposal)
+
+	bs = state.NewBlockState(&state.StateDB{})
+	tx.Payload = []byte(`{"name":"changeCluster", "args":[{"command" : "promote", "id": "xyz"}]}`)
+	_, err = ExecuteEnterpriseTx(bs, ccc, scs, tx, sender, receiver, testBlockNo)
+	assert.Error(t, err)
+	assert.Nil(t, bs.CCProposal)
+
 	bs = state.NewBlockState(&state.StateDB{})
 	tx.Payload = []byte(`{"name":"changeCluster", "args":[{"command" : "nocmd", "name": "aergonew", "address": "/ip4/127.0.0.1/tcp/11001", "PeerID":"16Uiu2HAmAAtqye6QQbeG9EZnrWJbGK8Xw74cZxpnGGEAZAB3zJ8B"}]}`)
 	_, err = ExecuteEnterpriseTx(bs, ccc, scs, tx, sender, receiver, testBlockNo)
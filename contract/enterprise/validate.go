@@ -157,7 +157,7 @@ func ValidateEnterpriseTx(tx *types.TxBody, sender *state.V,
 		}
 
 	case ChangeCluster:
-		if !consensus.UseRaft() {
+		if !consensus.UseRaft() && !consensus.UseBFT() {
 			return nil, ErrNotSupportedMethod
 		}
 
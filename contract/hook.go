@@ -6,6 +6,9 @@ package contract
 #include "vm.h"
 */
 import "C"
+import (
+	"github.com/aergoio/aergo/types"
+)
 
 func (ce *executor) setCountHook(limit C.int) {
 	if ce == nil ||
@@ -14,7 +17,7 @@ func (ce *executor) setCountHook(limit C.int) {
 		vmIsGasSystem(ce.ctx) {
 		return
 	}
-	if HardforkConfig.IsV2Fork(ce.ctx.blockInfo.No) {
+	if HardforkConfig.IsFeatureActive(types.FeatureLuaVMV2, ce.ctx.blockInfo.No) {
 		C.vm_set_timeout_count_hook(ce.L, limit)
 	} else {
 		C.vm_set_count_hook(ce.L, limit)
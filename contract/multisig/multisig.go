@@ -0,0 +1,111 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package multisig
+
+import (
+	"math/big"
+
+	"github.com/aergoio/aergo/fee"
+	"github.com/aergoio/aergo/state"
+	"github.com/aergoio/aergo/types"
+)
+
+var configKey = []byte("multisig")
+
+// GetConfig returns the config registered to the multisig account, or nil.
+func GetConfig(scs *state.ContractState) (*types.MultisigConfig, error) {
+	data, err := scs.GetData(configKey)
+	if err != nil || len(data) == 0 {
+		return nil, err
+	}
+	return types.ParseMultisigConfig(data)
+}
+
+// GetAccountConfig returns the config registered to the account addr.
+func GetAccountConfig(sdb *state.StateDB, addr []byte) (*types.MultisigConfig, error) {
+	if !types.IsMultisigAddress(addr) {
+		return nil, nil
+	}
+	scs, err := sdb.OpenContractStateAccount(types.ToAccountID(addr))
+	if err != nil {
+		return nil, err
+	}
+	return GetConfig(scs)
+}
+
+// ValidateSender checks that a tx sent by a multisig account comes from a
+// registered one. The witness itself is verified against the address with
+// the signature.
+func ValidateSender(bs *state.BlockState, sender *state.V, blockInfo *types.BlockHeaderInfo) error {
+	if !types.IsMultisigAddress(sender.ID()) {
+		return nil
+	}
+	if !blockInfo.IsFeatureActive(types.FeatureMultisig) {
+		return types.ErrMultisigNotRegistered
+	}
+	scs, err := bs.OpenContractState(sender.AccountID(), sender.State())
+	if err != nil {
+		return err
+	}
+	config, err := GetConfig(scs)
+	if err != nil {
+		return err
+	}
+	if config == nil {
+		return types.ErrMultisigNotRegistered
+	}
+	return nil
+}
+
+// ExecuteMultisigTx registers the config in the payload to the multisig
+// account of the recipient, and transfers the amount to it.
+func ExecuteMultisigTx(bs *state.BlockState, txBody *types.TxBody, sender, receiver *state.V,
+	blockInfo *types.BlockHeaderInfo) (*big.Int, []*types.Event, error) {
+	if !blockInfo.IsFeatureActive(types.FeatureMultisig) {
+		return nil, nil, types.ErrTxInvalidType
+	}
+	usedFee := new(big.Int).Mul(new(big.Int).SetUint64(fee.TxGas(len(txBody.GetPayload()))), bs.GasPrice)
+
+	config, err := types.ParseMultisigConfig(txBody.GetPayload())
+	if err != nil {
+		return usedFee, nil, err
+	}
+	scs, err := bs.OpenContractState(receiver.AccountID(), receiver.State())
+	if err != nil {
+		return usedFee, nil, err
+	}
+	if registered, err := GetConfig(scs); err != nil {
+		return usedFee, nil, err
+	} else if registered != nil {
+		return usedFee, nil, types.ErrMultisigAlreadyRegistered
+	}
+
+	amount := txBody.GetAmountBigInt()
+	if sender.Balance().Cmp(new(big.Int).Add(amount, usedFee)) < 0 {
+		return usedFee, nil, types.ErrInsufficientBalance
+	}
+	sender.SubBalance(amount)
+	receiver.AddBalance(amount)
+
+	data, err := config.MarshalJSON()
+	if err != nil {
+		return usedFee, nil, err
+	}
+	if err := scs.SetData(configKey, data); err != nil {
+		return usedFee, nil, err
+	}
+	if err := bs.StageContractState(scs); err != nil {
+		return usedFee, nil, err
+	}
+	return usedFee, []*types.Event{
+		{
+			ContractAddress: receiver.ID(),
+			EventIdx:        0,
+			EventName:       "create multisig",
+			JsonArgs:        string(data),
+		},
+	}, nil
+}
@@ -16,7 +16,7 @@ func ExecuteNameTx(bs *state.BlockState, scs *state.ContractState, txBody *types
 
 	systemContractState, err := bs.StateDB.OpenContractStateAccount(types.ToAccountID([]byte(types.AergoSystem)))
 
-	ci, err := ValidateNameTx(txBody, sender, scs, systemContractState)
+	ci, err := ValidateNameTx(txBody, sender, scs, systemContractState, blockInfo)
 	if err != nil {
 		return nil, err
 	}
@@ -39,11 +39,11 @@ func ExecuteNameTx(bs *state.BlockState, scs *state.ContractState, txBody *types
 	switch ci.Name {
 	case types.NameCreate:
 		if err = CreateName(scs, txBody, sender, nameState,
-			ci.Args[0].(string)); err != nil {
+			ci.Args[0].(string), blockInfo); err != nil {
 			return nil, err
 		}
 		jsonArgs := ""
-		if blockInfo.Version < 2 {
+		if !blockInfo.IsFeatureActive(types.FeatureSystemEventV2) {
 			jsonArgs = `{"name":"` + ci.Args[0].(string) + `"}`
 		} else {
 			jsonArgs = `["` + ci.Args[0].(string) + `"]`
@@ -60,7 +60,7 @@ func ExecuteNameTx(bs *state.BlockState, scs *state.ContractState, txBody *types
 			return nil, err
 		}
 		jsonArgs := ""
-		if blockInfo.Version < 2 {
+		if !blockInfo.IsFeatureActive(types.FeatureSystemEventV2) {
 			jsonArgs = `{"name":"` + ci.Args[0].(string) +
 				`","to":"` + ci.Args[1].(string) + `"}`
 		} else {
@@ -72,6 +72,36 @@ func ExecuteNameTx(bs *state.BlockState, scs *state.ContractState, txBody *types
 			EventName:       "update name",
 			JsonArgs:        jsonArgs,
 		})
+	case types.NameRenew:
+		if err = RenewName(scs, txBody, sender, nameState, ci.Args[0].(string)); err != nil {
+			return nil, err
+		}
+		events = append(events, &types.Event{
+			ContractAddress: receiver.ID(),
+			EventIdx:        0,
+			EventName:       "renew name",
+			JsonArgs:        `["` + ci.Args[0].(string) + `"]`,
+		})
+	case types.NameSetSubname:
+		if err = SetSubname(scs, ci.Args[0].(string), ci.Args[1].(string)); err != nil {
+			return nil, err
+		}
+		events = append(events, &types.Event{
+			ContractAddress: receiver.ID(),
+			EventIdx:        0,
+			EventName:       "set subname",
+			JsonArgs:        `["` + ci.Args[0].(string) + `","` + ci.Args[1].(string) + `"]`,
+		})
+	case types.NameSetPrimary:
+		if err = SetPrimaryName(scs, sender.ID(), ci.Args[0].(string)); err != nil {
+			return nil, err
+		}
+		events = append(events, &types.Event{
+			ContractAddress: receiver.ID(),
+			EventIdx:        0,
+			EventName:       "set primary name",
+			JsonArgs:        `["` + types.EncodeAddress(sender.ID()) + `","` + ci.Args[0].(string) + `"]`,
+		})
 	case types.SetContractOwner:
 		ownerState, err := SetContractOwner(bs, scs, ci.Args[0].(string), nameState)
 		if err != nil {
@@ -84,7 +114,7 @@ func ExecuteNameTx(bs *state.BlockState, scs *state.ContractState, txBody *types
 }
 
 func ValidateNameTx(tx *types.TxBody, sender *state.V,
-	scs, systemcs *state.ContractState) (*types.CallInfo, error) {
+	scs, systemcs *state.ContractState, blockInfo *types.BlockHeaderInfo) (*types.CallInfo, error) {
 	if sender != nil && sender.Balance().Cmp(tx.GetAmountBigInt()) < 0 {
 		return nil, types.ErrInsufficientBalance
 	}
@@ -99,8 +129,9 @@ func ValidateNameTx(tx *types.TxBody, sender *state.V,
 		if namePrice.Cmp(tx.GetAmountBigInt()) > 0 {
 			return nil, types.ErrTooSmallAmount
 		}
-		owner := getOwner(scs, []byte(name), false)
-		if owner != nil {
+		nameMap := getNameMap(scs, []byte(name), false)
+		if nameMap != nil && (!blockInfo.IsFeatureActive(types.FeatureNameExpiry) ||
+			nameStatus(scs, nameMap, blockInfo.No, false) != NameReleased) {
 			return nil, fmt.Errorf("aleady occupied %s", string(name))
 		}
 	case types.NameUpdate:
@@ -112,6 +143,46 @@ func ValidateNameTx(tx *types.TxBody, sender *state.V,
 			(!bytes.Equal(tx.Account, getOwner(scs, []byte(name), false))) {
 			return nil, fmt.Errorf("owner not matched : %s", name)
 		}
+		if blockInfo.IsFeatureActive(types.FeatureNameExpiry) {
+			if nameMap := getNameMap(scs, []byte(name), false); nameMap != nil &&
+				nameStatus(scs, nameMap, blockInfo.No, false) != NameActive {
+				return nil, fmt.Errorf("expired name : %s", name)
+			}
+		}
+	case types.NameRenew:
+		if !blockInfo.IsFeatureActive(types.FeatureNameExpiry) {
+			return nil, errors.New("could not execute unknown cmd")
+		}
+		namePrice := system.GetNamePriceFromState(systemcs)
+		if namePrice.Cmp(tx.GetAmountBigInt()) > 0 {
+			return nil, types.ErrTooSmallAmount
+		}
+		nameMap := getNameMap(scs, []byte(name), false)
+		if nameMap == nil || !bytes.Equal(tx.Account, nameMap.Owner) {
+			return nil, fmt.Errorf("owner not matched : %s", name)
+		}
+		if nameStatus(scs, nameMap, blockInfo.No, false) == NameReleased {
+			return nil, fmt.Errorf("released name : %s", name)
+		}
+	case types.NameSetSubname:
+		if !blockInfo.IsFeatureActive(types.FeatureNameExpiry) {
+			return nil, errors.New("could not execute unknown cmd")
+		}
+		_, parent, _ := types.ParseSubname(name)
+		nameMap := getNameMap(scs, []byte(parent), false)
+		if nameMap == nil || !bytes.Equal(tx.Account, nameMap.Owner) {
+			return nil, fmt.Errorf("owner not matched : %s", parent)
+		}
+		if nameStatus(scs, nameMap, blockInfo.No, false) != NameActive {
+			return nil, fmt.Errorf("expired name : %s", parent)
+		}
+	case types.NameSetPrimary:
+		if !blockInfo.IsFeatureActive(types.FeatureNameExpiry) {
+			return nil, errors.New("could not execute unknown cmd")
+		}
+		if name != "" && !bytes.Equal(tx.Account, getActiveAddress(scs, []byte(name), blockInfo.No)) {
+			return nil, fmt.Errorf("not resolved to the sender : %s", name)
+		}
 	case types.SetContractOwner:
 		owner := getOwner(scs, []byte(types.AergoName), false)
 		if owner != nil {
@@ -136,7 +207,7 @@ func SetContractOwner(bs *state.BlockState, scs *state.ContractState,
 	}
 	ownerState.AddBalance(nameState.Balance())
 	nameState.SubBalance(nameState.Balance())
-	if err = registerOwner(scs, name, rawaddr, name); err != nil {
+	if err = registerOwner(scs, name, rawaddr, name, 0); err != nil {
 		return nil, err
 	}
 	return ownerState, nil
@@ -1,6 +1,7 @@
 package name
 
 import (
+	"encoding/json"
 	"math/big"
 	"testing"
 
@@ -142,3 +143,174 @@ func nextBlockContractState(t *testing.T, bs *state.BlockState, scs *state.Contr
 	commitContractState(t, bs, scs)
 	return openContractState(t, bs)
 }
+
+type nameStateReader struct {
+	scs *state.ContractState
+}
+
+func (r *nameStateReader) GetNameAccountState() (*state.ContractState, error) {
+	return r.scs, nil
+}
+
+func buildNameCall(operation string, args ...interface{}) []byte {
+	payload, _ := json.Marshal(&types.CallInfo{Name: operation, Args: args})
+	return payload
+}
+
+func TestExecuteNameExpiry(t *testing.T) {
+	initTest(t)
+	defer deinitTest()
+	owner := types.ToAddress("AmMXVdJ8DnEFysN58cox9RADC74dF1CLrQimKCMdB4XXMkJeuQgL")
+	other := types.ToAddress("AmNHAxiGbZJjKjdGGNj2NBoAXGwdzX9Bg59eqbek9n49JpiaZ3As")
+	dest := "AmMSMkVHQ6qRVA7G7rqwjvv2NBwB48tTekJ2jFMrjfZrsofePgay"
+	name := "ab1234567890"
+	subname := "pay." + name
+
+	txBody := &types.TxBody{
+		Account:   owner,
+		Recipient: []byte(types.AergoName),
+		Amount:    big.NewInt(1000000000000000000).Bytes(),
+		Payload:   buildNameCall(types.NameCreate, name),
+	}
+	sender, _ := sdb.GetStateDB().GetAccountStateV(owner)
+	sender.AddBalance(types.MaxAER)
+	otherSender, _ := sdb.GetStateDB().GetAccountStateV(other)
+	otherSender.AddBalance(types.MaxAER)
+	receiver, _ := sdb.GetStateDB().GetAccountStateV(txBody.Recipient)
+	bs := sdb.NewBlockState(sdb.GetRoot())
+	scs := openContractState(t, bs)
+
+	// the new txs are not available before the fork
+	blockInfo := &types.BlockHeaderInfo{No: 10, Version: types.FeatureNameExpiry.Version() - 1}
+	txBody.Payload = buildNameCall(types.NameRenew, name)
+	_, err := ExecuteNameTx(bs, scs, txBody, sender, receiver, blockInfo)
+	assert.Error(t, err, "renew before the fork")
+
+	blockInfo.Version = types.FeatureNameExpiry.Version()
+	at := func(no types.BlockNo) *types.BlockHeaderInfo {
+		return &types.BlockHeaderInfo{No: no, Version: blockInfo.Version}
+	}
+	txBody.Payload = buildNameCall(types.NameCreate, name)
+	_, err = ExecuteNameTx(bs, scs, txBody, sender, receiver, blockInfo)
+	assert.NoError(t, err, "create name")
+	scs = nextBlockContractState(t, bs, scs)
+	expire := blockInfo.No + NameRegistrationPeriod
+
+	info, err := GetNameInfo(&nameStateReader{scs}, name, blockInfo.No+1)
+	assert.NoError(t, err)
+	assert.Equal(t, expire, info.ExpireNo)
+	assert.Equal(t, NameActive, info.Status)
+
+	// subname and primary name
+	txBody.Amount = nil
+	txBody.Payload = buildNameCall(types.NameSetSubname, subname, dest)
+	_, err = ExecuteNameTx(bs, scs, txBody, sender, receiver, blockInfo)
+	assert.NoError(t, err, "set subname")
+	txBody.Payload = buildNameCall(types.NameSetPrimary, name)
+	_, err = ExecuteNameTx(bs, scs, txBody, sender, receiver, blockInfo)
+	assert.NoError(t, err, "set primary name")
+	scs = nextBlockContractState(t, bs, scs)
+
+	assert.Equal(t, dest, types.EncodeAddress(Resolve(bs, []byte(subname), at(blockInfo.No+1))))
+	assert.Equal(t, owner, Resolve(bs, []byte(name), at(blockInfo.No+1)))
+	info, err = GetNameInfo(&nameStateReader{scs}, types.EncodeAddress(owner), blockInfo.No+1)
+	assert.NoError(t, err, "reverse lookup")
+	assert.Equal(t, name, info.Name.Name)
+
+	txBody.Account = other
+	txBody.Payload = buildNameCall(types.NameSetSubname, "x."+name, dest)
+	_, err = ExecuteNameTx(bs, scs, txBody, otherSender, receiver, blockInfo)
+	assert.Error(t, err, "set subname of another owner")
+	txBody.Payload = buildNameCall(types.NameSetPrimary, name)
+	_, err = ExecuteNameTx(bs, scs, txBody, otherSender, receiver, blockInfo)
+	assert.Error(t, err, "set primary name not resolved to the sender")
+
+	// expired names are not resolved, but can be renewed during the grace period
+	blockInfo.No = expire + 1
+	assert.Nil(t, Resolve(bs, []byte(name), at(blockInfo.No)))
+	assert.Nil(t, Resolve(bs, []byte(subname), at(blockInfo.No)))
+	_, err = GetNameInfo(&nameStateReader{scs}, types.EncodeAddress(owner), blockInfo.No)
+	assert.Equal(t, types.ErrNameNotFound, err)
+
+	txBody.Amount = big.NewInt(1000000000000000000).Bytes()
+	txBody.Payload = buildNameCall(types.NameCreate, name)
+	_, err = ExecuteNameTx(bs, scs, txBody, otherSender, receiver, blockInfo)
+	assert.Error(t, err, "create name in the grace period")
+
+	txBody.Account = owner
+	txBody.Payload = buildNameCall(types.NameRenew, name)
+	_, err = ExecuteNameTx(bs, scs, txBody, sender, receiver, blockInfo)
+	assert.NoError(t, err, "renew name")
+	scs = nextBlockContractState(t, bs, scs)
+	expire += NameRegistrationPeriod
+	assert.Equal(t, owner, Resolve(bs, []byte(name), at(blockInfo.No)))
+
+	// released names can be created by anyone
+	blockInfo.No = expire + NameGracePeriod + 1
+	info, err = GetNameInfo(&nameStateReader{scs}, name, blockInfo.No)
+	assert.NoError(t, err)
+	assert.Equal(t, NameReleased, info.Status)
+
+	_, err = ExecuteNameTx(bs, scs, txBody, sender, receiver, blockInfo)
+	assert.Error(t, err, "renew released name")
+
+	txBody.Account = other
+	txBody.Payload = buildNameCall(types.NameCreate, name)
+	_, err = ExecuteNameTx(bs, scs, txBody, otherSender, receiver, blockInfo)
+	assert.NoError(t, err, "create released name")
+	scs = nextBlockContractState(t, bs, scs)
+	assert.Equal(t, other, Resolve(bs, []byte(name), at(blockInfo.No)))
+	assert.Nil(t, Resolve(bs, []byte(subname), at(blockInfo.No)), "subname of the previous owner")
+}
+
+func TestNameExpiryStart(t *testing.T) {
+	initTest(t)
+	defer deinitTest()
+	owner := types.ToAddress("AmMXVdJ8DnEFysN58cox9RADC74dF1CLrQimKCMdB4XXMkJeuQgL")
+	dest := "AmMSMkVHQ6qRVA7G7rqwjvv2NBwB48tTekJ2jFMrjfZrsofePgay"
+	name := "ab1234567890"
+	subname := "pay." + name
+
+	txBody := &types.TxBody{
+		Account:   owner,
+		Recipient: []byte(types.AergoName),
+		Amount:    big.NewInt(1000000000000000000).Bytes(),
+		Payload:   buildNameCall(types.NameCreate, name),
+	}
+	sender, _ := sdb.GetStateDB().GetAccountStateV(owner)
+	sender.AddBalance(types.MaxAER)
+	receiver, _ := sdb.GetStateDB().GetAccountStateV(txBody.Recipient)
+	bs := sdb.NewBlockState(sdb.GetRoot())
+	scs := openContractState(t, bs)
+
+	legacy := &types.BlockHeaderInfo{No: 10, Version: types.FeatureNameExpiry.Version() - 1}
+	_, err := ExecuteNameTx(bs, scs, txBody, sender, receiver, legacy)
+	assert.NoError(t, err, "create name before the fork")
+	commitContractState(t, bs, scs)
+	assert.Equal(t, owner, Resolve(bs, []byte(name), legacy))
+	assert.Equal(t, []byte(subname), Resolve(bs, []byte(subname), legacy), "subname before the fork")
+
+	// the registration period of the legacy names starts at the activation
+	// block even if no name tx is executed there
+	activation := uint64(100)
+	assert.NoError(t, InitExpiryStart(bs, activation))
+	assert.NoError(t, InitExpiryStart(bs, activation+1))
+	bs.Update()
+	bs.Commit()
+	sdb.UpdateRoot(bs)
+	scs = openContractState(t, bs)
+	info, err := GetNameInfo(&nameStateReader{scs}, name, activation+1)
+	assert.NoError(t, err)
+	assert.Equal(t, activation+NameRegistrationPeriod, info.ExpireNo)
+
+	bi := &types.BlockHeaderInfo{No: activation + 1, Version: types.FeatureNameExpiry.Version()}
+	txBody.Amount = nil
+	txBody.Payload = buildNameCall(types.NameSetSubname, subname, dest)
+	_, err = ExecuteNameTx(bs, scs, txBody, sender, receiver, bi)
+	assert.NoError(t, err, "set subname")
+	commitContractState(t, bs, scs)
+	assert.Equal(t, dest, types.EncodeAddress(Resolve(bs, []byte(subname), bi)))
+
+	bi.No = activation + NameRegistrationPeriod + 1
+	assert.Nil(t, Resolve(bs, []byte(name), bi), "expired legacy name")
+}
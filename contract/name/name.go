@@ -1,6 +1,7 @@
 package name
 
 import (
+	"bytes"
 	"encoding/binary"
 	"fmt"
 	"strings"
@@ -11,10 +12,34 @@ import (
 
 var prefix = []byte("name")
 
+// reversePrefix is the prefix of the primary name of an address.
+var reversePrefix = []byte("rev")
+
+// expiryStartKey stores the block number from which the names registered
+// before the name expiry was activated start their registration period.
+var expiryStartKey = []byte("expirystart")
+
+const (
+	// NameRegistrationPeriod is the number of blocks for which a name is
+	// registered or renewed.
+	NameRegistrationPeriod = 365 * 24 * 60 * 60
+	// NameGracePeriod is the number of blocks after the expiry during which
+	// only the owner may renew a name. Afterwards the name is released.
+	NameGracePeriod = 30 * 24 * 60 * 60
+)
+
+// Status of a name, reported by GetNameInfo.
+const (
+	NameActive   = "ACTIVE"
+	NameGrace    = "GRACE"
+	NameReleased = "RELEASED"
+)
+
 type NameMap struct {
 	Version     byte
 	Owner       []byte
 	Destination []byte
+	ExpireNo    uint64
 }
 
 // AccountStateReader is an interface for getting a name account state.
@@ -22,16 +47,56 @@ type AccountStateReader interface {
 	GetNameAccountState() (*state.ContractState, error)
 }
 
-func CreateName(scs *state.ContractState, tx *types.TxBody, sender, receiver *state.V, name string) error {
+func CreateName(scs *state.ContractState, tx *types.TxBody, sender, receiver *state.V, name string,
+	blockInfo *types.BlockHeaderInfo) error {
 	amount := tx.GetAmountBigInt()
 	sender.SubBalance(amount)
 	receiver.AddBalance(amount)
-	return createName(scs, []byte(name), sender.ID())
+	var expireNo uint64
+	if blockInfo.IsFeatureActive(types.FeatureNameExpiry) {
+		expireNo = blockInfo.No + NameRegistrationPeriod
+	}
+	return createName(scs, []byte(name), sender.ID(), expireNo)
 }
 
-func createName(scs *state.ContractState, name []byte, owner []byte) error {
+func createName(scs *state.ContractState, name []byte, owner []byte, expireNo uint64) error {
 	//	return setAddress(scs, name, owner)
-	return registerOwner(scs, name, owner, owner)
+	return registerOwner(scs, name, owner, owner, expireNo)
+}
+
+// RenewName extends the registration period of name.
+func RenewName(scs *state.ContractState, tx *types.TxBody, sender, receiver *state.V, name string) error {
+	nameMap := getNameMap(scs, []byte(name), false)
+	if nameMap == nil {
+		return fmt.Errorf("%s is not created yet", name)
+	}
+	amount := tx.GetAmountBigInt()
+	sender.SubBalance(amount)
+	receiver.AddBalance(amount)
+	return registerOwner(scs, []byte(name), nameMap.Owner, nameMap.Destination,
+		expireNo(scs, nameMap, false)+NameRegistrationPeriod)
+}
+
+// SetSubname points subname to the address to. An empty to removes it.
+func SetSubname(scs *state.ContractState, subname, to string) error {
+	if to == "" {
+		return scs.DeleteData(nameKey([]byte(subname)))
+	}
+	_, parent, _ := types.ParseSubname(subname)
+	destination, err := types.DecodeAddress(to)
+	if err != nil {
+		return err
+	}
+	return registerOwner(scs, []byte(subname), getOwner(scs, []byte(parent), false), destination, 0)
+}
+
+// SetPrimaryName sets the name to which the address of owner is reversely
+// resolved. An empty name removes it.
+func SetPrimaryName(scs *state.ContractState, owner []byte, name string) error {
+	if name == "" {
+		return scs.DeleteData(append(reversePrefix, owner...))
+	}
+	return scs.SetData(append(reversePrefix, owner...), []byte(strings.ToLower(name)))
 }
 
 //UpdateName is avaliable after bid implement
@@ -65,12 +130,21 @@ func UpdateName(bs *state.BlockState, scs *state.ContractState, tx *types.TxBody
 
 func updateName(scs *state.ContractState, name []byte, owner []byte, to []byte) error {
 	//return setAddress(scs, name, to)
-	return registerOwner(scs, name, owner, to)
+	var expireNo uint64
+	if nameMap := getNameMap(scs, name, false); nameMap != nil {
+		expireNo = nameMap.ExpireNo
+	}
+	return registerOwner(scs, name, owner, to, expireNo)
 }
 
-//Resolve is resolve name for chain
-func Resolve(bs *state.BlockState, name []byte) []byte {
-	if len(name) == types.AddressLength ||
+//Resolve is resolve name for chain. Once the name expiry is activated, expired
+//names and subnames of expired names are not resolved.
+func Resolve(bs *state.BlockState, name []byte, bi *types.BlockHeaderInfo) []byte {
+	if len(name) == types.AddressLength {
+		return name
+	}
+	expiry := bi.IsFeatureActive(types.FeatureNameExpiry)
+	if _, _, isSubname := types.ParseSubname(string(name)); !(expiry && isSubname) &&
 		strings.Contains(string(name), ".") {
 		return name
 	}
@@ -78,7 +152,10 @@ func Resolve(bs *state.BlockState, name []byte) []byte {
 	if err != nil {
 		return name
 	}
-	return getAddress(scs, name)
+	if !expiry {
+		return getAddress(scs, name)
+	}
+	return getActiveAddress(scs, name, bi.No)
 }
 
 func openContract(bs *state.BlockState) (*state.ContractState, error) {
@@ -110,6 +187,83 @@ func getAddress(scs *state.ContractState, name []byte) []byte {
 	return nil
 }
 
+// getActiveAddress returns the destination of name if it is not expired at
+// blockNo. A subname is resolved only while its name is not expired and
+// owned by the owner who set it.
+func getActiveAddress(scs *state.ContractState, name []byte, blockNo types.BlockNo) []byte {
+	_, parent, isSubname := types.ParseSubname(string(name))
+	if !isSubname {
+		nameMap := getNameMap(scs, name, true)
+		if nameMap == nil || nameStatus(scs, nameMap, blockNo, true) != NameActive {
+			return nil
+		}
+		return nameMap.Destination
+	}
+	parentMap := getNameMap(scs, []byte(parent), true)
+	if parentMap == nil || nameStatus(scs, parentMap, blockNo, true) != NameActive {
+		return nil
+	}
+	nameMap := getNameMap(scs, name, true)
+	if nameMap == nil || !bytes.Equal(nameMap.Owner, parentMap.Owner) {
+		return nil
+	}
+	return nameMap.Destination
+}
+
+// expireNo returns the last block number of the registration period of
+// nameMap, or 0 if it does not expire.
+func expireNo(scs *state.ContractState, nameMap *NameMap, useInitial bool) types.BlockNo {
+	if nameMap.ExpireNo != 0 {
+		return nameMap.ExpireNo
+	}
+	// the names registered before the name expiry was activated
+	var (
+		data []byte
+		err  error
+	)
+	if useInitial {
+		data, err = scs.GetInitialData(expiryStartKey)
+	} else {
+		data, err = scs.GetData(expiryStartKey)
+	}
+	if err != nil || len(data) != 8 {
+		return 0
+	}
+	return binary.LittleEndian.Uint64(data) + NameRegistrationPeriod
+}
+
+// InitExpiryStart records blockNo as the start of the registration period of
+// the names registered before the name expiry was activated. It must be called
+// at the start of every block from the activation, and only the first call,
+// which is at the activation block, records the start.
+func InitExpiryStart(bs *state.BlockState, blockNo types.BlockNo) error {
+	scs, err := bs.GetNameAccountState()
+	if err != nil {
+		return err
+	}
+	if data, err := scs.GetData(expiryStartKey); err != nil || len(data) != 0 {
+		return err
+	}
+	buf := make([]byte, 8)
+	binary.LittleEndian.PutUint64(buf, blockNo)
+	if err := scs.SetData(expiryStartKey, buf); err != nil {
+		return err
+	}
+	return bs.StageContractState(scs)
+}
+
+func nameStatus(scs *state.ContractState, nameMap *NameMap, blockNo types.BlockNo, useInitial bool) string {
+	expire := expireNo(scs, nameMap, useInitial)
+	switch {
+	case expire == 0 || blockNo <= expire:
+		return NameActive
+	case blockNo <= expire+NameGracePeriod:
+		return NameGrace
+	default:
+		return NameReleased
+	}
+}
+
 func GetOwner(scs *state.ContractState, name []byte) []byte {
 	return getOwner(scs, name, true)
 }
@@ -123,8 +277,7 @@ func getOwner(scs *state.ContractState, name []byte, useInitial bool) []byte {
 }
 
 func getNameMap(scs *state.ContractState, name []byte, useInitial bool) *NameMap {
-	lowerCaseName := strings.ToLower(string(name))
-	key := append(prefix, lowerCaseName...)
+	key := nameKey(name)
 	var err error
 	var ownerdata []byte
 	if useInitial {
@@ -138,24 +291,70 @@ func getNameMap(scs *state.ContractState, name []byte, useInitial bool) *NameMap
 	return deserializeNameMap(ownerdata)
 }
 
-func GetNameInfo(r AccountStateReader, name string) (*types.NameInfo, error) {
+// GetNameInfo returns the name info of name at blockNo. If name is an
+// address, the info of its primary name is returned.
+func GetNameInfo(r AccountStateReader, name string, blockNo types.BlockNo) (*types.NameInfo, error) {
 	scs, err := r.GetNameAccountState()
 	if err != nil {
 		return nil, err
 	}
+	if len(name) == types.EncodedAddressLength {
+		addr, err := types.DecodeAddress(name)
+		if err != nil {
+			return nil, err
+		}
+		if name = GetPrimaryName(scs, addr, blockNo); name == "" {
+			return nil, types.ErrNameNotFound
+		}
+	}
 	owner := getOwner(scs, []byte(name), true)
-	return &types.NameInfo{Name: &types.Name{Name: string(name)}, Owner: owner, Destination: GetAddress(scs, []byte(name))}, err
+	info := &types.NameInfo{Name: &types.Name{Name: string(name)}, Owner: owner, Destination: GetAddress(scs, []byte(name))}
+	nameMap := getNameMap(scs, []byte(name), true)
+	if nameMap == nil {
+		return info, err
+	}
+	// a subname has the registration period of its name
+	if _, parent, isSubname := types.ParseSubname(name); isSubname {
+		info.Destination = nameMap.Destination
+		if nameMap = getNameMap(scs, []byte(parent), true); nameMap == nil || !bytes.Equal(nameMap.Owner, owner) {
+			info.Status = NameReleased
+			return info, err
+		}
+	}
+	info.ExpireNo = expireNo(scs, nameMap, true)
+	info.Status = nameStatus(scs, nameMap, blockNo, true)
+	return info, err
+}
+
+// GetPrimaryName returns the primary name of addr, if it is still resolved to
+// addr at blockNo.
+func GetPrimaryName(scs *state.ContractState, addr []byte, blockNo types.BlockNo) string {
+	name, err := scs.GetInitialData(append(reversePrefix, addr...))
+	if err != nil || len(name) == 0 {
+		return ""
+	}
+	if !bytes.Equal(getActiveAddress(scs, name, blockNo), addr) {
+		return ""
+	}
+	return string(name)
 }
 
-func registerOwner(scs *state.ContractState, name, owner, destination []byte) error {
+func registerOwner(scs *state.ContractState, name, owner, destination []byte, expireNo uint64) error {
 	nameMap := &NameMap{Version: 1, Owner: owner, Destination: destination}
+	if expireNo != 0 {
+		nameMap.Version = 2
+		nameMap.ExpireNo = expireNo
+	}
 	return setNameMap(scs, name, nameMap)
 }
 
 func setNameMap(scs *state.ContractState, name []byte, n *NameMap) error {
+	return scs.SetData(nameKey(name), serializeNameMap(n))
+}
+
+func nameKey(name []byte) []byte {
 	lowerCaseName := strings.ToLower(string(name))
-	key := append(prefix, lowerCaseName...)
-	return scs.SetData(key, serializeNameMap(n))
+	return append(prefix, lowerCaseName...)
 }
 
 func serializeNameMap(n *NameMap) []byte {
@@ -169,6 +368,10 @@ func serializeNameMap(n *NameMap) []byte {
 		binary.LittleEndian.PutUint64(buf, uint64(len(n.Destination)))
 		ret = append(ret, buf...)
 		ret = append(ret, n.Destination...)
+		if n.Version >= 2 {
+			binary.LittleEndian.PutUint64(buf, n.ExpireNo)
+			ret = append(ret, buf...)
+		}
 	}
 	return ret
 }
@@ -176,7 +379,7 @@ func serializeNameMap(n *NameMap) []byte {
 func deserializeNameMap(data []byte) *NameMap {
 	if data != nil {
 		version := data[0]
-		if version != 1 {
+		if version != 1 && version != 2 {
 			panic("could not deserializeOwner, not supported version")
 		}
 		offset := 1
@@ -194,10 +397,16 @@ func deserializeNameMap(data []byte) *NameMap {
 		offset = next
 		next = offset + int(sizeOfDest)
 		destination := data[offset:next]
+
+		var expireNo uint64
+		if version >= 2 {
+			expireNo = binary.LittleEndian.Uint64(data[next : next+8])
+		}
 		return &NameMap{
 			Version:     version,
 			Owner:       owner,
 			Destination: destination,
+			ExpireNo:    expireNo,
 		}
 	}
 	return nil
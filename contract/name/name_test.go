@@ -19,7 +19,7 @@ func initTest(t *testing.T) {
 	genesis := types.GetTestGenesis()
 	sdb = state.NewChainStateDB()
 	sdb.Init(string(db.BadgerImpl), "test", genesis.Block(), false)
-	err := sdb.SetGenesis(genesis, nil)
+	err := sdb.SetGenesis(genesis, nil, nil)
 	if err != nil {
 		t.Fatalf("failed init : %s", err.Error())
 	}
@@ -47,11 +47,11 @@ func TestName(t *testing.T) {
 	scs := openContractState(t, bs)
 	systemcs := openSystemContractState(t, bs)
 
-	err := CreateName(scs, tx, sender, receiver, name)
+	err := CreateName(scs, tx, sender, receiver, name, &types.BlockHeaderInfo{})
 	assert.NoError(t, err, "create name")
 
 	scs = nextBlockContractState(t, bs, scs)
-	_, err = ValidateNameTx(tx, sender, scs, systemcs)
+	_, err = ValidateNameTx(tx, sender, scs, systemcs, &types.BlockHeaderInfo{})
 	assert.Error(t, err, "same name")
 
 	ret := getAddress(scs, []byte(name))
@@ -81,7 +81,7 @@ func TestNameRecursive(t *testing.T) {
 	receiver, _ := sdb.GetStateDB().GetAccountStateV(tx.Recipient)
 	bs := sdb.NewBlockState(sdb.GetRoot())
 	scs := openContractState(t, bs)
-	err := CreateName(scs, tx, sender, receiver, name1)
+	err := CreateName(scs, tx, sender, receiver, name1, &types.BlockHeaderInfo{})
 	assert.NoError(t, err, "create name")
 
 	tx.Account = []byte(name1)
@@ -89,7 +89,7 @@ func TestNameRecursive(t *testing.T) {
 	tx.Payload = buildNamePayload(name2, types.NameCreate, "")
 
 	scs = nextBlockContractState(t, bs, scs)
-	err = CreateName(scs, tx, sender, receiver, name2)
+	err = CreateName(scs, tx, sender, receiver, name2, &types.BlockHeaderInfo{})
 	assert.NoError(t, err, "redirect name")
 
 	scs = nextBlockContractState(t, bs, scs)
@@ -123,7 +123,7 @@ func TestNameNil(t *testing.T) {
 	sender, _ := sdb.GetStateDB().GetAccountStateV(tx.Account)
 	receiver, _ := sdb.GetStateDB().GetAccountStateV(tx.Recipient)
 
-	err = CreateName(scs, tx, sender, receiver, name2)
+	err = CreateName(scs, tx, sender, receiver, name2, &types.BlockHeaderInfo{})
 	assert.NoError(t, err, "create name")
 }
 
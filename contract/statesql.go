@@ -154,6 +154,67 @@ func SaveRecoveryPoint(bs *state.BlockState) error {
 	return nil
 }
 
+const batchSavepoint = "batch"
+
+// SQLBatch undoes the changes of the SQL databases made by the operations of
+// a batch tx. A savepoint is set in the databases written before the batch,
+// and the databases first written in the batch are rolled back entirely.
+type SQLBatch struct {
+	written map[string]bool
+}
+
+// BeginSQLBatch sets the batch savepoint in the databases written so far in
+// the block.
+func BeginSQLBatch() (*SQLBatch, error) {
+	b := &SQLBatch{written: map[string]bool{}}
+	for name, db := range database.DBs {
+		if db.tx == nil {
+			continue
+		}
+		if err := db.tx.subSavepoint(batchSavepoint); err != nil {
+			return nil, err
+		}
+		b.written[name] = true
+	}
+	return b, nil
+}
+
+// Release keeps the changes made in the batch.
+func (b *SQLBatch) Release() error {
+	for name := range b.written {
+		if db, ok := database.DBs[name]; ok && db.tx != nil {
+			if err := db.tx.subRelease(batchSavepoint); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Rollback discards the changes made in the batch.
+func (b *SQLBatch) Rollback() error {
+	for name, db := range database.DBs {
+		if db.tx == nil {
+			continue
+		}
+		if !b.written[name] {
+			err := db.tx.rollback()
+			db.tx = nil
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		if err := db.tx.rollbackToSubSavepoint(batchSavepoint); err != nil {
+			return err
+		}
+		if err := db.tx.subRelease(batchSavepoint); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func beginTx(dbName string, rp uint64) (sqlTx, error) {
 	db, err := conn(dbName)
 	defer func() {
@@ -0,0 +1,323 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package system
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/aergoio/aergo/state"
+	"github.com/aergoio/aergo/types"
+)
+
+var (
+	delegationKey = []byte("delegation\\")
+	delegatedKey  = []byte("delegated\\")
+
+	ErrVotingPowerDelegated = errors.New("voting power is delegated")
+	ErrNoDelegation         = errors.New("voting power is not delegated")
+)
+
+// delegation is the voting power that a delegator gives to its delegate. The
+// amount follows the staking of the delegator.
+type delegation struct {
+	to     []byte
+	amount *big.Int
+}
+
+func serializeDelegation(d *delegation) []byte {
+	ret := []byte{byte(len(d.to))}
+	ret = append(ret, d.to...)
+	return append(ret, d.amount.Bytes()...)
+}
+
+func deserializeDelegation(data []byte) *delegation {
+	if len(data) == 0 {
+		return nil
+	}
+	size := int(data[0])
+	return &delegation{
+		to:     data[1 : 1+size],
+		amount: new(big.Int).SetBytes(data[1+size:]),
+	}
+}
+
+func getDelegation(scs *state.ContractState, delegator []byte) (*delegation, error) {
+	data, err := scs.GetData(append(delegationKey, delegator...))
+	if err != nil {
+		return nil, err
+	}
+	return deserializeDelegation(data), nil
+}
+
+func setDelegation(scs *state.ContractState, delegator []byte, d *delegation) error {
+	if d == nil {
+		return scs.DeleteData(append(delegationKey, delegator...))
+	}
+	return scs.SetData(append(delegationKey, delegator...), serializeDelegation(d))
+}
+
+// getDelegated returns the total voting power delegated to delegate.
+func getDelegated(scs *state.ContractState, delegate []byte) (*big.Int, error) {
+	data, err := scs.GetData(append(delegatedKey, delegate...))
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(data), nil
+}
+
+func addDelegated(scs *state.ContractState, delegate []byte, delta *big.Int) error {
+	delegated, err := getDelegated(scs, delegate)
+	if err != nil {
+		return err
+	}
+	return scs.SetData(append(delegatedKey, delegate...), delegated.Add(delegated, delta).Bytes())
+}
+
+// GetDelegation returns the delegate of addr and the voting power delegated
+// to addr by the others.
+func GetDelegation(scs *state.ContractState, addr []byte) ([]byte, *big.Int, error) {
+	d, err := getDelegation(scs, addr)
+	if err != nil {
+		return nil, nil, err
+	}
+	delegated, err := getDelegated(scs, addr)
+	if err != nil {
+		return nil, nil, err
+	}
+	if d == nil {
+		return nil, delegated, nil
+	}
+	return d.to, delegated, nil
+}
+
+// bpVotingPower returns the voting power of addr for the BP election, which
+// includes the power delegated to addr.
+func bpVotingPower(scs *state.ContractState, addr []byte, staked *big.Int, blockInfo *types.BlockHeaderInfo) (*big.Int, error) {
+	if !blockInfo.IsFeatureActive(types.FeatureDelegation) {
+		return staked, nil
+	}
+	delegated, err := getDelegated(scs, addr)
+	if err != nil {
+		return nil, err
+	}
+	return delegated.Add(delegated, staked), nil
+}
+
+// adjustDelegatedVote changes the BP vote of delegate by delta. The delegated
+// power is counted when the delegate votes if it hasn't voted yet.
+func adjustDelegatedVote(scs *state.ContractState, delegate []byte, delta *big.Int, blockNo types.BlockNo) error {
+	if delta.Sign() == 0 {
+		return nil
+	}
+	vote, err := getVote(scs, defaultVoteKey, delegate)
+	if err != nil {
+		return err
+	}
+	if vote.Amount == nil {
+		return nil
+	}
+	voteResult, err := loadVoteResult(scs, defaultVoteKey)
+	if err != nil {
+		return err
+	}
+	voteResult.blockNo = blockNo
+
+	id := types.ToAccountID(delegate)
+	votingPowerRank.sub(id, delegate, vote.GetAmountBigInt())
+	if err := voteResult.SubVote(vote); err != nil {
+		return err
+	}
+	vote.Amount = new(big.Int).Add(vote.GetAmountBigInt(), delta).Bytes()
+	if err := setVote(scs, defaultVoteKey, delegate, vote); err != nil {
+		return err
+	}
+	votingPowerRank.add(id, delegate, vote.GetAmountBigInt())
+	if err := voteResult.AddVote(vote); err != nil {
+		return err
+	}
+	return voteResult.Sync()
+}
+
+// refreshDelegation makes the delegation of delegator follow its staking.
+func refreshDelegation(context *SystemContext) error {
+	var (
+		scs       = context.scs
+		delegator = context.Sender.ID()
+	)
+	if !context.BlockInfo.IsFeatureActive(types.FeatureDelegation) {
+		return nil
+	}
+	d, err := getDelegation(scs, delegator)
+	if err != nil || d == nil {
+		return err
+	}
+	staked := context.Staked.GetAmountBigInt()
+	delta := new(big.Int).Sub(staked, d.amount)
+	if err := addDelegated(scs, d.to, delta); err != nil {
+		return err
+	}
+	if err := adjustDelegatedVote(scs, d.to, delta, context.BlockInfo.No); err != nil {
+		return err
+	}
+	if staked.Sign() == 0 {
+		return setDelegation(scs, delegator, nil)
+	}
+	d.amount = staked
+	return setDelegation(scs, delegator, d)
+}
+
+func validateForDelegate(account []byte, ci *types.CallInfo, txBody *types.TxBody, scs *state.ContractState,
+	blockInfo *types.BlockHeaderInfo) (*types.Staking, []byte, error) {
+	if !blockInfo.IsFeatureActive(types.FeatureDelegation) {
+		return nil, nil, fmt.Errorf("not supported operation")
+	}
+	if txBody.GetAmountBigInt().Sign() != 0 {
+		return nil, nil, fmt.Errorf("voting power must be delegated without amount")
+	}
+	staked, err := checkStakingBefore(account, scs)
+	if err != nil {
+		return nil, nil, types.ErrMustStakeBeforeVote
+	}
+	delegate, err := types.DecodeAddress(ci.Args[0].(string))
+	if err != nil {
+		return nil, nil, err
+	}
+	if string(delegate) == string(account) {
+		return nil, nil, fmt.Errorf("cannot delegate to oneself")
+	}
+	if d, err := getDelegation(scs, account); err != nil {
+		return nil, nil, err
+	} else if d != nil {
+		return nil, nil, ErrVotingPowerDelegated
+	}
+	// Delegations are not chained: a delegate doesn't delegate the power
+	// given to it.
+	if delegated, err := getDelegated(scs, account); err != nil {
+		return nil, nil, err
+	} else if delegated.Sign() != 0 {
+		return nil, nil, fmt.Errorf("cannot delegate the delegated voting power")
+	}
+	if d, err := getDelegation(scs, delegate); err != nil {
+		return nil, nil, err
+	} else if d != nil {
+		return nil, nil, fmt.Errorf("delegate has delegated its voting power")
+	}
+	return staked, delegate, nil
+}
+
+func validateForUndelegate(account []byte, txBody *types.TxBody, scs *state.ContractState,
+	blockInfo *types.BlockHeaderInfo) error {
+	if !blockInfo.IsFeatureActive(types.FeatureDelegation) {
+		return fmt.Errorf("not supported operation")
+	}
+	if txBody.GetAmountBigInt().Sign() != 0 {
+		return fmt.Errorf("delegation must be revoked without amount")
+	}
+	d, err := getDelegation(scs, account)
+	if err != nil {
+		return err
+	}
+	if d == nil {
+		return ErrNoDelegation
+	}
+	return nil
+}
+
+type delegateCmd struct {
+	*SystemContext
+	delegate []byte
+}
+
+func newDelegateCmd(ctx *SystemContext) (sysCmd, error) {
+	return &delegateCmd{SystemContext: ctx, delegate: ctx.Delegate}, nil
+}
+
+func (c *delegateCmd) run() (*types.Event, error) {
+	var (
+		scs    = c.scs
+		sender = c.Sender
+		amount = c.Staked.GetAmountBigInt()
+	)
+
+	// The delegator's own BP vote is replaced by the delegation.
+	if c.Vote.Amount != nil {
+		voteResult, err := loadVoteResult(scs, defaultVoteKey)
+		if err != nil {
+			return nil, err
+		}
+		voteResult.blockNo = c.BlockInfo.No
+		votingPowerRank.sub(sender.AccountID(), sender.ID(), c.Vote.GetAmountBigInt())
+		if err := voteResult.SubVote(c.Vote); err != nil {
+			return nil, err
+		}
+		if err := deleteVote(scs, defaultVoteKey, sender.ID()); err != nil {
+			return nil, err
+		}
+		if err := voteResult.Sync(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := setDelegation(scs, sender.ID(), &delegation{to: c.delegate, amount: amount}); err != nil {
+		return nil, err
+	}
+	if err := addDelegated(scs, c.delegate, amount); err != nil {
+		return nil, err
+	}
+	if err := adjustDelegatedVote(scs, c.delegate, amount, c.BlockInfo.No); err != nil {
+		return nil, err
+	}
+	return &types.Event{
+		ContractAddress: c.Receiver.ID(),
+		EventIdx:        0,
+		EventName:       "delegate",
+		JsonArgs: `["` +
+			types.EncodeAddress(sender.ID()) +
+			`", "` + types.EncodeAddress(c.delegate) +
+			`", "` + amount.String() + `"]`,
+	}, nil
+}
+
+type undelegateCmd struct {
+	*SystemContext
+}
+
+func newUndelegateCmd(ctx *SystemContext) (sysCmd, error) {
+	return &undelegateCmd{SystemContext: ctx}, nil
+}
+
+func (c *undelegateCmd) run() (*types.Event, error) {
+	var (
+		scs    = c.scs
+		sender = c.Sender
+	)
+
+	d, err := getDelegation(scs, sender.ID())
+	if err != nil {
+		return nil, err
+	}
+	revoked := new(big.Int).Neg(d.amount)
+	if err := addDelegated(scs, d.to, revoked); err != nil {
+		return nil, err
+	}
+	if err := adjustDelegatedVote(scs, d.to, revoked, c.BlockInfo.No); err != nil {
+		return nil, err
+	}
+	if err := setDelegation(scs, sender.ID(), nil); err != nil {
+		return nil, err
+	}
+	return &types.Event{
+		ContractAddress: c.Receiver.ID(),
+		EventIdx:        0,
+		EventName:       "undelegate",
+		JsonArgs: `["` +
+			types.EncodeAddress(sender.ID()) +
+			`", "` + types.EncodeAddress(d.to) +
+			`", "` + d.amount.String() + `"]`,
+	}, nil
+}
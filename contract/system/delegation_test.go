@@ -0,0 +1,88 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package system
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/aergoio/aergo/state"
+	"github.com/aergoio/aergo/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func bpVoteAmount(t *testing.T, scs *state.ContractState, voter *state.V) *big.Int {
+	vote, err := getVote(scs, defaultVoteKey, voter.ID())
+	assert.NoError(t, err, "fail to get vote")
+	return vote.GetAmountBigInt()
+}
+
+func TestVoteDelegation(t *testing.T) {
+	scs, delegator, receiver := initTest(t)
+	defer deinitTest()
+	votingPowerRank = newVpr()
+
+	delegate := getSender(t, "AmLt7Z3y2XTu7YS8KHNuyKM2QAszpFHSX77FLKEt7FAuRW7GEhj7")
+	blockInfo := &types.BlockHeaderInfo{No: 1, Version: types.FeatureDelegation.Version()}
+
+	for _, s := range []*state.V{delegator, delegate} {
+		s.AddBalance(types.MaxAER)
+		stake := &types.TxBody{Account: s.ID(), Amount: types.StakingMinimum.Bytes(), Payload: buildStakingPayload(true)}
+		_, err := ExecuteSystemTx(scs, stake, s, receiver, blockInfo)
+		assert.NoError(t, err, "staking failed")
+	}
+
+	vote := &types.TxBody{Account: delegate.ID(), Payload: buildVotingPayload(1)}
+	_, err := ExecuteSystemTx(scs, vote, delegate, receiver, blockInfo)
+	assert.NoError(t, err, "voting failed")
+	assert.Equal(t, types.StakingMinimum, bpVoteAmount(t, scs, delegate))
+
+	delegateTx := &types.TxBody{
+		Account: delegator.ID(),
+		Payload: []byte(`{"Name":"v1delegate","Args":["` + types.EncodeAddress(delegate.ID()) + `"]}`),
+	}
+	_, err = ValidateSystemTx(delegator.ID(), delegateTx, delegator, scs, &types.BlockHeaderInfo{No: 1, Version: 3})
+	assert.Error(t, err, "delegation is not supported before the block version 4")
+
+	events, err := ExecuteSystemTx(scs, delegateTx, delegator, receiver, blockInfo)
+	assert.NoError(t, err, "delegation failed")
+	assert.Equal(t, "delegate", events[0].EventName)
+	twice := new(big.Int).Mul(types.StakingMinimum, big.NewInt(2))
+	assert.Equal(t, twice, bpVoteAmount(t, scs, delegate))
+
+	to, delegated, err := GetDelegation(scs, delegator.ID())
+	assert.NoError(t, err)
+	assert.Equal(t, delegate.ID(), to)
+	assert.Equal(t, 0, delegated.Sign())
+	_, delegated, err = GetDelegation(scs, delegate.ID())
+	assert.NoError(t, err)
+	assert.Equal(t, types.StakingMinimum, delegated)
+
+	_, err = ExecuteSystemTx(scs, delegateTx, delegator, receiver, blockInfo)
+	assert.Equal(t, ErrVotingPowerDelegated, err)
+	vote.Account = delegator.ID()
+	_, err = ValidateSystemTx(delegator.ID(), vote, delegator, scs, blockInfo)
+	assert.Equal(t, ErrVotingPowerDelegated, err)
+
+	// The delegated power follows the staking of the delegator.
+	blockInfo.No += StakingDelay
+	stake := &types.TxBody{Account: delegator.ID(), Amount: types.StakingMinimum.Bytes(), Payload: buildStakingPayload(true)}
+	_, err = ExecuteSystemTx(scs, stake, delegator, receiver, blockInfo)
+	assert.NoError(t, err, "staking failed")
+	assert.Equal(t, new(big.Int).Mul(types.StakingMinimum, big.NewInt(3)), bpVoteAmount(t, scs, delegate))
+
+	undelegateTx := &types.TxBody{Account: delegator.ID(), Payload: []byte(`{"Name":"v1undelegate"}`)}
+	events, err = ExecuteSystemTx(scs, undelegateTx, delegator, receiver, blockInfo)
+	assert.NoError(t, err, "undelegation failed")
+	assert.Equal(t, "undelegate", events[0].EventName)
+	assert.Equal(t, types.StakingMinimum, bpVoteAmount(t, scs, delegate))
+
+	to, _, err = GetDelegation(scs, delegator.ID())
+	assert.NoError(t, err)
+	assert.Nil(t, to)
+	_, err = ExecuteSystemTx(scs, undelegateTx, delegator, receiver, blockInfo)
+	assert.Equal(t, ErrNoDelegation, err)
+}
@@ -0,0 +1,98 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package system
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/aergoio/aergo/consensus"
+	"github.com/aergoio/aergo/state"
+	"github.com/aergoio/aergo/types"
+	"github.com/golang/protobuf/proto"
+)
+
+var (
+	evidenceKey = []byte("evidence")
+
+	ErrEvidenceAlreadySubmitted = errors.New("evidence is already submitted")
+)
+
+type submitEvidenceCmd struct {
+	*SystemContext
+}
+
+func newSubmitEvidenceCmd(ctx *SystemContext) (sysCmd, error) {
+	return &submitEvidenceCmd{SystemContext: ctx}, nil
+}
+
+func (c *submitEvidenceCmd) run() (*types.Event, error) {
+	evidence := c.Evidence
+	data, err := proto.Marshal(evidence)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.scs.SetData(append(evidenceKey, evidence.Key()...), data); err != nil {
+		return nil, err
+	}
+	producer, err := evidence.Producer()
+	if err != nil {
+		return nil, err
+	}
+	return &types.Event{
+		ContractAddress: c.Receiver.ID(),
+		EventIdx:        0,
+		EventName:       "submitEvidence",
+		JsonArgs: fmt.Sprintf(`["%s", "%s", %d]`,
+			types.EncodeAddress(c.Sender.ID()), types.IDB58Encode(producer), evidence.Slot),
+	}, nil
+}
+
+// validateForEvidence checks the submitted evidence only with its content, so that every node gets the same result
+// regardless of the blocks it has seen.
+func validateForEvidence(ci *types.CallInfo, txBody *types.TxBody, scs *state.ContractState,
+	blockInfo *types.BlockHeaderInfo) (*types.DoubleSignEvidence, error) {
+	if !blockInfo.IsFeatureActive(types.FeatureEvidence) {
+		return nil, fmt.Errorf("not supported operation")
+	}
+	if txBody.GetAmountBigInt().Sign() != 0 {
+		return nil, fmt.Errorf("evidence must be submitted without amount")
+	}
+	if len(ci.Args) != 1 {
+		return nil, types.ErrTxInvalidPayload
+	}
+	encoded, ok := ci.Args[0].(string)
+	if !ok {
+		return nil, types.ErrTxInvalidPayload
+	}
+	evidence, err := types.DecodeDoubleSignEvidence(encoded)
+	if err != nil {
+		return nil, types.ErrTxInvalidPayload
+	}
+	if err := evidence.Verify(blockInfo.ChainId, consensus.BlockIntervalSec); err != nil {
+		return nil, err
+	}
+	submitted, err := getEvidence(scs, evidence.Key())
+	if err != nil {
+		return nil, err
+	}
+	if submitted != nil {
+		return nil, ErrEvidenceAlreadySubmitted
+	}
+	return evidence, nil
+}
+
+func getEvidence(scs *state.ContractState, key []byte) (*types.DoubleSignEvidence, error) {
+	data, err := scs.GetData(append(evidenceKey, key...))
+	if err != nil || len(data) == 0 {
+		return nil, err
+	}
+	var evidence types.DoubleSignEvidence
+	if err := proto.Unmarshal(data, &evidence); err != nil {
+		return nil, err
+	}
+	return &evidence, nil
+}
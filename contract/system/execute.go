@@ -23,9 +23,15 @@ type SystemContext struct {
 	Staked    *types.Staking
 	Vote      *types.Vote // voting
 	Proposal  *Proposal   // voting
+	Evidence  *types.DoubleSignEvidence
 	Sender    *state.V
 	Receiver  *state.V
 
+	// Unbondings is the sender's unstaked amounts waiting to be withdrawn.
+	Unbondings []*types.Unbonding
+	// Delegate is the account to which the sender delegates its voting power.
+	Delegate []byte
+
 	op     types.OpSysTx
 	scs    *state.ContractState
 	txBody *types.TxBody
@@ -66,6 +72,11 @@ func newSysCmd(account []byte, txBody *types.TxBody, sender, receiver *state.V,
 		types.OpvoteDAO: newVoteCmd,
 		types.Opstake:     newStakeCmd,
 		types.Opunstake:   newUnstakeCmd,
+		types.OpsubmitEvidence: newSubmitEvidenceCmd,
+		types.Opwithdraw:       newWithdrawCmd,
+		types.OpclaimReward:    newClaimRewardCmd,
+		types.Opdelegate:       newDelegateCmd,
+		types.Opundelegate:     newUndelegateCmd,
 	}
 
 	context, err := newSystemContext(account, txBody, sender, receiver, scs, blockInfo)
@@ -554,6 +554,9 @@ func TestProposalExecute(t *testing.T) {
 	}
 	_, err = ExecuteSystemTx(scs, unstakingTx.GetBody(), sender, receiver, blockInfo)
 	assert.NoError(t, err, "could not execute system tx")
+	blockInfo.No += StakingDelay
+	_, err = ExecuteSystemTx(scs, buildWithdrawTx(sender.ID()).GetBody(), sender, receiver, blockInfo)
+	assert.NoError(t, err, "could not execute system tx")
 	assert.Equal(t, new(big.Int).Sub(balance3, types.ProposalPrice), sender.Balance(), "sender.Balance() should be 2 after unstaking")
 
 	voteResult, err = getVoteResult(scs, GenProposalKey(bpCount.ID()), 1)
@@ -797,6 +800,9 @@ func TestProposalExecute2(t *testing.T) {
 	}
 	_, err = ExecuteSystemTx(scs, unstakingTx.GetBody(), sender, receiver, blockInfo)
 	assert.NoError(t, err, "could not execute system tx")
+	blockInfo.No += StakingDelay
+	_, err = ExecuteSystemTx(scs, buildWithdrawTx(sender.ID()).GetBody(), sender, receiver, blockInfo)
+	assert.NoError(t, err, "could not execute system tx")
 	assert.Equal(t, new(big.Int).Sub(balance2, types.ProposalPrice), sender.Balance(), "sender.Balance() should be 2 after unstaking")
 
 	voteResult, err = getVoteResult(scs, GenProposalKey(bpCount.ID()), 3)
@@ -1,6 +1,7 @@
 package system
 
 import (
+	"encoding/binary"
 	"math/big"
 	"strings"
 
@@ -22,9 +23,68 @@ const (
 	stakingMin
 	gasPrice
 	namePrice
+	unbondingPeriod
+	maxBlockSize
+	stakingDelay
+	votingReward
 	sysParamMax
 )
 
+const (
+	minBlockBodySize = 1 << 18
+	maxBlockBodySize = 1 << 25
+	// paramActivationDelay is the number of blocks between the end of a
+	// voting and the activation of the new value.
+	paramActivationDelay = StakingDelay
+)
+
+// paramSpec describes how a system parameter is decided by the DAO voting.
+type paramSpec struct {
+	// feature is the hardfork feature from which the parameter can be voted.
+	feature types.Feature
+	// min and max bound the candidate values. nil max means types.MaxAER.
+	min, max *big.Int
+	// delay is the number of blocks to wait before a new value is applied.
+	delay uint64
+}
+
+func (ps *paramSpec) upper() *big.Int {
+	if ps.max == nil {
+		return types.MaxAER
+	}
+	return ps.max
+}
+
+func (ps *paramSpec) validate(value *big.Int) bool {
+	return ps.min.Cmp(value) <= 0 && ps.upper().Cmp(value) >= 0
+}
+
+var paramSpecs = map[string]*paramSpec{}
+
+// registerParam makes the parameter id decidable by the DAO voting.
+func registerParam(id sysParamIndex, spec *paramSpec) {
+	paramSpecs[id.ID()] = spec
+	SystemProposal[id.ID()] = &Proposal{
+		ID:             id.ID(),
+		MultipleChoice: 1,
+	}
+}
+
+func init() {
+	registerParam(bpCount, &paramSpec{feature: types.FeatureDaoVote, min: big.NewInt(1), max: big.NewInt(100)})
+	registerParam(stakingMin, &paramSpec{feature: types.FeatureDaoVote, min: big.NewInt(1)})
+	registerParam(gasPrice, &paramSpec{feature: types.FeatureDaoVote, min: big.NewInt(1)})
+	registerParam(namePrice, &paramSpec{feature: types.FeatureDaoVote, min: big.NewInt(1)})
+	registerParam(unbondingPeriod, &paramSpec{feature: types.FeatureUnbonding, min: big.NewInt(1), max: big.NewInt(maxUnbondingPeriod)})
+	registerParam(maxBlockSize, &paramSpec{feature: types.FeatureGovernance, min: big.NewInt(minBlockBodySize), max: big.NewInt(maxBlockBodySize), delay: paramActivationDelay})
+	registerParam(stakingDelay, &paramSpec{feature: types.FeatureGovernance, min: big.NewInt(1), max: big.NewInt(maxUnbondingPeriod), delay: paramActivationDelay})
+	registerParam(votingReward, &paramSpec{feature: types.FeatureGovernance, min: big.NewInt(1), delay: paramActivationDelay})
+}
+
+func getParamSpec(id string) *paramSpec {
+	return paramSpecs[id]
+}
+
 var (
 	systemParams parameters
 
@@ -33,6 +93,12 @@ var (
 		stakingMin.ID(): types.StakingMinimum,
 		gasPrice.ID():   big.NewInt(1),
 		namePrice.ID():  big.NewInt(1000000000000000000),
+		// unbondingPeriod is only valid from the block version 3
+		unbondingPeriod.ID(): big.NewInt(StakingDelay),
+		stakingDelay.ID():    big.NewInt(StakingDelay),
+		votingReward.ID():    defaultReward,
+		// maxBlockSize has no default: the node configuration is used until
+		// it is decided by the voting.
 	}
 )
 
@@ -45,6 +111,10 @@ func genParamKey(id string) []byte {
 	return []byte("param\\" + strings.ToUpper(id))
 }
 
+func genPendingParamKey(id string) []byte {
+	return []byte("param\\pending\\" + strings.ToUpper(id))
+}
+
 func loadParam(g dataGetter) parameters {
 	ret := map[string]*big.Int{}
 	for i := sysParamIndex(0); i < sysParamMax; i++ {
@@ -82,6 +152,83 @@ func updateParam(s dataSetter, id string, value *big.Int) (*big.Int, error) {
 	return ret, nil
 }
 
+// applyParam sets the parameter id to value which won the voting at the block
+// blockNo. The value is applied after the activation delay of the parameter.
+func applyParam(scs *state.ContractState, id string, value *big.Int, blockNo types.BlockNo) error {
+	spec := getParamSpec(id)
+	if spec == nil || spec.delay == 0 {
+		_, err := updateParam(scs, id, value)
+		return err
+	}
+	key := genPendingParamKey(id)
+	pending, _, err := getPendingParam(scs, id)
+	if err != nil {
+		return err
+	}
+	current, err := getCurrentParam(scs, id)
+	if err != nil {
+		return err
+	}
+	if current != nil && current.Cmp(value) == 0 {
+		// The voting is back to the current value.
+		if pending != nil {
+			return scs.DeleteData(key)
+		}
+		return nil
+	}
+	if pending != nil && pending.Cmp(value) == 0 {
+		// Keep the activation block of the same value.
+		return nil
+	}
+	data := make([]byte, 8)
+	binary.LittleEndian.PutUint64(data, blockNo+spec.delay)
+	return scs.SetData(key, append(data, value.Bytes()...))
+}
+
+// getCurrentParam returns the value of the parameter id including the updates
+// in the current block.
+func getCurrentParam(scs *state.ContractState, id string) (*big.Int, error) {
+	data, err := scs.GetData(genParamKey(id))
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return DefaultParams[id], nil
+	}
+	return new(big.Int).SetBytes(data), nil
+}
+
+func getPendingParam(scs *state.ContractState, id string) (*big.Int, types.BlockNo, error) {
+	data, err := scs.GetData(genPendingParamKey(id))
+	if err != nil || len(data) < 8 {
+		return nil, 0, err
+	}
+	return new(big.Int).SetBytes(data[8:]), binary.LittleEndian.Uint64(data[:8]), nil
+}
+
+// ActivateParams applies the voted parameters whose activation block is not
+// after blockNo. It reports whether any parameter is updated.
+func ActivateParams(scs *state.ContractState, blockNo types.BlockNo) (bool, error) {
+	activated := false
+	for i := sysParamIndex(0); i < sysParamMax; i++ {
+		value, at, err := getPendingParam(scs, i.ID())
+		if err != nil {
+			return false, err
+		}
+		if value == nil || at > blockNo {
+			continue
+		}
+		if _, err := updateParam(scs, i.ID(), value); err != nil {
+			return false, err
+		}
+		if err := scs.DeleteData(genPendingParamKey(i.ID())); err != nil {
+			return false, err
+		}
+		activated = true
+	}
+	return activated, nil
+}
+
 func GetStakingMinimum() *big.Int {
 	return GetParam(stakingMin.ID())
 }
@@ -94,6 +241,20 @@ func GetNamePrice() *big.Int {
 	return GetParam(namePrice.ID())
 }
 
+// GetMaxBlockSize returns the maximum block body size decided by the voting.
+// It returns nil unless it has been decided.
+func GetMaxBlockSize() *big.Int {
+	return GetParam(maxBlockSize.ID())
+}
+
+func GetStakingDelay() *big.Int {
+	return GetParam(stakingDelay.ID())
+}
+
+func GetUnbondingPeriod() *big.Int {
+	return GetParam(unbondingPeriod.ID())
+}
+
 func GetNamePriceFromState(scs *state.ContractState) *big.Int {
 	return getParamFromState(scs, namePrice)
 }
@@ -102,6 +263,22 @@ func GetStakingMinimumFromState(scs *state.ContractState) *big.Int {
 	return getParamFromState(scs, stakingMin)
 }
 
+func GetUnbondingPeriodFromState(scs *state.ContractState) *big.Int {
+	return getParamFromState(scs, unbondingPeriod)
+}
+
+func GetStakingDelayFromState(scs *state.ContractState) uint64 {
+	return getParamFromState(scs, stakingDelay).Uint64()
+}
+
+func GetVotingRewardFromState(ar AccountStateReader) *big.Int {
+	scs, err := ar.GetSystemAccountState()
+	if err != nil {
+		panic("could not open system state when get voting reward")
+	}
+	return getParamFromState(scs, votingReward)
+}
+
 func GetGasPriceFromState(ar AccountStateReader) *big.Int {
 	scs, err := ar.GetSystemAccountState()
 	if err != nil {
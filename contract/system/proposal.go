@@ -39,40 +39,9 @@ type Proposal struct {
 	Default        *big.Int
 }
 
-var SystemProposal = map[string]*Proposal{
-	bpCount.ID(): &Proposal{
-		ID:             bpCount.ID(),
-		Description:    "",
-		Blockfrom:      0,
-		Blockto:        0,
-		MultipleChoice: 1,
-		Candidates:     nil,
-	},
-	stakingMin.ID(): &Proposal{
-		ID:             stakingMin.ID(),
-		Description:    "",
-		Blockfrom:      0,
-		Blockto:        0,
-		MultipleChoice: 1,
-		Candidates:     nil,
-	},
-	gasPrice.ID(): &Proposal{
-		ID:             gasPrice.ID(),
-		Description:    "",
-		Blockfrom:      0,
-		Blockto:        0,
-		MultipleChoice: 1,
-		Candidates:     nil,
-	},
-	namePrice.ID(): &Proposal{
-		ID:             namePrice.ID(),
-		Description:    "",
-		Blockfrom:      0,
-		Blockto:        0,
-		MultipleChoice: 1,
-		Candidates:     nil,
-	},
-}
+// SystemProposal is the proposals of the system parameters registered by
+// registerParam.
+var SystemProposal = map[string]*Proposal{}
 
 func (a *Proposal) GetKey() []byte {
 	return []byte(strings.ToUpper(a.ID))
@@ -223,3 +223,76 @@ func TestFailProposals(t *testing.T) {
 	assert.NoError(t, err, "valid")
 	assert.Equal(t, big.NewInt(101), GetGasPrice(), "check gas price")
 }
+
+func TestProposalActivationDelay(t *testing.T) {
+	scs, sender, receiver := initTest(t)
+	defer deinitTest()
+
+	balance3 := new(big.Int).Mul(types.StakingMinimum, big.NewInt(3))
+	sender2 := getSender(t, "AmNqJN2P1MA2Uc6X5byA4mDg2iuo95ANAyWCmd3LkZe4GhJkSyr4")
+	sender.AddBalance(balance3)
+	sender2.AddBalance(balance3)
+
+	blockInfo := &types.BlockHeaderInfo{No: uint64(0)}
+	stakingTx := &types.Tx{
+		Body: &types.TxBody{
+			Account: sender.ID(),
+			Amount:  types.StakingMinimum.Bytes(),
+			Payload: buildStakingPayload(true),
+			Type:    types.TxType_GOVERNANCE,
+		},
+	}
+	_, err := ExecuteSystemTx(scs, stakingTx.GetBody(), sender, receiver, blockInfo)
+	assert.NoError(t, err, "could not execute system tx")
+	stakingTx.Body.Account = sender2.ID()
+	_, err = ExecuteSystemTx(scs, stakingTx.GetBody(), sender2, receiver, blockInfo)
+	assert.NoError(t, err, "could not execute system tx")
+
+	voteTx := &types.Tx{
+		Body: &types.TxBody{
+			Account: sender.ID(),
+			Payload: []byte(`{"Name":"v1voteDAO", "Args":["stakingdelay", "100"]}`),
+			Type:    types.TxType_GOVERNANCE,
+		},
+	}
+	blockInfo.No++
+	blockInfo.Version = 3
+	_, err = ExecuteSystemTx(scs, voteTx.GetBody(), sender, receiver, blockInfo)
+	assert.Error(t, err, "before the governance version")
+
+	blockInfo.Version = types.FeatureGovernance.Version()
+	invalidTx := &types.Tx{
+		Body: &types.TxBody{
+			Account: sender.ID(),
+			Payload: []byte(`{"Name":"v1voteDAO", "Args":["maxblocksize", "1000"]}`),
+			Type:    types.TxType_GOVERNANCE,
+		},
+	}
+	_, err = ExecuteSystemTx(scs, invalidTx.GetBody(), sender, receiver, blockInfo)
+	assert.Error(t, err, "invalid range")
+
+	_, err = ExecuteSystemTx(scs, voteTx.GetBody(), sender, receiver, blockInfo)
+	assert.NoError(t, err, "valid")
+	voteTx.Body.Account = sender2.ID()
+	_, err = ExecuteSystemTx(scs, voteTx.GetBody(), sender2, receiver, blockInfo)
+	assert.NoError(t, err, "valid")
+
+	// The voted value waits for the activation delay.
+	assert.Equal(t, big.NewInt(StakingDelay), GetStakingDelay())
+	value, at, err := getPendingParam(scs, stakingDelay.ID())
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(100), value)
+	assert.Equal(t, blockInfo.No+paramActivationDelay, at)
+
+	activated, err := ActivateParams(scs, at-1)
+	assert.NoError(t, err)
+	assert.False(t, activated)
+
+	activated, err = ActivateParams(scs, at)
+	assert.NoError(t, err)
+	assert.True(t, activated)
+	assert.Equal(t, big.NewInt(100), GetStakingDelay())
+	value, _, err = getPendingParam(scs, stakingDelay.ID())
+	assert.NoError(t, err)
+	assert.Nil(t, value)
+}
@@ -8,6 +8,7 @@ import (
 	"encoding/binary"
 	"errors"
 	"math/big"
+	"sort"
 
 	"github.com/aergoio/aergo/state"
 	"github.com/aergoio/aergo/types"
@@ -18,13 +19,20 @@ var consensusType string
 var (
 	stakingKey      = []byte("staking")
 	stakingTotalKey = []byte("stakingtotal")
+	unbondingKey    = []byte("unbonding")
 
-	ErrInvalidCandidate = errors.New("invalid candidate")
+	ErrInvalidCandidate     = errors.New("invalid candidate")
+	ErrNoWithdrawableAmount = errors.New("no withdrawable amount")
+	ErrInvalidUnbondings    = errors.New("invalid unbondings data")
 )
 
 const StakingDelay = 60 * 60 * 24 //block interval
 //const StakingDelay = 5
 
+// maxUnbondingPeriod is the upper limit of the unbonding period which can be
+// set by voting.
+const maxUnbondingPeriod = 30 * StakingDelay
+
 func InitGovernance(consensus string) {
 	consensusType = consensus
 }
@@ -59,9 +67,12 @@ func (c *stakeCmd) run() (*types.Event, error) {
 	if err := addTotal(c.scs, amount); err != nil {
 		return nil, err
 	}
+	if err := refreshDelegation(c.SystemContext); err != nil {
+		return nil, err
+	}
 	sender.SubBalance(amount)
 	receiver.AddBalance(amount)
-	if c.SystemContext.BlockInfo.Version < 2 {
+	if !c.SystemContext.BlockInfo.IsFeatureActive(types.FeatureSystemEventV2) {
 		return &types.Event{
 			ContractAddress: receiver.ID(),
 			EventIdx:        0,
@@ -109,12 +120,25 @@ func (c *unstakeCmd) run() (*types.Event, error) {
 	if err := refreshAllVote(c.SystemContext); err != nil {
 		return nil, err
 	}
+	if err := refreshDelegation(c.SystemContext); err != nil {
+		return nil, err
+	}
 	if err := subTotal(scs, balanceAdjustment); err != nil {
 		return nil, err
 	}
-	sender.AddBalance(balanceAdjustment)
-	receiver.SubBalance(balanceAdjustment)
-	if c.SystemContext.BlockInfo.Version < 2 {
+	if !c.SystemContext.BlockInfo.IsFeatureActive(types.FeatureUnbonding) {
+		sender.AddBalance(balanceAdjustment)
+		receiver.SubBalance(balanceAdjustment)
+	} else {
+		// The unstaked amount stays in the system account until it's
+		// withdrawn after the unbonding period.
+		release := c.BlockInfo.No + GetUnbondingPeriodFromState(scs).Uint64()
+		if err := setUnbondings(scs, sender.ID(),
+			addUnbonding(c.Unbondings, balanceAdjustment, release)); err != nil {
+			return nil, err
+		}
+	}
+	if !c.SystemContext.BlockInfo.IsFeatureActive(types.FeatureSystemEventV2) {
 		return &types.Event{
 			ContractAddress: receiver.ID(),
 			EventIdx:        0,
@@ -134,6 +158,38 @@ func (c *unstakeCmd) run() (*types.Event, error) {
 	}, nil
 }
 
+type withdrawCmd struct {
+	*SystemContext
+}
+
+func newWithdrawCmd(ctx *SystemContext) (sysCmd, error) {
+	return &withdrawCmd{SystemContext: ctx}, nil
+}
+
+func (c *withdrawCmd) run() (*types.Event, error) {
+	var (
+		sender   = c.Sender
+		receiver = c.Receiver
+	)
+
+	released, pending := splitUnbondings(c.Unbondings, c.BlockInfo.No)
+	amount := sumUnbondings(released)
+
+	if err := setUnbondings(c.scs, sender.ID(), pending); err != nil {
+		return nil, err
+	}
+	sender.AddBalance(amount)
+	receiver.SubBalance(amount)
+	return &types.Event{
+		ContractAddress: receiver.ID(),
+		EventIdx:        0,
+		EventName:       "withdraw",
+		JsonArgs: `["` +
+			types.EncodeAddress(sender.ID()) +
+			`", "` + amount.String() + `"]`,
+	}, nil
+}
+
 func setStaking(scs *state.ContractState, who []byte, staking *types.Staking) error {
 	key := append(stakingKey, who...)
 	return scs.SetData(key, serializeStaking(staking))
@@ -153,10 +209,17 @@ func getStaking(scs *state.ContractState, who []byte) (*types.Staking, error) {
 }
 
 func GetStaking(scs *state.ContractState, address []byte) (*types.Staking, error) {
-	if address != nil {
-		return getStaking(scs, address)
+	if address == nil {
+		return nil, errors.New("invalid argument: address should not be nil")
+	}
+	staking, err := getStaking(scs, address)
+	if err != nil {
+		return nil, err
+	}
+	if staking.Unbondings, err = getUnbondings(scs, address); err != nil {
+		return nil, err
 	}
-	return nil, errors.New("invalid argument: address should not be nil")
+	return staking, nil
 }
 
 func GetStakingTotal(ar AccountStateReader) (*big.Int, error) {
@@ -209,3 +272,86 @@ func deserializeStaking(data []byte) *types.Staking {
 	amount := data[8:]
 	return &types.Staking{Amount: amount, When: when}
 }
+
+func setUnbondings(scs *state.ContractState, who []byte, unbondings []*types.Unbonding) error {
+	key := append(unbondingKey, who...)
+	return scs.SetData(key, serializeUnbondings(unbondings))
+}
+
+func getUnbondings(scs *state.ContractState, who []byte) ([]*types.Unbonding, error) {
+	key := append(unbondingKey, who...)
+	data, err := scs.GetData(key)
+	if err != nil {
+		return nil, err
+	}
+	return deserializeUnbondings(data)
+}
+
+// addUnbonding adds amount which is released at the block release. The
+// unbondings are kept in the order of their release since the unbonding period
+// may be lowered between the unstakings.
+func addUnbonding(unbondings []*types.Unbonding, amount *big.Int, release uint64) []*types.Unbonding {
+	i := sort.Search(len(unbondings), func(i int) bool {
+		return unbondings[i].GetRelease() >= release
+	})
+	if i < len(unbondings) && unbondings[i].GetRelease() == release {
+		u := unbondings[i]
+		u.Amount = new(big.Int).Add(new(big.Int).SetBytes(u.GetAmount()), amount).Bytes()
+		return unbondings
+	}
+	unbondings = append(unbondings, nil)
+	copy(unbondings[i+1:], unbondings[i:])
+	unbondings[i] = &types.Unbonding{Amount: amount.Bytes(), Release: release}
+	return unbondings
+}
+
+// splitUnbondings divides unbondings into the ones released until blockNo and
+// the others. Every unbonding is checked regardless of the order.
+func splitUnbondings(unbondings []*types.Unbonding, blockNo uint64) (released, pending []*types.Unbonding) {
+	for _, u := range unbondings {
+		if u.GetRelease() > blockNo {
+			pending = append(pending, u)
+		} else {
+			released = append(released, u)
+		}
+	}
+	return released, pending
+}
+
+func sumUnbondings(unbondings []*types.Unbonding) *big.Int {
+	sum := new(big.Int)
+	for _, u := range unbondings {
+		sum.Add(sum, new(big.Int).SetBytes(u.GetAmount()))
+	}
+	return sum
+}
+
+func serializeUnbondings(unbondings []*types.Unbonding) []byte {
+	var ret []byte
+	for _, u := range unbondings {
+		release := make([]byte, 8)
+		binary.LittleEndian.PutUint64(release, u.GetRelease())
+		ret = append(ret, release...)
+		ret = append(ret, byte(len(u.GetAmount())))
+		ret = append(ret, u.GetAmount()...)
+	}
+	return ret
+}
+
+func deserializeUnbondings(data []byte) ([]*types.Unbonding, error) {
+	var ret []*types.Unbonding
+	for len(data) > 0 {
+		if len(data) < 9 {
+			return nil, ErrInvalidUnbondings
+		}
+		release := binary.LittleEndian.Uint64(data[:8])
+		size := int(data[8])
+		if len(data) < 9+size {
+			return nil, ErrInvalidUnbondings
+		}
+		amount := data[9 : 9+size]
+		ret = append(ret, &types.Unbonding{Amount: amount, Release: release})
+		data = data[9+size:]
+	}
+	return ret, nil
+}
@@ -103,6 +103,181 @@ func TestBasicStakingUnstakingV2(t *testing.T) {
 	assert.Equal(t, new(big.Int).SetUint64(0), total, "total value")
 }
 
+func TestUnstakingWithUnbonding(t *testing.T) {
+	scs, sender, receiver := initTest(t)
+	defer deinitTest()
+
+	tx := &types.Tx{
+		Body: &types.TxBody{
+			Account: sender.ID(),
+			Amount:  types.StakingMinimum.Bytes(),
+			Payload: []byte(`{"Name":"v1stake"}`),
+		},
+	}
+	minplusmin := new(big.Int).Add(types.StakingMinimum, types.StakingMinimum)
+	sender.AddBalance(minplusmin)
+
+	blockInfo := &types.BlockHeaderInfo{No: uint64(0), Version: 3}
+	_, err := ExecuteSystemTx(scs, tx.GetBody(), sender, receiver, blockInfo)
+	assert.NoError(t, err, "staking failed")
+
+	blockInfo.No += StakingDelay
+	tx.Body.Payload = []byte(`{"Name":"v1unstake"}`)
+	events, err := ExecuteSystemTx(scs, tx.GetBody(), sender, receiver, blockInfo)
+	assert.NoError(t, err, "unstaking failed")
+	assert.Equal(t, "unstake", events[0].EventName, "event name")
+	assert.Equal(t, types.StakingMinimum, sender.Balance(), "unstaked amount should be locked")
+	assert.Equal(t, types.StakingMinimum, receiver.Balance(), "unstaked amount should stay in the system")
+
+	total, err := getStakingTotal(scs)
+	assert.NoError(t, err, "could not get staking total")
+	assert.Equal(t, new(big.Int).SetUint64(0), total, "total value")
+
+	release := blockInfo.No + StakingDelay
+	staking, err := GetStaking(scs, sender.ID())
+	assert.NoError(t, err, "could not get staking")
+	assert.Equal(t, 1, len(staking.GetUnbondings()), "unbonding count")
+	assert.Equal(t, types.StakingMinimum.Bytes(), staking.GetUnbondings()[0].GetAmount(), "unbonding amount")
+	assert.Equal(t, release, staking.GetUnbondings()[0].GetRelease(), "unbonding release")
+
+	withdrawTx := buildWithdrawTx(sender.ID())
+	blockInfo.No = release - 1
+	_, err = ValidateSystemTx(sender.ID(), withdrawTx.GetBody(), sender, scs, blockInfo)
+	assert.Equal(t, ErrNoWithdrawableAmount, err, "should not be withdrawn before the release")
+
+	withdrawTx.Body.Amount = types.StakingMinimum.Bytes()
+	blockInfo.No = release
+	_, err = ValidateSystemTx(sender.ID(), withdrawTx.GetBody(), sender, scs, blockInfo)
+	assert.Error(t, err, "withdrawal with amount should fail")
+
+	withdrawTx.Body.Amount = nil
+	events, err = ExecuteSystemTx(scs, withdrawTx.GetBody(), sender, receiver, blockInfo)
+	assert.NoError(t, err, "withdrawal failed")
+	assert.Equal(t, "withdraw", events[0].EventName, "event name")
+	assert.Equal(t, "[\"AmPNYHyzyh9zweLwDyuoiUuTVCdrdksxkRWDjVJS76WQLExa2Jr4\", \"10000000000000000000000\"]", events[0].JsonArgs, "event args")
+	assert.Equal(t, minplusmin, sender.Balance(), "sender.Balance() after withdrawal")
+	assert.Equal(t, new(big.Int).SetUint64(0), receiver.Balance(), "receiver.Balance() after withdrawal")
+
+	staking, err = GetStaking(scs, sender.ID())
+	assert.NoError(t, err, "could not get staking")
+	assert.Empty(t, staking.GetUnbondings(), "unbondings after withdrawal")
+
+	_, err = ValidateSystemTx(sender.ID(), withdrawTx.GetBody(), sender, scs, blockInfo)
+	assert.Equal(t, ErrNoWithdrawableAmount, err, "nothing to withdraw")
+
+	blockInfo.Version = 2
+	_, err = ValidateSystemTx(sender.ID(), withdrawTx.GetBody(), sender, scs, blockInfo)
+	assert.Error(t, err, "withdrawal is not supported before the block version 3")
+}
+
+func TestUnbondings(t *testing.T) {
+	var unbondings []*types.Unbonding
+	unbondings = addUnbonding(unbondings, big.NewInt(1), 10)
+	unbondings = addUnbonding(unbondings, big.NewInt(2), 10)
+	unbondings = addUnbonding(unbondings, big.NewInt(3), 20)
+	assert.Equal(t, 2, len(unbondings))
+	assert.Equal(t, big.NewInt(3).Bytes(), unbondings[0].GetAmount())
+
+	data := serializeUnbondings(unbondings)
+	decoded, err := deserializeUnbondings(data)
+	assert.NoError(t, err)
+	assert.Equal(t, len(unbondings), len(decoded))
+	for i, u := range decoded {
+		assert.Equal(t, unbondings[i].GetRelease(), u.GetRelease())
+		assert.Equal(t, unbondings[i].GetAmount(), u.GetAmount())
+	}
+	_, err = deserializeUnbondings(data[:len(data)-1])
+	assert.Equal(t, ErrInvalidUnbondings, err, "truncated amount")
+	_, err = deserializeUnbondings(data[:5])
+	assert.Equal(t, ErrInvalidUnbondings, err, "truncated release")
+
+	released, pending := splitUnbondings(unbondings, 9)
+	assert.Empty(t, released)
+	assert.Equal(t, 2, len(pending))
+	released, pending = splitUnbondings(unbondings, 10)
+	assert.Equal(t, big.NewInt(3), sumUnbondings(released))
+	assert.Equal(t, 1, len(pending))
+	released, pending = splitUnbondings(unbondings, 20)
+	assert.Equal(t, big.NewInt(6), sumUnbondings(released))
+	assert.Empty(t, pending)
+
+	// An earlier release by the lowered unbonding period
+	unbondings = addUnbonding(unbondings, big.NewInt(4), 5)
+	unbondings = addUnbonding(unbondings, big.NewInt(5), 15)
+	assert.Equal(t, 4, len(unbondings))
+	for i, release := range []uint64{5, 10, 15, 20} {
+		assert.Equal(t, release, unbondings[i].GetRelease())
+	}
+	released, pending = splitUnbondings(unbondings, 5)
+	assert.Equal(t, big.NewInt(4), sumUnbondings(released))
+	assert.Equal(t, 3, len(pending))
+
+	// The unbondings out of the release order are split as well.
+	released, pending = splitUnbondings([]*types.Unbonding{
+		{Amount: big.NewInt(1).Bytes(), Release: 20},
+		{Amount: big.NewInt(2).Bytes(), Release: 10},
+	}, 10)
+	assert.Equal(t, big.NewInt(2), sumUnbondings(released))
+	assert.Equal(t, 1, len(pending))
+}
+
+func TestUnbondingPeriodLowered(t *testing.T) {
+	scs, sender, receiver := initTest(t)
+	defer deinitTest()
+
+	stakeAmount := new(big.Int).Mul(types.StakingMinimum, big.NewInt(3))
+	tx := &types.Tx{
+		Body: &types.TxBody{
+			Account: sender.ID(),
+			Amount:  stakeAmount.Bytes(),
+			Payload: []byte(`{"Name":"v1stake"}`),
+		},
+	}
+	sender.AddBalance(stakeAmount)
+
+	blockInfo := &types.BlockHeaderInfo{No: uint64(0), Version: 4}
+	_, err := ExecuteSystemTx(scs, tx.GetBody(), sender, receiver, blockInfo)
+	assert.NoError(t, err, "staking failed")
+
+	blockInfo.No += StakingDelay
+	tx.Body.Amount = types.StakingMinimum.Bytes()
+	tx.Body.Payload = []byte(`{"Name":"v1unstake"}`)
+	_, err = ExecuteSystemTx(scs, tx.GetBody(), sender, receiver, blockInfo)
+	assert.NoError(t, err, "unstaking failed")
+	firstRelease := blockInfo.No + StakingDelay
+
+	// Lower the unbonding period and the staking delay.
+	_, err = updateParam(scs, unbondingPeriod.ID(), big.NewInt(1))
+	assert.NoError(t, err, "could not update unbonding period")
+	_, err = updateParam(scs, stakingDelay.ID(), big.NewInt(1))
+	assert.NoError(t, err, "could not update staking delay")
+	scs = commitNextBlock(t, scs)
+
+	blockInfo.No++
+	_, err = ExecuteSystemTx(scs, tx.GetBody(), sender, receiver, blockInfo)
+	assert.NoError(t, err, "unstaking failed")
+	secondRelease := blockInfo.No + 1
+	assert.True(t, secondRelease < firstRelease)
+
+	staking, err := GetStaking(scs, sender.ID())
+	assert.NoError(t, err, "could not get staking")
+	assert.Equal(t, 2, len(staking.GetUnbondings()), "unbonding count")
+	assert.Equal(t, secondRelease, staking.GetUnbondings()[0].GetRelease(), "unbonding release")
+	assert.Equal(t, firstRelease, staking.GetUnbondings()[1].GetRelease(), "unbonding release")
+
+	// The later unstaking is withdrawn at its own release.
+	withdrawTx := buildWithdrawTx(sender.ID())
+	blockInfo.No = secondRelease
+	events, err := ExecuteSystemTx(scs, withdrawTx.GetBody(), sender, receiver, blockInfo)
+	assert.NoError(t, err, "withdrawal failed")
+	assert.Equal(t, "[\"AmPNYHyzyh9zweLwDyuoiUuTVCdrdksxkRWDjVJS76WQLExa2Jr4\", \"10000000000000000000000\"]", events[0].JsonArgs, "event args")
+
+	staking, err = GetStaking(scs, sender.ID())
+	assert.NoError(t, err, "could not get staking")
+	assert.Equal(t, 1, len(staking.GetUnbondings()), "unbonding count after withdrawal")
+	assert.Equal(t, firstRelease, staking.GetUnbondings()[0].GetRelease(), "unbonding release")
+}
+
 func TestStaking1Unstaking2(t *testing.T) {
 	scs, sender, receiver := initTest(t)
 	defer deinitTest()
@@ -12,12 +12,16 @@ func _() {
 	_ = x[stakingMin-1]
 	_ = x[gasPrice-2]
 	_ = x[namePrice-3]
-	_ = x[sysParamMax-4]
+	_ = x[unbondingPeriod-4]
+	_ = x[maxBlockSize-5]
+	_ = x[stakingDelay-6]
+	_ = x[votingReward-7]
+	_ = x[sysParamMax-8]
 }
 
-const _sysParamIndex_name = "bpCountstakingMingasPricenamePricesysParamMax"
+const _sysParamIndex_name = "bpCountstakingMingasPricenamePriceunbondingPeriodmaxBlockSizestakingDelayvotingRewardsysParamMax"
 
-var _sysParamIndex_index = [...]uint8{0, 7, 17, 25, 34, 45}
+var _sysParamIndex_index = [...]uint8{0, 7, 17, 25, 34, 49, 61, 73, 85, 96}
 
 func (i sysParamIndex) String() string {
 	if i < 0 || i >= sysParamIndex(len(_sysParamIndex_index)-1) {
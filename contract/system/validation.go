@@ -39,6 +39,13 @@ func ValidateSystemTx(account []byte, txBody *types.TxBody, sender *state.V,
 		if err != nil {
 			return nil, err
 		}
+		if blockInfo.IsFeatureActive(types.FeatureDelegation) {
+			if d, err := getDelegation(scs, account); err != nil {
+				return nil, err
+			} else if d != nil {
+				return nil, ErrVotingPowerDelegated
+			}
+		}
 		context.Staked = staked
 		context.Vote = oldvote
 	case types.Opunstake:
@@ -47,14 +54,56 @@ func ValidateSystemTx(account []byte, txBody *types.TxBody, sender *state.V,
 			return nil, err
 		}
 		context.Staked = staked
+		if blockInfo.IsFeatureActive(types.FeatureUnbonding) {
+			unbondings, err := getUnbondings(scs, account)
+			if err != nil {
+				return nil, err
+			}
+			context.Unbondings = unbondings
+		}
+	case types.Opwithdraw:
+		unbondings, err := validateForWithdraw(account, txBody, scs, blockInfo)
+		if err != nil {
+			return nil, err
+		}
+		context.Unbondings = unbondings
+	case types.Opdelegate:
+		staked, delegate, err := validateForDelegate(account, &ci, txBody, scs, blockInfo)
+		if err != nil {
+			return nil, err
+		}
+		oldvote, err := GetVote(scs, account, defaultVoteKey)
+		if err != nil {
+			return nil, err
+		}
+		context.Staked = staked
+		context.Delegate = delegate
+		context.Vote = oldvote
+	case types.Opundelegate:
+		if err := validateForUndelegate(account, txBody, scs, blockInfo); err != nil {
+			return nil, err
+		}
+	case types.OpclaimReward:
+		if _, err := validateForClaimReward(account, txBody, scs, blockInfo); err != nil {
+			return nil, err
+		}
+	case types.OpsubmitEvidence:
+		evidence, err := validateForEvidence(&ci, txBody, scs, blockInfo)
+		if err != nil {
+			return nil, err
+		}
+		context.Evidence = evidence
 	case types.OpvoteDAO:
-		if blockInfo.Version < 2 {
+		if !blockInfo.IsFeatureActive(types.FeatureDaoVote) {
 			return nil, fmt.Errorf("not supported operation")
 		}
 		id, err := parseIDForProposal(&ci)
 		if err != nil {
 			return nil, err
 		}
+		if spec := getParamSpec(id); spec == nil || !blockInfo.IsFeatureActive(spec.feature) {
+			return nil, fmt.Errorf("not supported operation")
+		}
 		proposal, err := getProposal(id)
 		if proposal == nil {
 			return nil, err
@@ -126,7 +175,7 @@ func validateForStaking(account []byte, txBody *types.TxBody, scs *state.Contrac
 	if err != nil {
 		return nil, err
 	}
-	if staked.GetAmount() != nil && staked.GetWhen()+StakingDelay > blockNo {
+	if staked.GetAmount() != nil && staked.GetWhen()+GetStakingDelayFromState(scs) > blockNo {
 		return nil, types.ErrLessTimeHasPassed
 	}
 	toBe := new(big.Int).Add(staked.GetAmountBigInt(), txBody.GetAmountBigInt())
@@ -160,7 +209,7 @@ func validateForUnstaking(account []byte, txBody *types.TxBody, scs *state.Contr
 	if staked.GetAmountBigInt().Cmp(txBody.GetAmountBigInt()) < 0 {
 		return nil, types.ErrExceedAmount
 	}
-	if staked.GetWhen()+StakingDelay > blockNo {
+	if staked.GetWhen()+GetStakingDelayFromState(scs) > blockNo {
 		return nil, types.ErrLessTimeHasPassed
 	}
 	toBe := new(big.Int).Sub(staked.GetAmountBigInt(), txBody.GetAmountBigInt())
@@ -171,6 +220,24 @@ func validateForUnstaking(account []byte, txBody *types.TxBody, scs *state.Contr
 	return staked, nil
 }
 
+func validateForWithdraw(account []byte, txBody *types.TxBody, scs *state.ContractState,
+	blockInfo *types.BlockHeaderInfo) ([]*types.Unbonding, error) {
+	if !blockInfo.IsFeatureActive(types.FeatureUnbonding) {
+		return nil, fmt.Errorf("not supported operation")
+	}
+	if txBody.GetAmountBigInt().Sign() != 0 {
+		return nil, fmt.Errorf("withdrawal must be requested without amount")
+	}
+	unbondings, err := getUnbondings(scs, account)
+	if err != nil {
+		return nil, err
+	}
+	if released, _ := splitUnbondings(unbondings, blockInfo.No); len(released) == 0 {
+		return nil, ErrNoWithdrawableAmount
+	}
+	return unbondings, nil
+}
+
 func parseIDForProposal(ci *types.CallInfo) (string, error) {
 	//length should be checked before this function
 	id, ok := ci.Args[0].(string)
@@ -181,20 +248,9 @@ func parseIDForProposal(ci *types.CallInfo) (string, error) {
 }
 
 func validateById(id string, candidate *big.Int) bool {
-	if big.NewInt(0).Cmp(candidate) == 0 {
+	spec := getParamSpec(id)
+	if spec == nil {
 		return false
 	}
-	switch id {
-	case bpCount.ID():
-		if big.NewInt(100).Cmp(candidate) < 0 {
-			return false
-		}
-	case stakingMin.ID(),
-		gasPrice.ID(),
-		namePrice.ID():
-		if types.MaxAER.Cmp(candidate) < 0 {
-			return false
-		}
-	}
-	return true
+	return spec.validate(candidate)
 }
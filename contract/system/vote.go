@@ -112,13 +112,21 @@ func newVoteCmd(ctx *SystemContext) (sysCmd, error) {
 		Candidate: cmd.candidate,
 		Amount:    staked.GetAmount(),
 	}
+	if cmd.op == types.OpvoteBP {
+		power, err := bpVotingPower(scs, cmd.Sender.ID(), staked.GetAmountBigInt(), cmd.BlockInfo)
+		if err != nil {
+			return nil, err
+		}
+		cmd.newVote.Amount = power.Bytes()
+	}
 
 	cmd.voteResult, err = loadVoteResult(scs, cmd.issue)
 	if err != nil {
 		return nil, err
 	}
+	cmd.voteResult.blockNo = cmd.BlockInfo.No
 
-	if cmd.BlockInfo.Version < 2 {
+	if !cmd.BlockInfo.IsFeatureActive(types.FeatureVoteV2) {
 		cmd.add = func(v *types.Vote) error {
 			return cmd.voteResult.AddVote(v)
 		}
@@ -150,7 +158,7 @@ func (c *voteCmd) run() (*types.Event, error) {
 	if err := c.updateVoteResult(); err != nil {
 		return nil, err
 	}
-	if c.SystemContext.BlockInfo.Version < 2 {
+	if !c.SystemContext.BlockInfo.IsFeatureActive(types.FeatureSystemEventV2) {
 		return &types.Event{
 			ContractAddress: c.Receiver.ID(),
 			EventIdx:        0,
@@ -216,8 +224,14 @@ func refreshAllVote(context *SystemContext) error {
 		if err != nil {
 			return err
 		}
+		limit := stakedAmount
+		if types.OpvoteBP.ID() == i.ID() {
+			if limit, err = bpVotingPower(scs, account, stakedAmount, context.BlockInfo); err != nil {
+				return err
+			}
+		}
 		if oldvote.Amount == nil ||
-			new(big.Int).SetBytes(oldvote.Amount).Cmp(stakedAmount) <= 0 {
+			new(big.Int).SetBytes(oldvote.Amount).Cmp(limit) <= 0 {
 			continue
 		}
 		if types.OpvoteBP.ID() != i.ID() {
@@ -233,10 +247,11 @@ func refreshAllVote(context *SystemContext) error {
 		if err != nil {
 			return err
 		}
+		voteResult.blockNo = context.BlockInfo.No
 		if err = voteResult.SubVote(oldvote); err != nil {
 			return err
 		}
-		oldvote.Amount = staked.GetAmount()
+		oldvote.Amount = limit.Bytes()
 		if err = setVote(scs, key, account, oldvote); err != nil {
 			return err
 		}
@@ -282,6 +297,10 @@ func setVote(scs *state.ContractState, key, voter []byte, vote *types.Vote) erro
 	}
 }
 
+func deleteVote(scs *state.ContractState, key, voter []byte) error {
+	return scs.DeleteData(append(append(voteKey, key...), voter...))
+}
+
 // BuildOrderedCandidates returns a candidate list ordered by votes.xs
 func BuildOrderedCandidates(vote map[string]*big.Int) []string {
 	// TODO: cleanup
@@ -28,7 +28,7 @@ func initTest(t *testing.T) (*state.ContractState, *state.V, *state.V) {
 	cdb = state.NewChainStateDB()
 	cdb.Init(string(db.BadgerImpl), "test", nil, false)
 	genesis := types.GetTestGenesis()
-	err := cdb.SetGenesis(genesis, nil)
+	err := cdb.SetGenesis(genesis, nil, nil)
 
 	bs = cdb.NewBlockState(cdb.GetRoot())
 	if err != nil {
@@ -255,9 +255,19 @@ func buildStakingPayload(isStaking bool) []byte {
 	return []byte(`{"Name":"v1unstake"}`)
 }
 
+func buildWithdrawTx(account []byte) *types.Tx {
+	return &types.Tx{
+		Body: &types.TxBody{
+			Account: account,
+			Payload: []byte(`{"Name":"v1withdraw"}`),
+			Type:    types.TxType_GOVERNANCE,
+		},
+	}
+}
+
 func TestVotingCatalog(t *testing.T) {
 	cat := GetVotingCatalog()
-	assert.Equal(t, 5, len(cat))
+	assert.Equal(t, 9, len(cat))
 	for _, issue := range cat {
 		fmt.Println(issue.ID())
 	}
@@ -21,6 +21,8 @@ type VoteResult struct {
 	total *big.Int
 
 	scs *state.ContractState
+	// blockNo is the number of the block where the voting takes place.
+	blockNo types.BlockNo
 }
 
 func newVoteResult(key []byte, total *big.Int) *VoteResult {
@@ -117,7 +119,7 @@ func (vr *VoteResult) Sync() error {
 			if !ok {
 				return fmt.Errorf("abnormal winner is in vote %s", string(vr.key))
 			}
-			if _, err := updateParam(vr.scs, string(vr.key), value); err != nil {
+			if err := applyParam(vr.scs, string(vr.key), value, vr.blockNo); err != nil {
 				return err
 			}
 		}
@@ -0,0 +1,217 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package system
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/aergoio/aergo/state"
+	"github.com/aergoio/aergo/types"
+)
+
+var (
+	rewardPerPowerKey = []byte("votingreward\\acc")
+	voterRewardKey    = []byte("votingreward\\voter")
+
+	// rewardScale keeps the precision of the reward per voting power, which
+	// is usually much less than 1.
+	rewardScale = new(big.Int).Exp(ten, big.NewInt(27), nil)
+
+	ErrNoVotingReward = errors.New("no voting reward to claim")
+)
+
+// voterReward is the voting reward of a voter. The reward is settled whenever
+// the voting power of the voter changes: the reward accrued since the last
+// settlement is the voting power multiplied by the increase of the reward per
+// voting power.
+type voterReward struct {
+	// snapshot is the reward per voting power at the last settlement.
+	snapshot *big.Int
+	// accrued is the reward settled but not claimed yet.
+	accrued *big.Int
+}
+
+func (vr *voterReward) settle(rewardPerPower, power *big.Int) {
+	if power != nil && power.Sign() > 0 {
+		earned := new(big.Int).Sub(rewardPerPower, vr.snapshot)
+		earned.Mul(earned, power).Div(earned, rewardScale)
+		vr.accrued.Add(vr.accrued, earned)
+	}
+	vr.snapshot = new(big.Int).Set(rewardPerPower)
+}
+
+func serializeVoterReward(vr *voterReward) []byte {
+	snapshot := vr.snapshot.Bytes()
+	ret := []byte{byte(len(snapshot))}
+	ret = append(ret, snapshot...)
+	return append(ret, vr.accrued.Bytes()...)
+}
+
+func deserializeVoterReward(data []byte) *voterReward {
+	vr := &voterReward{snapshot: new(big.Int), accrued: new(big.Int)}
+	if len(data) == 0 {
+		return vr
+	}
+	size := int(data[0])
+	vr.snapshot.SetBytes(data[1 : 1+size])
+	vr.accrued.SetBytes(data[1+size:])
+	return vr
+}
+
+func getRewardPerPower(s dataGetter) (*big.Int, error) {
+	data, err := s.GetData(rewardPerPowerKey)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(data), nil
+}
+
+func getVoterReward(s dataGetter, addr []byte) (*voterReward, error) {
+	data, err := s.GetData(append(voterRewardKey, addr...))
+	if err != nil {
+		return nil, err
+	}
+	return deserializeVoterReward(data), nil
+}
+
+func setVoterReward(s dataSetter, addr []byte, vr *voterReward) error {
+	return s.SetData(append(voterRewardKey, addr...), serializeVoterReward(vr))
+}
+
+// settleVotingReward settles the reward accrued to the voter addr, whose
+// voting power has been power so far. It must be called before the voting
+// power is changed.
+func settleVotingReward(s *state.ContractState, addr []byte, power *big.Int) error {
+	rewardPerPower, err := getRewardPerPower(s)
+	if err != nil {
+		return err
+	}
+	// Nothing is distributed yet. The voter record is left untouched so that
+	// the state doesn't change before the voting reward hardfork.
+	if rewardPerPower.Sign() == 0 {
+		return nil
+	}
+	vr, err := getVoterReward(s, addr)
+	if err != nil {
+		return err
+	}
+	vr.settle(rewardPerPower, power)
+	return setVoterReward(s, addr, vr)
+}
+
+func votingPowerOf(addr []byte) *big.Int {
+	if votingPowerRank == nil {
+		return nil
+	}
+	return votingPowerRank.votingPowerOf(types.ToAccountID(addr))
+}
+
+// DistributeVotingReward shares reward among all the voters in proportion to
+// their voting power. The reward must be moved to the system account by the
+// caller, which is paid out when each voter claims it.
+func DistributeVotingReward(scs *state.ContractState, reward *big.Int) error {
+	if votingPowerRank == nil {
+		return ErrNoVotingRewardRank
+	}
+	total := votingPowerRank.getTotalPower()
+	if total.Sign() == 0 {
+		return ErrNoVotingRewardWinner
+	}
+	rewardPerPower, err := getRewardPerPower(scs)
+	if err != nil {
+		return err
+	}
+	delta := new(big.Int).Mul(reward, rewardScale)
+	delta.Div(delta, total)
+	return scs.SetData(rewardPerPowerKey, rewardPerPower.Add(rewardPerPower, delta).Bytes())
+}
+
+// GetVotingReward returns the voting reward accrued to addr, which can be
+// claimed now.
+func GetVotingReward(scs *state.ContractState, addr []byte) (*types.VotingReward, error) {
+	if addr == nil {
+		return nil, errors.New("invalid argument: address should not be nil")
+	}
+	vr, err := pendingVotingReward(scs, addr)
+	if err != nil {
+		return nil, err
+	}
+	reward := &types.VotingReward{Address: addr, Accrued: vr.accrued.Bytes()}
+	if power := votingPowerOf(addr); power != nil {
+		reward.Power = power.Bytes()
+	}
+	return reward, nil
+}
+
+// pendingVotingReward returns the reward of addr settled up to now without
+// updating the state.
+func pendingVotingReward(scs *state.ContractState, addr []byte) (*voterReward, error) {
+	rewardPerPower, err := getRewardPerPower(scs)
+	if err != nil {
+		return nil, err
+	}
+	vr, err := getVoterReward(scs, addr)
+	if err != nil {
+		return nil, err
+	}
+	vr.settle(rewardPerPower, votingPowerOf(addr))
+	return vr, nil
+}
+
+func validateForClaimReward(account []byte, txBody *types.TxBody, scs *state.ContractState,
+	blockInfo *types.BlockHeaderInfo) (*big.Int, error) {
+	if !blockInfo.IsFeatureActive(types.FeatureVotingReward) {
+		return nil, fmt.Errorf("not supported operation")
+	}
+	if txBody.GetAmountBigInt().Sign() != 0 {
+		return nil, fmt.Errorf("voting reward must be claimed without amount")
+	}
+	vr, err := pendingVotingReward(scs, account)
+	if err != nil {
+		return nil, err
+	}
+	if vr.accrued.Sign() == 0 {
+		return nil, ErrNoVotingReward
+	}
+	return vr.accrued, nil
+}
+
+type claimRewardCmd struct {
+	*SystemContext
+}
+
+func newClaimRewardCmd(ctx *SystemContext) (sysCmd, error) {
+	return &claimRewardCmd{SystemContext: ctx}, nil
+}
+
+func (c *claimRewardCmd) run() (*types.Event, error) {
+	var (
+		sender   = c.Sender
+		receiver = c.Receiver
+	)
+
+	vr, err := pendingVotingReward(c.scs, sender.ID())
+	if err != nil {
+		return nil, err
+	}
+	amount := vr.accrued
+	vr.accrued = new(big.Int)
+	if err := setVoterReward(c.scs, sender.ID(), vr); err != nil {
+		return nil, err
+	}
+	sender.AddBalance(amount)
+	receiver.SubBalance(amount)
+	return &types.Event{
+		ContractAddress: receiver.ID(),
+		EventIdx:        0,
+		EventName:       "claimReward",
+		JsonArgs: `["` +
+			types.EncodeAddress(sender.ID()) +
+			`", "` + amount.String() + `"]`,
+	}, nil
+}
@@ -0,0 +1,88 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package system
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/aergoio/aergo/state"
+	"github.com/aergoio/aergo/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func changeVotingPower(t *testing.T, scs *state.ContractState, voter *state.V, power int64) {
+	votingPowerRank.add(voter.AccountID(), voter.ID(), big.NewInt(power))
+	_, err := votingPowerRank.apply(scs)
+	assert.NoError(t, err, "fail to apply voting power")
+}
+
+func accruedVotingReward(t *testing.T, scs *state.ContractState, voter *state.V) *big.Int {
+	reward, err := GetVotingReward(scs, voter.ID())
+	assert.NoError(t, err, "fail to get voting reward")
+	return new(big.Int).SetBytes(reward.GetAccrued())
+}
+
+func TestProportionalVotingReward(t *testing.T) {
+	scs, _, receiver := initTest(t)
+	defer deinitTest()
+	votingPowerRank = newVpr()
+
+	voter1 := getSender(t, "AmLt7Z3y2XTu7YS8KHNuyKM2QAszpFHSX77FLKEt7FAuRW7GEhj7")
+	voter2 := getSender(t, "AmNqJN2P1MA2Uc6X5byA4mDg2iuo95ANAyWCmd3LkZe4GhJkSyr4")
+
+	changeVotingPower(t, scs, voter1, 1000)
+	changeVotingPower(t, scs, voter2, 3000)
+
+	// Nothing is recorded before the first distribution.
+	data, err := scs.GetData(append(voterRewardKey, voter1.ID()...))
+	assert.NoError(t, err)
+	assert.Empty(t, data)
+
+	assert.NoError(t, DistributeVotingReward(scs, big.NewInt(400)))
+	receiver.AddBalance(big.NewInt(400))
+	assert.Equal(t, big.NewInt(100), accruedVotingReward(t, scs, voter1))
+	assert.Equal(t, big.NewInt(300), accruedVotingReward(t, scs, voter2))
+
+	// The reward so far is kept when the voting power changes.
+	changeVotingPower(t, scs, voter1, 1000)
+	assert.Equal(t, big.NewInt(100), accruedVotingReward(t, scs, voter1))
+
+	assert.NoError(t, DistributeVotingReward(scs, big.NewInt(500)))
+	receiver.AddBalance(big.NewInt(500))
+	assert.Equal(t, big.NewInt(300), accruedVotingReward(t, scs, voter1))
+	assert.Equal(t, big.NewInt(600), accruedVotingReward(t, scs, voter2))
+
+	claimTx := &types.Tx{
+		Body: &types.TxBody{
+			Account: voter1.ID(),
+			Payload: []byte(`{"Name":"v1claimReward"}`),
+			Type:    types.TxType_GOVERNANCE,
+		},
+	}
+	blockInfo := &types.BlockHeaderInfo{No: 1, Version: 3}
+	_, err = ValidateSystemTx(voter1.ID(), claimTx.GetBody(), voter1, scs, blockInfo)
+	assert.Error(t, err, "claim is not supported before the block version 4")
+
+	blockInfo.Version = types.FeatureVotingReward.Version()
+	events, err := ExecuteSystemTx(scs, claimTx.GetBody(), voter1, receiver, blockInfo)
+	assert.NoError(t, err, "fail to claim voting reward")
+	assert.Equal(t, "claimReward", events[0].EventName)
+	assert.Equal(t, big.NewInt(300), voter1.Balance())
+	assert.Equal(t, big.NewInt(600), receiver.Balance())
+	assert.Equal(t, big.NewInt(0), accruedVotingReward(t, scs, voter1))
+
+	_, err = ValidateSystemTx(voter1.ID(), claimTx.GetBody(), voter1, scs, blockInfo)
+	assert.Equal(t, ErrNoVotingReward, err)
+}
+
+func TestDistributeVotingRewardWithoutVoter(t *testing.T) {
+	scs, _, _ := initTest(t)
+	defer deinitTest()
+	votingPowerRank = newVpr()
+
+	assert.Equal(t, ErrNoVotingRewardWinner, DistributeVotingReward(scs, big.NewInt(100)))
+}
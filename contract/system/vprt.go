@@ -567,6 +567,13 @@ func (v *vpr) apply(s *state.ContractState) (int, error) {
 
 	for id, delta := range v.changes {
 		if delta.cmp(zeroValue) != 0 {
+			if s != nil {
+				// The voting reward must be settled with the voting power
+				// before the change.
+				if err := settleVotingReward(s, delta.getAddr(), v.votingPowerOf(id)); err != nil {
+					return 0, err
+				}
+			}
 			vp := v.voters.addVotingPower(id, delta)
 			if s != nil {
 				i := v.store.update(vp)
@@ -658,5 +665,5 @@ func (dv *deltaVP) cmp(rhs *big.Int) int {
 }
 
 func GetVotingRewardAmount() *big.Int {
-	return defaultReward
+	return GetParam(votingReward.ID())
 }
@@ -135,7 +135,7 @@ func initDB(t *testing.T) {
 	vprStateDB = vprChainStateDB.GetStateDB()
 	genesis := types.GetTestGenesis()
 
-	err := vprChainStateDB.SetGenesis(genesis, nil)
+	err := vprChainStateDB.SetGenesis(genesis, nil, nil)
 	assert.NoError(t, err, "failed init")
 }
 
@@ -289,7 +289,7 @@ func newExecutor(
 		ctrLgr.Error().Err(ce.err).Str("contract", types.EncodeAddress(contractId)).Msg("new AergoLua executor")
 		return ce
 	}
-	if HardforkConfig.IsV2Fork(ctx.blockInfo.No) {
+	if HardforkConfig.IsFeatureActive(types.FeatureLuaVMV2, ctx.blockInfo.No) {
 		C.setHardforkV2(ce.L)
 		C.vm_set_timeout_hook(ce.L)
 	}
@@ -912,10 +912,43 @@ func setContract(contractState *state.ContractState, contractAddress, payload []
 	return contract, codePayload.Args(), nil
 }
 
+// errNoConstructor is returned by create when the contract has no
+// constructor to call.
+var errNoConstructor = errors.New("constructor is not found")
+
+// constructorArgsError is returned by create when the constructor arguments
+// are invalid.
+type constructorArgsError struct {
+	err error
+}
+
+func (e *constructorArgsError) Error() string {
+	return "constructor call error:" + e.err.Error()
+}
+
+// Create deploys a contract and calls its constructor. A contract without a
+// constructor is deployed as is, and invalid constructor arguments are
+// reported as the result of the deployment.
 func Create(
 	contractState *state.ContractState,
 	code, contractAddress []byte,
 	ctx *vmContext,
+) (string, []*types.Event, *big.Int, error) {
+	rv, events, usedFee, err := create(contractState, code, contractAddress, ctx)
+	if err == errNoConstructor {
+		return "", nil, usedFee, nil
+	}
+	if e, ok := err.(*constructorArgsError); ok {
+		errMsg, _ := json.Marshal(e.Error())
+		return string(errMsg), nil, usedFee, nil
+	}
+	return rv, events, usedFee, err
+}
+
+func create(
+	contractState *state.ContractState,
+	code, contractAddress []byte,
+	ctx *vmContext,
 ) (string, []*types.Event, *big.Int, error) {
 	if len(code) == 0 {
 		return "", nil, ctx.usedFee(), errors.New("contract code is required")
@@ -936,15 +969,14 @@ func Create(
 	if len(args) > 0 {
 		err = getCallInfo(&ci.Args, args, contractAddress)
 		if err != nil {
-			errMsg, _ := json.Marshal("constructor call error:" + err.Error())
-			return string(errMsg), nil, ctx.usedFee(), nil
+			return "", nil, ctx.usedFee(), &constructorArgsError{err}
 		}
 	}
 
 	contexts[ctx.service] = ctx
 
 	// create a sql database for the contract
-	if !HardforkConfig.IsV2Fork(ctx.blockInfo.No) {
+	if !HardforkConfig.IsFeatureActive(types.FeatureLazySQLDB, ctx.blockInfo.No) {
 		if db := luaGetDbHandle(&ctx.service); db == nil {
 			return "", nil, ctx.usedFee(), newVmError(errors.New("can't open a database connection"))
 		}
@@ -952,7 +984,7 @@ func Create(
 
 	ce := newExecutor(contract, contractAddress, ctx, &ci, ctx.curContract.amount, true, false, contractState)
 	if ce == nil {
-		return "", nil, ctx.usedFee(), nil
+		return "", nil, ctx.usedFee(), errNoConstructor
 	}
 	defer ce.close()
 	ce.setCountHook(callMaxInstLimit)
@@ -206,7 +206,7 @@ func getCtrState(ctx *vmContext, aid types.AccountID) (*callState, error) {
 }
 
 func vmIsGasSystem(ctx *vmContext) bool {
-	return !ctx.isQuery && PubNet && ctx.blockInfo.Version >= 2
+	return !ctx.isQuery && PubNet && ctx.blockInfo.IsFeatureActive(types.FeatureGasFee)
 }
 
 func setInstCount(ctx *vmContext, parent *LState, child *LState) {
@@ -240,7 +240,7 @@ func luaCallContract(L *LState, service *C.int, contractId *C.char, fname *C.cha
 		return -1, C.CString("[Contract.LuaCallContract] contract state not found")
 	}
 	contractAddress := C.GoString(contractId)
-	cid, err := getAddressNameResolved(contractAddress, ctx.bs)
+	cid, err := getAddressNameResolved(contractAddress, ctx.bs, ctx.blockInfo)
 	if err != nil {
 		return -1, C.CString("[Contract.LuaCallContract] invalid contractId: " + err.Error())
 	}
@@ -345,7 +345,7 @@ func luaDelegateCallContract(L *LState, service *C.int, contractId *C.char,
 	if ctx == nil {
 		return -1, C.CString("[Contract.LuaDelegateCallContract] contract state not found")
 	}
-	cid, err := getAddressNameResolved(contractIdStr, ctx.bs)
+	cid, err := getAddressNameResolved(contractIdStr, ctx.bs, ctx.blockInfo)
 	if err != nil {
 		return -1, C.CString("[Contract.LuaDelegateCallContract] invalid contractId: " + err.Error())
 	}
@@ -405,12 +405,12 @@ func luaDelegateCallContract(L *LState, service *C.int, contractId *C.char,
 	return ret, nil
 }
 
-func getAddressNameResolved(account string, bs *state.BlockState) ([]byte, error) {
+func getAddressNameResolved(account string, bs *state.BlockState, bi *types.BlockHeaderInfo) ([]byte, error) {
 	accountLen := len(account)
 	if accountLen == types.EncodedAddressLength {
 		return types.DecodeAddress(account)
 	} else if accountLen == types.NameLength {
-		cid := name.Resolve(bs, []byte(account))
+		cid := name.Resolve(bs, []byte(account), bi)
 		if cid == nil {
 			return nil, errors.New("name not founded :" + account)
 		}
@@ -432,7 +432,7 @@ func luaSendAmount(L *LState, service *C.int, contractId *C.char, amount *C.char
 	if (ctx.isQuery == true || ctx.nestedView > 0) && amountBig.Cmp(zeroBig) > 0 {
 		return C.CString("[Contract.LuaSendAmount] send not permitted in query")
 	}
-	cid, err := getAddressNameResolved(C.GoString(contractId), ctx.bs)
+	cid, err := getAddressNameResolved(C.GoString(contractId), ctx.bs, ctx.blockInfo)
 	if err != nil {
 		return C.CString("[Contract.LuaSendAmount] invalid contractId: " + err.Error())
 	}
@@ -651,7 +651,7 @@ func luaGetBalance(L *LState, service *C.int, contractId *C.char) (*C.char, *C.c
 	if contractId == nil {
 		return C.CString(ctx.curContract.callState.ctrState.GetBalanceBigInt().String()), nil
 	}
-	cid, err := getAddressNameResolved(C.GoString(contractId), ctx.bs)
+	cid, err := getAddressNameResolved(C.GoString(contractId), ctx.bs, ctx.blockInfo)
 	if err != nil {
 		return nil, C.CString("[Contract.LuaGetBalance] invalid contractId: " + err.Error())
 	}
@@ -1002,7 +1002,7 @@ func luaDeployContract(
 	// get code
 	var code []byte
 
-	cid, err := getAddressNameResolved(contractStr, bs)
+	cid, err := getAddressNameResolved(contractStr, bs, ctx.blockInfo)
 	if err == nil {
 		aid := types.ToAccountID(cid)
 		contractState, err := getOnlyContractState(ctx, aid)
@@ -1018,7 +1018,7 @@ func luaDeployContract(
 	}
 
 	if len(code) == 0 {
-		if HardforkConfig.IsV2Fork(ctx.blockInfo.No) {
+		if HardforkConfig.IsFeatureActive(types.FeatureDeployCompileInVM, ctx.blockInfo.No) {
 			code, err = compile(contractStr, L)
 		} else {
 			code, err = compile(contractStr, nil)
@@ -1110,7 +1110,7 @@ func luaDeployContract(
 	}
 
 	// create a sql database for the contract
-	if !HardforkConfig.IsV2Fork(ctx.blockInfo.No) {
+	if !HardforkConfig.IsFeatureActive(types.FeatureLazySQLDB, ctx.blockInfo.No) {
 		if db := luaGetDbHandle(&ctx.service); db == nil {
 			return -1, C.CString("[System.LuaDeployContract] DB err: cannot open a database")
 		}
@@ -1197,7 +1197,7 @@ func luaIsContract(L *LState, service *C.int, contractId *C.char) (C.int, *C.cha
 	if ctx == nil {
 		return -1, C.CString("[Contract.LuaIsContract] contract state not found")
 	}
-	cid, err := getAddressNameResolved(C.GoString(contractId), ctx.bs)
+	cid, err := getAddressNameResolved(C.GoString(contractId), ctx.bs, ctx.blockInfo)
 	if err != nil {
 		return -1, C.CString("[Contract.LuaIsContract] invalid contractId: " + err.Error())
 	}
@@ -1239,6 +1239,9 @@ func luaGovernance(L *LState, service *C.int, gType C.char, arg *C.char) *C.char
 	case 'D':
 		amountBig = zeroBig
 		payload = []byte(fmt.Sprintf(`{"Name":"%s","Args":%s}`, types.OpvoteDAO.Cmd(), C.GoString(arg)))
+	case 'W':
+		amountBig = zeroBig
+		payload = []byte(fmt.Sprintf(`{"Name":"%s"}`, types.Opwithdraw.Cmd()))
 	}
 
 	aid := types.ToAccountID([]byte(types.AergoSystem))
@@ -1256,7 +1259,7 @@ func luaGovernance(L *LState, service *C.int, gType C.char, arg *C.char) *C.char
 		Amount:  amountBig.Bytes(),
 		Payload: payload,
 	}
-	if HardforkConfig.IsV2Fork(ctx.blockInfo.No) {
+	if HardforkConfig.IsFeatureActive(types.FeatureSystemCallSender, ctx.blockInfo.No) {
 		txBody.Account = curContract.contractId
 	}
 	err = types.ValidateSystemTx(&txBody)
@@ -1267,6 +1270,9 @@ func luaGovernance(L *LState, service *C.int, gType C.char, arg *C.char) *C.char
 	if err != nil {
 		return C.CString("[Contract.LuaGovernance] database error: " + err.Error())
 	}
+	// The balance returned to the contract differs from the amount of the tx
+	// since the unstaked amount is locked during the unbonding period.
+	systemBalance := scsState.curState.GetBalanceBigInt()
 	evs, err := system.ExecuteSystemTx(scsState.ctrState, &txBody, sender, receiver, ctx.blockInfo)
 	if err != nil {
 		rErr := clearRecovery(L, ctx, seq, true)
@@ -1294,12 +1300,13 @@ func luaGovernance(L *LState, service *C.int, gType C.char, arg *C.char) *C.char
 				_, _ = ctx.traceFile.WriteString(fmt.Sprintf("After sender: %s receiver: %s\n",
 					senderState.GetBalanceBigInt().String(), scsState.curState.GetBalanceBigInt().String()))
 			}
-		} else if gType == 'U' {
-			seq, _ = setRecoveryPoint(aid, ctx, scsState.curState, ctx.curContract.callState, amountBig, true)
+		} else if gType == 'U' || gType == 'W' {
+			returned := new(big.Int).Sub(systemBalance, scsState.curState.GetBalanceBigInt())
+			seq, _ = setRecoveryPoint(aid, ctx, scsState.curState, ctx.curContract.callState, returned, true)
 			if ctx.traceFile != nil {
 				_, _ = ctx.traceFile.WriteString(fmt.Sprintf("[GOVERNANCE]aid(%s)\n", aid.String()))
 				_, _ = ctx.traceFile.WriteString(fmt.Sprintf("snapshot set %d\n", seq))
-				_, _ = ctx.traceFile.WriteString(fmt.Sprintf("unstaking : %s\n", amountBig.String()))
+				_, _ = ctx.traceFile.WriteString(fmt.Sprintf("unstaking : %s\n", returned.String()))
 				_, _ = ctx.traceFile.WriteString(fmt.Sprintf("After sender: %s receiver: %s\n",
 					senderState.GetBalanceBigInt().String(), scsState.curState.GetBalanceBigInt().String()))
 			}
@@ -38,6 +38,17 @@ type DummyChain struct {
 	timeout       int
 	clearLState   func()
 	gasPrice      *big.Int
+	// timeShift is added to the timestamps of the new blocks. timeShifts
+	// holds the shift applied to each block of blocks to restore it when the
+	// block is disconnected.
+	timeShift  time.Duration
+	timeShifts []time.Duration
+	// baseNo is the number of the first block, which is the forked block of
+	// a chain loaded by LoadForkedDummyChain.
+	baseNo types.BlockNo
+	// aliases maps the hashed names impersonating real accounts to their
+	// addresses.
+	aliases map[string][]byte
 }
 
 var addressRegexp *regexp.Regexp
@@ -69,17 +80,29 @@ func LoadDummyChain(opts ...func(d *DummyChain)) (*DummyChain, error) {
 		return nil, err
 	}
 	genesis := types.GetTestGenesis()
-	bc.sdb.SetGenesis(genesis, nil)
+	bc.sdb.SetGenesis(genesis, nil, nil)
 	bc.bestBlock = genesis.Block()
 	bc.bestBlockNo = genesis.Block().BlockNo()
 	bc.bestBlockId = genesis.Block().BlockID()
 	bc.blockIds = append(bc.blockIds, bc.bestBlockId)
 	bc.blocks = append(bc.blocks, genesis.Block())
+	bc.timeShifts = append(bc.timeShifts, 0)
+	if err = bc.init(config.AllEnabledHardforkConfig, opts...); err != nil {
+		return nil, err
+	}
+	return bc, nil
+}
+
+// init sets up the databases and the environment of the contracts, which
+// are shared by the new and the forked chains.
+func (bc *DummyChain) init(hardfork *config.HardforkConfig, opts ...func(d *DummyChain)) error {
+	dataPath := bc.tmpDir
 	bc.testReceiptDB = db.NewDB(db.BadgerImpl, path.Join(dataPath, "receiptDB"))
 	loadTestDatabase(dataPath) // sql database
 	SetStateSQLMaxDBSize(1024)
 	StartLStateFactory()
-	HardforkConfig = config.AllEnabledHardforkConfig
+	HardforkConfig = hardfork
+	bc.aliases = make(map[string][]byte)
 
 	// To pass the governance tests.
 	types.InitGovernance("dpos", true)
@@ -87,6 +110,9 @@ func LoadDummyChain(opts ...func(d *DummyChain)) (*DummyChain, error) {
 
 	// To pass dao parameters test
 	scs, err := bc.sdb.GetStateDB().OpenContractStateAccount(types.ToAccountID([]byte("aergo.system")))
+	if err != nil {
+		return err
+	}
 	system.InitSystemParams(scs, 3)
 
 	fee.EnableZeroFee()
@@ -94,11 +120,14 @@ func LoadDummyChain(opts ...func(d *DummyChain)) (*DummyChain, error) {
 	for _, opt := range opts {
 		opt(bc)
 	}
-	return bc, nil
+	return nil
 }
 
 func (bc *DummyChain) Release() {
-	bc.testReceiptDB.Close()
+	if bc.testReceiptDB != nil {
+		bc.testReceiptDB.Close()
+	}
+	_ = bc.sdb.Close()
 	if bc.clearLState != nil {
 		bc.clearLState()
 	}
@@ -109,12 +138,18 @@ func (bc *DummyChain) BestBlockNo() uint64 {
 	return bc.bestBlockNo
 }
 
+// ShiftTime moves the clock of the chain forward by d. It applies to the
+// blocks connected afterward, and is undone with them by DisConnectBlock.
+func (bc *DummyChain) ShiftTime(d time.Duration) {
+	bc.timeShift += d
+}
+
 func (bc *DummyChain) newBState() *state.BlockState {
 	bc.cBlock = &types.Block{
 		Header: &types.BlockHeader{
 			PrevBlockHash: bc.bestBlockId[:],
 			BlockNo:       bc.bestBlockNo + 1,
-			Timestamp:     time.Now().UnixNano(),
+			Timestamp:     time.Now().Add(bc.timeShift).UnixNano(),
 			ChainID:       types.MakeChainId(bc.bestBlock.GetHeader().ChainID, HardforkConfig.Version(bc.bestBlockNo+1)),
 		},
 	}
@@ -130,7 +165,7 @@ func (bc *DummyChain) BeginReceiptTx() db.Transaction {
 }
 
 func (bc *DummyChain) GetABI(contract string) (*types.ABI, error) {
-	cState, err := bc.sdb.GetStateDB().OpenContractStateAccount(types.ToAccountID(strHash(contract)))
+	cState, err := bc.sdb.GetStateDB().OpenContractStateAccount(types.ToAccountID(bc.strHash(contract)))
 	if err != nil {
 		return nil, err
 	}
@@ -153,7 +188,7 @@ func (bc *DummyChain) GetReceipt(txHash []byte) *types.Receipt {
 }
 
 func (bc *DummyChain) GetAccountState(name string) (*types.State, error) {
-	return bc.sdb.GetStateDB().GetAccountState(types.ToAccountID(strHash(name)))
+	return bc.sdb.GetStateDB().GetAccountState(types.ToAccountID(bc.strHash(name)))
 }
 
 func (bc *DummyChain) GetStaking(name string) (*types.Staking, error) {
@@ -161,11 +196,14 @@ func (bc *DummyChain) GetStaking(name string) (*types.Staking, error) {
 	if err != nil {
 		return nil, err
 	}
-	return system.GetStaking(scs, strHash(name))
+	return system.GetStaking(scs, bc.strHash(name))
 }
 
 func (bc *DummyChain) GetBlockByNo(blockNo types.BlockNo) (*types.Block, error) {
-	return bc.blocks[blockNo], nil
+	if blockNo < bc.baseNo || blockNo-bc.baseNo >= types.BlockNo(len(bc.blocks)) {
+		return nil, fmt.Errorf("block %d not found", blockNo)
+	}
+	return bc.blocks[blockNo-bc.baseNo], nil
 }
 
 func (bc *DummyChain) GetBestBlock() (*types.Block, error) {
@@ -195,7 +233,7 @@ func NewLuaTxAccountBig(name string, balance *big.Int) *luaTxAccount {
 }
 
 func (l *luaTxAccount) run(bs *state.BlockState, bc *DummyChain, bi *types.BlockHeaderInfo, receiptTx db.Transaction) error {
-	id := types.ToAccountID(l.name)
+	id := types.ToAccountID(bc.address(l.name))
 	accountState, err := bs.GetAccountState(id)
 	if err != nil {
 		return err
@@ -223,8 +261,8 @@ func NewLuaTxSendBig(sender, receiver string, balance *big.Int) *luaTxSend {
 }
 
 func (l *luaTxSend) run(bs *state.BlockState, bc *DummyChain, bi *types.BlockHeaderInfo, receiptTx db.Transaction) error {
-	senderID := types.ToAccountID(l.sender)
-	receiverID := types.ToAccountID(l.receiver)
+	senderID := types.ToAccountID(bc.address(l.sender))
+	receiverID := types.ToAccountID(bc.address(l.receiver))
 
 	if senderID == receiverID {
 		return fmt.Errorf("sender and receiever cannot be same")
@@ -259,6 +297,9 @@ type luaTxCommon struct {
 	code        []byte
 	id          uint64
 	feeDelegate bool
+
+	validUntilNo   uint64
+	validUntilTime int64
 }
 
 func (l *luaTxCommon) Hash() []byte {
@@ -317,6 +358,20 @@ func newTxId() uint64 {
 	return luaTxId
 }
 
+// validateExpiry checks the expiry of the tx like the chain does for the
+// block of bi.
+func (l *luaTxCommon) validateExpiry(bi *types.BlockHeaderInfo) error {
+	body := &types.TxBody{ValidUntilNo: l.validUntilNo, ValidUntilTime: l.validUntilTime}
+	return body.ValidateExpiry(bi)
+}
+
+// ValidUntil sets the last block number and block time (unix seconds) in
+// which the tx can be included. Zero means no limit.
+func (l *luaTxDef) ValidUntil(no uint64, ts int64) *luaTxDef {
+	l.validUntilNo, l.validUntilTime = no, ts
+	return l
+}
+
 func (l *luaTxDef) Constructor(args string) *luaTxDef {
 	if len(args) == 0 || strings.Compare(args, "[]") == 0 || l.cErr != nil {
 		return l
@@ -325,9 +380,11 @@ func (l *luaTxDef) Constructor(args string) *luaTxDef {
 	return l
 }
 
-func contractFrame(l *luaTxCommon, bs *state.BlockState,
+func contractFrame(l *luaTxCommon, bs *state.BlockState, bc *DummyChain,
 	run func(s, c *state.V, id types.AccountID, cs *state.ContractState) (*big.Int, error)) error {
 
+	l.sender, l.contract = bc.address(l.sender), bc.address(l.contract)
+
 	creatorId := types.ToAccountID(l.sender)
 	creatorState, err := bs.GetAccountStateV(l.sender)
 	if err != nil {
@@ -392,7 +449,10 @@ func (l *luaTxDef) run(bs *state.BlockState, bc *DummyChain, bi *types.BlockHead
 	if l.cErr != nil {
 		return l.cErr
 	}
-	return contractFrame(&l.luaTxCommon, bs,
+	if err := l.validateExpiry(bi); err != nil {
+		return err
+	}
+	return contractFrame(&l.luaTxCommon, bs, bc,
 		func(sender, contract *state.V, contractId types.AccountID, eContractState *state.ContractState) (*big.Int, error) {
 			contract.State().SqlRecoveryPoint = 1
 
@@ -469,9 +529,19 @@ func (l *luaTxCall) Fail(expectedErr string) *luaTxCall {
 	return l
 }
 
+// ValidUntil sets the last block number and block time (unix seconds) in
+// which the tx can be included. Zero means no limit.
+func (l *luaTxCall) ValidUntil(no uint64, ts int64) *luaTxCall {
+	l.validUntilNo, l.validUntilTime = no, ts
+	return l
+}
+
 func (l *luaTxCall) run(bs *state.BlockState, bc *DummyChain, bi *types.BlockHeaderInfo, receiptTx db.Transaction) error {
-	err := contractFrame(&l.luaTxCommon, bs,
+	err := contractFrame(&l.luaTxCommon, bs, bc,
 		func(sender, contract *state.V, contractId types.AccountID, eContractState *state.ContractState) (*big.Int, error) {
+			if err := l.validateExpiry(bi); err != nil {
+				return nil, err
+			}
 			ctx := newVmContext(bs, bc, sender, contract, eContractState, sender.ID(), l.Hash(), bi, "", true,
 				false, contract.State().SqlRecoveryPoint, BlockFactory, l.luaTxCommon.amount, math.MaxUint64, l.feeDelegate)
 			if traceState {
@@ -549,6 +619,7 @@ func (bc *DummyChain) ConnectBlock(txs ...luaTx) error {
 	bc.bestBlockId = types.ToBlockID(bc.cBlock.BlockHash())
 	bc.blockIds = append(bc.blockIds, bc.bestBlockId)
 	bc.blocks = append(bc.blocks, bc.cBlock)
+	bc.timeShifts = append(bc.timeShifts, bc.timeShift)
 
 	return nil
 }
@@ -560,9 +631,12 @@ func (bc *DummyChain) DisConnectBlock() error {
 	bc.bestBlockNo--
 	bc.blockIds = bc.blockIds[0 : len(bc.blockIds)-1]
 	bc.blocks = bc.blocks[0 : len(bc.blocks)-1]
+	bc.timeShifts = bc.timeShifts[0 : len(bc.timeShifts)-1]
 	bc.bestBlockId = bc.blockIds[len(bc.blockIds)-1]
+	bc.timeShift = bc.timeShifts[len(bc.timeShifts)-1]
 
 	bestBlock := bc.blocks[len(bc.blocks)-1]
+	bc.bestBlock = bestBlock
 
 	var sroot []byte
 	if bestBlock != nil {
@@ -572,11 +646,11 @@ func (bc *DummyChain) DisConnectBlock() error {
 }
 
 func (bc *DummyChain) Query(contract, queryInfo, expectedErr string, expectedRvs ...string) error {
-	cState, err := bc.sdb.GetStateDB().OpenContractStateAccount(types.ToAccountID(strHash(contract)))
+	cState, err := bc.sdb.GetStateDB().OpenContractStateAccount(types.ToAccountID(bc.strHash(contract)))
 	if err != nil {
 		return err
 	}
-	rv, err := Query(strHash(contract), bc.newBState(), bc, cState, []byte(queryInfo))
+	rv, err := Query(bc.strHash(contract), bc.newBState(), bc, cState, []byte(queryInfo))
 	if expectedErr != "" {
 		if err == nil {
 			return fmt.Errorf("no error, expected: %s", expectedErr)
@@ -601,11 +675,11 @@ func (bc *DummyChain) Query(contract, queryInfo, expectedErr string, expectedRvs
 }
 
 func (bc *DummyChain) QueryOnly(contract, queryInfo string, expectedErr string) (bool, string, error) {
-	cState, err := bc.sdb.GetStateDB().OpenContractStateAccount(types.ToAccountID(strHash(contract)))
+	cState, err := bc.sdb.GetStateDB().OpenContractStateAccount(types.ToAccountID(bc.strHash(contract)))
 	if err != nil {
 		return false, "", err
 	}
-	rv, err := Query(strHash(contract), bc.newBState(), nil, cState, []byte(queryInfo))
+	rv, err := Query(bc.strHash(contract), bc.newBState(), nil, cState, []byte(queryInfo))
 
 	if expectedErr != "" {
 		if err == nil {
@@ -628,6 +702,27 @@ func StrToAddress(name string) string {
 	return types.EncodeAddress(strHash(name))
 }
 
+// StrToAddress returns the address of name in the chain, which is the
+// impersonated address if name impersonates an account.
+func (bc *DummyChain) StrToAddress(name string) string {
+	return types.EncodeAddress(bc.strHash(name))
+}
+
+// strHash returns the address of name like strHash, resolving the names
+// impersonating accounts.
+func (bc *DummyChain) strHash(name string) []byte {
+	return bc.address(strHash(name))
+}
+
+// address returns the impersonated address if a is the address of a name
+// impersonating an account, or a itself.
+func (bc *DummyChain) address(a []byte) []byte {
+	if address, ok := bc.aliases[string(a)]; ok {
+		return address
+	}
+	return a
+}
+
 func OnPubNet(dc *DummyChain) {
 	flushLState := func() {
 		for i := 0; i <= lStateMaxSize; i++ {
@@ -0,0 +1,293 @@
+package contract
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"math/big"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/aergoio/aergo-lib/db"
+	"github.com/aergoio/aergo/config"
+	"github.com/aergoio/aergo/state"
+	"github.com/aergoio/aergo/types"
+	"github.com/gogo/protobuf/proto"
+)
+
+// ForkLatest forks a chain from its latest block.
+const ForkLatest = types.BlockNo(math.MaxUint64)
+
+// The keys in the chain database of a node.
+var (
+	// forkLatestKey is the key of the latest block number.
+	forkLatestKey = []byte("chain.latest")
+	// forkGenesisKey is the key of the genesis info.
+	forkGenesisKey = []byte("chain.genesisInfo")
+	// forkHardforkKey is the key of the hardfork versions.
+	forkHardforkKey = []byte("hardfork")
+)
+
+// LoadForkedDummyChain loads a dummy chain starting from the state of a node
+// at the block blockNo. src is the data directory of the node, which must
+// not be running, or a snapshot file, a gzipped tar archive of the data
+// directory. The databases of the node are copied to a temporary directory
+// before they are opened, since badger writes to a database even to read
+// it, and the new blocks are written to an overlay on the copy. The chain
+// follows the hardfork schedule of the node and runs as a public chain if
+// the node is on one. The SQL databases of the contracts are not forked.
+func LoadForkedDummyChain(src string, blockNo types.BlockNo, opts ...func(d *DummyChain)) (*DummyChain, error) {
+	dataPath, err := ioutil.TempDir("", "data")
+	if err != nil {
+		return nil, err
+	}
+	bc := &DummyChain{
+		sdb:      state.NewChainStateDB(),
+		tmpDir:   dataPath,
+		gasPrice: new(big.Int).SetUint64(1),
+	}
+	defer func() {
+		if err != nil {
+			bc.Release()
+		}
+	}()
+
+	dataDir := filepath.Join(dataPath, "fork")
+	if info, e := os.Stat(src); e != nil {
+		err = e
+		return nil, err
+	} else if info.IsDir() {
+		if err = copyForkDBs(src, dataDir); err != nil {
+			return nil, err
+		}
+	} else if dataDir, err = extractSnapshot(src, dataDir); err != nil {
+		return nil, err
+	}
+
+	block, hardfork, genesis, err := readForkChain(dataDir, blockNo)
+	if err != nil {
+		return nil, err
+	}
+	statePath, err := existingPath(dataDir, "state")
+	if err != nil {
+		return nil, err
+	}
+	base := db.NewDB(db.BadgerImpl, statePath)
+	if err = bc.sdb.InitFork(base, string(db.BadgerImpl), dataPath, block.GetHeader().GetBlocksRootHash(), false); err != nil {
+		base.Close()
+		return nil, err
+	}
+
+	bc.bestBlock = block
+	bc.bestBlockNo = block.BlockNo()
+	bc.bestBlockId = block.BlockID()
+	bc.blockIds = append(bc.blockIds, bc.bestBlockId)
+	bc.blocks = append(bc.blocks, block)
+	bc.timeShifts = append(bc.timeShifts, 0)
+	bc.baseNo = block.BlockNo()
+	if genesis.PublicNet() {
+		opts = append([]func(d *DummyChain){OnPubNet}, opts...)
+	}
+	if err = bc.init(hardfork, opts...); err != nil {
+		return nil, err
+	}
+	return bc, nil
+}
+
+// BaseBlockNo returns the number of the first block of the chain, which is
+// the forked block or the genesis block.
+func (bc *DummyChain) BaseBlockNo() types.BlockNo {
+	return bc.baseNo
+}
+
+// Impersonate makes name refer to the account of address so that the txs
+// of name are sent from the account without its key.
+func (bc *DummyChain) Impersonate(name, address string) error {
+	a, err := types.DecodeAddress(address)
+	if err != nil {
+		return err
+	}
+	bc.aliases[string(strHash(name))] = a
+	return nil
+}
+
+func existingPath(dataDir, name string) (string, error) {
+	p := filepath.Join(dataDir, name)
+	if info, err := os.Stat(p); err != nil {
+		return "", err
+	} else if !info.IsDir() {
+		return "", fmt.Errorf("%s is not a directory", p)
+	}
+	return p, nil
+}
+
+// copyForkDBs copies the chain and the state databases in the data directory
+// of a node to dir.
+func copyForkDBs(dataDir, dir string) error {
+	for _, name := range []string{"chain", "state"} {
+		src, err := existingPath(dataDir, name)
+		if err != nil {
+			return err
+		}
+		if err := copyDir(src, filepath.Join(dir, name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func copyDir(src, dst string) error {
+	return filepath.Walk(src, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if info.IsDir() {
+			return os.MkdirAll(target, 0700)
+		}
+		in, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer in.Close()
+		out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(out, in)
+		if cerr := out.Close(); err == nil {
+			err = cerr
+		}
+		return err
+	})
+}
+
+// readForkChain reads the block blockNo, the hardfork schedule and the
+// genesis from the chain database of a node.
+func readForkChain(dataDir string, blockNo types.BlockNo) (*types.Block, *config.HardforkConfig, *types.Genesis, error) {
+	chainPath, err := existingPath(dataDir, "chain")
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	store := db.NewDB(db.BadgerImpl, chainPath)
+	defer store.Close()
+
+	if blockNo == ForkLatest {
+		latest := store.Get(forkLatestKey)
+		if len(latest) == 0 {
+			return nil, nil, nil, fmt.Errorf("no block in %s", chainPath)
+		}
+		blockNo = types.BlockNoFromBytes(latest)
+	}
+	hash := store.Get(types.BlockNoToBytes(blockNo))
+	if len(hash) == 0 {
+		return nil, nil, nil, fmt.Errorf("block %d not found in %s", blockNo, chainPath)
+	}
+	block := &types.Block{}
+	if err := proto.Unmarshal(store.Get(hash), block); err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to read block %d: %v", blockNo, err)
+	}
+
+	genesis := types.GetGenesisFromBytes(store.Get(forkGenesisKey))
+	if genesis == nil {
+		return nil, nil, nil, fmt.Errorf("no genesis info in %s", chainPath)
+	}
+	hardfork, err := forkHardforkConfig(store, genesis)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return block, hardfork, genesis, nil
+}
+
+// forkHardforkConfig returns the hardfork schedule of a node, which is fixed
+// for the mainnet and the testnet and recorded in the chain database for the
+// others. The versions unknown to the node are not scheduled.
+func forkHardforkConfig(store db.DB, genesis *types.Genesis) (*config.HardforkConfig, error) {
+	if genesis.IsMainNet() {
+		c := *config.MainNetHardforkConfig
+		return &c, nil
+	} else if genesis.IsTestNet() {
+		c := *config.TestNetHardforkConfig
+		return &c, nil
+	}
+	data := store.Get(forkHardforkKey)
+	if len(data) == 0 {
+		return nil, errors.New("no hardfork config in the chain database")
+	}
+	c := new(config.HardforkConfig)
+	v := reflect.ValueOf(c).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		v.Field(i).SetUint(math.MaxUint64)
+	}
+	if err := json.Unmarshal(data, c); err != nil {
+		return nil, fmt.Errorf("invalid hardfork config: %v", err)
+	}
+	return c, nil
+}
+
+// extractSnapshot extracts a gzipped tar archive of a data directory into dir
+// and returns the extracted data directory. The archive may contain the data
+// directory itself or its content.
+func extractSnapshot(src, dir string) (string, error) {
+	f, err := os.Open(src)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return "", fmt.Errorf("invalid snapshot %s: %v", src, err)
+	}
+	defer gz.Close()
+
+	root := ""
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return "", fmt.Errorf("invalid snapshot %s: %v", src, err)
+		}
+		name := filepath.Clean(hdr.Name)
+		if filepath.IsAbs(name) || name == ".." || strings.HasPrefix(name, ".."+string(filepath.Separator)) {
+			return "", fmt.Errorf("invalid path in snapshot: %s", hdr.Name)
+		}
+		target := filepath.Join(dir, name)
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0700); err != nil {
+				return "", err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0700); err != nil {
+				return "", err
+			}
+			out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+			if err != nil {
+				return "", err
+			}
+			_, err = io.Copy(out, tr)
+			out.Close()
+			if err != nil {
+				return "", err
+			}
+			// remember the directory holding the chain database
+			if base := filepath.Base(filepath.Dir(name)); base == "chain" {
+				root = filepath.Dir(filepath.Dir(name))
+			}
+		}
+	}
+	return filepath.Join(dir, root), nil
+}
@@ -0,0 +1,55 @@
+package fee
+
+import (
+	"math/big"
+)
+
+const (
+	// BaseFeeTargetSize is the block body size for which the base fee of the
+	// next block stays the same. The base fee rises after a larger block and
+	// falls after a smaller one.
+	BaseFeeTargetSize = 512 * 1024
+	// baseFeeChangeDenominator bounds the change of the base fee between two
+	// blocks to 1/8.
+	baseFeeChangeDenominator = 8
+)
+
+// minBaseFee is the lowest base fee. It is far below the gas price decided by
+// the governance so that the base fee keeps falling after the small blocks.
+var minBaseFee = big.NewInt(1)
+
+// MinBaseFee returns the lowest base fee.
+func MinBaseFee() *big.Int {
+	return new(big.Int).Set(minBaseFee)
+}
+
+// NextBaseFee returns the base fee of the block following a block whose base
+// fee is baseFee and whose body size is size. It is not lower than MinBaseFee.
+func NextBaseFee(baseFee *big.Int, size int64) *big.Int {
+	next := new(big.Int).Set(baseFee)
+	if size != BaseFeeTargetSize {
+		next = adjustBaseFee(next, size)
+	}
+	if next.Cmp(minBaseFee) < 0 {
+		next.Set(minBaseFee)
+	}
+	return next
+}
+
+func adjustBaseFee(baseFee *big.Int, size int64) *big.Int {
+	next := new(big.Int).Set(baseFee)
+	diff := size - BaseFeeTargetSize
+	if diff < 0 {
+		diff = -diff
+	}
+	delta := new(big.Int).Mul(baseFee, big.NewInt(diff))
+	delta.Div(delta, big.NewInt(BaseFeeTargetSize))
+	delta.Div(delta, big.NewInt(baseFeeChangeDenominator))
+	if size > BaseFeeTargetSize {
+		if delta.Sign() == 0 {
+			delta.SetInt64(1)
+		}
+		return next.Add(next, delta)
+	}
+	return next.Sub(next, delta)
+}
@@ -0,0 +1,45 @@
+package fee
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestNextBaseFee(t *testing.T) {
+	tests := []struct {
+		name    string
+		baseFee int64
+		size    int64
+		want    int64
+	}{
+		{"target", 800, BaseFeeTargetSize, 800},
+		{"full", 800, 2 * BaseFeeTargetSize, 900},
+		{"empty", 800, 0, 700},
+		{"half", 800, BaseFeeTargetSize / 2, 750},
+		{"rise at least 1", 1, BaseFeeTargetSize + 1, 2},
+		{"min", 1, 0, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NextBaseFee(big.NewInt(tt.baseFee), tt.size); got.Cmp(big.NewInt(tt.want)) != 0 {
+				t.Errorf("NextBaseFee() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBaseFeeFallsOnEmptyBlocks(t *testing.T) {
+	gasPrice := big.NewInt(50000000000)
+	baseFee := new(big.Int).Set(gasPrice)
+	for i := 0; i < 100; i++ {
+		next := NextBaseFee(baseFee, 0)
+		if next.Cmp(baseFee) >= 0 {
+			t.Fatalf("NextBaseFee(%v) = %v, want lower", baseFee, next)
+		}
+		baseFee = next
+	}
+	// far below the gas price decided by the governance
+	if limit := new(big.Int).Div(gasPrice, big.NewInt(1000)); baseFee.Cmp(limit) >= 0 {
+		t.Errorf("base fee = %v, want lower than %v", baseFee, limit)
+	}
+}
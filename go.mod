@@ -23,6 +23,7 @@ require (
 	github.com/gogo/protobuf v1.3.0
 	github.com/golang/mock v1.3.1
 	github.com/golang/protobuf v1.3.1
+	github.com/gorilla/websocket v1.4.1
 	github.com/grpc-ecosystem/grpc-opentracing v0.0.0-20180507213350-8e809c8a8645
 	github.com/hashicorp/golang-lru v0.5.1
 	github.com/improbable-eng/grpc-web v0.9.6
@@ -40,14 +41,14 @@ require (
 	github.com/multiformats/go-multiaddr v0.1.1
 	github.com/multiformats/go-multiaddr-dns v0.2.0 // indirect
 	github.com/multiformats/go-multiaddr-net v0.1.0
-	github.com/nmarley/aergo-lib v0.0.1 // indirect
+	github.com/nmarley/aergo-lib v0.0.1
 	github.com/opentracing-contrib/go-observer v0.0.0-20170622124052-a52f23424492 // indirect
 	github.com/opentracing/opentracing-go v1.0.2
 	github.com/openzipkin-contrib/zipkin-go-opentracing v0.3.5
 	github.com/orcaman/concurrent-map v0.0.0-20190314100340-2693aad1ed75 // indirect
 	github.com/pkg/errors v0.8.1
 	github.com/pkg/term v0.0.0-20190109203006-aa71e9d9e942 // indirect
-	github.com/prometheus/client_golang v1.0.0 // indirect
+	github.com/prometheus/client_golang v1.0.0
 	github.com/rs/cors v1.6.0 // indirect
 	github.com/rs/zerolog v1.16.1-0.20191111091419-e709c5d91e35
 	github.com/serialx/hashring v0.0.0-20190515033939-7706f26af194 // indirect
@@ -56,6 +57,7 @@ require (
 	github.com/spf13/viper v1.5.0
 	github.com/stretchr/testify v1.4.0
 	github.com/sunpuyo/badger v0.0.0-20181022123248-bb757672e2c7 // indirect
+	github.com/tyler-smith/go-bip39 v1.0.2
 	github.com/willf/bitset v1.1.10 // indirect
 	github.com/willf/bloom v2.0.3+incompatible
 	golang.org/x/crypto v0.0.0-20191112222119-e1110fd1c708
@@ -23,6 +23,7 @@ import (
 	"github.com/aergoio/aergo/chain"
 	cfg "github.com/aergoio/aergo/config"
 	"github.com/aergoio/aergo/contract/enterprise"
+	"github.com/aergoio/aergo/contract/multisig"
 	"github.com/aergoio/aergo/contract/name"
 	"github.com/aergoio/aergo/contract/system"
 	"github.com/aergoio/aergo/fee"
@@ -55,8 +56,10 @@ type MemPool struct {
 	cfg *cfg.Config
 
 	sdb           *state.ChainStateDB
+	ca            types.ChainAccessor
 	bestBlockID   types.BlockID
 	bestBlockInfo *types.BlockHeaderInfo
+	nextBaseFee   *big.Int
 	stateDB       *state.StateDB
 	verifier      *actor.PID
 	orphan        int
@@ -81,9 +84,13 @@ type MemPool struct {
 // NewMemPoolService create and return new MemPool
 func NewMemPoolService(cfg *cfg.Config, cs *chain.ChainService) *MemPool {
 
-	var sdb *state.ChainStateDB
+	var (
+		sdb *state.ChainStateDB
+		ca  types.ChainAccessor
+	)
 	if cs != nil {
 		sdb = cs.SDB()
+		ca = cs
 	} else { // Test
 		fee.EnableZeroFee()
 	}
@@ -91,6 +98,7 @@ func NewMemPoolService(cfg *cfg.Config, cs *chain.ChainService) *MemPool {
 	actor := &MemPool{
 		cfg: cfg,
 		sdb: sdb,
+		ca:  ca,
 		//cache:    map[types.TxID]types.Transaction{},
 		cache:    sync.Map{},
 		pool:     map[types.AccountID]*txList{},
@@ -363,6 +371,7 @@ func (mp *MemPool) setStateDB(block *types.Block) (bool, bool) {
 		}
 		mp.bestBlockID = newBlockID
 		mp.bestBlockInfo = types.NewBlockHeaderInfo(block)
+		mp.nextBaseFee = types.NextBaseFee(block, system.GetGasPrice())
 		stateRoot := block.GetHeader().GetBlocksRootHash()
 		if mp.stateDB == nil {
 			mp.stateDB = mp.sdb.OpenNewStateDB(stateRoot)
@@ -460,6 +469,20 @@ func (mp *MemPool) removeOnBlockArrival(block *types.Block) error {
 		check++
 	}
 
+	// evict the txs which expire before the next block, whatever the account
+	for _, list := range mp.pool {
+		diff, delTxs := list.FilterByExpiry(block.BlockNo()+1, block.GetHeader().GetTimestamp())
+		if len(delTxs) == 0 {
+			continue
+		}
+		mp.orphan -= diff
+		for _, tx := range delTxs {
+			mp.cache.Delete(types.ToTxID(tx.GetHash()))
+			mp.length--
+		}
+		mp.releaseMemPoolList(list)
+	}
+
 	ag[1] = time.Since(start)
 	mp.Debug().Int("given", len(block.GetBody().GetTxs())).
 		Int("check", check).
@@ -528,6 +551,20 @@ func (mp *MemPool) getNameDest(account []byte, owner bool) []byte {
 	return name.GetAddress(scs, account)
 }
 
+// gasPrice returns the base fee of the next block, or the gas price decided
+// by the governance until the base fee is activated.
+func (mp *MemPool) gasPrice() *big.Int {
+	if mp.nextBaseFee != nil && types.FeatureBaseFee.ActiveAt(mp.nextBlockVersion()) {
+		return mp.nextBaseFee
+	}
+	if mp.ca != nil {
+		if price, err := mp.ca.GetSystemValue(types.GasPrice); err == nil {
+			return price
+		}
+	}
+	return system.GetGasPrice()
+}
+
 func (mp *MemPool) nextBlockVersion() int32 {
 	return mp.cfg.Hardfork.Version(mp.bestBlockInfo.No)
 }
@@ -544,15 +581,34 @@ func (mp *MemPool) validateTx(tx types.Transaction, account types.Address) error
 	if err != nil {
 		return err
 	}
-	err = tx.ValidateWithSenderState(ns, system.GetGasPrice(), mp.nextBlockVersion())
+	err = tx.ValidateWithSenderState(ns, mp.gasPrice(), mp.nextBlockVersion())
 	if err != nil && err != types.ErrTxNonceToohigh {
 		return err
 	}
+	if err := tx.GetBody().ValidateGasTip(mp.nextBlockVersion()); err != nil {
+		return err
+	}
+	if tx.GetBody().HasExpiry() {
+		if !types.FeatureTxExpiry.ActiveAt(mp.nextBlockVersion()) {
+			return types.ErrTxFormatInvalid
+		}
+		if tx.GetBody().IsExpired(mp.bestBlockInfo.No+1, time.Now().UnixNano()) {
+			return types.ErrTxExpired
+		}
+	}
 
 	//NOTE: don't overwrite err, if err == ErrTxNonceToohigh
 	//because err should be ErrNonceToohigh if following validation has passed
 	//this will be refactored soon
 
+	if types.IsMultisigAddress(account) {
+		if config, err := multisig.GetAccountConfig(mp.stateDB, account); err != nil {
+			return err
+		} else if config == nil {
+			return types.ErrMultisigNotRegistered
+		}
+	}
+
 	switch tx.GetBody().GetType() {
 	case types.TxType_REDEPLOY:
 		if chain.IsPublic() {
@@ -603,7 +659,8 @@ func (mp *MemPool) validateTx(tx types.Transaction, account types.Address) error
 			if err != nil {
 				return err
 			}
-			if _, err := name.ValidateNameTx(tx.GetBody(), sender, scs, systemcs); err != nil {
+			if _, err := name.ValidateNameTx(tx.GetBody(), sender, scs, systemcs,
+				&types.BlockHeaderInfo{No: mp.bestBlockInfo.No + 1, Version: mp.nextBlockVersion()}); err != nil {
 				return err
 			}
 		case types.AergoEnterprise:
@@ -619,6 +676,19 @@ func (mp *MemPool) validateTx(tx types.Transaction, account types.Address) error
 				return err
 			}
 		}
+	case types.TxType_MULTISIG:
+		if !types.FeatureMultisig.ActiveAt(mp.nextBlockVersion()) {
+			return types.ErrTxInvalidType
+		}
+		if config, err := multisig.GetAccountConfig(mp.stateDB, tx.GetBody().GetRecipient()); err != nil {
+			return err
+		} else if config != nil {
+			return types.ErrMultisigAlreadyRegistered
+		}
+	case types.TxType_BATCH:
+		if !types.FeatureBatchTx.ActiveAt(mp.nextBlockVersion()) {
+			return types.ErrTxInvalidType
+		}
 	case types.TxType_FEEDELEGATION:
 		var recipient []byte
 
@@ -634,7 +704,7 @@ func (mp *MemPool) validateTx(tx types.Transaction, account types.Address) error
 			return err
 		}
 		bal := aergoState.GetBalanceBigInt()
-		fee, err := tx.GetMaxFee(bal, system.GetGasPrice(), mp.nextBlockVersion())
+		fee, err := tx.GetMaxFee(bal, mp.gasPrice(), mp.nextBlockVersion())
 		if err != nil {
 			return err
 		}
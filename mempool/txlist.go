@@ -10,7 +10,6 @@ import (
 	"sync"
 	"time"
 
-	"github.com/aergoio/aergo/contract/system"
 	"github.com/aergoio/aergo/types"
 )
 
@@ -145,7 +144,7 @@ func (tl *txList) FilterByState(st *types.State) (int, []types.Transaction) {
 	var left []types.Transaction
 	removed := tl.list[:0]
 	for i, x := range tl.list {
-		err := x.ValidateWithSenderState(st, system.GetGasPrice(), tl.mp.nextBlockVersion())
+		err := x.ValidateWithSenderState(st, tl.mp.gasPrice(), tl.mp.nextBlockVersion())
 		if err == nil || err == types.ErrTxNonceToohigh {
 			if err != nil && !balCheck {
 				left = append(left, tl.list[i:]...)
@@ -171,6 +170,40 @@ func (tl *txList) FilterByState(st *types.State) (int, []types.Transaction) {
 	return oldCnt - newCnt, removed
 }
 
+// FilterByExpiry removes the transactions which can no longer be included in
+// the block numbered no and made after ts. The following transactions of the
+// account become orphans.
+func (tl *txList) FilterByExpiry(no types.BlockNo, ts int64) (int, []types.Transaction) {
+	tl.Lock()
+	defer tl.Unlock()
+
+	oldCnt := len(tl.list) - tl.ready
+	var left, removed []types.Transaction
+	for _, x := range tl.list {
+		if x.GetBody().IsExpired(no, ts) {
+			removed = append(removed, x)
+		} else {
+			left = append(left, x)
+		}
+	}
+	if len(removed) == 0 {
+		return 0, nil
+	}
+
+	tl.list = left
+	tl.ready = 0
+	for i := 0; i < len(tl.list); i++ {
+		if !tl.continuous(i) {
+			break
+		}
+		tl.ready++
+	}
+	newCnt := len(tl.list) - tl.ready
+
+	tl.lastTime = time.Now()
+	return oldCnt - newCnt, removed
+}
+
 // FilterByPrice will evict transactions that needs more amount than balance
 /*
 func (tl *txList) FilterByPrice(balance uint64) error {
@@ -83,3 +83,36 @@ type ExportAccountRsp struct {
 	Wif []byte
 	Err error
 }
+
+type CreateHDWallet struct {
+	Path       string
+	Passphrase string
+}
+
+type ImportHDWallet struct {
+	Mnemonic   string
+	Path       string
+	Passphrase string
+}
+
+type HDWalletRsp struct {
+	Mnemonic string
+	Path     string
+	Err      error
+}
+
+type DeriveHDAccount struct {
+	Index      uint32
+	Passphrase string
+}
+
+type UnlockHDAccount struct {
+	Index      uint32
+	Passphrase string
+}
+
+type GetHDAccounts struct{}
+type GetHDAccountsRsp struct {
+	Accounts *types.AccountList
+	Err      error
+}
@@ -88,6 +88,15 @@ type GetABIRsp struct {
 	Err error
 }
 
+// GetCode requests the deployed code of a contract.
+type GetCode struct {
+	Contract []byte
+}
+type GetCodeRsp struct {
+	Code []byte
+	Err  error
+}
+
 type GetQuery struct {
 	Contract  []byte
 	Queryinfo []byte
@@ -143,6 +152,15 @@ type GetStakingRsp struct {
 	Err     error
 }
 
+type GetVotingReward struct {
+	Addr []byte
+}
+
+type GetVotingRewardRsp struct {
+	Reward *types.VotingReward
+	Err    error
+}
+
 type GetNameInfo struct {
 	Name    string
 	BlockNo types.BlockNo
@@ -195,6 +213,14 @@ type ListEventsRsp struct {
 	Err    error
 }
 
+// ListEvidences requests double sign evidences detected by local node
+type ListEvidences struct{}
+
+type ListEvidencesRsp struct {
+	Evidences []*types.DoubleSignEvidence
+	Err       error
+}
+
 type VerifyStart struct{}
 
 type GetParams struct{}
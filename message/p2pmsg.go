@@ -116,10 +116,12 @@ type GetBlockChunksRsp struct {
 }
 
 // GetPeers requests p2p actor to get remote peers that is connected.
+// If Known is set, the actor returns peers in address book instead, including disconnected ones.
 // The actor returns *GetPeersRsp
 type GetPeers struct {
 	NoHidden bool
 	ShowSelf bool
+	Known    bool
 }
 
 type PeerInfo struct {
@@ -133,6 +135,13 @@ type PeerInfo struct {
 	LastBlockNumber uint64
 	State           types.PeerState
 	Self            bool
+
+	// fields below are connection history, and only filled for known peers
+	LastSuccess time.Time
+	LastFailure time.Time
+	SuccessCnt  int32
+	FailCnt     int32
+	Latency     time.Duration
 }
 
 // GetPeersRsp contains peer meta information and current states.
@@ -223,6 +232,12 @@ type SendRaftRsp struct {
 	Err error
 }
 
+// SendBFT sends the message of bft consensus to ToWhom. It is sent to all the connected peers if ToWhom is empty.
+type SendBFT struct {
+	ToWhom []types.PeerID
+	Body   *types.BftMessage
+}
+
 type P2PWhiteListConfEnableEvent struct {
 	Name string
 	On   bool
@@ -255,6 +255,26 @@ func (p2ps *P2P) SendRaftMessage(context actor.Context, msg *message.SendRaft) {
 	// return success
 }
 
+func (p2ps *P2P) SendBFTMessage(context actor.Context, msg *message.SendBFT) {
+	mo := p2ps.mf.NewMsgRequestOrder(false, p2pcommon.BFTConsensusMessage, msg.Body)
+
+	var peers []p2pcommon.RemotePeer
+	if len(msg.ToWhom) == 0 {
+		peers = p2ps.pm.GetPeers()
+	} else {
+		for _, peerID := range msg.ToWhom {
+			if remotePeer, exists := p2ps.pm.GetPeer(peerID); exists {
+				peers = append(peers, remotePeer)
+			}
+		}
+	}
+
+	for _, neighbor := range peers {
+		if neighbor != nil && neighbor.State() == types.RUNNING {
+			neighbor.SendMessage(mo)
+		}
+	}
+}
 
 func (p2ps *P2P) SendIssueCertMessage(context actor.Context, msg message.IssueAgentCertificate) {
 	peerID := msg.ProducerID
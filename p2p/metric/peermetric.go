@@ -23,6 +23,13 @@ type PeerMetric struct {
 
 	InMetric  DataMetric
 	OutMetric DataMetric
+
+	// latency is moving average of ping round-trip time in nanoseconds
+	latency int64
+
+	// delayedReqs and droppedReqs are the number of incoming requests which exceeded rate limit
+	delayedReqs int64
+	droppedReqs int64
 }
 
 var _ p2pcommon.MsgIOListener = (*PeerMetric)(nil)
@@ -54,3 +61,36 @@ func (m *PeerMetric) InputAdded(added int) {
 func (m *PeerMetric) OutputAdded(added int) {
 	atomic.AddInt64(&m.totalOut, int64(added))
 }
+
+// AddLatency reflects new round-trip time to average latency of peer.
+func (m *PeerMetric) AddLatency(rtt time.Duration) {
+	prev := atomic.LoadInt64(&m.latency)
+	if prev == 0 {
+		atomic.StoreInt64(&m.latency, int64(rtt))
+	} else {
+		atomic.StoreInt64(&m.latency, (prev*3+int64(rtt))/4)
+	}
+}
+
+// Latency returns average round-trip time of ping, or zero if not measured yet.
+func (m *PeerMetric) Latency() time.Duration {
+	return time.Duration(atomic.LoadInt64(&m.latency))
+}
+
+// OnRequestDelayed is called when handling of incoming request is postponed by rate limit.
+func (m *PeerMetric) OnRequestDelayed(protocol p2pcommon.SubProtocol) {
+	atomic.AddInt64(&m.delayedReqs, 1)
+}
+
+// OnRequestDropped is called when incoming request is refused by rate limit.
+func (m *PeerMetric) OnRequestDropped(protocol p2pcommon.SubProtocol) {
+	atomic.AddInt64(&m.droppedReqs, 1)
+}
+
+func (m *PeerMetric) DelayedRequests() int64 {
+	return atomic.LoadInt64(&m.delayedReqs)
+}
+
+func (m *PeerMetric) DroppedRequests() int64 {
+	return atomic.LoadInt64(&m.droppedReqs)
+}
@@ -12,6 +12,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/aergoio/aergo/p2p/metric"
 	"github.com/aergoio/aergo/p2p/p2pcommon"
 	"github.com/aergoio/aergo/p2p/p2pmock"
 	"github.com/golang/mock/gomock"
@@ -393,3 +394,27 @@ func Test_pbTxNoticeOrder_SendTo(t *testing.T) {
 		})
 	}
 }
+
+func Test_remotePeerImpl_ConsumeRequestLatency(t *testing.T) {
+	mm := metric.NewMetricManager(1)
+	factory := &baseMOFactory{}
+	p := &remotePeerImpl{
+		logger:   logger,
+		metric:   mm.NewMetric(dummyPeerID, 1),
+		requests: make(map[p2pcommon.MsgID]*requestInfo),
+	}
+
+	ping := factory.NewMsgRequestOrder(true, p2pcommon.PingRequest, &types.Ping{})
+	p.requests[ping.GetMsgID()] = &requestInfo{cTime: time.Now().Add(-time.Millisecond * 100), reqMO: ping}
+	other := factory.NewMsgRequestOrder(true, p2pcommon.GetBlocksRequest, &types.GetBlockRequest{})
+	p.requests[other.GetMsgID()] = &requestInfo{cTime: time.Now().Add(-time.Second * 10), reqMO: other}
+
+	// only the round-trip time of ping is the latency
+	p.ConsumeRequest(other.GetMsgID())
+	assert.Equal(t, time.Duration(0), p.metric.Latency())
+
+	p.ConsumeRequest(ping.GetMsgID())
+	assert.True(t, p.metric.Latency() >= time.Millisecond*100)
+	assert.True(t, p.metric.Latency() < time.Second*10)
+	assert.Empty(t, p.requests)
+}
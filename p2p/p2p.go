@@ -58,6 +58,7 @@ type P2P struct {
 	lm     p2pcommon.ListManager
 	cm     p2pcommon.CertificateManager
 	tnt    *txNoticeTracer
+	rl     *rateLimits
 
 	mutex sync.Mutex
 
@@ -98,6 +99,11 @@ func (p2ps *P2P) initP2P(chainSvc *chain.ChainService) {
 
 	p2ps.selfMeta = SetupSelfMeta(p2pkey.NodeID(), cfg.P2P, cfg.Consensus.EnableBp)
 	p2ps.initLocalSettings(cfg.P2P)
+	rl, err := newRateLimits(cfg.P2P)
+	if err != nil {
+		panic("invalid rate limit setting: " + err.Error())
+	}
+	p2ps.rl = rl
 	// set selfMeta.AcceptedRole and init role manager
 	p2ps.cm = newCertificateManager(p2ps, p2ps, p2ps.Logger)
 	p2ps.prm = p2ps.initRoleManager(p2ps.useRaft, p2ps.selfMeta.Role, p2ps.cm)
@@ -201,6 +207,7 @@ func (p2ps *P2P) BeforeStop() {
 func (p2ps *P2P) Statistics() *map[string]interface{} {
 	stmap := make(map[string]interface{})
 	stmap["netstat"] = p2ps.mm.Summary()
+	stmap["peers"] = len(p2ps.pm.GetPeers())
 	stmap["config"] = p2ps.cfg.P2P
 	stmap["status"] = p2ps.selfMeta
 	wlSummary := p2ps.lm.Summary()
@@ -268,8 +275,12 @@ func (p2ps *P2P) Receive(context actor.Context) {
 	case *message.GetSelf:
 		context.Respond(p2ps.selfMeta)
 	case *message.GetPeers:
-		peers := p2ps.pm.GetPeerAddresses(msg.NoHidden, msg.ShowSelf)
-		context.Respond(&message.GetPeersRsp{Peers: peers})
+		if msg.Known {
+			context.Respond(&message.GetPeersRsp{Peers: p2ps.pm.GetKnownPeers(msg.NoHidden)})
+		} else {
+			peers := p2ps.pm.GetPeerAddresses(msg.NoHidden, msg.ShowSelf)
+			context.Respond(&message.GetPeersRsp{Peers: peers})
+		}
 	case *message.GetSyncAncestor:
 		p2ps.GetSyncAncestor(context, msg)
 	case *message.MapQueryMsg:
@@ -293,6 +304,8 @@ func (p2ps *P2P) Receive(context actor.Context) {
 		clusterReceiver.StartGet()
 	case *message.SendRaft:
 		p2ps.SendRaftMessage(context, msg)
+	case *message.SendBFT:
+		p2ps.SendBFTMessage(context, msg)
 	case *message.RaftClusterEvent:
 		p2ps.Logger.Debug().Int("added", len(msg.BPAdded)).Int("removed", len(msg.BPRemoved)).Msg("bp changed")
 		p2ps.prm.UpdateBP(msg.BPAdded, msg.BPRemoved)
@@ -437,6 +450,9 @@ func (p2ps *P2P) insertHandlers(peer p2pcommon.RemotePeer) {
 	peer.AddMessageHandler(p2pcommon.GetClusterResponse, subproto.NewGetClusterRespHandler(p2ps.pm, peer, logger, p2ps))
 	peer.AddMessageHandler(p2pcommon.RaftWrapperMessage, subproto.NewRaftWrapperHandler(p2ps.pm, peer, logger, p2ps, p2ps.consacc))
 
+	// BFT support
+	peer.AddMessageHandler(p2pcommon.BFTConsensusMessage, subproto.NewBFTMessageHandler(p2ps.pm, peer, logger, p2ps, p2ps.consacc))
+
 	// certificate
 	peer.AddMessageHandler(p2pcommon.IssueCertificateRequest, subproto.NewIssueCertReqHandler(p2ps.pm, p2ps.cm, peer, logger, p2ps))
 	peer.AddMessageHandler(p2pcommon.IssueCertificateResponse, subproto.NewIssueCertRespHandler(p2ps.pm, p2ps.cm, peer, logger, p2ps))
@@ -454,7 +470,12 @@ func (p2ps *P2P) CreateHSHandler(outbound bool, pid types.PeerID) p2pcommon.HSHa
 func (p2ps *P2P) CreateRemotePeer(remoteInfo p2pcommon.RemoteInfo, seq uint32, rw p2pcommon.MsgReadWriter) p2pcommon.RemotePeer {
 	newPeer := newRemotePeer(remoteInfo, seq, p2ps.pm, p2ps, p2ps.Logger, p2ps.mf, p2ps.signer, rw)
 	newPeer.tnt = p2ps.tnt
-	rw.AddIOListener(p2ps.mm.NewMetric(newPeer.ID(), newPeer.ManageNumber()))
+	newPeer.metric = p2ps.mm.NewMetric(newPeer.ID(), newPeer.ManageNumber())
+	rw.AddIOListener(newPeer.metric)
+	if p2ps.rl.bandwidthLimited() {
+		rw.AddIOListener(p2ps.rl)
+	}
+	newPeer.reqLimiters = p2ps.rl.newRequestLimiters()
 
 	// FIXME need refactoring
 	// raft role
@@ -35,6 +35,8 @@ type PeerManager interface {
 	// GetPeers return all registered(handshaked) remote peers. It is thread safe
 	GetPeers() []RemotePeer
 	GetPeerAddresses(noHidden bool, showSelf bool) []*message.PeerInfo
+	// GetKnownPeers returns peers in address book with connection history, including currently disconnected peers.
+	GetKnownPeers(noHidden bool) []*message.PeerInfo
 
 	GetPeerBlockInfos() []types.PeerBlockInfo
 
@@ -5,9 +5,20 @@
 
 package p2pcommon
 
+import "fmt"
+
 // SubProtocol identifies the lower type of p2p message
 type SubProtocol uint32
 
 func (i SubProtocol) Uint32() uint32 {
 	return uint32(i)
-}
\ No newline at end of file
+}
+// ParseSubProtocol returns SubProtocol which has the name, or error if no such SubProtocol exists.
+func ParseSubProtocol(name string) (SubProtocol, error) {
+	for _, sp := range allSubProtocols {
+		if sp.String() == name {
+			return sp, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown subprotocol %s", name)
+}
@@ -11,6 +11,7 @@ const (
 	_SubProtocol_name_3 = "GetTXsRequestGetTXsResponseNewTxNotice"
 	_SubProtocol_name_4 = "BlockProducedNotice"
 	_SubProtocol_name_5 = "GetClusterRequestGetClusterResponseRaftWrapperMessage"
+	_SubProtocol_name_6 = "BFTConsensusMessage"
 )
 
 var (
@@ -40,6 +41,8 @@ func (i SubProtocol) String() string {
 	case 12545 <= i && i <= 12547:
 		i -= 12545
 		return _SubProtocol_name_5[_SubProtocol_index_5[i]:_SubProtocol_index_5[i+1]]
+	case i == 12801:
+		return _SubProtocol_name_6
 	default:
 		return "SubProtocol(" + strconv.FormatInt(int64(i), 10) + ")"
 	}
@@ -53,4 +53,19 @@ const (
 	RaftWrapperMessage  //
 )
 
+// subprotocols for bft consensus
+const (
+	_ SubProtocol = 0x3200 + iota
+	// BFTConsensusMessage carries the proposals, votes and commits among bft validators
+	BFTConsensusMessage
+)
+
+// allSubProtocols is list of all available subprotocols, used to find subprotocol by name
+var allSubProtocols = []SubProtocol{StatusRequest, PingRequest, PingResponse, GoAway, AddressesRequest, AddressesResponse,
+	IssueCertificateRequest, IssueCertificateResponse, CertificateRenewedNotice,
+	GetBlocksRequest, GetBlocksResponse, GetBlockHeadersRequest, GetBlockHeadersResponse, NewBlockNotice,
+	GetAncestorRequest, GetAncestorResponse, GetHashesRequest, GetHashesResponse, GetHashByNoRequest, GetHashByNoResponse,
+	GetTXsRequest, GetTXsResponse, NewTxNotice, BlockProducedNotice,
+	GetClusterRequest, GetClusterResponse, RaftWrapperMessage, BFTConsensusMessage}
+
 //go:generate stringer -type=SubProtocol
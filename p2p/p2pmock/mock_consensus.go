@@ -39,6 +39,21 @@ func (m *MockConsensusAccessor) EXPECT() *MockConsensusAccessorMockRecorder {
 	return m.recorder
 }
 
+// BPStats mocks base method
+func (m *MockConsensusAccessor) BPStats(arg0 uint64) (*types.BPStatList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BPStats", arg0)
+	ret0, _ := ret[0].(*types.BPStatList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BPStats indicates an expected call of BPStats
+func (mr *MockConsensusAccessorMockRecorder) BPStats(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BPStats", reflect.TypeOf((*MockConsensusAccessor)(nil).BPStats), arg0)
+}
+
 // ClusterInfo mocks base method
 func (m *MockConsensusAccessor) ClusterInfo(arg0 []byte) *types.GetClusterInfoResponse {
 	m.ctrl.T.Helper()
@@ -111,6 +126,20 @@ func (mr *MockConsensusAccessorMockRecorder) RaftAccessor() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RaftAccessor", reflect.TypeOf((*MockConsensusAccessor)(nil).RaftAccessor))
 }
 
+// BFTAccessor mocks base method
+func (m *MockConsensusAccessor) BFTAccessor() consensus.BFTAccessor {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BFTAccessor")
+	ret0, _ := ret[0].(consensus.BFTAccessor)
+	return ret0
+}
+
+// BFTAccessor indicates an expected call of BFTAccessor
+func (mr *MockConsensusAccessorMockRecorder) BFTAccessor() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BFTAccessor", reflect.TypeOf((*MockConsensusAccessor)(nil).BFTAccessor))
+}
+
 // MockAergoRaftAccessor is a mock of AergoRaftAccessor interface
 type MockAergoRaftAccessor struct {
 	ctrl     *gomock.Controller
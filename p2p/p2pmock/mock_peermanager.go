@@ -194,6 +194,20 @@ func (mr *MockPeerManagerMockRecorder) GetPeerAddresses(noHidden, showSelf inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPeerAddresses", reflect.TypeOf((*MockPeerManager)(nil).GetPeerAddresses), noHidden, showSelf)
 }
 
+// GetKnownPeers mocks base method
+func (m *MockPeerManager) GetKnownPeers(noHidden bool) []*message.PeerInfo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKnownPeers", noHidden)
+	ret0, _ := ret[0].([]*message.PeerInfo)
+	return ret0
+}
+
+// GetKnownPeers indicates an expected call of GetKnownPeers
+func (mr *MockPeerManagerMockRecorder) GetKnownPeers(noHidden interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKnownPeers", reflect.TypeOf((*MockPeerManager)(nil).GetKnownPeers), noHidden)
+}
+
 // GetPeerBlockInfos mocks base method
 func (m *MockPeerManager) GetPeerBlockInfos() []types.PeerBlockInfo {
 	m.ctrl.T.Helper()
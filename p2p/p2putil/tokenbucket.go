@@ -0,0 +1,91 @@
+/*
+ * @file
+ * @copyright defined in aergo/LICENSE.txt
+ */
+
+package p2putil
+
+import (
+	"sync"
+	"time"
+)
+
+// TokenBucket is threadsafe token bucket rate limiter. Tokens are refilled at rate per second, and at most burst tokens
+// can be stored. A nil TokenBucket means unlimited and always permits immediately.
+type TokenBucket struct {
+	mutex  sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+
+	// nowFunc is replaceable for test
+	nowFunc func() time.Time
+}
+
+// NewTokenBucket create a token bucket which is full at the beginning. It returns nil if rate is not positive, which
+// means no limit. burst smaller than rate is adjusted to rate.
+func NewTokenBucket(rate, burst int64) *TokenBucket {
+	if rate <= 0 {
+		return nil
+	}
+	if burst < rate {
+		burst = rate
+	}
+	tb := &TokenBucket{rate: float64(rate), burst: float64(burst), tokens: float64(burst), nowFunc: time.Now}
+	tb.last = tb.nowFunc()
+	return tb
+}
+
+// Take consumes n tokens and returns how long the caller should wait until the tokens are actually available.
+// Tokens are reserved even if it returns positive duration, so that caller must wait or give up that amount.
+func (tb *TokenBucket) Take(n int) time.Duration {
+	if tb == nil || n <= 0 {
+		return 0
+	}
+	tb.mutex.Lock()
+	defer tb.mutex.Unlock()
+	tb.refill()
+	tb.tokens -= float64(n)
+	if tb.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-tb.tokens / tb.rate * float64(time.Second))
+}
+
+// TryTake consumes n tokens only if they are available now, and returns whether they are consumed.
+func (tb *TokenBucket) TryTake(n int) bool {
+	if tb == nil || n <= 0 {
+		return true
+	}
+	tb.mutex.Lock()
+	defer tb.mutex.Unlock()
+	tb.refill()
+	if tb.tokens < float64(n) {
+		return false
+	}
+	tb.tokens -= float64(n)
+	return true
+}
+
+// Rate returns refill rate per second, or 0 if unlimited.
+func (tb *TokenBucket) Rate() int64 {
+	if tb == nil {
+		return 0
+	}
+	return int64(tb.rate)
+}
+
+// refill must be called in lock
+func (tb *TokenBucket) refill() {
+	now := tb.nowFunc()
+	elapsed := now.Sub(tb.last)
+	tb.last = now
+	if elapsed <= 0 {
+		return
+	}
+	tb.tokens += elapsed.Seconds() * tb.rate
+	if tb.tokens > tb.burst {
+		tb.tokens = tb.burst
+	}
+}
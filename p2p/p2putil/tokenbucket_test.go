@@ -0,0 +1,67 @@
+/*
+ * @file
+ * @copyright defined in aergo/LICENSE.txt
+ */
+
+package p2putil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTokenBucket(t *testing.T) {
+	tests := []struct {
+		name      string
+		rate      int64
+		burst     int64
+		wantNil   bool
+		wantBurst float64
+	}{
+		{"TUnlimited", 0, 100, true, 0},
+		{"TNegative", -1, 100, true, 0},
+		{"TNormal", 10, 100, false, 100},
+		{"TSmallBurst", 10, 1, false, 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewTokenBucket(tt.rate, tt.burst)
+			assert.Equal(t, tt.wantNil, got == nil)
+			if !tt.wantNil {
+				assert.Equal(t, tt.wantBurst, got.burst)
+				assert.Equal(t, tt.wantBurst, got.tokens)
+			}
+		})
+	}
+}
+
+func TestTokenBucket_Take(t *testing.T) {
+	now := time.Now()
+	tb := NewTokenBucket(10, 10)
+	tb.nowFunc = func() time.Time { return now }
+	tb.last = now
+
+	assert.Equal(t, time.Duration(0), tb.Take(10))
+	// bucket is empty and 5 tokens are owed
+	assert.Equal(t, time.Millisecond*500, tb.Take(5))
+	assert.False(t, tb.TryTake(1))
+
+	// refilled after one second, but the owed tokens are subtracted
+	now = now.Add(time.Second)
+	assert.True(t, tb.TryTake(5))
+	assert.False(t, tb.TryTake(1))
+
+	// never exceeds burst
+	now = now.Add(time.Hour)
+	assert.False(t, tb.TryTake(11))
+	assert.True(t, tb.TryTake(10))
+}
+
+func TestTokenBucket_Nil(t *testing.T) {
+	var tb *TokenBucket
+	assert.Equal(t, time.Duration(0), tb.Take(1000000))
+	assert.True(t, tb.TryTake(1000000))
+	assert.Equal(t, int64(0), tb.Rate())
+}
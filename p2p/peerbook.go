@@ -0,0 +1,265 @@
+/*
+ * @file
+ * @copyright defined in aergo/LICENSE.txt
+ */
+
+package p2p
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/aergoio/aergo-lib/log"
+	"github.com/aergoio/aergo/p2p/p2pcommon"
+	"github.com/aergoio/aergo/p2p/p2putil"
+	"github.com/aergoio/aergo/types"
+	"github.com/multiformats/go-multiaddr"
+)
+
+// constants for known peer address book
+const (
+	peerBookDirName  = "p2p"
+	peerBookFileName = "knownpeers.json"
+
+	// peerBookSaveInterval is interval to flush changed address book to file
+	peerBookSaveInterval = time.Minute * 5
+	// maxKnownPeers is maximum number of peers kept in address book. peers with lowest score are dropped first.
+	maxKnownPeers = 1000
+	// knownPeerTTL is period after which peer that never succeeded to connect is forgotten
+	knownPeerTTL = time.Hour * 24 * 7
+	// maxBookedDialCnt is max number of known peers added to waiting pool at start time
+	maxBookedDialCnt = 20
+)
+
+// knownPeer is connection history of a peer that the local node met before
+type knownPeer struct {
+	ID          types.PeerID
+	Role        types.PeerRole
+	Addresses   []string
+	Version     string
+	FirstSeen   time.Time
+	LastSuccess time.Time
+	LastFailure time.Time
+	SuccessCnt  int32
+	FailCnt     int32
+	// Latency is averaged round-trip time of ping
+	Latency time.Duration
+}
+
+func (kp *knownPeer) meta() p2pcommon.PeerMeta {
+	addrs := make([]types.Multiaddr, 0, len(kp.Addresses))
+	for _, a := range kp.Addresses {
+		ma, err := multiaddr.NewMultiaddr(a)
+		if err != nil {
+			continue
+		}
+		addrs = append(addrs, ma)
+	}
+	return p2pcommon.PeerMeta{ID: kp.ID, Role: kp.Role, Addresses: addrs, Version: kp.Version}
+}
+
+// score is rough estimation of how worth it is to dial peer. higher is better.
+func (kp *knownPeer) score() float64 {
+	total := float64(kp.SuccessCnt + kp.FailCnt)
+	if total == 0 {
+		return 0
+	}
+	s := float64(kp.SuccessCnt) / total
+	// penalize slow peers; peer with 1 second latency loses half of score.
+	if kp.Latency > 0 {
+		s = s / (1 + kp.Latency.Seconds())
+	}
+	// recent failure has more weight than old failure
+	if kp.LastFailure.After(kp.LastSuccess) {
+		s = s / 2
+	}
+	return s
+}
+
+// peerBook is persistent address book which remembers peers and quality of connections to them.
+// It is not thread safe and must be accessed only in peerManager goroutine.
+type peerBook struct {
+	logger   *log.Logger
+	filePath string
+	peers    map[types.PeerID]*knownPeer
+	dirty    bool
+}
+
+func newPeerBook(logger *log.Logger, dataDir string) *peerBook {
+	pb := &peerBook{logger: logger, peers: make(map[types.PeerID]*knownPeer)}
+	if len(dataDir) > 0 {
+		pb.filePath = filepath.Join(dataDir, peerBookDirName, peerBookFileName)
+	}
+	return pb
+}
+
+// load reads address book from file. missing file is not an error, since it is normal at first run.
+func (pb *peerBook) load() error {
+	if len(pb.filePath) == 0 {
+		return nil
+	}
+	data, err := ioutil.ReadFile(pb.filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	var list []*knownPeer
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	for _, kp := range list {
+		pb.peers[kp.ID] = kp
+	}
+	pb.logger.Info().Int("size", len(pb.peers)).Str("path", pb.filePath).Msg("loaded known peers")
+	return nil
+}
+
+// save writes address book to file if it was changed.
+func (pb *peerBook) save() error {
+	if len(pb.filePath) == 0 || !pb.dirty {
+		return nil
+	}
+	pb.prune(time.Now())
+	data, err := json.MarshalIndent(pb.list(), "", " ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(pb.filePath), 0700); err != nil {
+		return err
+	}
+	// write to temporary file and then rename it, not to leave broken file on crash
+	tmpPath := pb.filePath + ".tmp"
+	if err := ioutil.WriteFile(tmpPath, data, 0600); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpPath, pb.filePath); err != nil {
+		return err
+	}
+	pb.dirty = false
+	return nil
+}
+
+func (pb *peerBook) getOrAdd(meta p2pcommon.PeerMeta) *knownPeer {
+	kp, found := pb.peers[meta.ID]
+	if !found {
+		kp = &knownPeer{ID: meta.ID, FirstSeen: time.Now()}
+		pb.peers[meta.ID] = kp
+	}
+	// update address with latest advertised information
+	if len(meta.Addresses) > 0 {
+		kp.Addresses = make([]string, len(meta.Addresses))
+		for i, a := range meta.Addresses {
+			kp.Addresses[i] = a.String()
+		}
+	}
+	if len(meta.Version) > 0 {
+		kp.Version = meta.Version
+	}
+	pb.dirty = true
+	return kp
+}
+
+// reportSuccess records that connection to peer is established.
+func (pb *peerBook) reportSuccess(meta p2pcommon.PeerMeta, role types.PeerRole) {
+	kp := pb.getOrAdd(meta)
+	kp.Role = role
+	kp.LastSuccess = time.Now()
+	kp.SuccessCnt++
+}
+
+// reportFailure records that outbound connection to peer is failed.
+func (pb *peerBook) reportFailure(meta p2pcommon.PeerMeta) {
+	kp := pb.getOrAdd(meta)
+	kp.LastFailure = time.Now()
+	kp.FailCnt++
+}
+
+// reportLatency updates average latency of peer
+func (pb *peerBook) reportLatency(id types.PeerID, latency time.Duration) {
+	if latency <= 0 {
+		return
+	}
+	kp, found := pb.peers[id]
+	if !found {
+		return
+	}
+	if kp.Latency == 0 {
+		kp.Latency = latency
+	} else {
+		kp.Latency = (kp.Latency*3 + latency) / 4
+	}
+	pb.dirty = true
+}
+
+// prune removes stale peers and the ones with the lowest score if book is too large.
+func (pb *peerBook) prune(now time.Time) {
+	for id, kp := range pb.peers {
+		if kp.LastSuccess.IsZero() && now.Sub(kp.FirstSeen) > knownPeerTTL {
+			delete(pb.peers, id)
+		} else if !kp.LastSuccess.IsZero() && now.Sub(kp.LastSuccess) > knownPeerTTL && kp.LastFailure.After(kp.LastSuccess) {
+			delete(pb.peers, id)
+		}
+	}
+	if len(pb.peers) > maxKnownPeers {
+		list := pb.list()
+		for _, kp := range list[maxKnownPeers:] {
+			delete(pb.peers, kp.ID)
+		}
+	}
+}
+
+// list returns known peers ordered by score, descending.
+func (pb *peerBook) list() []*knownPeer {
+	list := make([]*knownPeer, 0, len(pb.peers))
+	for _, kp := range pb.peers {
+		list = append(list, kp)
+	}
+	sort.Sort(byScore(list))
+	return list
+}
+
+// bestCandidates returns known peers which are worth to dial first.
+func (pb *peerBook) bestCandidates(max int, exclude func(id types.PeerID) bool) []p2pcommon.PeerMeta {
+	metas := make([]p2pcommon.PeerMeta, 0, max)
+	for _, kp := range pb.list() {
+		if len(metas) >= max {
+			break
+		}
+		// never dial peers which were not connected at least once
+		if kp.SuccessCnt == 0 || exclude(kp.ID) {
+			continue
+		}
+		meta := kp.meta()
+		if len(meta.Addresses) == 0 {
+			pb.logger.Debug().Str(p2putil.LogPeerID, p2putil.ShortForm(kp.ID)).Msg("skipping known peer without valid address")
+			continue
+		}
+		metas = append(metas, meta)
+	}
+	return metas
+}
+
+func (pb *peerBook) scoreOf(id types.PeerID) float64 {
+	if kp, found := pb.peers[id]; found {
+		return kp.score()
+	}
+	return 0
+}
+
+type byScore []*knownPeer
+
+func (a byScore) Len() int      { return len(a) }
+func (a byScore) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
+func (a byScore) Less(i, j int) bool {
+	si, sj := a[i].score(), a[j].score()
+	if si != sj {
+		return si > sj
+	}
+	return a[i].LastSuccess.After(a[j].LastSuccess)
+}
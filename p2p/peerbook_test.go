@@ -0,0 +1,110 @@
+/*
+ * @file
+ * @copyright defined in aergo/LICENSE.txt
+ */
+
+package p2p
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/aergoio/aergo/p2p/p2pcommon"
+	"github.com/aergoio/aergo/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_peerBook_SaveAndLoad(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "peerbook")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	m1 := p2pcommon.NewMetaWith1Addr(dummyPeerID, "192.168.1.2", 7846, "v2.0.0")
+	m2 := p2pcommon.NewMetaWith1Addr(dummyPeerID2, "192.168.1.3", 7846, "v2.0.0")
+
+	pb := newPeerBook(logger, tmpDir)
+	pb.reportSuccess(m1, types.PeerRole_Producer)
+	pb.reportLatency(m1.ID, time.Millisecond*100)
+	pb.reportFailure(m2)
+	if err := pb.save(); err != nil {
+		t.Fatalf("save() error = %v", err)
+	}
+
+	loaded := newPeerBook(logger, tmpDir)
+	if err := loaded.load(); err != nil {
+		t.Fatalf("load() error = %v", err)
+	}
+	assert.Equal(t, 2, len(loaded.peers))
+	kp := loaded.peers[m1.ID]
+	assert.NotNil(t, kp)
+	assert.Equal(t, types.PeerRole_Producer, kp.Role)
+	assert.Equal(t, int32(1), kp.SuccessCnt)
+	assert.Equal(t, time.Millisecond*100, kp.Latency)
+	assert.True(t, kp.meta().Addresses[0].Equal(m1.Addresses[0]))
+	assert.Equal(t, int32(1), loaded.peers[m2.ID].FailCnt)
+}
+
+func Test_peerBook_LoadMissingFile(t *testing.T) {
+	pb := newPeerBook(logger, "/nonexistent/path/for/test")
+	assert.Nil(t, pb.load())
+	assert.Equal(t, 0, len(pb.peers))
+}
+
+func Test_peerBook_bestCandidates(t *testing.T) {
+	good := p2pcommon.NewMetaWith1Addr(dummyPeerID, "192.168.1.2", 7846, "v2.0.0")
+	slow := p2pcommon.NewMetaWith1Addr(dummyPeerID2, "192.168.1.3", 7846, "v2.0.0")
+	failed := p2pcommon.NewMetaWith1Addr(dummyPeerID3, "192.168.1.4", 7846, "v2.0.0")
+
+	pb := newPeerBook(logger, "")
+	pb.reportSuccess(good, types.PeerRole_Watcher)
+	pb.reportLatency(good.ID, time.Millisecond*10)
+	pb.reportSuccess(slow, types.PeerRole_Watcher)
+	pb.reportLatency(slow.ID, time.Second*2)
+	pb.reportFailure(failed)
+
+	tests := []struct {
+		name    string
+		max     int
+		exclude []types.PeerID
+		want    []types.PeerID
+	}{
+		{"TAll", 10, nil, []types.PeerID{good.ID, slow.ID}},
+		{"TMax", 1, nil, []types.PeerID{good.ID}},
+		{"TExclude", 10, []types.PeerID{good.ID}, []types.PeerID{slow.ID}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exclude := func(id types.PeerID) bool {
+				for _, e := range tt.exclude {
+					if e == id {
+						return true
+					}
+				}
+				return false
+			}
+			got := pb.bestCandidates(tt.max, exclude)
+			ids := make([]types.PeerID, len(got))
+			for i, m := range got {
+				ids[i] = m.ID
+			}
+			assert.Equal(t, tt.want, ids)
+		})
+	}
+}
+
+func Test_peerBook_prune(t *testing.T) {
+	pb := newPeerBook(logger, "")
+	old := time.Now().Add(-knownPeerTTL * 2)
+	pb.peers[dummyPeerID] = &knownPeer{ID: dummyPeerID, FirstSeen: old, LastFailure: old}
+	pb.peers[dummyPeerID2] = &knownPeer{ID: dummyPeerID2, FirstSeen: old, LastSuccess: old, LastFailure: old.Add(time.Hour), SuccessCnt: 1, FailCnt: 1}
+	pb.peers[dummyPeerID3] = &knownPeer{ID: dummyPeerID3, FirstSeen: old, LastSuccess: time.Now(), SuccessCnt: 1}
+
+	pb.prune(time.Now())
+	assert.Equal(t, 1, len(pb.peers))
+	_, found := pb.peers[dummyPeerID3]
+	assert.True(t, found)
+}
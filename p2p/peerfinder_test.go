@@ -44,7 +44,7 @@ func init() {
 	}
 }
 func createDummyPM() *peerManager {
-	dummyPM := &peerManager{designatedPeers: desigPeerMap,
+	dummyPM := &peerManager{book: newPeerBook(logger, ""), designatedPeers: desigPeerMap,
 		remotePeers:  make(map[types.PeerID]p2pcommon.RemotePeer),
 		waitingPeers: make(map[types.PeerID]*p2pcommon.WaitingPeer, 10),
 	}
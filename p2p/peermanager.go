@@ -45,6 +45,8 @@ type peerManager struct {
 
 	peerFinder p2pcommon.PeerFinder
 	wpManager  p2pcommon.WaitingPeerManager
+	// book remembers peers met before, and it is kept across restarts
+	book *peerBook
 
 	mutex        *sync.Mutex
 	manageNumber uint32
@@ -102,6 +104,7 @@ func NewPeerManager(is p2pcommon.InternalService, hsFactory p2pcommon.HSHandlerF
 		logger:            logger,
 		mutex:             &sync.Mutex{},
 		skipHandshakeSync: skipHandshakeSync,
+		book:              newPeerBook(logger, cfg.DataDir),
 
 		status:          initial,
 		designatedPeers: make(map[types.PeerID]p2pcommon.PeerMeta, len(cfg.P2P.NPAddPeers)),
@@ -162,6 +165,28 @@ func (pm *peerManager) init() {
 			pm.waitingPeers[meta.ID] = &p2pcommon.WaitingPeer{Meta: meta, Designated: true, NextTrial: time.Now()}
 		}
 	}
+	pm.initKnownPeers()
+}
+
+// initKnownPeers loads address book and adds well-connected peers in the book to waiting pool, so that they are dialed
+// before peers from polaris or other nodes are discovered.
+func (pm *peerManager) initKnownPeers() {
+	if err := pm.book.load(); err != nil {
+		pm.logger.Warn().Err(err).Msg("failed to load known peers. starting with empty address book")
+		return
+	}
+	// static node connects to designated peers only
+	if !pm.conf.NPDiscoverPeers {
+		return
+	}
+	selfID := pm.SelfNodeID()
+	exclude := func(id types.PeerID) bool {
+		_, waiting := pm.waitingPeers[id]
+		return waiting || types.IsSamePeerID(id, selfID)
+	}
+	for _, meta := range pm.book.bestCandidates(maxBookedDialCnt, exclude) {
+		pm.waitingPeers[meta.ID] = &p2pcommon.WaitingPeer{Meta: meta, NextTrial: time.Now()}
+	}
 }
 
 func (pm *peerManager) AddPeerEventListener(l p2pcommon.PeerEventListener) {
@@ -220,6 +245,7 @@ func (pm *peerManager) runManagePeers() {
 	initialAddrDelay := time.Second * 2
 	finderTimer := time.NewTimer(initialAddrDelay)
 	connManTimer := time.NewTimer(initialAddrDelay << 1)
+	bookTicker := time.NewTicker(peerBookSaveInterval)
 
 MANLOOP:
 	for {
@@ -282,9 +308,12 @@ MANLOOP:
 			}
 		case task := <-pm.taskChannel:
 			task()
+		case <-bookTicker.C:
+			pm.saveBook()
 		case <-pm.finishChannel:
 			finderTimer.Stop()
 			connManTimer.Stop()
+			bookTicker.Stop()
 			break MANLOOP
 		}
 	}
@@ -318,9 +347,22 @@ CLEANUPLOOP:
 			break CLEANUPLOOP
 		}
 	}
+	pm.saveBook()
 	atomic.StoreInt32(&pm.status, stopped)
 }
 
+// saveBook reflects latency of connected peers and writes address book to file.
+func (pm *peerManager) saveBook() {
+	for _, peer := range pm.remotePeers {
+		if m, found := pm.mm.Metric(peer.ID()); found {
+			pm.book.reportLatency(peer.ID(), m.Latency())
+		}
+	}
+	if err := pm.book.save(); err != nil {
+		pm.logger.Warn().Err(err).Msg("failed to save known peers")
+	}
+}
+
 // tryRegister register peer to peer manager, if peer with same peer
 func (pm *peerManager) tryRegister(hsResult connPeerResult) p2pcommon.RemotePeer {
 	remote := hsResult.remote
@@ -348,6 +390,7 @@ func (pm *peerManager) tryRegister(hsResult connPeerResult) p2pcommon.RemotePeer
 	go newPeer.RunPeer()
 
 	pm.insertPeer(peerID, newPeer)
+	pm.book.reportSuccess(remote.Meta, newPeer.AcceptedRole())
 	pm.logger.Info().Str("role", newPeer.AcceptedRole().String()).Bool("outbound", remote.Connection.Outbound).Str("zone",remote.Zone.String()).Str(p2putil.LogPeerName, newPeer.Name()).Str("addr", remote.Connection.IP.String()+":"+strconv.Itoa(int(remote.Connection.Port))).Msg("peer is added to peerService")
 
 	pm.mutex.Lock()
@@ -486,12 +529,38 @@ func (pm *peerManager) GetPeerAddresses(noHidden bool, showSelf bool) []*message
 		lastStatus := aPeer.LastStatus()
 		rCerts, _ := p2putil.ConvertCertsToProto(aPeer.RemoteInfo().Certificates)
 		pi := &message.PeerInfo{
-			&addr, rCerts, aPeer.AcceptedRole(), meta.Version, ri.Hidden, lastStatus.CheckTime, lastStatus.BlockHash, lastStatus.BlockNumber, aPeer.State(), false}
+			Addr: &addr, Certificates: rCerts, AcceptedRole: aPeer.AcceptedRole(), Version: meta.Version, Hidden: ri.Hidden, CheckTime: lastStatus.CheckTime, LastBlockHash: lastStatus.BlockHash, LastBlockNumber: lastStatus.BlockNumber, State: aPeer.State(), Self: false}
 		peers = append(peers, pi)
 	}
 	return peers
 }
 
+func (pm *peerManager) GetKnownPeers(noHidden bool) []*message.PeerInfo {
+	retChan := make(chan []*message.PeerInfo)
+	pm.taskChannel <- func() {
+		list := pm.book.list()
+		peers := make([]*message.PeerInfo, 0, len(list))
+		for _, kp := range list {
+			if _, hidden := pm.hiddenPeerSet[kp.ID]; noHidden && hidden {
+				continue
+			}
+			addr := kp.meta().ToPeerAddress()
+			pi := &message.PeerInfo{Addr: &addr, AcceptedRole: kp.Role, Version: kp.Version, State: types.DOWN,
+				LastSuccess: kp.LastSuccess, LastFailure: kp.LastFailure, SuccessCnt: kp.SuccessCnt, FailCnt: kp.FailCnt, Latency: kp.Latency}
+			if peer, connected := pm.remotePeers[kp.ID]; connected {
+				lastStatus := peer.LastStatus()
+				pi.State, pi.CheckTime, pi.LastBlockHash, pi.LastBlockNumber = peer.State(), lastStatus.CheckTime, lastStatus.BlockHash, lastStatus.BlockNumber
+				if m, found := pm.mm.Metric(kp.ID); found && m.Latency() > 0 {
+					pi.Latency = m.Latency()
+				}
+			}
+			peers = append(peers, pi)
+		}
+		retChan <- peers
+	}
+	return <-retChan
+}
+
 // this method should be called inside pm.mutex
 func (pm *peerManager) insertPeer(ID types.PeerID, peer p2pcommon.RemotePeer) {
 	pm.remotePeers[ID] = peer
@@ -500,7 +569,9 @@ func (pm *peerManager) insertPeer(ID types.PeerID, peer p2pcommon.RemotePeer) {
 
 // this method should be called inside pm.mutex
 func (pm *peerManager) deletePeer(peer p2pcommon.RemotePeer) {
-	pm.mm.Remove(peer.ID(), peer.ManageNumber())
+	if m := pm.mm.Remove(peer.ID(), peer.ManageNumber()); m != nil {
+		pm.book.reportLatency(peer.ID(), m.Latency())
+	}
 	delete(pm.remotePeers, peer.ID())
 	pm.updatePeerCache()
 }
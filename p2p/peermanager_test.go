@@ -133,6 +133,7 @@ func TestPeerManager_GetPeerAddresses(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			pm := &peerManager{
+				book:        newPeerBook(logger, ""),
 				remotePeers: make(map[types.PeerID]p2pcommon.RemotePeer),
 				mutex:       &sync.Mutex{},
 			}
@@ -229,6 +230,7 @@ func Test_peerManager_runManagePeers_MultiConnWorks(t *testing.T) {
 
 			dummyCfg := &cfg.P2PConfig{}
 			pm := &peerManager{
+				book:         newPeerBook(logger, ""),
 				peerFinder:   mockPeerFinder,
 				wpManager:    mockWPManager,
 				remotePeers:  make(map[types.PeerID]p2pcommon.RemotePeer, 10),
@@ -302,6 +304,7 @@ func Test_peerManager_Stop(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			pm := &peerManager{
+				book:          newPeerBook(logger, ""),
 				logger:        logger,
 				finishChannel: make(chan struct{}, 1),
 			}
@@ -354,6 +357,7 @@ func Test_peerManager_StopInRun(t *testing.T) {
 			mockWPManager := p2pmock.NewMockWaitingPeerManager(ctrl)
 
 			pm := &peerManager{
+				book:       newPeerBook(logger, ""),
 				logger:     logger,
 				nt:         mockNT,
 				peerFinder: mockPeerFinder,
@@ -512,6 +516,7 @@ func Test_peerManager_tryRegister(t *testing.T) {
 			mockRW.EXPECT().WriteMsg(gomock.Any()).MaxTimes(1)
 
 			pm := &peerManager{
+				book:            newPeerBook(logger, ""),
 				peerFactory:     mockPeerFactory,
 				designatedPeers: desigPeers,
 				hiddenPeerSet:   hiddenPeers,
@@ -590,6 +595,7 @@ func Test_peerManager_tryRegisterCollision(t *testing.T) {
 			mockRW.EXPECT().WriteMsg(gomock.Any()).MaxTimes(1)
 
 			pm := &peerManager{
+				book:            newPeerBook(logger, ""),
 				is:              mockIS,
 				peerFactory:     mockPeerFactory,
 				designatedPeers: make(map[types.PeerID]p2pcommon.PeerMeta),
@@ -645,6 +651,7 @@ func Test_peerManager_updatePeerCache(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	pm := &peerManager{
+		book:          newPeerBook(logger, ""),
 		logger:        logger,
 		mutex:         &sync.Mutex{},
 		remotePeers:   make(map[types.PeerID]p2pcommon.RemotePeer, 100),
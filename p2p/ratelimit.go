@@ -0,0 +1,96 @@
+/*
+ * @file
+ * @copyright defined in aergo/LICENSE.txt
+ */
+
+package p2p
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aergoio/aergo/config"
+	"github.com/aergoio/aergo/p2p/p2pcommon"
+	"github.com/aergoio/aergo/p2p/p2putil"
+)
+
+// maxRequestDelay is the longest time to postpone handling of over-limit request. If the request should wait more than
+// this, the remote peer is considered to be flooding and is disconnected with GoAway message.
+const maxRequestDelay = time.Second * 3
+
+// rateLimits contains bandwidth limiters shared by all peers and the setting of per-peer request limits.
+type rateLimits struct {
+	in  *p2putil.TokenBucket
+	out *p2putil.TokenBucket
+
+	reqRates map[p2pcommon.SubProtocol]int64
+}
+
+func newRateLimits(conf *config.P2PConfig) (*rateLimits, error) {
+	rl := &rateLimits{
+		in:       p2putil.NewTokenBucket(conf.NPMaxInBandwidth, conf.NPMaxInBandwidth),
+		out:      p2putil.NewTokenBucket(conf.NPMaxOutBandwidth, conf.NPMaxOutBandwidth),
+		reqRates: make(map[p2pcommon.SubProtocol]int64),
+	}
+	for _, str := range conf.NPRequestRates {
+		sp, rate, err := parseRequestRate(str)
+		if err != nil {
+			return nil, err
+		}
+		rl.reqRates[sp] = rate
+	}
+	return rl, nil
+}
+
+// parseRequestRate parses string in form of <subprotocol name>=<rate>
+func parseRequestRate(str string) (p2pcommon.SubProtocol, int64, error) {
+	tokens := strings.Split(str, "=")
+	if len(tokens) != 2 {
+		return 0, 0, fmt.Errorf("invalid request rate format %s", str)
+	}
+	sp, err := p2pcommon.ParseSubProtocol(strings.TrimSpace(tokens[0]))
+	if err != nil {
+		return 0, 0, err
+	}
+	rate, err := strconv.ParseInt(strings.TrimSpace(tokens[1]), 10, 64)
+	if err != nil || rate <= 0 {
+		return 0, 0, fmt.Errorf("invalid request rate of %s : %s", sp.String(), tokens[1])
+	}
+	return sp, rate, nil
+}
+
+// newRequestLimiters creates new token buckets for a remote peer. It returns nil if no limit is set.
+func (rl *rateLimits) newRequestLimiters() map[p2pcommon.SubProtocol]*p2putil.TokenBucket {
+	if len(rl.reqRates) == 0 {
+		return nil
+	}
+	limiters := make(map[p2pcommon.SubProtocol]*p2putil.TokenBucket, len(rl.reqRates))
+	for sp, rate := range rl.reqRates {
+		limiters[sp] = p2putil.NewTokenBucket(rate, rate)
+	}
+	return limiters
+}
+
+// bandwidthLimited returns whether inbound or outbound bandwidth is limited
+func (rl *rateLimits) bandwidthLimited() bool {
+	return rl.in != nil || rl.out != nil
+}
+
+// OnRead blocks read goroutine of the peer until inbound bandwidth is available, so that the remote peer is
+// throttled by transport layer flow control.
+func (rl *rateLimits) OnRead(protocol p2pcommon.SubProtocol, read int) {
+	if wait := rl.in.Take(read); wait > 0 {
+		time.Sleep(wait)
+	}
+}
+
+// OnWrite blocks write goroutine of the peer until outbound bandwidth is available.
+func (rl *rateLimits) OnWrite(protocol p2pcommon.SubProtocol, write int) {
+	if wait := rl.out.Take(write); wait > 0 {
+		time.Sleep(wait)
+	}
+}
+
+var _ p2pcommon.MsgIOListener = (*rateLimits)(nil)
@@ -0,0 +1,90 @@
+/*
+ * @file
+ * @copyright defined in aergo/LICENSE.txt
+ */
+
+package p2p
+
+import (
+	"testing"
+
+	"github.com/aergoio/aergo/config"
+	"github.com/aergoio/aergo/p2p/metric"
+	"github.com/aergoio/aergo/p2p/p2pcommon"
+	"github.com/aergoio/aergo/p2p/p2putil"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseRequestRate(t *testing.T) {
+	tests := []struct {
+		name     string
+		str      string
+		wantSP   p2pcommon.SubProtocol
+		wantRate int64
+		wantErr  bool
+	}{
+		{"TBlocks", "GetBlocksRequest=10", p2pcommon.GetBlocksRequest, 10, false},
+		{"TSpaces", " GetTXsRequest = 100 ", p2pcommon.GetTXsRequest, 100, false},
+		{"TNoRate", "GetTXsRequest", 0, 0, true},
+		{"TUnknownProto", "GetSomething=10", 0, 0, true},
+		{"TWrongRate", "GetTXsRequest=ten", 0, 0, true},
+		{"TZeroRate", "GetTXsRequest=0", 0, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sp, rate, err := parseRequestRate(tt.str)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseRequestRate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr {
+				assert.Equal(t, tt.wantSP, sp)
+				assert.Equal(t, tt.wantRate, rate)
+			}
+		})
+	}
+}
+
+func Test_newRateLimits(t *testing.T) {
+	conf := &config.P2PConfig{NPMaxOutBandwidth: 1024, NPRequestRates: []string{"GetBlocksRequest=10"}}
+	rl, err := newRateLimits(conf)
+	assert.Nil(t, err)
+	assert.Nil(t, rl.in)
+	assert.NotNil(t, rl.out)
+	assert.True(t, rl.bandwidthLimited())
+	limiters := rl.newRequestLimiters()
+	assert.Equal(t, 1, len(limiters))
+	assert.Equal(t, int64(10), limiters[p2pcommon.GetBlocksRequest].Rate())
+
+	rl, err = newRateLimits(&config.P2PConfig{})
+	assert.Nil(t, err)
+	assert.False(t, rl.bandwidthLimited())
+	assert.Nil(t, rl.newRequestLimiters())
+
+	_, err = newRateLimits(&config.P2PConfig{NPRequestRates: []string{"wrong"}})
+	assert.NotNil(t, err)
+}
+
+func Test_remotePeerImpl_checkRequestRate(t *testing.T) {
+	mm := metric.NewMetricManager(1)
+	p := &remotePeerImpl{
+		logger: logger,
+		metric: mm.NewMetric(dummyPeerID, 1),
+		reqLimiters: map[p2pcommon.SubProtocol]*p2putil.TokenBucket{
+			p2pcommon.GetBlocksRequest: p2putil.NewTokenBucket(100, 100),
+		},
+	}
+	// not limited
+	for i := 0; i < 200; i++ {
+		assert.Nil(t, p.checkRequestRate(p2pcommon.GetTXsRequest))
+	}
+	assert.Equal(t, int64(0), p.metric.DelayedRequests())
+
+	for i := 0; i < 100; i++ {
+		assert.Nil(t, p.checkRequestRate(p2pcommon.GetBlocksRequest))
+	}
+	assert.Equal(t, int64(0), p.metric.DelayedRequests())
+	// the next request exceeds burst and should be delayed, not refused
+	assert.Nil(t, p.checkRequestRate(p2pcommon.GetBlocksRequest))
+	assert.Equal(t, int64(1), p.metric.DelayedRequests())
+	assert.Equal(t, int64(0), p.metric.DroppedRequests())
+}
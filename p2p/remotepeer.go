@@ -65,6 +65,8 @@ type remotePeerImpl struct {
 	reqMutex *sync.Mutex
 
 	handlers map[p2pcommon.SubProtocol]p2pcommon.MessageHandler
+	// reqLimiters limits the rate of incoming requests per subprotocol. nil means no limit.
+	reqLimiters map[p2pcommon.SubProtocol]*p2putil.TokenBucket
 
 	// TODO make automatic disconnect if remote peer cause too many wrong message
 	blkHashCache *lru.Cache
@@ -291,6 +293,10 @@ func (p *remotePeerImpl) handleMsg(msg p2pcommon.Message) (err error) {
 		return fmt.Errorf("invalid protocol %s", subProto)
 	}
 
+	if err = p.checkRequestRate(subProto); err != nil {
+		return err
+	}
+
 	handler.PreHandle()
 
 	payload, err := handler.ParsePayload(msg.Payload())
@@ -315,6 +321,32 @@ func (p *remotePeerImpl) handleMsg(msg p2pcommon.Message) (err error) {
 	return nil
 }
 
+// checkRequestRate delays handling of the message if remote peer sends requests more than the limit, or sends GoAway
+// and returns error if remote peer is flooding.
+func (p *remotePeerImpl) checkRequestRate(subProto p2pcommon.SubProtocol) error {
+	limiter, found := p.reqLimiters[subProto]
+	if !found {
+		return nil
+	}
+	wait := limiter.Take(1)
+	if wait == 0 {
+		return nil
+	}
+	if wait > maxRequestDelay {
+		if p.metric != nil {
+			p.metric.OnRequestDropped(subProto)
+		}
+		p.logger.Info().Str(p2putil.LogPeerName, p.Name()).Str(p2putil.LogProtoID, subProto.String()).Int64("limit", limiter.Rate()).Msg("Remote peer sent too many requests")
+		p.goAwayMsg("too many requests")
+		return fmt.Errorf("too many %s requests", subProto)
+	}
+	if p.metric != nil {
+		p.metric.OnRequestDelayed(subProto)
+	}
+	time.Sleep(wait)
+	return nil
+}
+
 // Stop stops aPeer works
 func (p *remotePeerImpl) Stop() {
 	prevState := p.state.SetAndGet(types.STOPPING)
@@ -374,8 +406,13 @@ func (p *remotePeerImpl) PushTxsNotice(txHashes []types.TxID) {
 // ConsumeRequest remove request from request history.
 func (p *remotePeerImpl) ConsumeRequest(originalID p2pcommon.MsgID) {
 	p.reqMutex.Lock()
+	req, found := p.requests[originalID]
 	delete(p.requests, originalID)
 	p.reqMutex.Unlock()
+	// round-trip time of ping is used as latency of peer
+	if found && p.metric != nil && req.reqMO != nil && req.reqMO.GetProtocolID() == p2pcommon.PingRequest {
+		p.metric.AddLatency(time.Since(req.cTime))
+	}
 }
 
 // requestIDNotFoundReceiver is to handle response msg which the original message is not identified
@@ -0,0 +1,46 @@
+/*
+ * @file
+ * @copyright defined in aergo/LICENSE.txt
+ */
+
+package subproto
+
+import (
+	"github.com/aergoio/aergo-lib/log"
+	"github.com/aergoio/aergo/consensus"
+	"github.com/aergoio/aergo/p2p/p2pcommon"
+	"github.com/aergoio/aergo/p2p/p2putil"
+	"github.com/aergoio/aergo/types"
+)
+
+// receive message of bft consensus and toss it to bft
+type bftMessageHandler struct {
+	BaseMsgHandler
+
+	consAcc consensus.ConsensusAccessor
+}
+
+var _ p2pcommon.MessageHandler = (*bftMessageHandler)(nil)
+
+// NewBFTMessageHandler creates handler for BFTConsensusMessage
+func NewBFTMessageHandler(pm p2pcommon.PeerManager, peer p2pcommon.RemotePeer, logger *log.Logger, actor p2pcommon.ActorService, consAcc consensus.ConsensusAccessor) *bftMessageHandler {
+	ph := &bftMessageHandler{
+		BaseMsgHandler: BaseMsgHandler{protocol: p2pcommon.BFTConsensusMessage, pm: pm, peer: peer, actor: actor, logger: logger},
+		consAcc:        consAcc,
+	}
+	return ph
+}
+
+func (ph *bftMessageHandler) ParsePayload(rawbytes []byte) (p2pcommon.MessageBody, error) {
+	return p2putil.UnmarshalAndReturn(rawbytes, &types.BftMessage{})
+}
+
+func (ph *bftMessageHandler) Handle(msg p2pcommon.Message, msgBody p2pcommon.MessageBody) {
+	remotePeer := ph.peer
+	data := msgBody.(*types.BftMessage)
+
+	// toss data to bft module
+	if err := ph.consAcc.BFTAccessor().Process(remotePeer.ID(), data); err != nil {
+		ph.logger.Debug().Str(p2putil.LogPeerName, remotePeer.Name()).Err(err).Msg("error while processing bft message")
+	}
+}
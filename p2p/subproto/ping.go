@@ -71,7 +71,7 @@ func (ph *pingResponseHandler) Handle(msg p2pcommon.Message, msgBody p2pcommon.M
 	remotePeer := ph.peer
 	//data := msgBody.(*types.Pong)
 	p2putil.DebugLogReceive(ph.logger, ph.protocol, msg.ID().String(), remotePeer, nil)
-	remotePeer.ConsumeRequest(msg.ID())
+	remotePeer.ConsumeRequest(msg.OriginalID())
 }
 
 // newGoAwayHandler creates handler for PingResponse
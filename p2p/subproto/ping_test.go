@@ -124,3 +124,22 @@ func Test_pingRequestHandler_handle(t *testing.T) {
 	}
 }
 
+
+func Test_pingResponseHandler_handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := log.NewLogger("test.subproto")
+	mockPM := p2pmock.NewMockPeerManager(ctrl)
+	mockPeer := p2pmock.NewMockRemotePeer(ctrl)
+	mockActor := p2pmock.NewMockActorService(ctrl)
+	mockPeer.EXPECT().Name().Return("16..aadecf@1").AnyTimes()
+
+	reqID := p2pcommon.NewMsgID()
+	// the request of ping must be consumed to measure the round-trip time
+	mockPeer.EXPECT().ConsumeRequest(reqID).Times(1)
+
+	msg := p2pcommon.NewSimpleRespMsgVal(p2pcommon.PingResponse, p2pcommon.NewMsgID(), reqID)
+	ph := NewPingRespHandler(mockPM, mockPeer, logger, mockActor)
+	ph.Handle(msg, &types.Pong{})
+}
@@ -113,7 +113,8 @@ func (dpm *basePeerManager) connectWaitingPeers(maxJob int) {
 	for _, wp := range dpm.pm.waitingPeers {
 		peers = append(peers, wp)
 	}
-	sort.Sort(byNextTrial(peers))
+	// peers that were well connected in the past are tried first
+	sort.Sort(byDialPriority{peers: peers, book: dpm.pm.book})
 
 	added := 0
 	now := time.Now()
@@ -257,6 +258,9 @@ func (dpm *basePeerManager) OnWorkDone(result p2pcommon.ConnWorkResult) {
 		dpm.logger.Debug().Str(p2putil.LogPeerName, p2putil.ShortMetaForm(meta)).Int("trial", wp.TrialCnt).Err(result.Result).Msg("Connection job finished")
 	}
 	wp.LastResult = result.Result
+	if result.Result != nil {
+		dpm.pm.book.reportFailure(meta)
+	}
 	// success to connect
 	if result.Result == nil {
 		dpm.logger.Debug().Str(p2putil.LogPeerName, p2putil.ShortMetaForm(meta)).Msg("Deleting unimportant failed peer.")
@@ -370,6 +374,22 @@ func (a byNextTrial) Len() int           { return len(a) }
 func (a byNextTrial) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
 func (a byNextTrial) Less(i, j int) bool { return a[i].NextTrial.Before(a[j].NextTrial) }
 
+// byDialPriority sorts waiting peers by score in address book, and then by next trial time.
+type byDialPriority struct {
+	peers []*p2pcommon.WaitingPeer
+	book  *peerBook
+}
+
+func (a byDialPriority) Len() int      { return len(a.peers) }
+func (a byDialPriority) Swap(i, j int) { a.peers[i], a.peers[j] = a.peers[j], a.peers[i] }
+func (a byDialPriority) Less(i, j int) bool {
+	si, sj := a.book.scoreOf(a.peers[i].Meta.ID), a.book.scoreOf(a.peers[j].Meta.ID)
+	if si != sj {
+		return si > sj
+	}
+	return a.peers[i].NextTrial.Before(a.peers[j].NextTrial)
+}
+
 type ConnWork struct {
 	PeerID    types.PeerID
 	Meta      p2pcommon.PeerMeta
@@ -196,6 +196,7 @@ func Test_basePeerManager_tryAddPeer(t *testing.T) {
 			mockIS.EXPECT().LocalSettings().Return(p2pcommon.LocalSettings{}).AnyTimes()
 
 			pm := &peerManager{
+				book:          newPeerBook(logger, ""),
 				hsFactory:     mockHSFactory,
 				peerConnected: make(chan connPeerResult, 10),
 			}
@@ -328,7 +329,7 @@ func Test_basePeerManager_connectWaitingPeers(t *testing.T) {
 				wpMap[w.Meta.ID] = w
 			}
 
-			dummyPM := &peerManager{nt: mockNT, waitingPeers: wpMap, workDoneChannel: make(chan p2pcommon.ConnWorkResult, 10)}
+			dummyPM := &peerManager{book: newPeerBook(logger, ""), nt: mockNT, waitingPeers: wpMap, workDoneChannel: make(chan p2pcommon.ConnWorkResult, 10)}
 
 			dpm := &basePeerManager{
 				pm:          dummyPM,
@@ -380,7 +381,7 @@ func Test_basePeerManager_OnInboundConn(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			dummyPM := &peerManager{}
+			dummyPM := &peerManager{book: newPeerBook(logger, "")}
 			mockLM := p2pmock.NewMockListManager(ctrl)
 
 			mockStream := p2pmock.NewMockStream(ctrl)
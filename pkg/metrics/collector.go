@@ -0,0 +1,172 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package metrics
+
+import (
+	"time"
+
+	"github.com/aergoio/aergo/message"
+	"github.com/aergoio/aergo/pkg/component"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// StatisticsSource gives the statistics of the registered components. It is
+// implemented by component.ComponentHub.
+type StatisticsSource interface {
+	Statistics(timeOutSec time.Duration, target string) (map[string]*component.CompStatRsp, error)
+}
+
+// compStat maps a value of the statistics of a component to a metric. path
+// is the sequence of the keys leading to the value in the statistics map.
+type compStat struct {
+	comp      string
+	path      []string
+	desc      *prometheus.Desc
+	valueType prometheus.ValueType
+}
+
+func newCompStat(comp string, path []string, vt prometheus.ValueType, subsystem, name, help string) *compStat {
+	return &compStat{
+		comp:      comp,
+		path:      path,
+		desc:      prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, name), help, nil, nil),
+		valueType: vt,
+	}
+}
+
+var compStats = []*compStat{
+	newCompStat(message.ChainSvc, []string{"orphan"}, prometheus.GaugeValue,
+		"chain", "orphan_blocks", "Number of blocks in the orphan pool."),
+	newCompStat(message.MemPoolSvc, []string{"total"}, prometheus.GaugeValue,
+		"mempool", "txs", "Number of transactions in the mempool, including the orphan ones."),
+	newCompStat(message.MemPoolSvc, []string{"orphan"}, prometheus.GaugeValue,
+		"mempool", "orphan_txs", "Number of orphan transactions in the mempool."),
+	newCompStat(message.MemPoolSvc, []string{"dead"}, prometheus.CounterValue,
+		"mempool", "dead_txs_total", "Number of transactions evicted from the mempool."),
+	newCompStat(message.P2PSvc, []string{"peers"}, prometheus.GaugeValue,
+		"p2p", "peers", "Number of connected peers."),
+	newCompStat(message.P2PSvc, []string{"netstat", "in"}, prometheus.CounterValue,
+		"p2p", "received_bytes_total", "Number of bytes received from peers."),
+	newCompStat(message.P2PSvc, []string{"netstat", "out"}, prometheus.CounterValue,
+		"p2p", "sent_bytes_total", "Number of bytes sent to peers."),
+	newCompStat(message.SyncerSvc, []string{"running"}, prometheus.GaugeValue,
+		"syncer", "running", "Whether a chain synchronization is in progress."),
+	newCompStat(message.SyncerSvc, []string{"start"}, prometheus.GaugeValue,
+		"syncer", "start_height", "Block number at which the running synchronization started."),
+	newCompStat(message.SyncerSvc, []string{"end"}, prometheus.GaugeValue,
+		"syncer", "target_height", "Block number the running synchronization targets."),
+	newCompStat(message.SyncerSvc, []string{"block_added"}, prometheus.GaugeValue,
+		"syncer", "added_height", "Block number of the last block added by the synchronization."),
+	newCompStat(message.SyncerSvc, []string{"block_fetched"}, prometheus.GaugeValue,
+		"syncer", "fetched_height", "Block number of the last block fetched by the synchronization."),
+}
+
+var (
+	msgQueueLenDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "component", "msg_queue_length"),
+		"Number of messages waiting in the actor mailbox of a component.",
+		[]string{"component"}, nil)
+	processedMsgDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "component", "processed_msgs_total"),
+		"Number of messages processed by a component.",
+		[]string{"component"}, nil)
+)
+
+// Collector collects the statistics of all the components whenever the
+// metrics are scraped.
+type Collector struct {
+	src     StatisticsSource
+	timeout time.Duration
+}
+
+// NewCollector returns a collector reading the component statistics from src.
+// A component which does not answer within timeout is skipped.
+func NewCollector(src StatisticsSource, timeout time.Duration) *Collector {
+	return &Collector{src: src, timeout: timeout}
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- msgQueueLenDesc
+	ch <- processedMsgDesc
+	for _, s := range compStats {
+		ch <- s.desc
+	}
+}
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	rsps, err := c.src.Statistics(c.timeout, "")
+	if err != nil {
+		return
+	}
+	for name, rsp := range rsps {
+		if rsp == nil || len(rsp.Error) > 0 {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(msgQueueLenDesc, prometheus.GaugeValue, float64(rsp.MsgQueueLen), name)
+		ch <- prometheus.MustNewConstMetric(processedMsgDesc, prometheus.CounterValue, float64(rsp.AccProcessedMsg), name)
+	}
+	for _, s := range compStats {
+		rsp, exist := rsps[s.comp]
+		if !exist || rsp == nil {
+			continue
+		}
+		if v, ok := lookup(rsp.Actor, s.path); ok {
+			ch <- prometheus.MustNewConstMetric(s.desc, s.valueType, v)
+		}
+	}
+}
+
+// lookup follows path through the nested statistics maps and returns the
+// value found as a float.
+func lookup(stat interface{}, path []string) (float64, bool) {
+	for _, key := range path {
+		var m map[string]interface{}
+		switch v := stat.(type) {
+		case *map[string]interface{}:
+			if v == nil {
+				return 0, false
+			}
+			m = *v
+		case map[string]interface{}:
+			m = v
+		default:
+			return 0, false
+		}
+		var exist bool
+		if stat, exist = m[key]; !exist {
+			return 0, false
+		}
+	}
+	return toFloat(stat)
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint:
+		return float64(n), true
+	case uint32:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case float64:
+		return n, true
+	case bool:
+		if n {
+			return 1, true
+		}
+		return 0, true
+	default:
+		return 0, false
+	}
+}
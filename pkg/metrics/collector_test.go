@@ -0,0 +1,87 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aergoio/aergo/message"
+	"github.com/aergoio/aergo/pkg/component"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+type stubSource map[string]*component.CompStatRsp
+
+func (s stubSource) Statistics(time.Duration, string) (map[string]*component.CompStatRsp, error) {
+	return s, nil
+}
+
+func TestCollector(t *testing.T) {
+	src := stubSource{
+		message.MemPoolSvc: &component.CompStatRsp{
+			MsgQueueLen: 3,
+			Actor:       &map[string]interface{}{"total": 10, "orphan": 2, "dead": uint64(5)},
+		},
+		message.P2PSvc: &component.CompStatRsp{
+			Actor: &map[string]interface{}{
+				"peers":   4,
+				"netstat": map[string]interface{}{"in": int64(100), "out": int64(200)},
+			},
+		},
+		message.SyncerSvc: &component.CompStatRsp{
+			Error: "timeout",
+		},
+	}
+
+	reg := prometheus.NewPedanticRegistry()
+	if err := reg.Register(NewCollector(src, time.Second)); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `
+# HELP aergo_mempool_txs Number of transactions in the mempool, including the orphan ones.
+# TYPE aergo_mempool_txs gauge
+aergo_mempool_txs 10
+# HELP aergo_p2p_peers Number of connected peers.
+# TYPE aergo_p2p_peers gauge
+aergo_p2p_peers 4
+# HELP aergo_p2p_received_bytes_total Number of bytes received from peers.
+# TYPE aergo_p2p_received_bytes_total counter
+aergo_p2p_received_bytes_total 100
+# HELP aergo_component_msg_queue_length Number of messages waiting in the actor mailbox of a component.
+# TYPE aergo_component_msg_queue_length gauge
+aergo_component_msg_queue_length{component="MemPoolSvc"} 3
+aergo_component_msg_queue_length{component="p2pSvc"} 0
+`
+	if err := testutil.GatherAndCompare(reg, strings.NewReader(expected),
+		"aergo_mempool_txs", "aergo_p2p_peers", "aergo_p2p_received_bytes_total",
+		"aergo_component_msg_queue_length", "aergo_syncer_running"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestLookup(t *testing.T) {
+	stat := &map[string]interface{}{
+		"running": true,
+		"config":  struct{}{},
+		"netstat": map[string]interface{}{"in": int64(7)},
+	}
+	tests := []struct {
+		path []string
+		want float64
+		ok   bool
+	}{
+		{[]string{"running"}, 1, true},
+		{[]string{"netstat", "in"}, 7, true},
+		{[]string{"config"}, 0, false},
+		{[]string{"missing"}, 0, false},
+		{[]string{"running", "in"}, 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := lookup(stat, tt.path)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("lookup(%v) = %v, %v, want %v, %v", tt.path, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+// Package metrics exposes the node statistics in the Prometheus exposition
+// format.
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "aergo"
+
+var (
+	// BlockHeight is the block number of the current best block.
+	BlockHeight = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "chain",
+		Name:      "block_height",
+		Help:      "Block number of the best block.",
+	})
+	// LibHeight is the block number of the last irreversible block.
+	LibHeight = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "chain",
+		Name:      "lib_height",
+		Help:      "Block number of the last irreversible block.",
+	})
+	// BlockConnectTime measures how long it takes to execute a block and to
+	// connect it to the main chain.
+	BlockConnectTime = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "chain",
+		Name:      "block_connect_seconds",
+		Help:      "Time taken to execute and connect a block to the main chain.",
+		Buckets:   prometheus.ExponentialBuckets(0.001, 2, 14),
+	})
+	// ReorgCount is the number of the chain reorganizations.
+	ReorgCount = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "chain",
+		Name:      "reorg_total",
+		Help:      "Number of chain reorganizations.",
+	})
+	// ReorgTime measures how long a chain reorganization takes.
+	ReorgTime = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "chain",
+		Name:      "reorg_seconds",
+		Help:      "Time taken by a chain reorganization.",
+		Buckets:   prometheus.ExponentialBuckets(0.01, 2, 12),
+	})
+	// VMExecutionTime measures how long a contract call or deployment runs in
+	// the VM.
+	VMExecutionTime = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "vm",
+		Name:      "execution_seconds",
+		Help:      "Time taken by the VM to run a contract call or deployment.",
+		Buckets:   prometheus.ExponentialBuckets(0.0001, 2, 16),
+	})
+
+	registry = prometheus.NewRegistry()
+)
+
+func init() {
+	registry.MustRegister(
+		BlockHeight,
+		LibHeight,
+		BlockConnectTime,
+		ReorgCount,
+		ReorgTime,
+		VMExecutionTime,
+		prometheus.NewGoCollector(),
+		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{Namespace: namespace}),
+	)
+}
+
+// Register adds a collector to the metrics exported by the /metrics endpoint.
+func Register(c prometheus.Collector) error {
+	return registry.Register(c)
+}
+
+// Handler returns the http handler serving the registered metrics.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+// NewServer returns a http server serving the metrics at /metrics on addr.
+func NewServer(addr string) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", Handler())
+	return &http.Server{Addr: addr, Handler: mux}
+}
@@ -8,8 +8,10 @@ import (
 	"context"
 	"strings"
 
+	"github.com/aergoio/aergo-lib/log"
 	"github.com/aergoio/aergo/types"
 
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/peer"
@@ -18,6 +20,8 @@ import (
 
 type Authentication = int
 
+var auditLogger = log.NewLogger("rpcaudit")
+
 const (
 	ReadBlockChain  Authentication = 1
 	WriteBlockChain Authentication = 2
@@ -50,7 +54,50 @@ func (rpc *AergoRPCService) setClientAuthMap(v []string) {
 	rpc.clientAuthLock.Unlock()
 }
 
+func (rpc *AergoRPCService) isClientAuthOn() bool {
+	rpc.clientAuthLock.RLock()
+	defer rpc.clientAuthLock.RUnlock()
+
+	return rpc.clientAuthOn && len(rpc.clientAuth) > 0
+}
+
+// checkAuth checks whether the caller of a method may use the permission
+// auth. If the token authentication is configured, a call with a token is
+// authorized by the role of the token and a call without it by the client
+// certificate, or by the anonymous role if the certificate check is off.
 func (rpc *AergoRPCService) checkAuth(ctx context.Context, auth Authentication) error {
+	if rpc.tokenAuth == nil {
+		return rpc.checkCertAuth(ctx, auth)
+	}
+	p, err := rpc.callPrincipal(ctx)
+	if err == nil {
+		if p != nil {
+			err = p.authorize(rpcMethod(ctx), auth)
+		} else {
+			err = rpc.checkCertAuth(ctx, auth)
+		}
+	}
+	rpc.audit(ctx, p, auth, err)
+	return err
+}
+
+// checkAuthAccount checks whether the caller may send a transaction signed by
+// account. Only the token authentication restricts accounts.
+func (rpc *AergoRPCService) checkAuthAccount(ctx context.Context, auth Authentication, account []byte) error {
+	if rpc.tokenAuth == nil {
+		return nil
+	}
+	p, err := rpc.callPrincipal(ctx)
+	if err != nil || p == nil {
+		return err
+	}
+	if err = p.authorizeAccount(rpcMethod(ctx), auth, account); err != nil {
+		rpc.audit(ctx, p, auth, err)
+	}
+	return err
+}
+
+func (rpc *AergoRPCService) checkCertAuth(ctx context.Context, auth Authentication) error {
 	rpc.clientAuthLock.RLock()
 	defer rpc.clientAuthLock.RUnlock()
 
@@ -82,6 +129,108 @@ func (rpc *AergoRPCService) checkAuth(ctx context.Context, auth Authentication)
 	return status.Error(codes.Unauthenticated, "permission forbidden")
 }
 
+// callPrincipal returns the principal of a call. It returns nil if the call
+// is checked by the client certificate.
+func (rpc *AergoRPCService) callPrincipal(ctx context.Context) (*principal, error) {
+	if p := principalFromContext(ctx); p != nil {
+		return p, nil
+	}
+	if token := bearerToken(ctx); len(token) > 0 {
+		return rpc.tokenAuth.authenticate(token)
+	}
+	if rpc.isClientAuthOn() {
+		return nil, nil
+	}
+	if p := rpc.tokenAuth.anonymous(peerAddr(ctx)); p != nil {
+		return p, nil
+	}
+	return nil, status.Error(codes.Unauthenticated, "authentication token required")
+}
+
+// authenticateCall authenticates a call once before its method runs and
+// applies the rate limit of the caller. The returned context carries the
+// principal for the permission checks of the method.
+func (rpc *AergoRPCService) authenticateCall(ctx context.Context) (context.Context, error) {
+	if rpc.tokenAuth == nil {
+		return ctx, nil
+	}
+	p, err := rpc.callPrincipal(ctx)
+	if err == nil && p != nil {
+		err = p.allowCall()
+	}
+	if err != nil {
+		rpc.audit(ctx, p, 0, err)
+		return ctx, err
+	}
+	if p == nil {
+		return ctx, nil
+	}
+	return context.WithValue(ctx, principalKey{}, p), nil
+}
+
+// audit logs the denied calls and the calls writing to the chain or
+// controlling the node.
+func (rpc *AergoRPCService) audit(ctx context.Context, p *principal, auth Authentication, err error) {
+	if err == nil && auth&(WriteBlockChain|ControlNode) == 0 {
+		return
+	}
+	caller := "cert"
+	if p != nil {
+		caller = p.name
+	}
+	if err != nil {
+		auditLogger.Warn().Str("caller", caller).Str("peer", peerAddr(ctx)).Str("method", rpcMethod(ctx)).
+			Err(err).Msg("rpc call denied")
+	} else {
+		auditLogger.Info().Str("caller", caller).Str("peer", peerAddr(ctx)).Str("method", rpcMethod(ctx)).
+			Msg("privileged rpc call")
+	}
+}
+
+type rpcMethodKey struct{}
+
+// withRPCMethod sets the name of the rpc method called through a gateway
+// other than grpc.
+func withRPCMethod(ctx context.Context, method string) context.Context {
+	return context.WithValue(ctx, rpcMethodKey{}, method)
+}
+
+// rpcMethod returns the name of the rpc method being called, like SendTX.
+func rpcMethod(ctx context.Context) string {
+	if m, ok := ctx.Value(rpcMethodKey{}).(string); ok {
+		return m
+	}
+	if full, ok := grpc.Method(ctx); ok {
+		return full[strings.LastIndex(full, "/")+1:]
+	}
+	return ""
+}
+
+func (rpc *AergoRPCService) unaryAuthInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := rpc.authenticateCall(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (rpc *AergoRPCService) streamAuthInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := rpc.authenticateCall(ss.Context())
+	if err != nil {
+		return err
+	}
+	return handler(srv, &authServerStream{ServerStream: ss, ctx: ctx})
+}
+
+type authServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authServerStream) Context() context.Context {
+	return s.ctx
+}
+
 func parseValue(perm string) (string, int) {
 	v := strings.Split(perm, ":")
 	if len(v) != 2 {
@@ -89,20 +238,26 @@ func parseValue(perm string) (string, int) {
 		return "", 0
 	}
 
+	return v[0], parsePermission(v[1])
+}
+
+// parsePermission converts the permission letters R, W, C and S to
+// Authentication.
+func parsePermission(perm string) Authentication {
 	permission := 0
-	if strings.Contains(v[1], "R") {
+	if strings.Contains(perm, "R") {
 		permission |= ReadBlockChain
 	}
-	if strings.Contains(v[1], "W") {
+	if strings.Contains(perm, "W") {
 		permission |= WriteBlockChain
 	}
-	if strings.Contains(v[1], "C") {
+	if strings.Contains(perm, "C") {
 		permission |= ControlNode
 	}
-	if strings.Contains(v[1], "S") {
+	if strings.Contains(perm, "S") {
 		permission |= ShowNode
 	}
-	return v[0], permission
+	return permission
 }
 
 func parseConf(conf *types.EnterpriseConfig) (map[string]Authentication, bool) {
@@ -12,6 +12,7 @@ import (
 	"encoding/binary"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"reflect"
 	"strings"
 	"sync"
@@ -21,6 +22,7 @@ import (
 	"github.com/aergoio/aergo-actor/actor"
 	"github.com/aergoio/aergo-lib/log"
 	"github.com/aergoio/aergo/chain"
+	luacUtil "github.com/aergoio/aergo/cmd/aergoluac/util"
 	"github.com/aergoio/aergo/consensus"
 	"github.com/aergoio/aergo/consensus/impl/raftv2"
 	"github.com/aergoio/aergo/internal/common"
@@ -67,12 +69,17 @@ type AergoRPCService struct {
 	clientAuthLock sync.RWMutex
 	clientAuthOn   bool
 	clientAuth     map[string]Authentication
+	tokenAuth      *tokenAuth
 }
 
 // FIXME remove redundant constants
 const halfMinute = time.Second * 30
 const defaultActorTimeout = time.Second * 3
 
+// maxContractSourceSize limits the source compiled by VerifyContractSource.
+// It is large enough for any contract whose bytecode fits in a tx.
+const maxContractSourceSize = 1024 * 1024
+
 var _ types.AergoRPCServiceServer = (*AergoRPCService)(nil)
 
 func (rpc *AergoRPCService) SetConsensusAccessor(ca consensus.ConsensusAccessor) {
@@ -117,7 +124,8 @@ func (rpc *AergoRPCService) fillPeerMetrics(result *types.Metrics) {
 	mets := make([]*types.PeerMetric, len(metrics))
 	for i, met := range metrics {
 		rMet := &types.PeerMetric{PeerID: []byte(met.PeerID), SumIn: met.TotalIn(), AvrIn: met.InMetric.APS(),
-			SumOut: met.TotalOut(), AvrOut: met.OutMetric.APS()}
+			SumOut: met.TotalOut(), AvrOut: met.OutMetric.APS(),
+			DelayedReqs: met.DelayedRequests(), DroppedReqs: met.DroppedRequests()}
 		mets[i] = rMet
 	}
 
@@ -356,6 +364,9 @@ func (rpc *AergoRPCService) BroadcastToListBlockMetadataStream(meta *types.Block
 
 // ListBlockStream starts a stream of new blocks
 func (rpc *AergoRPCService) ListBlockStream(in *types.Empty, stream types.AergoRPCService_ListBlockStreamServer) error {
+	if err := rpc.checkAuth(stream.Context(), ReadBlockChain); err != nil {
+		return err
+	}
 	streamId := atomic.AddUint32(&rpc.streamID, 1)
 	rpc.blockStreamLock.Lock()
 	rpc.blockStream[streamId] = stream
@@ -376,6 +387,9 @@ func (rpc *AergoRPCService) ListBlockStream(in *types.Empty, stream types.AergoR
 
 // ListBlockMetadataStream starts a stream of new blocks' metadata
 func (rpc *AergoRPCService) ListBlockMetadataStream(in *types.Empty, stream types.AergoRPCService_ListBlockMetadataStreamServer) error {
+	if err := rpc.checkAuth(stream.Context(), ReadBlockChain); err != nil {
+		return err
+	}
 	streamID := atomic.AddUint32(&rpc.streamID, 1)
 	rpc.blockMetadataStreamLock.Lock()
 	rpc.blockMetadataStream[streamID] = stream
@@ -555,6 +569,9 @@ func (rpc *AergoRPCService) SendTX(ctx context.Context, tx *types.Tx) (*types.Co
 	if err := rpc.checkAuth(ctx, WriteBlockChain); err != nil {
 		return nil, err
 	}
+	if err := rpc.checkAuthAccount(ctx, WriteBlockChain, tx.GetBody().GetAccount()); err != nil {
+		return nil, err
+	}
 	if tx.Body.Nonce == 0 {
 		getStateResult, err := rpc.hub.RequestFuture(message.ChainSvc,
 			&message.GetState{Account: tx.Body.Account}, defaultActorTimeout, "rpc.(*AergoRPCService).SendTx").Result()
@@ -623,6 +640,11 @@ func (rpc *AergoRPCService) CommitTX(ctx context.Context, in *types.TxList) (*ty
 	if in.Txs == nil {
 		return nil, status.Errorf(codes.InvalidArgument, "input tx is empty")
 	}
+	for _, tx := range in.Txs {
+		if err := rpc.checkAuthAccount(ctx, WriteBlockChain, tx.GetBody().GetAccount()); err != nil {
+			return nil, err
+		}
+	}
 	rs := make([]*types.CommitResult, len(in.Txs))
 	futures := make([]*actor.Future, len(in.Txs))
 	results := &types.CommitResultList{Results: rs}
@@ -849,11 +871,106 @@ func (rpc *AergoRPCService) ExportAccount(ctx context.Context, in *types.Persona
 	return &types.SingleBytes{Value: rsp.Wif}, rsp.Err
 }
 
+// CreateHDWallet handle rpc request createhdwallet
+func (rpc *AergoRPCService) CreateHDWallet(ctx context.Context, in *types.HDWallet) (*types.HDWallet, error) {
+	if err := rpc.checkAuth(ctx, WriteBlockChain); err != nil {
+		return nil, err
+	}
+	return rpc.requestHDWallet(&message.CreateHDWallet{Path: in.Path, Passphrase: in.Passphrase},
+		"rpc.(*AergoRPCService).CreateHDWallet")
+}
+
+// ImportHDWallet handle rpc request importhdwallet
+func (rpc *AergoRPCService) ImportHDWallet(ctx context.Context, in *types.HDWallet) (*types.HDWallet, error) {
+	if err := rpc.checkAuth(ctx, WriteBlockChain); err != nil {
+		return nil, err
+	}
+	return rpc.requestHDWallet(&message.ImportHDWallet{Mnemonic: in.Mnemonic, Path: in.Path, Passphrase: in.Passphrase},
+		"rpc.(*AergoRPCService).ImportHDWallet")
+}
+
+func (rpc *AergoRPCService) requestHDWallet(msg interface{}, caller string) (*types.HDWallet, error) {
+	result, err := rpc.hub.RequestFutureResult(message.AccountsSvc, msg, defaultActorTimeout, caller)
+	if err != nil {
+		if err == component.ErrHubUnregistered {
+			return nil, status.Errorf(codes.Unavailable, "Unavailable personal feature")
+		}
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+
+	rsp, ok := result.(*message.HDWalletRsp)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "internal type (%v) error", reflect.TypeOf(result))
+	}
+	if rsp.Err != nil {
+		return nil, rsp.Err
+	}
+	return &types.HDWallet{Mnemonic: rsp.Mnemonic, Path: rsp.Path}, nil
+}
+
+// DeriveHDAccount handle rpc request derivehdaccount
+func (rpc *AergoRPCService) DeriveHDAccount(ctx context.Context, in *types.HDAccount) (*types.Account, error) {
+	if err := rpc.checkAuth(ctx, WriteBlockChain); err != nil {
+		return nil, err
+	}
+	return rpc.requestAccount(&message.DeriveHDAccount{Index: in.Index, Passphrase: in.Passphrase},
+		"rpc.(*AergoRPCService).DeriveHDAccount")
+}
+
+// UnlockHDAccount handle rpc request unlockhdaccount
+func (rpc *AergoRPCService) UnlockHDAccount(ctx context.Context, in *types.HDAccount) (*types.Account, error) {
+	if err := rpc.checkAuth(ctx, WriteBlockChain); err != nil {
+		return nil, err
+	}
+	return rpc.requestAccount(&message.UnlockHDAccount{Index: in.Index, Passphrase: in.Passphrase},
+		"rpc.(*AergoRPCService).UnlockHDAccount")
+}
+
+func (rpc *AergoRPCService) requestAccount(msg interface{}, caller string) (*types.Account, error) {
+	result, err := rpc.hub.RequestFutureResult(message.AccountsSvc, msg, defaultActorTimeout, caller)
+	if err != nil {
+		if err == component.ErrHubUnregistered {
+			return nil, status.Errorf(codes.Unavailable, "Unavailable personal feature")
+		}
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+
+	rsp, ok := result.(*message.AccountRsp)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "internal type (%v) error", reflect.TypeOf(result))
+	}
+	return rsp.Account, rsp.Err
+}
+
+// GetHDAccounts handle rpc request gethdaccounts
+func (rpc *AergoRPCService) GetHDAccounts(ctx context.Context, in *types.Empty) (*types.AccountList, error) {
+	if err := rpc.checkAuth(ctx, ShowNode); err != nil {
+		return nil, err
+	}
+	result, err := rpc.hub.RequestFutureResult(message.AccountsSvc,
+		&message.GetHDAccounts{}, defaultActorTimeout, "rpc.(*AergoRPCService).GetHDAccounts")
+	if err != nil {
+		if err == component.ErrHubUnregistered {
+			return nil, status.Errorf(codes.Unavailable, "Unavailable personal feature")
+		}
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+
+	rsp, ok := result.(*message.GetHDAccountsRsp)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "internal type (%v) error", reflect.TypeOf(result))
+	}
+	return rsp.Accounts, rsp.Err
+}
+
 // SignTX handle rpc request signtx
 func (rpc *AergoRPCService) SignTX(ctx context.Context, in *types.Tx) (*types.Tx, error) {
 	if err := rpc.checkAuth(ctx, WriteBlockChain); err != nil {
 		return nil, err
 	}
+	if err := rpc.checkAuthAccount(ctx, WriteBlockChain, in.GetBody().GetAccount()); err != nil {
+		return nil, err
+	}
 	result, err := rpc.hub.RequestFutureResult(message.AccountsSvc,
 		&message.SignTx{Tx: in}, defaultActorTimeout, "rpc.(*AergoRPCService).SignTX")
 	if err != nil {
@@ -904,7 +1021,7 @@ func (rpc *AergoRPCService) GetPeers(ctx context.Context, in *types.PeersParams)
 		return nil, err
 	}
 	result, err := rpc.hub.RequestFuture(message.P2PSvc,
-		&message.GetPeers{in.NoHidden, in.ShowSelf}, halfMinute, "rpc.(*AergoRPCService).GetPeers").Result()
+		&message.GetPeers{NoHidden: in.NoHidden, ShowSelf: in.ShowSelf, Known: in.Known}, halfMinute, "rpc.(*AergoRPCService).GetPeers").Result()
 	if err != nil {
 		return nil, err
 	}
@@ -917,6 +1034,15 @@ func (rpc *AergoRPCService) GetPeers(ctx context.Context, in *types.PeersParams)
 	for _, pi := range rsp.Peers {
 		blkNotice := &types.NewBlockNotice{BlockHash: pi.LastBlockHash, BlockNo: pi.LastBlockNumber}
 		peer := &types.Peer{Address: pi.Addr, State: int32(pi.State), Bestblock: blkNotice, LashCheck: pi.CheckTime.UnixNano(), Hidden: pi.Hidden, Selfpeer: pi.Self, Version: pi.Version, Certificates:pi.Certificates, AcceptedRole:pi.AcceptedRole}
+		if in.Known {
+			peer.SuccessCnt, peer.FailCnt, peer.Latency = pi.SuccessCnt, pi.FailCnt, int64(pi.Latency)
+			if !pi.LastSuccess.IsZero() {
+				peer.LastSuccess = pi.LastSuccess.UnixNano()
+			}
+			if !pi.LastFailure.IsZero() {
+				peer.LastFailure = pi.LastFailure.UnixNano()
+			}
+		}
 		ret.Peers = append(ret.Peers, peer)
 	}
 
@@ -1004,6 +1130,26 @@ func (rpc *AergoRPCService) GetStaking(ctx context.Context, in *types.AccountAdd
 	return rsp.Staking, rsp.Err
 }
 
+//GetVotingReward handle rpc request getvotingreward
+func (rpc *AergoRPCService) GetVotingReward(ctx context.Context, in *types.AccountAddress) (*types.VotingReward, error) {
+	if err := rpc.checkAuth(ctx, ReadBlockChain); err != nil {
+		return nil, err
+	}
+	if len(in.Value) > types.AddressLength {
+		return nil, status.Errorf(codes.InvalidArgument, "Only support valid address")
+	}
+	result, err := rpc.hub.RequestFuture(message.ChainSvc,
+		&message.GetVotingReward{Addr: in.Value}, defaultActorTimeout, "rpc.(*AergoRPCService).GetVotingReward").Result()
+	if err != nil {
+		return nil, err
+	}
+	rsp, ok := result.(*message.GetVotingRewardRsp)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "internal type (%v) error", reflect.TypeOf(result))
+	}
+	return rsp.Reward, rsp.Err
+}
+
 func (rpc *AergoRPCService) GetNameInfo(ctx context.Context, in *types.Name) (*types.NameInfo, error) {
 	if err := rpc.checkAuth(ctx, ReadBlockChain); err != nil {
 		return nil, err
@@ -1055,6 +1201,39 @@ func (rpc *AergoRPCService) GetABI(ctx context.Context, in *types.SingleBytes) (
 	return rsp.ABI, rsp.Err
 }
 
+// VerifyContractSource recompiles the source of a contract and compares the
+// result with the deployed code.
+func (rpc *AergoRPCService) VerifyContractSource(ctx context.Context, in *types.ContractSource) (*types.SourceVerification, error) {
+	if err := rpc.checkAuth(ctx, ReadBlockChain); err != nil {
+		return nil, err
+	}
+	if len(in.Source) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "empty source")
+	}
+	if len(in.Source) > maxContractSourceSize {
+		return nil, status.Errorf(codes.InvalidArgument, "source is too large: %d > %d", len(in.Source), maxContractSourceSize)
+	}
+	if version := luacUtil.CompilerVersion(); in.CompilerVersion != "" && in.CompilerVersion != version {
+		return &types.SourceVerification{
+			CompilerVersion: version,
+			Reason:          fmt.Sprintf("compiler version %s is not available", in.CompilerVersion),
+		}, nil
+	}
+	result, err := rpc.hub.RequestFuture(message.ChainSvc,
+		&message.GetCode{Contract: in.ContractAddress}, defaultActorTimeout, "rpc.(*AergoRPCService).VerifyContractSource").Result()
+	if err != nil {
+		return nil, err
+	}
+	rsp, ok := result.(message.GetCodeRsp)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "internal type (%v) error", reflect.TypeOf(result))
+	}
+	if rsp.Err != nil {
+		return nil, rsp.Err
+	}
+	return luacUtil.VerifySource(luacUtil.LuaCode(rsp.Code), in.Source), nil
+}
+
 func (rpc *AergoRPCService) QueryContract(ctx context.Context, in *types.Query) (*types.SingleBytes, error) {
 	if err := rpc.checkAuth(ctx, ReadBlockChain); err != nil {
 		return nil, err
@@ -1099,6 +1278,9 @@ func fromTimestamp(timestamp *timestamp.Timestamp) time.Time {
 }
 
 func (rpc *AergoRPCService) ListEventStream(in *types.FilterInfo, stream types.AergoRPCService_ListEventStreamServer) error {
+	if err := rpc.checkAuth(stream.Context(), ReadBlockChain); err != nil {
+		return err
+	}
 	err := in.ValidateCheck(0)
 	if err != nil {
 		return err
@@ -1162,6 +1344,23 @@ func (rpc *AergoRPCService) ListEvents(ctx context.Context, in *types.FilterInfo
 	return &types.EventList{Events: rsp.Events}, rsp.Err
 }
 
+// ListEvidences handles rpc request listing double sign evidences detected by this node
+func (rpc *AergoRPCService) ListEvidences(ctx context.Context, in *types.Empty) (*types.DoubleSignEvidenceList, error) {
+	if err := rpc.checkAuth(ctx, ReadBlockChain); err != nil {
+		return nil, err
+	}
+	result, err := rpc.hub.RequestFuture(message.ChainSvc,
+		&message.ListEvidences{}, defaultActorTimeout, "rpc.(*AergoRPCService).ListEvidences").Result()
+	if err != nil {
+		return nil, err
+	}
+	rsp, ok := result.(*message.ListEvidencesRsp)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "internal type (%v) error", reflect.TypeOf(result))
+	}
+	return &types.DoubleSignEvidenceList{Evidences: rsp.Evidences}, rsp.Err
+}
+
 func (rpc *AergoRPCService) GetServerInfo(ctx context.Context, in *types.KeyParams) (*types.ServerInfo, error) {
 	if err := rpc.checkAuth(ctx, ShowNode); err != nil {
 		return nil, err
@@ -1190,6 +1389,17 @@ func (rpc *AergoRPCService) GetConsensusInfo(ctx context.Context, in *types.Empt
 	return rpc.consensusAccessor.ConsensusInfo(), nil
 }
 
+// GetBPStats handles rpc request bpstat.
+func (rpc *AergoRPCService) GetBPStats(ctx context.Context, in *types.BPStatParams) (*types.BPStatList, error) {
+	if err := rpc.checkAuth(ctx, ReadBlockChain); err != nil {
+		return nil, err
+	}
+	if rpc.consensusAccessor == nil {
+		return nil, ErrUninitAccessor
+	}
+	return rpc.consensusAccessor.BPStats(in.GetBlockNo())
+}
+
 // ChainStat handles rpc request chainstat.
 func (rpc *AergoRPCService) ChainStat(ctx context.Context, in *types.Empty) (*types.ChainStats, error) {
 	if err := rpc.checkAuth(ctx, ReadBlockChain); err != nil {
@@ -1221,7 +1431,7 @@ func (rpc *AergoRPCService) ChangeMembership(ctx context.Context, in *types.Memb
 		return nil, err
 	}
 
-	reply := &types.MembershipChangeReply{Attr: &types.MemberAttr{ID: uint64(member.ID), Name: member.Name, Address: member.Address, PeerID: []byte(types.PeerID(member.PeerID))}}
+	reply := &types.MembershipChangeReply{Attr: &types.MemberAttr{ID: uint64(member.ID), Name: member.Name, Address: member.Address, PeerID: []byte(types.PeerID(member.PeerID)), IsLearner: member.IsLearner}}
 	return reply, nil
 }
 
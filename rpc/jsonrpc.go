@@ -0,0 +1,426 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	jsonrpcVersion = "2.0"
+	// maxJSONRPCRequestSize limits the size of a request body or a websocket
+	// message. It is large enough for a contract deployment.
+	maxJSONRPCRequestSize = 16 * 1024 * 1024
+	jsonrpcWriteTimeout   = 4 * time.Second
+
+	// subscriptionMethod is the method of the notifications sent to the
+	// websocket subscribers.
+	subscriptionMethod = "aergo_subscription"
+)
+
+// Error codes defined by the JSON-RPC 2.0 specification. jsonrpcServerError
+// is used for the errors returned by the rpc service.
+const (
+	jsonrpcParseError     = -32700
+	jsonrpcInvalidRequest = -32600
+	jsonrpcMethodNotFound = -32601
+	jsonrpcInvalidParams  = -32602
+	jsonrpcInternalError  = -32603
+	jsonrpcServerError    = -32000
+)
+
+type jsonrpcRequest struct {
+	Version string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+func (req *jsonrpcRequest) isNotification() bool {
+	return len(req.ID) == 0
+}
+
+type jsonrpcResponse struct {
+	Version string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *jsonrpcError   `json:"error,omitempty"`
+}
+
+type jsonrpcNotification struct {
+	Version string      `json:"jsonrpc"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params"`
+}
+
+type jsonrpcError struct {
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+func (e *jsonrpcError) Error() string {
+	return e.Message
+}
+
+func newJSONRPCError(code int, format string, args ...interface{}) *jsonrpcError {
+	return &jsonrpcError{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+// toJSONRPCError converts an error returned by a method, which is usually a
+// grpc status error, to a JSON-RPC error object.
+func toJSONRPCError(err error) *jsonrpcError {
+	if e, ok := err.(*jsonrpcError); ok {
+		return e
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		return &jsonrpcError{Code: jsonrpcServerError, Message: err.Error()}
+	}
+	code := jsonrpcServerError
+	if st.Code() == codes.InvalidArgument {
+		code = jsonrpcInvalidParams
+	}
+	return &jsonrpcError{Code: code, Message: st.Message(), Data: st.Code().String()}
+}
+
+// jsonrpcServer serves the AergoRPCService methods as JSON-RPC 2.0 methods
+// over HTTP and WebSocket. The methods are named aergo_<method> and use the
+// same base58 and JSON conventions as aergocli.
+type jsonrpcServer struct {
+	rpc            *AergoRPCService
+	upgrader       websocket.Upgrader
+	allowedOrigins []string
+	subID          uint64
+}
+
+// newJSONRPCServer creates a gateway for rpc. A request from a browser, that
+// is a POST or a WebSocket with an Origin header, may only be made from the
+// same origin or from one of allowedOrigins, where "*" allows any.
+func newJSONRPCServer(rpc *AergoRPCService, allowedOrigins []string) *jsonrpcServer {
+	return &jsonrpcServer{
+		rpc:            rpc,
+		allowedOrigins: allowedOrigins,
+		upgrader: websocket.Upgrader{
+			CheckOrigin: func(r *http.Request) bool {
+				return checkOrigin(r, allowedOrigins)
+			},
+		},
+	}
+}
+
+// checkOrigin accepts a request without an Origin header, which does not come
+// from a browser, a request from the same host, and a request from one of the
+// allowed origins.
+func checkOrigin(r *http.Request, allowedOrigins []string) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	if u, err := url.Parse(origin); err == nil && strings.EqualFold(u.Host, r.Host) {
+		return true
+	}
+	for _, allowed := range allowedOrigins {
+		if allowed == "*" || strings.EqualFold(strings.TrimSuffix(allowed, "/"), origin) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *jsonrpcServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if websocket.IsWebSocketUpgrade(r) {
+		s.serveWebsocket(w, r)
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !checkOrigin(r, s.allowedOrigins) {
+		http.Error(w, "origin not allowed", http.StatusForbidden)
+		return
+	}
+	// A browser can't send a JSON request to another origin without the CORS
+	// preflight, unlike a form or a text/plain one.
+	if mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type")); err != nil || mediaType != "application/json" {
+		http.Error(w, "content type must be application/json", http.StatusUnsupportedMediaType)
+		return
+	}
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxJSONRPCRequestSize))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if rsp := s.handleMessage(withPeer(r), body, nil); rsp != nil {
+		w.Write(rsp)
+	}
+}
+
+// withPeer returns the context of r carrying the peer information and the
+// authorization header of the client, which are used by the permission check
+// of the rpc service.
+func withPeer(r *http.Request) context.Context {
+	p := &peer.Peer{Addr: httpAddr(r.RemoteAddr)}
+	if r.TLS != nil {
+		p.AuthInfo = credentials.TLSInfo{State: *r.TLS}
+	}
+	ctx := peer.NewContext(r.Context(), p)
+	if auth := r.Header.Get("Authorization"); len(auth) > 0 {
+		ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", auth))
+	}
+	return ctx
+}
+
+type httpAddr string
+
+func (a httpAddr) Network() string { return "tcp" }
+func (a httpAddr) String() string  { return string(a) }
+
+// handleMessage handles a single request or a batch of requests and returns
+// the encoded response. It returns nil if no response is needed.
+func (s *jsonrpcServer) handleMessage(ctx context.Context, msg []byte, conn *wsConn) []byte {
+	msg = bytes.TrimSpace(msg)
+	if len(msg) > 0 && msg[0] == '[' {
+		var reqs []json.RawMessage
+		if err := json.Unmarshal(msg, &reqs); err != nil {
+			return marshalResponse(errorResponse(nil, newJSONRPCError(jsonrpcParseError, "parse error")))
+		}
+		if len(reqs) == 0 {
+			return marshalResponse(errorResponse(nil, newJSONRPCError(jsonrpcInvalidRequest, "empty batch")))
+		}
+		rsps := make([]*jsonrpcResponse, 0, len(reqs))
+		for _, raw := range reqs {
+			if rsp := s.handleRequest(ctx, raw, conn); rsp != nil {
+				rsps = append(rsps, rsp)
+			}
+		}
+		if len(rsps) == 0 {
+			return nil
+		}
+		return marshalResponse(rsps)
+	}
+	if rsp := s.handleRequest(ctx, msg, conn); rsp != nil {
+		return marshalResponse(rsp)
+	}
+	return nil
+}
+
+func (s *jsonrpcServer) handleRequest(ctx context.Context, raw []byte, conn *wsConn) *jsonrpcResponse {
+	var req jsonrpcRequest
+	if err := json.Unmarshal(raw, &req); err != nil {
+		return errorResponse(nil, newJSONRPCError(jsonrpcParseError, "parse error"))
+	}
+	if req.Version != jsonrpcVersion || len(req.Method) == 0 {
+		return errorResponse(req.ID, newJSONRPCError(jsonrpcInvalidRequest, "invalid request"))
+	}
+
+	var (
+		result interface{}
+		err    error
+	)
+	if m, exist := jsonrpcMethods[req.Method]; exist {
+		var params []json.RawMessage
+		if params, err = splitParams(req.Params); err == nil {
+			mctx := withRPCMethod(ctx, m.rpcMethod)
+			if mctx, err = s.rpc.authenticateCall(mctx); err == nil {
+				result, err = m.call(s.rpc, mctx, params)
+			}
+		}
+	} else if wm, exist := jsonrpcWsMethods[req.Method]; exist && conn != nil {
+		var (
+			params []json.RawMessage
+			method string
+		)
+		if params, err = splitParams(req.Params); err == nil {
+			if method, err = wm.rpcMethod(params); err == nil {
+				mctx := withRPCMethod(ctx, method)
+				if mctx, err = s.rpc.authenticateCall(mctx); err == nil {
+					result, err = wm.call(s, conn, mctx, params)
+				}
+			}
+		}
+	} else {
+		err = newJSONRPCError(jsonrpcMethodNotFound, "method %s not found", req.Method)
+	}
+
+	if req.isNotification() {
+		return nil
+	}
+	if err != nil {
+		return errorResponse(req.ID, toJSONRPCError(err))
+	}
+	return &jsonrpcResponse{Version: jsonrpcVersion, ID: req.ID, Result: result}
+}
+
+// splitParams returns the positional parameters of a request.
+func splitParams(raw json.RawMessage) ([]json.RawMessage, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+	var params []json.RawMessage
+	if err := json.Unmarshal(raw, &params); err != nil {
+		return nil, newJSONRPCError(jsonrpcInvalidParams, "params must be an array")
+	}
+	return params, nil
+}
+
+func errorResponse(id json.RawMessage, err *jsonrpcError) *jsonrpcResponse {
+	if len(id) == 0 {
+		id = json.RawMessage("null")
+	}
+	return &jsonrpcResponse{Version: jsonrpcVersion, ID: id, Error: err}
+}
+
+func marshalResponse(rsp interface{}) []byte {
+	b, err := json.Marshal(rsp)
+	if err != nil {
+		logger.Warn().Err(err).Msg("failed to marshal json-rpc response")
+		b, _ = json.Marshal(errorResponse(nil, newJSONRPCError(jsonrpcInternalError, "internal error")))
+	}
+	return b
+}
+
+// wsConn is a websocket connection of a JSON-RPC client. It keeps the
+// subscriptions of the client, which are cancelled when the connection is
+// closed.
+type wsConn struct {
+	conn      *websocket.Conn
+	writeLock sync.Mutex
+
+	subLock sync.Mutex
+	subs    map[string]context.CancelFunc
+	// pending are the subscriptions which start after the response of the
+	// current message is written.
+	pending []func()
+}
+
+func (s *jsonrpcServer) serveWebsocket(w http.ResponseWriter, r *http.Request) {
+	conn, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		logger.Debug().Err(err).Msg("failed to upgrade json-rpc websocket")
+		return
+	}
+	// The deadlines of the http server are still set on the hijacked
+	// connection.
+	conn.UnderlyingConn().SetDeadline(time.Time{})
+	conn.SetReadLimit(maxJSONRPCRequestSize)
+
+	c := &wsConn{conn: conn, subs: make(map[string]context.CancelFunc)}
+	ctx := withPeer(r)
+	defer c.close()
+
+	for {
+		_, msg, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		if rsp := s.handleMessage(ctx, msg, c); rsp != nil {
+			if err := c.write(rsp); err != nil {
+				return
+			}
+		}
+		c.startPending()
+	}
+}
+
+func (c *wsConn) write(msg []byte) error {
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+	c.conn.SetWriteDeadline(time.Now().Add(jsonrpcWriteTimeout))
+	return c.conn.WriteMessage(websocket.TextMessage, msg)
+}
+
+func (c *wsConn) notify(subID string, result interface{}) error {
+	b, err := json.Marshal(&jsonrpcNotification{
+		Version: jsonrpcVersion,
+		Method:  subscriptionMethod,
+		Params: map[string]interface{}{
+			"subscription": subID,
+			"result":       result,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return c.write(b)
+}
+
+func (c *wsConn) subscribe(id string, cancel context.CancelFunc, run func()) {
+	c.subLock.Lock()
+	defer c.subLock.Unlock()
+	c.subs[id] = cancel
+	c.pending = append(c.pending, run)
+}
+
+func (c *wsConn) unsubscribe(id string) bool {
+	c.subLock.Lock()
+	defer c.subLock.Unlock()
+	cancel, exist := c.subs[id]
+	if exist {
+		cancel()
+		delete(c.subs, id)
+	}
+	return exist
+}
+
+func (c *wsConn) startPending() {
+	c.subLock.Lock()
+	pending := c.pending
+	c.pending = nil
+	c.subLock.Unlock()
+	for _, run := range pending {
+		go run()
+	}
+}
+
+func (c *wsConn) close() {
+	c.subLock.Lock()
+	for id, cancel := range c.subs {
+		cancel()
+		delete(c.subs, id)
+	}
+	c.pending = nil
+	c.subLock.Unlock()
+	c.conn.Close()
+}
+
+func (s *jsonrpcServer) newSubscriptionID() string {
+	return fmt.Sprintf("0x%x", atomic.AddUint64(&s.subID, 1))
+}
+
+// wsStream adapts a websocket subscription to the server stream used by the
+// streaming rpc methods. Only Context is used by those methods, so the other
+// methods of grpc.ServerStream are left unimplemented.
+type wsStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	notify func(result interface{}) error
+}
+
+func (s *wsStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,161 @@
+package rpc
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestJSONRPCHandleMessage(t *testing.T) {
+	s := newJSONRPCServer(&AergoRPCService{}, nil)
+	tests := []struct {
+		name    string
+		msg     string
+		wantNil bool
+		code    int
+	}{
+		{"parse error", `{"jsonrpc":"2.0",`, false, jsonrpcParseError},
+		{"no version", `{"id":1,"method":"aergo_getBlock"}`, false, jsonrpcInvalidRequest},
+		{"unknown method", `{"jsonrpc":"2.0","id":1,"method":"aergo_none"}`, false, jsonrpcMethodNotFound},
+		{"ws only method", `{"jsonrpc":"2.0","id":1,"method":"aergo_subscribe","params":["newBlocks"]}`, false, jsonrpcMethodNotFound},
+		{"params not array", `{"jsonrpc":"2.0","id":1,"method":"aergo_getBlock","params":{}}`, false, jsonrpcInvalidParams},
+		{"missing params", `{"jsonrpc":"2.0","id":1,"method":"aergo_getBlock","params":[]}`, false, jsonrpcInvalidParams},
+		{"invalid hash", `{"jsonrpc":"2.0","id":1,"method":"aergo_getReceipt","params":["0OIl"]}`, false, jsonrpcInvalidParams},
+		{"notification", `{"jsonrpc":"2.0","method":"aergo_none"}`, true, 0},
+		{"empty batch", `[]`, false, jsonrpcInvalidRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rsp := s.handleMessage(context.Background(), []byte(tt.msg), nil)
+			if tt.wantNil {
+				if rsp != nil {
+					t.Errorf("handleMessage() = %s, want nil", rsp)
+				}
+				return
+			}
+			var r struct {
+				Error *jsonrpcError
+			}
+			if err := json.Unmarshal(rsp, &r); err != nil {
+				t.Fatalf("invalid response %s: %v", rsp, err)
+			}
+			if r.Error == nil || r.Error.Code != tt.code {
+				t.Errorf("handleMessage() = %s, want code %d", rsp, tt.code)
+			}
+		})
+	}
+}
+
+func TestJSONRPCBatch(t *testing.T) {
+	s := newJSONRPCServer(&AergoRPCService{}, nil)
+	msg := `[
+		{"jsonrpc":"2.0","id":1,"method":"aergo_none"},
+		{"jsonrpc":"2.0","method":"aergo_none"},
+		{"jsonrpc":"2.0","id":"two","method":"aergo_getBlock"}
+	]`
+	var rsps []jsonrpcResponse
+	if err := json.Unmarshal(s.handleMessage(context.Background(), []byte(msg), nil), &rsps); err != nil {
+		t.Fatal(err)
+	}
+	if len(rsps) != 2 {
+		t.Fatalf("len(responses) = %d, want 2", len(rsps))
+	}
+	if string(rsps[0].ID) != "1" || string(rsps[1].ID) != `"two"` {
+		t.Errorf("ids = %s, %s", rsps[0].ID, rsps[1].ID)
+	}
+}
+
+func TestDecodeBlockRef(t *testing.T) {
+	number := make([]byte, 8)
+	binary.LittleEndian.PutUint64(number, 100)
+	tests := []struct {
+		raw     string
+		want    []byte
+		wantErr bool
+	}{
+		{`100`, number, false},
+		{`"100"`, number, false},
+		{`"2"`, func() []byte { b := make([]byte, 8); b[0] = 2; return b }(), false},
+		{`"5Kd3NBUAdUnhyzenEwVLy9pBKxSwXvE9FMPyR4UKZvpe6E3AgLr"`, nil, false},
+		{`"0OIl"`, nil, true},
+		{`true`, nil, true},
+	}
+	for _, tt := range tests {
+		got, err := decodeBlockRef(json.RawMessage(tt.raw))
+		if (err != nil) != tt.wantErr {
+			t.Errorf("decodeBlockRef(%s) error = %v, wantErr %v", tt.raw, err, tt.wantErr)
+			continue
+		}
+		if tt.want != nil && string(got) != string(tt.want) {
+			t.Errorf("decodeBlockRef(%s) = %v, want %v", tt.raw, got, tt.want)
+		}
+	}
+}
+
+func TestJSONRPCCheckOrigin(t *testing.T) {
+	tests := []struct {
+		name    string
+		origin  string
+		allowed []string
+		want    bool
+	}{
+		{"no origin", "", nil, true},
+		{"same origin", "http://node.example:7845", nil, true},
+		{"other origin", "http://evil.example", nil, false},
+		{"allowed origin", "https://app.example", []string{"https://app.example/"}, true},
+		{"not allowed origin", "https://evil.example", []string{"https://app.example"}, false},
+		{"any origin", "https://evil.example", []string{"*"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "http://node.example:7845/jsonrpc", nil)
+			if tt.origin != "" {
+				r.Header.Set("Origin", tt.origin)
+			}
+			if got := checkOrigin(r, tt.allowed); got != tt.want {
+				t.Errorf("checkOrigin() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSONRPCServeHTTP(t *testing.T) {
+	s := newJSONRPCServer(&AergoRPCService{}, []string{"https://app.example"})
+	const msg = `{"jsonrpc":"2.0","id":1,"method":"aergo_none"}`
+	tests := []struct {
+		name        string
+		method      string
+		origin      string
+		contentType string
+		code        int
+	}{
+		{"no origin", "POST", "", "application/json", http.StatusOK},
+		{"charset", "POST", "", "application/json; charset=utf-8", http.StatusOK},
+		{"allowed origin", "POST", "https://app.example", "application/json", http.StatusOK},
+		{"other origin", "POST", "http://evil.example", "application/json", http.StatusForbidden},
+		{"form", "POST", "", "application/x-www-form-urlencoded", http.StatusUnsupportedMediaType},
+		{"text", "POST", "", "text/plain", http.StatusUnsupportedMediaType},
+		{"no content type", "POST", "", "", http.StatusUnsupportedMediaType},
+		{"get", "GET", "", "", http.StatusMethodNotAllowed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, "http://node.example:7845/jsonrpc", strings.NewReader(msg))
+			if tt.origin != "" {
+				r.Header.Set("Origin", tt.origin)
+			}
+			if tt.contentType != "" {
+				r.Header.Set("Content-Type", tt.contentType)
+			}
+			w := httptest.NewRecorder()
+			s.ServeHTTP(w, r)
+			if w.Code != tt.code {
+				t.Errorf("ServeHTTP() = %d, want %d", w.Code, tt.code)
+			}
+		})
+	}
+}
@@ -0,0 +1,767 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package rpc
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"math/big"
+	"strconv"
+
+	"github.com/aergoio/aergo/cmd/aergocli/util"
+	aergojson "github.com/aergoio/aergo/cmd/aergocli/util/encoding/json"
+	"github.com/aergoio/aergo/types"
+	"github.com/golang/protobuf/proto"
+	"github.com/mr-tron/base58/base58"
+)
+
+type jsonrpcMethod func(rpc *AergoRPCService, ctx context.Context, params []json.RawMessage) (interface{}, error)
+
+// jsonrpcWsMethod is a method available only over websocket.
+type jsonrpcWsMethod func(s *jsonrpcServer, c *wsConn, ctx context.Context, params []json.RawMessage) (interface{}, error)
+
+// jsonrpcMethods are the JSON-RPC methods with the names of the rpc methods
+// they call, which are used by the permission check of the token
+// authentication.
+var jsonrpcMethods = map[string]struct {
+	rpcMethod string
+	call      jsonrpcMethod
+}{
+	"aergo_blockchain":          {"Blockchain", jsonBlockchain},
+	"aergo_getChainInfo":        {"GetChainInfo", jsonGetChainInfo},
+	"aergo_getConsensusInfo":    {"GetConsensusInfo", jsonGetConsensusInfo},
+	"aergo_chainStat":           {"ChainStat", jsonChainStat},
+	"aergo_getBlock":            {"GetBlock", jsonGetBlock},
+	"aergo_getBlockMetadata":    {"GetBlockMetadata", jsonGetBlockMetadata},
+	"aergo_getBlockBody":        {"GetBlockBody", jsonGetBlockBody},
+	"aergo_listBlockHeaders":    {"ListBlockHeaders", jsonListBlockHeaders},
+	"aergo_listBlockMetadata":   {"ListBlockMetadata", jsonListBlockMetadata},
+	"aergo_getTx":               {"GetTX", jsonGetTx},
+	"aergo_getBlockTx":          {"GetBlockTX", jsonGetBlockTx},
+	"aergo_getReceipt":          {"GetReceipt", jsonGetReceipt},
+	"aergo_sendTx":              {"CommitTX", jsonSendTx},
+	"aergo_getState":            {"GetState", jsonGetState},
+	"aergo_getStaking":          {"GetStaking", jsonGetStaking},
+	"aergo_getAccountVotes":     {"GetAccountVotes", jsonGetAccountVotes},
+	"aergo_getVotes":            {"GetVotes", jsonGetVotes},
+	"aergo_getNameInfo":         {"GetNameInfo", jsonGetNameInfo},
+	"aergo_getABI":              {"GetABI", jsonGetABI},
+	"aergo_queryContract":       {"QueryContract", jsonQueryContract},
+	"aergo_verifyContract":      {"VerifyContractSource", jsonVerifyContract},
+	"aergo_listEvents":          {"ListEvents", jsonListEvents},
+	"aergo_getPeers":            {"GetPeers", jsonGetPeers},
+	"aergo_nodeState":           {"NodeState", jsonNodeState},
+	"aergo_getEnterpriseConfig": {"GetEnterpriseConfig", jsonGetEnterpriseConfig},
+}
+
+var jsonrpcWsMethods = map[string]struct {
+	rpcMethod func(params []json.RawMessage) (string, error)
+	call      jsonrpcWsMethod
+}{
+	"aergo_subscribe":   {subscriptionRPCMethod, jsonSubscribe},
+	"aergo_unsubscribe": {func([]json.RawMessage) (string, error) { return "Unsubscribe", nil }, jsonUnsubscribe},
+}
+
+// parseParams decodes the positional params into args. The trailing args
+// without a corresponding param are left untouched, so they are optional.
+func parseParams(params []json.RawMessage, args ...interface{}) error {
+	if len(params) > len(args) {
+		return newJSONRPCError(jsonrpcInvalidParams, "too many params: %d > %d", len(params), len(args))
+	}
+	for i, p := range params {
+		if err := json.Unmarshal(p, args[i]); err != nil {
+			return newJSONRPCError(jsonrpcInvalidParams, "invalid param %d: %s", i, err.Error())
+		}
+	}
+	return nil
+}
+
+func requireParams(params []json.RawMessage, n int) error {
+	if len(params) < n {
+		return newJSONRPCError(jsonrpcInvalidParams, "missing params: %d < %d", len(params), n)
+	}
+	return nil
+}
+
+func decodeHash(s string) ([]byte, error) {
+	hash, err := base58.Decode(s)
+	if err != nil || len(hash) == 0 {
+		return nil, newJSONRPCError(jsonrpcInvalidParams, "invalid hash: %s", s)
+	}
+	return hash, nil
+}
+
+func decodeAccount(s string) ([]byte, error) {
+	addr, err := types.DecodeAddress(s)
+	if err != nil {
+		return nil, newJSONRPCError(jsonrpcInvalidParams, "invalid address %s: %s", s, err.Error())
+	}
+	return addr, nil
+}
+
+// decodeBlockRef decodes a block reference given as a base58 block hash or a
+// block number, as accepted by the GetBlock method.
+func decodeBlockRef(raw json.RawMessage) ([]byte, error) {
+	var number uint64
+	if err := json.Unmarshal(raw, &number); err == nil {
+		return blockNoToBytes(number), nil
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return nil, newJSONRPCError(jsonrpcInvalidParams, "block hash or number is required")
+	}
+	if number, err := strconv.ParseUint(s, 10, 64); err == nil {
+		return blockNoToBytes(number), nil
+	}
+	return decodeHash(s)
+}
+
+func blockNoToBytes(number uint64) []byte {
+	b := make([]byte, 8)
+	binary.LittleEndian.PutUint64(b, number)
+	return b
+}
+
+// pbJSON encodes a protobuf message in the aergocli convention, where byte
+// slices are base58 encoded.
+func pbJSON(pb proto.Message) (json.RawMessage, error) {
+	b, err := aergojson.Marshal(pb)
+	if err != nil {
+		return nil, err
+	}
+	return json.RawMessage(b), nil
+}
+
+// rawJSON returns b as is if it is a valid json value or as a json string
+// otherwise.
+func rawJSON(b []byte) interface{} {
+	if json.Valid(b) {
+		return json.RawMessage(b)
+	}
+	return string(b)
+}
+
+func amountString(b []byte) string {
+	return new(big.Int).SetBytes(b).String()
+}
+
+type jsonBlockMetadata struct {
+	Hash    string
+	Header  util.InOutBlockHeader
+	Txcount int32
+	Size    int64
+}
+
+func convBlockMetadata(meta *types.BlockMetadata) *jsonBlockMetadata {
+	b := util.ConvBlock(&types.Block{Hash: meta.GetHash(), Header: meta.GetHeader()})
+	return &jsonBlockMetadata{Hash: b.Hash, Header: b.Header, Txcount: meta.GetTxcount(), Size: meta.GetSize()}
+}
+
+func jsonBlockchain(rpc *AergoRPCService, ctx context.Context, params []json.RawMessage) (interface{}, error) {
+	status, err := rpc.Blockchain(ctx, &types.Empty{})
+	if err != nil {
+		return nil, err
+	}
+	return json.RawMessage(util.ConvBlockchainStatus(status)), nil
+}
+
+func jsonGetChainInfo(rpc *AergoRPCService, ctx context.Context, params []json.RawMessage) (interface{}, error) {
+	info, err := rpc.GetChainInfo(ctx, &types.Empty{})
+	if err != nil {
+		return nil, err
+	}
+	return json.RawMessage(util.ConvChainInfoMsg(info)), nil
+}
+
+func jsonGetConsensusInfo(rpc *AergoRPCService, ctx context.Context, params []json.RawMessage) (interface{}, error) {
+	info, err := rpc.GetConsensusInfo(ctx, &types.Empty{})
+	if err != nil {
+		return nil, err
+	}
+	return pbJSON(info)
+}
+
+func jsonChainStat(rpc *AergoRPCService, ctx context.Context, params []json.RawMessage) (interface{}, error) {
+	stat, err := rpc.ChainStat(ctx, &types.Empty{})
+	if err != nil {
+		return nil, err
+	}
+	return rawJSON([]byte(stat.GetReport())), nil
+}
+
+// jsonGetBlock handles aergo_getBlock [hashOrNumber]
+func jsonGetBlock(rpc *AergoRPCService, ctx context.Context, params []json.RawMessage) (interface{}, error) {
+	if err := requireParams(params, 1); err != nil {
+		return nil, err
+	}
+	ref, err := decodeBlockRef(params[0])
+	if err != nil {
+		return nil, err
+	}
+	block, err := rpc.GetBlock(ctx, &types.SingleBytes{Value: ref})
+	if err != nil {
+		return nil, err
+	}
+	return util.ConvBlock(block), nil
+}
+
+// jsonGetBlockMetadata handles aergo_getBlockMetadata [hashOrNumber]
+func jsonGetBlockMetadata(rpc *AergoRPCService, ctx context.Context, params []json.RawMessage) (interface{}, error) {
+	if err := requireParams(params, 1); err != nil {
+		return nil, err
+	}
+	ref, err := decodeBlockRef(params[0])
+	if err != nil {
+		return nil, err
+	}
+	meta, err := rpc.GetBlockMetadata(ctx, &types.SingleBytes{Value: ref})
+	if err != nil {
+		return nil, err
+	}
+	return convBlockMetadata(meta), nil
+}
+
+// jsonGetBlockBody handles aergo_getBlockBody [hashOrNumber, offset, size]
+func jsonGetBlockBody(rpc *AergoRPCService, ctx context.Context, params []json.RawMessage) (interface{}, error) {
+	if err := requireParams(params, 1); err != nil {
+		return nil, err
+	}
+	ref, err := decodeBlockRef(params[0])
+	if err != nil {
+		return nil, err
+	}
+	paging := &types.PageParams{}
+	if err := parseParams(params[1:], &paging.Offset, &paging.Size); err != nil {
+		return nil, err
+	}
+	body, err := rpc.GetBlockBody(ctx, &types.BlockBodyParams{Hashornumber: ref, Paging: paging})
+	if err != nil {
+		return nil, err
+	}
+	txs := make([]*util.InOutTx, 0, len(body.GetBody().GetTxs()))
+	for _, tx := range body.GetBody().GetTxs() {
+		txs = append(txs, util.ConvTx(tx))
+	}
+	return map[string]interface{}{
+		"Txs":    txs,
+		"Total":  body.GetTotal(),
+		"Offset": body.GetOffset(),
+		"Size":   body.GetSize(),
+	}, nil
+}
+
+type jsonListParams struct {
+	Hash   string
+	Height uint64
+	Size   uint32
+	Offset uint32
+	Asc    bool
+}
+
+func (p *jsonListParams) toListParams() (*types.ListParams, error) {
+	lp := &types.ListParams{Height: p.Height, Size: p.Size, Offset: p.Offset, Asc: p.Asc}
+	if len(p.Hash) > 0 {
+		hash, err := decodeHash(p.Hash)
+		if err != nil {
+			return nil, err
+		}
+		lp.Hash = hash
+	}
+	return lp, nil
+}
+
+// jsonListBlockHeaders handles aergo_listBlockHeaders [{Hash, Height, Size, Offset, Asc}]
+func jsonListBlockHeaders(rpc *AergoRPCService, ctx context.Context, params []json.RawMessage) (interface{}, error) {
+	var p jsonListParams
+	if err := parseParams(params, &p); err != nil {
+		return nil, err
+	}
+	lp, err := p.toListParams()
+	if err != nil {
+		return nil, err
+	}
+	list, err := rpc.ListBlockHeaders(ctx, lp)
+	if err != nil {
+		return nil, err
+	}
+	blocks := make([]*util.InOutBlock, 0, len(list.GetBlocks()))
+	for _, b := range list.GetBlocks() {
+		blocks = append(blocks, util.ConvBlock(b))
+	}
+	return blocks, nil
+}
+
+// jsonListBlockMetadata handles aergo_listBlockMetadata [{Hash, Height, Size, Offset, Asc}]
+func jsonListBlockMetadata(rpc *AergoRPCService, ctx context.Context, params []json.RawMessage) (interface{}, error) {
+	var p jsonListParams
+	if err := parseParams(params, &p); err != nil {
+		return nil, err
+	}
+	lp, err := p.toListParams()
+	if err != nil {
+		return nil, err
+	}
+	list, err := rpc.ListBlockMetadata(ctx, lp)
+	if err != nil {
+		return nil, err
+	}
+	metas := make([]*jsonBlockMetadata, 0, len(list.GetBlocks()))
+	for _, m := range list.GetBlocks() {
+		metas = append(metas, convBlockMetadata(m))
+	}
+	return metas, nil
+}
+
+// jsonGetTx handles aergo_getTx [txHash]. It looks up the mempool first and
+// then the chain.
+func jsonGetTx(rpc *AergoRPCService, ctx context.Context, params []json.RawMessage) (interface{}, error) {
+	hash, err := hashParam(params)
+	if err != nil {
+		return nil, err
+	}
+	if tx, err := rpc.GetTX(ctx, &types.SingleBytes{Value: hash}); err == nil {
+		return util.ConvTxEx(tx, util.Base58), nil
+	}
+	txInBlock, err := rpc.GetBlockTX(ctx, &types.SingleBytes{Value: hash})
+	if err != nil {
+		return nil, err
+	}
+	return util.ConvTxInBlockEx(txInBlock, util.Base58), nil
+}
+
+// jsonGetBlockTx handles aergo_getBlockTx [txHash]
+func jsonGetBlockTx(rpc *AergoRPCService, ctx context.Context, params []json.RawMessage) (interface{}, error) {
+	hash, err := hashParam(params)
+	if err != nil {
+		return nil, err
+	}
+	txInBlock, err := rpc.GetBlockTX(ctx, &types.SingleBytes{Value: hash})
+	if err != nil {
+		return nil, err
+	}
+	return util.ConvTxInBlockEx(txInBlock, util.Base58), nil
+}
+
+// jsonGetReceipt handles aergo_getReceipt [txHash]
+func jsonGetReceipt(rpc *AergoRPCService, ctx context.Context, params []json.RawMessage) (interface{}, error) {
+	hash, err := hashParam(params)
+	if err != nil {
+		return nil, err
+	}
+	receipt, err := rpc.GetReceipt(ctx, &types.SingleBytes{Value: hash})
+	if err != nil {
+		return nil, err
+	}
+	return pbJSON(receipt)
+}
+
+func hashParam(params []json.RawMessage) ([]byte, error) {
+	if err := requireParams(params, 1); err != nil {
+		return nil, err
+	}
+	var s string
+	if err := parseParams(params, &s); err != nil {
+		return nil, err
+	}
+	return decodeHash(s)
+}
+
+func accountParam(params []json.RawMessage) ([]byte, error) {
+	if err := requireParams(params, 1); err != nil {
+		return nil, err
+	}
+	var s string
+	if err := parseParams(params, &s); err != nil {
+		return nil, err
+	}
+	return decodeAccount(s)
+}
+
+type jsonCommitResult struct {
+	Hash   string `json:"hash"`
+	Error  string `json:"error"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// jsonSendTx handles aergo_sendTx [tx] where tx is a signed transaction or a
+// list of them in the format of aergocli.
+func jsonSendTx(rpc *AergoRPCService, ctx context.Context, params []json.RawMessage) (interface{}, error) {
+	if err := requireParams(params, 1); err != nil {
+		return nil, err
+	}
+	if len(params) > 1 {
+		return nil, newJSONRPCError(jsonrpcInvalidParams, "too many params")
+	}
+	txs, err := util.ParseBase58Tx(params[0])
+	if err != nil {
+		return nil, newJSONRPCError(jsonrpcInvalidParams, "invalid tx: %s", err.Error())
+	}
+	rsp, err := rpc.CommitTX(ctx, &types.TxList{Txs: txs})
+	if err != nil {
+		return nil, err
+	}
+	results := make([]*jsonCommitResult, 0, len(rsp.GetResults()))
+	for _, r := range rsp.GetResults() {
+		results = append(results, &jsonCommitResult{
+			Hash:   base58.Encode(r.GetHash()),
+			Error:  r.GetError().String(),
+			Detail: r.GetDetail(),
+		})
+	}
+	if params[0][0] != '[' && len(results) == 1 {
+		return results[0], nil
+	}
+	return results, nil
+}
+
+// jsonGetState handles aergo_getState [address]
+func jsonGetState(rpc *AergoRPCService, ctx context.Context, params []json.RawMessage) (interface{}, error) {
+	addr, err := accountParam(params)
+	if err != nil {
+		return nil, err
+	}
+	state, err := rpc.GetState(ctx, &types.SingleBytes{Value: addr})
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"account": types.EncodeAddress(addr),
+		"nonce":   state.GetNonce(),
+		"balance": state.GetBalanceBigInt().String(),
+	}, nil
+}
+
+// jsonGetStaking handles aergo_getStaking [address]
+func jsonGetStaking(rpc *AergoRPCService, ctx context.Context, params []json.RawMessage) (interface{}, error) {
+	addr, err := accountParam(params)
+	if err != nil {
+		return nil, err
+	}
+	staking, err := rpc.GetStaking(ctx, &types.AccountAddress{Value: addr})
+	if err != nil {
+		return nil, err
+	}
+	unbondings := make([]map[string]interface{}, 0, len(staking.GetUnbondings()))
+	for _, u := range staking.GetUnbondings() {
+		unbondings = append(unbondings, map[string]interface{}{
+			"amount":  amountString(u.GetAmount()),
+			"release": u.GetRelease(),
+		})
+	}
+	return map[string]interface{}{
+		"account":   types.EncodeAddress(addr),
+		"staked":    amountString(staking.GetAmount()),
+		"when":      staking.GetWhen(),
+		"unbonding": unbondings,
+	}, nil
+}
+
+// jsonGetAccountVotes handles aergo_getAccountVotes [address]
+func jsonGetAccountVotes(rpc *AergoRPCService, ctx context.Context, params []json.RawMessage) (interface{}, error) {
+	addr, err := accountParam(params)
+	if err != nil {
+		return nil, err
+	}
+	votes, err := rpc.GetAccountVotes(ctx, &types.AccountAddress{Value: addr})
+	if err != nil {
+		return nil, err
+	}
+	return pbJSON(votes)
+}
+
+// jsonGetVotes handles aergo_getVotes [id, count]
+func jsonGetVotes(rpc *AergoRPCService, ctx context.Context, params []json.RawMessage) (interface{}, error) {
+	in := &types.VoteParams{}
+	if err := parseParams(params, &in.Id, &in.Count); err != nil {
+		return nil, err
+	}
+	votes, err := rpc.GetVotes(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return pbJSON(votes)
+}
+
+// jsonGetNameInfo handles aergo_getNameInfo [name, blockNo]
+func jsonGetNameInfo(rpc *AergoRPCService, ctx context.Context, params []json.RawMessage) (interface{}, error) {
+	if err := requireParams(params, 1); err != nil {
+		return nil, err
+	}
+	in := &types.Name{}
+	if err := parseParams(params, &in.Name, &in.BlockNo); err != nil {
+		return nil, err
+	}
+	info, err := rpc.GetNameInfo(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"name":        info.GetName().GetName(),
+		"owner":       types.EncodeAddress(info.GetOwner()),
+		"destination": types.EncodeAddress(info.GetDestination()),
+		"expireNo":    info.GetExpireNo(),
+		"status":      info.GetStatus(),
+	}, nil
+}
+
+// jsonGetABI handles aergo_getABI [address]
+func jsonGetABI(rpc *AergoRPCService, ctx context.Context, params []json.RawMessage) (interface{}, error) {
+	addr, err := accountParam(params)
+	if err != nil {
+		return nil, err
+	}
+	abi, err := rpc.GetABI(ctx, &types.SingleBytes{Value: addr})
+	if err != nil {
+		return nil, err
+	}
+	return pbJSON(abi)
+}
+
+// jsonQueryContract handles aergo_queryContract [address, {"Name": fn, "Args": [...]}]
+func jsonQueryContract(rpc *AergoRPCService, ctx context.Context, params []json.RawMessage) (interface{}, error) {
+	if err := requireParams(params, 2); err != nil {
+		return nil, err
+	}
+	addr, err := accountParam(params[:1])
+	if err != nil {
+		return nil, err
+	}
+	rsp, err := rpc.QueryContract(ctx, &types.Query{ContractAddress: addr, Queryinfo: params[1]})
+	if err != nil {
+		return nil, err
+	}
+	return rawJSON(rsp.GetValue()), nil
+}
+
+// jsonVerifyContract handles aergo_verifyContract [address, source, compiler_version?]
+func jsonVerifyContract(rpc *AergoRPCService, ctx context.Context, params []json.RawMessage) (interface{}, error) {
+	if err := requireParams(params, 2); err != nil {
+		return nil, err
+	}
+	var address string
+	in := &types.ContractSource{}
+	if err := parseParams(params, &address, &in.Source, &in.CompilerVersion); err != nil {
+		return nil, err
+	}
+	addr, err := decodeAccount(address)
+	if err != nil {
+		return nil, err
+	}
+	in.ContractAddress = addr
+	v, err := rpc.VerifyContractSource(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return pbJSON(v)
+}
+
+type jsonFilter struct {
+	ContractAddress string
+	EventName       string
+	Blockfrom       uint64
+	Blockto         uint64
+	Desc            bool
+	ArgFilter       json.RawMessage
+	RecentBlockCnt  int32
+}
+
+func (f *jsonFilter) toFilterInfo() (*types.FilterInfo, error) {
+	addr, err := decodeAccount(f.ContractAddress)
+	if err != nil {
+		return nil, err
+	}
+	return &types.FilterInfo{
+		ContractAddress: addr,
+		EventName:       f.EventName,
+		Blockfrom:       f.Blockfrom,
+		Blockto:         f.Blockto,
+		Desc:            f.Desc,
+		ArgFilter:       f.ArgFilter,
+		RecentBlockCnt:  f.RecentBlockCnt,
+	}, nil
+}
+
+func filterParam(params []json.RawMessage) (*types.FilterInfo, error) {
+	if err := requireParams(params, 1); err != nil {
+		return nil, err
+	}
+	var f jsonFilter
+	if err := parseParams(params, &f); err != nil {
+		return nil, err
+	}
+	return f.toFilterInfo()
+}
+
+// jsonListEvents handles aergo_listEvents [{ContractAddress, EventName, ...}]
+func jsonListEvents(rpc *AergoRPCService, ctx context.Context, params []json.RawMessage) (interface{}, error) {
+	filter, err := filterParam(params)
+	if err != nil {
+		return nil, err
+	}
+	events, err := rpc.ListEvents(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return pbJSON(events)
+}
+
+// jsonGetPeers handles aergo_getPeers [noHidden, showSelf]
+func jsonGetPeers(rpc *AergoRPCService, ctx context.Context, params []json.RawMessage) (interface{}, error) {
+	in := &types.PeersParams{}
+	if err := parseParams(params, &in.NoHidden, &in.ShowSelf); err != nil {
+		return nil, err
+	}
+	list, err := rpc.GetPeers(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	peers := make([]*util.InOutPeer, 0, len(list.GetPeers()))
+	for _, p := range list.GetPeers() {
+		peers = append(peers, util.ConvPeer(p))
+	}
+	return peers, nil
+}
+
+// jsonNodeState handles aergo_nodeState [component, timeout]
+func jsonNodeState(rpc *AergoRPCService, ctx context.Context, params []json.RawMessage) (interface{}, error) {
+	var (
+		component string
+		timeout   uint64 = 3
+	)
+	if err := parseParams(params, &component, &timeout); err != nil {
+		return nil, err
+	}
+	rsp, err := rpc.NodeState(ctx, &types.NodeReq{Component: []byte(component), Timeout: blockNoToBytes(timeout)})
+	if err != nil {
+		return nil, err
+	}
+	return rawJSON(rsp.GetValue()), nil
+}
+
+// jsonGetEnterpriseConfig handles aergo_getEnterpriseConfig [key]
+func jsonGetEnterpriseConfig(rpc *AergoRPCService, ctx context.Context, params []json.RawMessage) (interface{}, error) {
+	if err := requireParams(params, 1); err != nil {
+		return nil, err
+	}
+	in := &types.EnterpriseConfigKey{}
+	if err := parseParams(params, &in.Key); err != nil {
+		return nil, err
+	}
+	conf, err := rpc.GetEnterpriseConfig(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return pbJSON(conf)
+}
+
+// Subscription kinds of aergo_subscribe.
+const (
+	subNewBlocks        = "newBlocks"
+	subNewBlockMetadata = "newBlockMetadata"
+	subEvents           = "events"
+)
+
+// subscriptionMethods are the streaming rpc methods serving the subscriptions.
+var subscriptionMethods = map[string]string{
+	subNewBlocks:        "ListBlockStream",
+	subNewBlockMetadata: "ListBlockMetadataStream",
+	subEvents:           "ListEventStream",
+}
+
+// subscriptionRPCMethod returns the streaming rpc method serving the
+// subscription requested by the params of aergo_subscribe.
+func subscriptionRPCMethod(params []json.RawMessage) (string, error) {
+	if err := requireParams(params, 1); err != nil {
+		return "", err
+	}
+	var kind string
+	if err := json.Unmarshal(params[0], &kind); err != nil {
+		return "", newJSONRPCError(jsonrpcInvalidParams, "invalid subscription kind")
+	}
+	method, exist := subscriptionMethods[kind]
+	if !exist {
+		return "", newJSONRPCError(jsonrpcInvalidParams, "unknown subscription kind: %s", kind)
+	}
+	return method, nil
+}
+
+// jsonSubscribe handles aergo_subscribe [kind, filter]. kind is one of
+// newBlocks, newBlockMetadata and events. filter is required only for
+// events. The notifications are sent by the aergo_subscription method.
+func jsonSubscribe(s *jsonrpcServer, c *wsConn, ctx context.Context, params []json.RawMessage) (interface{}, error) {
+	// The kind is already checked by subscriptionRPCMethod before the call.
+	var kind string
+	_ = json.Unmarshal(params[0], &kind)
+	if err := s.rpc.checkAuth(ctx, ReadBlockChain); err != nil {
+		return nil, err
+	}
+
+	id := s.newSubscriptionID()
+	subCtx, cancel := context.WithCancel(ctx)
+	stream := &wsStream{
+		ctx: subCtx,
+		notify: func(result interface{}) error {
+			return c.notify(id, result)
+		},
+	}
+
+	var run func()
+	switch kind {
+	case subNewBlocks:
+		run = func() { s.rpc.ListBlockStream(&types.Empty{}, &wsBlockStream{stream}) }
+	case subNewBlockMetadata:
+		run = func() { s.rpc.ListBlockMetadataStream(&types.Empty{}, &wsBlockMetadataStream{stream}) }
+	case subEvents:
+		filter, err := filterParam(params[1:])
+		if err == nil {
+			if err = filter.ValidateCheck(0); err == nil {
+				_, err = filter.GetExArgFilter()
+			}
+		}
+		if err != nil {
+			cancel()
+			return nil, toJSONRPCError(err)
+		}
+		run = func() { s.rpc.ListEventStream(filter, &wsEventStream{stream}) }
+	}
+	c.subscribe(id, cancel, run)
+	return id, nil
+}
+
+// jsonUnsubscribe handles aergo_unsubscribe [subscriptionID]
+func jsonUnsubscribe(s *jsonrpcServer, c *wsConn, ctx context.Context, params []json.RawMessage) (interface{}, error) {
+	if err := requireParams(params, 1); err != nil {
+		return nil, err
+	}
+	var id string
+	if err := parseParams(params, &id); err != nil {
+		return nil, err
+	}
+	return c.unsubscribe(id), nil
+}
+
+type wsBlockStream struct{ *wsStream }
+
+func (s *wsBlockStream) Send(block *types.Block) error {
+	return s.notify(util.ConvBlock(block))
+}
+
+type wsBlockMetadataStream struct{ *wsStream }
+
+func (s *wsBlockMetadataStream) Send(meta *types.BlockMetadata) error {
+	return s.notify(convBlockMetadata(meta))
+}
+
+type wsEventStream struct{ *wsStream }
+
+func (s *wsEventStream) Send(event *types.Event) error {
+	b, err := pbJSON(event)
+	if err != nil {
+		return err
+	}
+	return s.notify(b)
+}
@@ -7,11 +7,13 @@ package rpc
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"math"
 	"net"
 	"net/http"
 	"reflect"
@@ -71,9 +73,25 @@ func NewRPC(cfg *config.Config, chainAccessor types.ChainAccessor, version strin
 		grpc.MaxRecvMsgSize(1024 * 1024 * 256),
 	}
 
+	var unaryInterceptors []grpc.UnaryServerInterceptor
+	var streamInterceptors []grpc.StreamServerInterceptor
 	if cfg.RPC.NetServiceTrace {
-		opts = append(opts, grpc.UnaryInterceptor(otgrpc.OpenTracingServerInterceptor(tracer)))
-		opts = append(opts, grpc.StreamInterceptor(otgrpc.OpenTracingStreamServerInterceptor(tracer)))
+		unaryInterceptors = append(unaryInterceptors, otgrpc.OpenTracingServerInterceptor(tracer))
+		streamInterceptors = append(streamInterceptors, otgrpc.OpenTracingStreamServerInterceptor(tracer))
+	}
+	if len(cfg.RPC.NSAuthPolicy) > 0 {
+		ta, err := loadTokenAuth(cfg.RPC.NSAuthPolicy)
+		if err != nil {
+			logger.Fatal().Err(err).Str("file", cfg.RPC.NSAuthPolicy).Msg("could not load rpc auth policy")
+		}
+		actualServer.tokenAuth = ta
+		unaryInterceptors = append(unaryInterceptors, actualServer.unaryAuthInterceptor)
+		streamInterceptors = append(streamInterceptors, actualServer.streamAuthInterceptor)
+		logger.Info().Str("file", cfg.RPC.NSAuthPolicy).Msg("rpc token authentication enabled")
+	}
+	if len(unaryInterceptors) > 0 {
+		opts = append(opts, grpc.UnaryInterceptor(chainUnaryInterceptors(unaryInterceptors)))
+		opts = append(opts, grpc.StreamInterceptor(chainStreamInterceptors(streamInterceptors)))
 	}
 
 	var entConf *types.EnterpriseConfig
@@ -135,8 +153,16 @@ func NewRPC(cfg *config.Config, chainAccessor types.ChainAccessor, version strin
 	actualServer.actorHelper = rpcsvc
 	actualServer.setClientAuth(entConf)
 
+	var otherHandler http.Handler = http.DefaultServeMux
+	if cfg.RPC.NSEnableJSONRPC {
+		mux := http.NewServeMux()
+		mux.Handle("/jsonrpc", newJSONRPCServer(actualServer, cfg.RPC.NSJSONRPCOrigins))
+		mux.Handle("/", http.DefaultServeMux)
+		otherHandler = mux
+	}
+
 	rpcsvc.httpServer = &http.Server{
-		Handler:        rpcsvc.grpcWebHandlerFunc(grpcWebServer, http.DefaultServeMux),
+		Handler:        rpcsvc.grpcWebHandlerFunc(grpcWebServer, otherHandler),
 		ReadTimeout:    4 * time.Second,
 		WriteTimeout:   4 * time.Second,
 		MaxHeaderBytes: 1 << 20,
@@ -339,10 +365,63 @@ func (ns *RPC) CollectServerInfo(categories []string) *types.ServerInfo {
 	}
 	configInfo := make(map[string]*types.ConfigItem)
 	types.AddCategory(configInfo, "base").AddBool("personal", ns.conf.BaseConfig.Personal)
-	types.AddCategory(configInfo, "account").AddInt("unlocktimeout", int(ns.conf.Account.UnlockTimeout))
+	types.AddCategory(configInfo, "account").AddInt("unlocktimeout", int(ns.conf.Account.UnlockTimeout)).
+		Add("keystore", ns.conf.Account.Keystore)
+	if ns.ca != nil && ns.conf.Hardfork != nil {
+		if best, err := ns.ca.GetBestBlock(); err == nil {
+			addHardforkInfo(configInfo, ns.conf.Hardfork, best.BlockNo())
+		}
+	}
 	return &types.ServerInfo{Status: statusInfo, Config: configInfo}
 }
 
+// addHardforkInfo adds the hardfork version of the best block and the state
+// of every feature known to the node: "active:<block no>" from the block,
+// "scheduled:<block no>" at a future block or "unscheduled".
+func addHardforkInfo(configInfo map[string]*types.ConfigItem, hf *config.HardforkConfig, best types.BlockNo) {
+	types.AddCategory(configInfo, "hardfork").AddInt("version", int(hf.Version(best)))
+	features := types.AddCategory(configInfo, "feature")
+	for _, f := range types.Features() {
+		bno := hf.ForkNo(f.Version())
+		switch {
+		case hf.IsFeatureActive(f, best):
+			features.Add(string(f), fmt.Sprintf("active:%d", bno))
+		case bno != math.MaxUint64:
+			features.Add(string(f), fmt.Sprintf("scheduled:%d", bno))
+		default:
+			features.Add(string(f), "unscheduled")
+		}
+	}
+}
+
+// chainUnaryInterceptors returns an interceptor calling interceptors in
+// order.
+func chainUnaryInterceptors(interceptors []grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		for i := len(interceptors) - 1; i > 0; i-- {
+			next, h := interceptors[i], handler
+			handler = func(ctx context.Context, req interface{}) (interface{}, error) {
+				return next(ctx, req, info, h)
+			}
+		}
+		return interceptors[0](ctx, req, info, handler)
+	}
+}
+
+// chainStreamInterceptors returns an interceptor calling interceptors in
+// order.
+func chainStreamInterceptors(interceptors []grpc.StreamServerInterceptor) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		for i := len(interceptors) - 1; i > 0; i-- {
+			next, h := interceptors[i], handler
+			handler = func(srv interface{}, ss grpc.ServerStream) error {
+				return next(srv, ss, info, h)
+			}
+		}
+		return interceptors[0](srv, ss, info, handler)
+	}
+}
+
 const defaultTTL = time.Second * 4
 
 // TellRequest implement interface method of ActorService
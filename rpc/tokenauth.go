@@ -0,0 +1,461 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aergoio/aergo/types"
+	"github.com/hashicorp/golang-lru/simplelru"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// anonymousRole is the role applied to the calls without a token, if it is
+// defined in the policy.
+const anonymousRole = "anonymous"
+
+// maxRateLimiters bounds the number of the rate limiters kept for the callers.
+// The least recently used one is dropped when it is exceeded.
+const maxRateLimiters = 10000
+
+// authPolicy is the token authentication policy loaded from the file set by
+// the nsauthpolicy configuration. An example:
+//
+//	{
+//	  "jwt": {"alg": "HS256", "secret": "..."},
+//	  "roles": {
+//	    "reader": {"rules": [{"permissions": "R"}], "rateLimit": 10, "burst": 20},
+//	    "sender": {"rules": [{"permissions": "R"},
+//	                         {"methods": ["SendTX", "CommitTX"], "accounts": ["Amg..."]}]}
+//	  },
+//	  "apiKeys": [{"name": "customer1", "keyHash": "<hex sha256 of the key>", "role": "sender"}]
+//	}
+type authPolicy struct {
+	JWT     *jwtConfig           `json:"jwt"`
+	Roles   map[string]*authRole `json:"roles"`
+	APIKeys []*apiKey            `json:"apiKeys"`
+}
+
+// jwtConfig sets the verification key of the JWTs. HS256 uses secret and
+// RS256 or ES256 uses the PEM encoded public key in the file publicKey.
+type jwtConfig struct {
+	Alg       string `json:"alg"`
+	Secret    string `json:"secret"`
+	PublicKey string `json:"publicKey"`
+}
+
+type authRole struct {
+	Rules []*authRule `json:"rules"`
+	// RateLimit is the number of calls allowed per second for each key or
+	// token subject. Zero means no limit.
+	RateLimit float64 `json:"rateLimit"`
+	Burst     int     `json:"burst"`
+}
+
+// authRule allows the methods matching Methods, which are method names or
+// patterns like "Get*", and the methods requiring one of Permissions, which
+// are the letters used in RPCPERMISSIONS. If Accounts is set, the
+// transactions sent by the allowed methods must be signed by one of them.
+type authRule struct {
+	Methods     []string `json:"methods"`
+	Permissions string   `json:"permissions"`
+	Accounts    []string `json:"accounts"`
+
+	permission Authentication
+	accounts   [][]byte
+}
+
+type apiKey struct {
+	Name    string `json:"name"`
+	KeyHash string `json:"keyHash"`
+	Role    string `json:"role"`
+}
+
+type jwtClaims struct {
+	Subject   string `json:"sub"`
+	Role      string `json:"role"`
+	ExpiresAt int64  `json:"exp"`
+	NotBefore int64  `json:"nbf"`
+}
+
+// principal is the authenticated caller of a method.
+type principal struct {
+	name    string
+	role    *authRole
+	limiter *rateLimiter
+}
+
+type principalKey struct{}
+
+// tokenAuth authenticates the calls with a bearer token, which is a JWT or an
+// API key, and authorizes them by the role of the token.
+type tokenAuth struct {
+	roles     map[string]*authRole
+	keys      map[string]*apiKey
+	jwtAlg    string
+	jwtVerify func(signed, sig []byte) error
+
+	limiterLock sync.Mutex
+	limiters    *simplelru.LRU
+}
+
+func loadTokenAuth(policyFile string) (*tokenAuth, error) {
+	data, err := ioutil.ReadFile(policyFile)
+	if err != nil {
+		return nil, err
+	}
+	var policy authPolicy
+	if err := json.Unmarshal(data, &policy); err != nil {
+		return nil, fmt.Errorf("invalid auth policy: %s", err.Error())
+	}
+	return newTokenAuth(&policy, filepath.Dir(policyFile))
+}
+
+func newTokenAuth(policy *authPolicy, baseDir string) (*tokenAuth, error) {
+	limiters, err := simplelru.NewLRU(maxRateLimiters, nil)
+	if err != nil {
+		return nil, err
+	}
+	ta := &tokenAuth{
+		roles:    policy.Roles,
+		keys:     make(map[string]*apiKey),
+		limiters: limiters,
+	}
+	if ta.roles == nil {
+		ta.roles = make(map[string]*authRole)
+	}
+	for name, role := range ta.roles {
+		for _, rule := range role.Rules {
+			rule.permission = parsePermission(rule.Permissions)
+			for _, m := range rule.Methods {
+				if _, err := path.Match(m, ""); err != nil {
+					return nil, fmt.Errorf("invalid method pattern %s of role %s", m, name)
+				}
+			}
+			for _, a := range rule.Accounts {
+				account, err := types.DecodeAddress(a)
+				if err != nil {
+					return nil, fmt.Errorf("invalid account %s of role %s: %s", a, name, err.Error())
+				}
+				rule.accounts = append(rule.accounts, account)
+			}
+		}
+	}
+	for _, key := range policy.APIKeys {
+		if _, exist := ta.roles[key.Role]; !exist {
+			return nil, fmt.Errorf("unknown role %s of api key %s", key.Role, key.Name)
+		}
+		h, err := hex.DecodeString(key.KeyHash)
+		if err != nil || len(h) != sha256.Size {
+			return nil, fmt.Errorf("invalid key hash of api key %s", key.Name)
+		}
+		ta.keys[hex.EncodeToString(h)] = key
+	}
+	if policy.JWT != nil {
+		if err := ta.setJWTVerifier(policy.JWT, baseDir); err != nil {
+			return nil, err
+		}
+	}
+	return ta, nil
+}
+
+func (ta *tokenAuth) setJWTVerifier(conf *jwtConfig, baseDir string) error {
+	ta.jwtAlg = conf.Alg
+	if conf.Alg == "HS256" {
+		if len(conf.Secret) == 0 {
+			return errors.New("jwt secret is empty")
+		}
+		secret := []byte(conf.Secret)
+		ta.jwtVerify = func(signed, sig []byte) error {
+			mac := hmac.New(sha256.New, secret)
+			mac.Write(signed)
+			if !hmac.Equal(mac.Sum(nil), sig) {
+				return errors.New("invalid signature")
+			}
+			return nil
+		}
+		return nil
+	}
+
+	keyFile := conf.PublicKey
+	if !filepath.IsAbs(keyFile) {
+		keyFile = filepath.Join(baseDir, keyFile)
+	}
+	data, err := ioutil.ReadFile(keyFile)
+	if err != nil {
+		return err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return errors.New("invalid jwt public key")
+	}
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return err
+	}
+	switch conf.Alg {
+	case "RS256":
+		key, ok := pub.(*rsa.PublicKey)
+		if !ok {
+			return errors.New("jwt public key is not a RSA key")
+		}
+		ta.jwtVerify = func(signed, sig []byte) error {
+			h := sha256.Sum256(signed)
+			return rsa.VerifyPKCS1v15(key, crypto.SHA256, h[:], sig)
+		}
+	case "ES256":
+		key, ok := pub.(*ecdsa.PublicKey)
+		if !ok {
+			return errors.New("jwt public key is not a ECDSA key")
+		}
+		ta.jwtVerify = func(signed, sig []byte) error {
+			if len(sig) != 64 {
+				return errors.New("invalid signature")
+			}
+			h := sha256.Sum256(signed)
+			r := new(big.Int).SetBytes(sig[:32])
+			s := new(big.Int).SetBytes(sig[32:])
+			if !ecdsa.Verify(key, h[:], r, s) {
+				return errors.New("invalid signature")
+			}
+			return nil
+		}
+	default:
+		return fmt.Errorf("unsupported jwt algorithm: %s", conf.Alg)
+	}
+	return nil
+}
+
+// authenticate returns the principal of token. A token with three parts
+// separated by dots is a JWT and the others are API keys.
+func (ta *tokenAuth) authenticate(token string) (*principal, error) {
+	if strings.Count(token, ".") == 2 {
+		claims, err := ta.verifyJWT(token, time.Now())
+		if err != nil {
+			return nil, status.Errorf(codes.Unauthenticated, "invalid token: %s", err.Error())
+		}
+		role, exist := ta.roles[claims.Role]
+		if !exist {
+			return nil, status.Errorf(codes.Unauthenticated, "unknown role: %s", claims.Role)
+		}
+		return ta.newPrincipal("jwt:"+claims.Subject, role), nil
+	}
+
+	h := sha256.Sum256([]byte(token))
+	key, exist := ta.keys[hex.EncodeToString(h[:])]
+	if !exist {
+		return nil, status.Error(codes.Unauthenticated, "invalid api key")
+	}
+	return ta.newPrincipal("key:"+key.Name, ta.roles[key.Role]), nil
+}
+
+// anonymous returns the principal of a call without a token from addr. It
+// returns nil if the anonymous role is not defined.
+func (ta *tokenAuth) anonymous(addr string) *principal {
+	role, exist := ta.roles[anonymousRole]
+	if !exist {
+		return nil
+	}
+	if host := strings.LastIndex(addr, ":"); host > 0 {
+		addr = addr[:host]
+	}
+	return ta.newPrincipal(anonymousRole+"@"+addr, role)
+}
+
+func (ta *tokenAuth) newPrincipal(name string, role *authRole) *principal {
+	p := &principal{name: name, role: role}
+	if role.RateLimit > 0 {
+		ta.limiterLock.Lock()
+		if limiter, exist := ta.limiters.Get(name); exist {
+			p.limiter = limiter.(*rateLimiter)
+		} else {
+			p.limiter = newRateLimiter(role.RateLimit, role.Burst)
+			ta.limiters.Add(name, p.limiter)
+		}
+		ta.limiterLock.Unlock()
+	}
+	return p
+}
+
+func (ta *tokenAuth) verifyJWT(token string, now time.Time) (*jwtClaims, error) {
+	if ta.jwtVerify == nil {
+		return nil, errors.New("jwt is not allowed")
+	}
+	parts := strings.Split(token, ".")
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	if err := decodeJWTPart(parts[0], &header); err != nil {
+		return nil, err
+	}
+	// The algorithm is fixed by the policy, which prevents a token from
+	// choosing a weaker one like "none".
+	if header.Alg != ta.jwtAlg {
+		return nil, fmt.Errorf("unexpected algorithm: %s", header.Alg)
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, err
+	}
+	if err := ta.jwtVerify([]byte(parts[0]+"."+parts[1]), sig); err != nil {
+		return nil, err
+	}
+	var claims jwtClaims
+	if err := decodeJWTPart(parts[1], &claims); err != nil {
+		return nil, err
+	}
+	if claims.ExpiresAt != 0 && now.Unix() >= claims.ExpiresAt {
+		return nil, errors.New("token expired")
+	}
+	if claims.NotBefore != 0 && now.Unix() < claims.NotBefore {
+		return nil, errors.New("token not valid yet")
+	}
+	return &claims, nil
+}
+
+func decodeJWTPart(part string, v interface{}) error {
+	b, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+// authorize checks whether the principal may call method, which requires
+// the permission auth.
+func (p *principal) authorize(method string, auth Authentication) error {
+	for _, rule := range p.role.Rules {
+		if rule.allows(method, auth) {
+			return nil
+		}
+	}
+	return status.Errorf(codes.PermissionDenied, "%s is not allowed to call %s", p.name, method)
+}
+
+// authorizeAccount checks whether the principal may call method with a
+// transaction signed by account.
+func (p *principal) authorizeAccount(method string, auth Authentication, account []byte) error {
+	for _, rule := range p.role.Rules {
+		if !rule.allows(method, auth) {
+			continue
+		}
+		if len(rule.accounts) == 0 {
+			return nil
+		}
+		for _, a := range rule.accounts {
+			if bytes.Equal(a, account) {
+				return nil
+			}
+		}
+	}
+	return status.Errorf(codes.PermissionDenied, "%s is not allowed to call %s from %s",
+		p.name, method, types.EncodeAddress(account))
+}
+
+func (r *authRule) allows(method string, auth Authentication) bool {
+	if r.permission&auth != 0 {
+		return true
+	}
+	for _, m := range r.Methods {
+		if ok, _ := path.Match(m, method); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *principal) allowCall() error {
+	if p.limiter != nil && !p.limiter.allow(time.Now()) {
+		return status.Errorf(codes.ResourceExhausted, "rate limit exceeded for %s", p.name)
+	}
+	return nil
+}
+
+// rateLimiter is a token bucket refilled by rate tokens per second up to
+// burst.
+type rateLimiter struct {
+	sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimiter(rate float64, burst int) *rateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &rateLimiter{rate: rate, burst: float64(burst), tokens: float64(burst)}
+}
+
+func (l *rateLimiter) allow(now time.Time) bool {
+	l.Lock()
+	defer l.Unlock()
+	if !l.last.IsZero() {
+		l.tokens += now.Sub(l.last).Seconds() * l.rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+	}
+	l.last = now
+	if l.tokens < 1 {
+		return false
+	}
+	l.tokens--
+	return true
+}
+
+// bearerToken returns the token in the authorization metadata of ctx.
+func bearerToken(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return ""
+	}
+	token := strings.TrimSpace(values[0])
+	if len(token) > 7 && strings.EqualFold(token[:7], "bearer ") {
+		token = strings.TrimSpace(token[7:])
+	}
+	return token
+}
+
+func peerAddr(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		return p.Addr.String()
+	}
+	return ""
+}
+
+func principalFromContext(ctx context.Context) *principal {
+	p, _ := ctx.Value(principalKey{}).(*principal)
+	return p
+}
@@ -0,0 +1,223 @@
+package rpc
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aergoio/aergo/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	testJWTSecret = "test secret"
+	testAccount   = "AmNpn7K9wg6wsn6oMkTirQSUNdqtDm94iCrrpP5ZpwCAAxxPrsU2"
+)
+
+func testKeyHash(key string) string {
+	h := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(h[:])
+}
+
+func testJWT(secret string, alg string, claims *jwtClaims) string {
+	header, _ := json.Marshal(map[string]string{"alg": alg, "typ": "JWT"})
+	payload, _ := json.Marshal(claims)
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(signed))
+	return signed + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func newTestTokenAuth(t *testing.T) *tokenAuth {
+	policy := &authPolicy{
+		JWT: &jwtConfig{Alg: "HS256", Secret: testJWTSecret},
+		Roles: map[string]*authRole{
+			"reader": {Rules: []*authRule{{Permissions: "R"}}, RateLimit: 1, Burst: 2},
+			"sender": {Rules: []*authRule{
+				{Permissions: "R"},
+				{Methods: []string{"SendTX", "CommitTX"}, Accounts: []string{testAccount}},
+			}},
+			"admin": {Rules: []*authRule{{Methods: []string{"*"}}}},
+		},
+		APIKeys: []*apiKey{
+			{Name: "reader1", KeyHash: testKeyHash("reader key"), Role: "reader"},
+			{Name: "sender1", KeyHash: testKeyHash("sender key"), Role: "sender"},
+		},
+	}
+	ta, err := newTokenAuth(policy, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ta
+}
+
+func TestNewTokenAuthInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy *authPolicy
+	}{
+		{"unknown role", &authPolicy{APIKeys: []*apiKey{{Name: "k", KeyHash: testKeyHash("k"), Role: "none"}}}},
+		{"invalid key hash", &authPolicy{
+			Roles:   map[string]*authRole{"r": {}},
+			APIKeys: []*apiKey{{Name: "k", KeyHash: "abcd", Role: "r"}},
+		}},
+		{"invalid account", &authPolicy{Roles: map[string]*authRole{
+			"r": {Rules: []*authRule{{Accounts: []string{"AmNpn7K9wg6wsn6oMkTirQSUNdqtDm94iCrrpP5ZpwCAAxxPrsU3"}}}},
+		}}},
+		{"invalid pattern", &authPolicy{Roles: map[string]*authRole{
+			"r": {Rules: []*authRule{{Methods: []string{"[Get"}}}},
+		}}},
+		{"empty secret", &authPolicy{JWT: &jwtConfig{Alg: "HS256"}}},
+		{"unsupported alg", &authPolicy{JWT: &jwtConfig{Alg: "none"}}},
+	}
+	for _, tt := range tests {
+		if _, err := newTokenAuth(tt.policy, ""); err == nil {
+			t.Errorf("%s: newTokenAuth() succeeded", tt.name)
+		}
+	}
+}
+
+func TestTokenAuthenticate(t *testing.T) {
+	ta := newTestTokenAuth(t)
+	now := time.Now().Unix()
+	tests := []struct {
+		name    string
+		token   string
+		want    string
+		wantErr bool
+	}{
+		{"api key", "reader key", "key:reader1", false},
+		{"unknown api key", "other key", "", true},
+		{"jwt", testJWT(testJWTSecret, "HS256", &jwtClaims{Subject: "alice", Role: "admin", ExpiresAt: now + 60}), "jwt:alice", false},
+		{"jwt wrong secret", testJWT("other", "HS256", &jwtClaims{Subject: "alice", Role: "admin"}), "", true},
+		{"jwt wrong alg", testJWT(testJWTSecret, "none", &jwtClaims{Subject: "alice", Role: "admin"}), "", true},
+		{"jwt expired", testJWT(testJWTSecret, "HS256", &jwtClaims{Subject: "alice", Role: "admin", ExpiresAt: now - 1}), "", true},
+		{"jwt not before", testJWT(testJWTSecret, "HS256", &jwtClaims{Subject: "alice", Role: "admin", NotBefore: now + 60}), "", true},
+		{"jwt unknown role", testJWT(testJWTSecret, "HS256", &jwtClaims{Subject: "alice", Role: "none"}), "", true},
+	}
+	for _, tt := range tests {
+		p, err := ta.authenticate(tt.token)
+		if tt.wantErr {
+			if status.Code(err) != codes.Unauthenticated {
+				t.Errorf("%s: authenticate() error = %v, want Unauthenticated", tt.name, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: authenticate() error = %v", tt.name, err)
+		} else if p.name != tt.want {
+			t.Errorf("%s: authenticate() = %s, want %s", tt.name, p.name, tt.want)
+		}
+	}
+}
+
+func TestPrincipalAuthorize(t *testing.T) {
+	ta := newTestTokenAuth(t)
+	account, _ := types.DecodeAddress(testAccount)
+	other := make([]byte, len(account))
+
+	reader, _ := ta.authenticate("reader key")
+	sender, _ := ta.authenticate("sender key")
+	admin := ta.newPrincipal("admin", ta.roles["admin"])
+
+	tests := []struct {
+		name    string
+		p       *principal
+		method  string
+		auth    Authentication
+		account []byte
+		code    codes.Code
+	}{
+		{"reader reads", reader, "GetBlock", ReadBlockChain, nil, codes.OK},
+		{"reader sends", reader, "SendTX", WriteBlockChain, nil, codes.PermissionDenied},
+		{"reader controls", reader, "SetConfItem", ControlNode, nil, codes.PermissionDenied},
+		{"sender sends", sender, "SendTX", WriteBlockChain, account, codes.OK},
+		{"sender sends from other", sender, "SendTX", WriteBlockChain, other, codes.PermissionDenied},
+		{"sender signs", sender, "SignTX", WriteBlockChain, account, codes.PermissionDenied},
+		{"admin controls", admin, "SetConfItem", ControlNode, nil, codes.OK},
+		{"admin sends from any", admin, "CommitTX", WriteBlockChain, other, codes.OK},
+	}
+	for _, tt := range tests {
+		var err error
+		if tt.account != nil {
+			err = tt.p.authorizeAccount(tt.method, tt.auth, tt.account)
+		} else {
+			err = tt.p.authorize(tt.method, tt.auth)
+		}
+		if status.Code(err) != tt.code {
+			t.Errorf("%s: error = %v, want %s", tt.name, err, tt.code)
+		}
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	l := newRateLimiter(2, 3)
+	now := time.Now()
+	for i := 0; i < 3; i++ {
+		if !l.allow(now) {
+			t.Fatalf("call %d not allowed within burst", i)
+		}
+	}
+	if l.allow(now) {
+		t.Error("call allowed over burst")
+	}
+	if !l.allow(now.Add(500 * time.Millisecond)) {
+		t.Error("call not allowed after refill")
+	}
+	if l.allow(now.Add(500 * time.Millisecond)) {
+		t.Error("call allowed over refilled tokens")
+	}
+}
+
+func TestTokenAuthSharedLimiter(t *testing.T) {
+	ta := newTestTokenAuth(t)
+	for i := 0; i < 2; i++ {
+		p, _ := ta.authenticate("reader key")
+		if err := p.allowCall(); err != nil {
+			t.Fatalf("call %d: %v", i, err)
+		}
+	}
+	p, _ := ta.authenticate("reader key")
+	if err := p.allowCall(); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("allowCall() error = %v, want ResourceExhausted", err)
+	}
+}
+
+func TestTokenAuthLimiterBound(t *testing.T) {
+	ta := newTestTokenAuth(t)
+	role := ta.roles["reader"]
+	for i := 0; i < maxRateLimiters+10; i++ {
+		ta.newPrincipal(fmt.Sprintf("jwt:user%d", i), role)
+	}
+	if n := ta.limiters.Len(); n != maxRateLimiters {
+		t.Errorf("limiters = %d, want %d", n, maxRateLimiters)
+	}
+}
+
+func TestBearerToken(t *testing.T) {
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{"Bearer abc", "abc"},
+		{"bearer  abc ", "abc"},
+		{"abc", "abc"},
+	}
+	for _, tt := range tests {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", tt.header))
+		if got := bearerToken(ctx); got != tt.want {
+			t.Errorf("bearerToken(%q) = %q, want %q", tt.header, got, tt.want)
+		}
+	}
+	if got := bearerToken(context.Background()); got != "" {
+		t.Errorf("bearerToken() = %q, want empty", got)
+	}
+}
@@ -60,6 +60,24 @@ func (sdb *ChainStateDB) Init(dbType string, dataDir string, bestBlock *types.Bl
 	return nil
 }
 
+// InitFork initializes the state of a fork of another chain. base is the
+// state database of the chain, which is only read, and root is the state
+// root to fork from. The new states are written to a database in dataDir.
+func (sdb *ChainStateDB) InitFork(base db.DB, dbType string, dataDir string, root []byte, test bool) error {
+	sdb.Lock()
+	defer sdb.Unlock()
+
+	if len(root) != 0 && len(base.Get(root)) == 0 {
+		return fmt.Errorf("state root %s not found", enc.ToString(root))
+	}
+
+	sdb.testmode = test
+	dbPath := common.PathMkdirAll(dataDir, stateName)
+	sdb.store = NewOverlayDB(base, db.NewDB(db.ImplType(dbType), dbPath))
+	sdb.states = NewStateDB(sdb.store, root, sdb.testmode)
+	return nil
+}
+
 // Close saves latest block information of the chain
 func (sdb *ChainStateDB) Close() error {
 	sdb.Lock()
@@ -87,7 +105,11 @@ func (sdb *ChainStateDB) OpenNewStateDB(root []byte) *StateDB {
 	return NewStateDB(sdb.store, root, sdb.testmode)
 }
 
-func (sdb *ChainStateDB) SetGenesis(genesis *types.Genesis, bpInit func(*StateDB, *types.Genesis) error) error {
+// SetGenesis creates the state of the genesis block. The contracts of the
+// genesis are deployed with their code, storage and balance, and then ctrInit
+// calls their constructors if it is not nil.
+func (sdb *ChainStateDB) SetGenesis(genesis *types.Genesis, bpInit func(*StateDB, *types.Genesis) error,
+	ctrInit func(*BlockState, *types.Genesis) error) error {
 	block := genesis.Block()
 	stateDB := sdb.OpenNewStateDB(sdb.GetRoot())
 
@@ -125,6 +147,19 @@ func (sdb *ChainStateDB) SetGenesis(genesis *types.Genesis, bpInit func(*StateDB
 		}
 	}
 
+	for _, c := range genesis.Contracts {
+		balance, err := setGenesisContract(gbState, c)
+		if err != nil {
+			return fmt.Errorf("failed to deploy genesis contract %s: %s", c.Address, err.Error())
+		}
+		genesis.AddBalance(balance)
+	}
+	if len(genesis.Contracts) > 0 && ctrInit != nil {
+		if err := ctrInit(gbState, genesis); err != nil {
+			return err
+		}
+	}
+
 	// save state of genesis block
 	// FIXME don't use chainstate API
 	if err := sdb.Apply(gbState); err != nil {
@@ -136,6 +171,47 @@ func (sdb *ChainStateDB) SetGenesis(genesis *types.Genesis, bpInit func(*StateDB
 	return nil
 }
 
+func setGenesisContract(bs *BlockState, c *types.GenesisContract) (*big.Int, error) {
+	id, err := c.ID()
+	if err != nil {
+		return nil, err
+	}
+	code, err := c.LuaCode()
+	if err != nil {
+		return nil, err
+	}
+	data, err := c.StorageData()
+	if err != nil {
+		return nil, err
+	}
+	balance, err := c.BalanceBigInt()
+	if err != nil {
+		return nil, err
+	}
+
+	aid := types.ToAccountID(id)
+	scs, err := bs.OpenContractStateAccount(aid)
+	if err != nil {
+		return nil, err
+	}
+	if err := scs.SetCode(code); err != nil {
+		return nil, err
+	}
+	for key, value := range data {
+		if err := scs.SetData([]byte(key), value); err != nil {
+			return nil, err
+		}
+	}
+	scs.State.Balance = balance.Bytes()
+	if err := bs.StageContractState(scs); err != nil {
+		return nil, err
+	}
+	if err := bs.PutState(aid, scs.State); err != nil {
+		return nil, err
+	}
+	return balance, nil
+}
+
 // Apply specific blockstate to statedb of main chain
 func (sdb *ChainStateDB) Apply(bstate *BlockState) error {
 	sdb.Lock()
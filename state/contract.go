@@ -37,6 +37,21 @@ func (states *StateDB) StageContractState(st *ContractState) error {
 	return nil
 }
 
+// ContractSnapshot represents revision numbers of the staged contract
+// storages. With Snapshot, it undoes the changes of several txs together.
+type ContractSnapshot map[types.AccountID]Snapshot
+
+// SnapshotContracts returns revision numbers of the staged contract storages
+func (states *StateDB) SnapshotContracts() ContractSnapshot {
+	return states.cache.snapshot()
+}
+
+// RollbackContracts discards changes of the contract storages to revision
+// numbers
+func (states *StateDB) RollbackContracts(revisions ContractSnapshot) error {
+	return states.cache.rollback(revisions)
+}
+
 // GetSystemAccountState returns the ContractState of the AERGO system account.
 func (states *StateDB) GetSystemAccountState() (*ContractState, error) {
 	return states.OpenContractStateAccount(types.ToAccountID([]byte(types.AergoSystem)))
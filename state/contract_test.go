@@ -1,10 +1,13 @@
 package state
 
 import (
+	"encoding/json"
+	"math/big"
 	"os"
 	"testing"
 
 	"github.com/aergoio/aergo-lib/db"
+	"github.com/aergoio/aergo/cmd/aergoluac/encoding"
 	"github.com/aergoio/aergo/types"
 	"github.com/stretchr/testify/assert"
 )
@@ -18,7 +21,7 @@ func initTest(t *testing.T) {
 	stateDB = chainStateDB.GetStateDB()
 	genesis := types.GetTestGenesis()
 
-	err := chainStateDB.SetGenesis(genesis, nil)
+	err := chainStateDB.SetGenesis(genesis, nil, nil)
 	assert.NoError(t, err, "failed init")
 }
 func deinitTest() {
@@ -294,3 +297,40 @@ func TestContractStateRollback(t *testing.T) {
 	res, _ = contractState.GetData(testKey)
 	assert.Nil(t, res)
 }
+
+func TestGenesisContract(t *testing.T) {
+	sdb := NewChainStateDB()
+	_ = sdb.Init(string(db.BadgerImpl), "test", nil, false)
+	defer func() {
+		_ = sdb.Close()
+		_ = os.RemoveAll("test")
+	}()
+
+	code := []byte("bytecode and abi")
+	genesis := types.GetTestGenesis()
+	genesis.Contracts = []*types.GenesisContract{{
+		Address: "sys.token",
+		Code:    encoding.EncodeCode(code),
+		Storage: map[string]json.RawMessage{"name": json.RawMessage(`"token"`)},
+		Balance: "1000",
+	}}
+	var called bool
+	err := sdb.SetGenesis(genesis, nil, func(bs *BlockState, g *types.Genesis) error {
+		called = true
+		return nil
+	})
+	assert.NoError(t, err, "failed to set genesis")
+	assert.True(t, called, "contract init is not called")
+	assert.Equal(t, big.NewInt(1000), genesis.TotalBalance())
+	assert.Equal(t, sdb.GetRoot(), genesis.Block().GetHeader().GetBlocksRootHash())
+
+	contractState, err := sdb.GetStateDB().OpenContractStateAccount(types.ToAccountID([]byte("sys.token")))
+	assert.NoError(t, err, "could not open contract state")
+	res, err := contractState.GetCode()
+	assert.NoError(t, err)
+	assert.Equal(t, code, res)
+	res, err = contractState.GetData([]byte("_sv_name"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte(`"token"`), res)
+	assert.Equal(t, big.NewInt(1000), contractState.GetBalanceBigInt())
+}
@@ -0,0 +1,244 @@
+package state
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/aergoio/aergo-lib/db"
+)
+
+// overlayDB is a copy-on-write database over a read-only base database. All
+// the writes go to the top database, and the keys deleted from the overlay
+// are hidden from the base.
+type overlayDB struct {
+	base, top db.DB
+
+	lock    sync.RWMutex
+	deleted map[string]struct{}
+}
+
+// NewOverlayDB returns a database which reads base and writes top. base is
+// never modified.
+func NewOverlayDB(base, top db.DB) db.DB {
+	return &overlayDB{
+		base:    base,
+		top:     top,
+		deleted: make(map[string]struct{}),
+	}
+}
+
+func (o *overlayDB) Type() string {
+	return o.top.Type()
+}
+
+func (o *overlayDB) Set(key, value []byte) {
+	o.top.Set(key, value)
+	o.undelete(key)
+}
+
+func (o *overlayDB) Delete(key []byte) {
+	o.top.Delete(key)
+	o.markDeleted(key)
+}
+
+func (o *overlayDB) Get(key []byte) []byte {
+	if o.top.Exist(key) {
+		return o.top.Get(key)
+	}
+	if o.isDeleted(key) {
+		return []byte{}
+	}
+	return o.base.Get(key)
+}
+
+func (o *overlayDB) Exist(key []byte) bool {
+	if o.top.Exist(key) {
+		return true
+	}
+	return !o.isDeleted(key) && o.base.Exist(key)
+}
+
+func (o *overlayDB) Iterator(start, end []byte) db.Iterator {
+	it := &overlayIterator{
+		o:       o,
+		top:     o.top.Iterator(start, end),
+		base:    o.base.Iterator(start, end),
+		reverse: bytes.Compare(start, end) == 1,
+	}
+	it.skipDeleted()
+	return it
+}
+
+func (o *overlayDB) NewTx() db.Transaction {
+	return &overlayTx{o: o, tx: o.top.NewTx()}
+}
+
+func (o *overlayDB) NewBulk() db.Bulk {
+	return &overlayBulk{o: o, bulk: o.top.NewBulk()}
+}
+
+func (o *overlayDB) Close() {
+	o.top.Close()
+	o.base.Close()
+}
+
+func (o *overlayDB) isDeleted(key []byte) bool {
+	o.lock.RLock()
+	defer o.lock.RUnlock()
+	_, deleted := o.deleted[string(key)]
+	return deleted
+}
+
+func (o *overlayDB) markDeleted(key []byte) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	o.deleted[string(key)] = struct{}{}
+}
+
+func (o *overlayDB) undelete(key []byte) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	delete(o.deleted, string(key))
+}
+
+// overlayOps records the operations of a transaction or a bulk so that the
+// deletions are applied to the overlay when they are written.
+type overlayOps struct {
+	keys    [][]byte
+	deletes []bool
+}
+
+func (ops *overlayOps) add(key []byte, isDelete bool) {
+	ops.keys = append(ops.keys, key)
+	ops.deletes = append(ops.deletes, isDelete)
+}
+
+func (ops *overlayOps) apply(o *overlayDB) {
+	for i, key := range ops.keys {
+		if ops.deletes[i] {
+			o.markDeleted(key)
+		} else {
+			o.undelete(key)
+		}
+	}
+	ops.reset()
+}
+
+func (ops *overlayOps) reset() {
+	ops.keys, ops.deletes = nil, nil
+}
+
+type overlayTx struct {
+	overlayOps
+	o  *overlayDB
+	tx db.Transaction
+}
+
+func (t *overlayTx) Set(key, value []byte) {
+	t.tx.Set(key, value)
+	t.add(key, false)
+}
+
+func (t *overlayTx) Delete(key []byte) {
+	t.tx.Delete(key)
+	t.add(key, true)
+}
+
+func (t *overlayTx) Commit() {
+	t.tx.Commit()
+	t.apply(t.o)
+}
+
+func (t *overlayTx) Discard() {
+	t.tx.Discard()
+	t.reset()
+}
+
+type overlayBulk struct {
+	overlayOps
+	o    *overlayDB
+	bulk db.Bulk
+}
+
+func (b *overlayBulk) Set(key, value []byte) {
+	b.bulk.Set(key, value)
+	b.add(key, false)
+}
+
+func (b *overlayBulk) Delete(key []byte) {
+	b.bulk.Delete(key)
+	b.add(key, true)
+}
+
+func (b *overlayBulk) Flush() {
+	b.bulk.Flush()
+	b.apply(b.o)
+}
+
+func (b *overlayBulk) DiscardLast() {
+	b.bulk.DiscardLast()
+	b.reset()
+}
+
+// overlayIterator merges the iterators of the top and the base databases. A
+// key in both is read from the top.
+type overlayIterator struct {
+	o         *overlayDB
+	top, base db.Iterator
+	reverse   bool
+}
+
+// fromTop returns true if the current key is taken from the top iterator.
+func (it *overlayIterator) fromTop() bool {
+	if !it.base.Valid() {
+		return true
+	}
+	if !it.top.Valid() {
+		return false
+	}
+	c := bytes.Compare(it.top.Key(), it.base.Key())
+	if it.reverse {
+		c = -c
+	}
+	return c <= 0
+}
+
+func (it *overlayIterator) skipDeleted() {
+	for it.base.Valid() {
+		if it.top.Valid() && bytes.Equal(it.top.Key(), it.base.Key()) {
+			it.base.Next()
+			continue
+		}
+		if it.fromTop() || !it.o.isDeleted(it.base.Key()) {
+			return
+		}
+		it.base.Next()
+	}
+}
+
+func (it *overlayIterator) Next() {
+	if it.fromTop() {
+		it.top.Next()
+	} else {
+		it.base.Next()
+	}
+	it.skipDeleted()
+}
+
+func (it *overlayIterator) Valid() bool {
+	return it.top.Valid() || it.base.Valid()
+}
+
+func (it *overlayIterator) Key() []byte {
+	if it.fromTop() {
+		return it.top.Key()
+	}
+	return it.base.Key()
+}
+
+func (it *overlayIterator) Value() []byte {
+	if it.fromTop() {
+		return it.top.Value()
+	}
+	return it.base.Value()
+}
@@ -0,0 +1,82 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/aergoio/aergo-lib/db"
+	"github.com/aergoio/aergo/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOverlayDB(t *testing.T) {
+	base := db.NewDB(db.MemoryImpl, "")
+	base.Set([]byte("a"), []byte("base a"))
+	base.Set([]byte("b"), []byte("base b"))
+	base.Set([]byte("c"), []byte("base c"))
+
+	o := NewOverlayDB(base, db.NewDB(db.MemoryImpl, ""))
+	o.Set([]byte("b"), []byte("top b"))
+	o.Set([]byte("d"), []byte("top d"))
+	o.Delete([]byte("c"))
+
+	assert.Equal(t, []byte("base a"), o.Get([]byte("a")))
+	assert.Equal(t, []byte("top b"), o.Get([]byte("b")))
+	assert.Empty(t, o.Get([]byte("c")))
+	assert.False(t, o.Exist([]byte("c")))
+	assert.True(t, o.Exist([]byte("d")))
+
+	// the base is not modified
+	assert.Equal(t, []byte("base b"), base.Get([]byte("b")))
+	assert.Equal(t, []byte("base c"), base.Get([]byte("c")))
+	assert.False(t, base.Exist([]byte("d")))
+
+	var keys, values []string
+	for it := o.Iterator(nil, nil); it.Valid(); it.Next() {
+		keys = append(keys, string(it.Key()))
+		values = append(values, string(it.Value()))
+	}
+	assert.Equal(t, []string{"a", "b", "d"}, keys)
+	assert.Equal(t, []string{"base a", "top b", "top d"}, values)
+
+	tx := o.NewTx()
+	tx.Set([]byte("c"), []byte("tx c"))
+	tx.Delete([]byte("a"))
+	tx.Commit()
+	assert.Equal(t, []byte("tx c"), o.Get([]byte("c")))
+	assert.False(t, o.Exist([]byte("a")))
+	assert.Equal(t, []byte("base a"), base.Get([]byte("a")))
+}
+
+func TestChainStateDBInitFork(t *testing.T) {
+	initTest(t)
+	defer deinitTest()
+
+	account := types.ToAccountID([]byte("fork_address"))
+	assert.NoError(t, stateDB.PutState(account, &testStates[0]))
+	assert.NoError(t, stateDB.Update())
+	assert.NoError(t, stateDB.Commit())
+	root := stateDB.GetRoot()
+
+	fork := NewChainStateDB()
+	err := fork.InitFork(chainStateDB.store, string(db.MemoryImpl), "test", []byte("unknown root hash 32 bytes long!"), false)
+	assert.Error(t, err)
+	err = fork.InitFork(chainStateDB.store, string(db.MemoryImpl), "test", root, false)
+	assert.NoError(t, err)
+
+	forkState := fork.GetStateDB()
+	st, err := forkState.GetAccountState(account)
+	assert.NoError(t, err)
+	assert.True(t, stateEquals(&testStates[0], st))
+
+	assert.NoError(t, forkState.PutState(account, &testStates[1]))
+	assert.NoError(t, forkState.Update())
+	assert.NoError(t, forkState.Commit())
+
+	// the forked chain is not modified
+	st, err = stateDB.GetAccountState(account)
+	assert.NoError(t, err)
+	assert.True(t, stateEquals(&testStates[0], st))
+	st, err = forkState.GetAccountState(account)
+	assert.NoError(t, err)
+	assert.True(t, stateEquals(&testStates[1], st))
+}
@@ -40,6 +40,38 @@ func (cache *storageCache) put(key types.AccountID, storage *bufferedStorage) {
 	cache.storages[key] = storage
 }
 
+func (cache *storageCache) snapshot() ContractSnapshot {
+	cache.lock.RLock()
+	defer cache.lock.RUnlock()
+	revisions := ContractSnapshot{}
+	for key, storage := range cache.storages {
+		if storage != nil {
+			revisions[key] = Snapshot(storage.buffer.snapshot())
+		}
+	}
+	return revisions
+}
+
+func (cache *storageCache) rollback(revisions ContractSnapshot) error {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+	for key, storage := range cache.storages {
+		if storage == nil {
+			continue
+		}
+		revision, ok := revisions[key]
+		if !ok {
+			// staged after the snapshot
+			delete(cache.storages, key)
+			continue
+		}
+		if err := storage.buffer.rollback(int(revision)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 type bufferedStorage struct {
 	buffer *stateBuffer
 	trie   *trie.Trie
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"github.com/aergoio/aergo/types"
 )
@@ -21,6 +22,11 @@ func getGenesis(path string) *types.Genesis {
 		fmt.Printf("fail to deserialize %s (error:%s)\n", path, err)
 		return nil
 	}
+	// Embed the code of the contracts in the dump.
+	if err := genesis.ReadContractFiles(filepath.Dir(path)); err != nil {
+		fmt.Printf("fail to read contract files of %s (error:%s)\n", path, err)
+		return nil
+	}
 	return genesis
 }
 
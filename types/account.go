@@ -13,6 +13,23 @@ const AddressLength = 33
 const NameLength = 12
 const EncodedAddressLength = 52
 
+// SubnameLength is the maximum length of the label of a subname.
+const SubnameLength = 12
+
+// ParseSubname splits a subname such as pay.myname123456 into its label and
+// the name it belongs to.
+func ParseSubname(subname string) (label, parent string, ok bool) {
+	i := strings.IndexByte(subname, '.')
+	if i < 1 || i > SubnameLength || len(subname)-i-1 != NameLength {
+		return "", "", false
+	}
+	label, parent = subname[:i], subname[i+1:]
+	if validateAllowedChar([]byte(label)) != nil || validateAllowedChar([]byte(parent)) != nil {
+		return "", "", false
+	}
+	return label, parent, true
+}
+
 //NewAccount alloc new account object
 func NewAccount(addr []byte) *Account {
 	return &Account{
@@ -48,3 +48,19 @@ func TestToAddress(t *testing.T) {
 	addr := ToAddress("")
 	assert.Equal(t, 0, len(addr), "nil")
 }
+
+func TestParseSubname(t *testing.T) {
+	label, parent, ok := ParseSubname("pay.ab1234567890")
+	assert.True(t, ok)
+	assert.Equal(t, "pay", label)
+	assert.Equal(t, "ab1234567890", parent)
+
+	for _, invalid := range []string{
+		AergoSystem, AergoName, AergoEnterprise,
+		"ab1234567890", ".ab1234567890", "pay.ab123", "pay.x.ab1234567890",
+		"toolonglabel1.ab1234567890", "p_y.ab1234567890",
+	} {
+		_, _, ok := ParseSubname(invalid)
+		assert.False(t, ok, invalid)
+	}
+}
@@ -0,0 +1,162 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package types
+
+import (
+	"encoding/json"
+	"math/big"
+)
+
+// BatchMaxOps is the maximum number of operations in a batch tx.
+const BatchMaxOps = 16
+
+// BatchOp is an operation of a batch tx. Every operation is sent by the
+// account of the batch tx.
+type BatchOp struct {
+	Type      TxType
+	Recipient []byte
+	Amount    []byte
+	Payload   []byte
+}
+
+// BatchOpResult is the result of an operation of a batch tx, reported in the
+// receipt of the tx.
+type BatchOpResult struct {
+	Status string `json:"status"`
+	Ret    string `json:"ret,omitempty"`
+}
+
+type batchOpJSON struct {
+	Type      string          `json:"type"`
+	Recipient string          `json:"recipient"`
+	Amount    string          `json:"amount,omitempty"`
+	Payload   json.RawMessage `json:"payload,omitempty"`
+}
+
+// ParseBatch decodes and validates the JSON encoded operations of a batch
+// tx, e.g.
+//
+//	[{"type":"TRANSFER","recipient":"Am...","amount":"1000"},
+//	 {"type":"CALL","recipient":"Am...","payload":{"Name":"inc","Args":[]}}]
+func ParseBatch(payload []byte) ([]*BatchOp, error) {
+	var in []batchOpJSON
+	if err := json.Unmarshal(payload, &in); err != nil {
+		return nil, ErrTxInvalidPayload
+	}
+	if len(in) == 0 || len(in) > BatchMaxOps {
+		return nil, ErrTxInvalidPayload
+	}
+	ops := make([]*BatchOp, len(in))
+	for i, o := range in {
+		txType, ok := TxType_value[o.Type]
+		if !ok {
+			return nil, ErrTxInvalidType
+		}
+		recipient, err := DecodeAddress(o.Recipient)
+		if err != nil {
+			return nil, err
+		}
+		op := &BatchOp{Type: TxType(txType), Recipient: recipient, Payload: o.Payload}
+		if o.Amount != "" {
+			amount, ok := new(big.Int).SetString(o.Amount, 10)
+			if !ok || amount.Sign() < 0 {
+				return nil, ErrTxInvalidAmount
+			}
+			op.Amount = amount.Bytes()
+		}
+		if err := op.validate(); err != nil {
+			return nil, err
+		}
+		ops[i] = op
+	}
+	return ops, nil
+}
+
+// EncodeBatch returns the payload of a batch tx made of ops.
+func EncodeBatch(ops []*BatchOp) ([]byte, error) {
+	out := make([]batchOpJSON, len(ops))
+	for i, op := range ops {
+		out[i] = batchOpJSON{
+			Type:      op.Type.String(),
+			Recipient: EncodeAddress(op.Recipient),
+			Payload:   op.Payload,
+		}
+		if len(op.Amount) != 0 {
+			out[i].Amount = new(big.Int).SetBytes(op.Amount).String()
+		}
+	}
+	return json.Marshal(out)
+}
+
+func (op *BatchOp) validate() error {
+	if len(op.Recipient) == 0 || len(op.Recipient) > AddressLength {
+		return ErrTxInvalidRecipient
+	}
+	if new(big.Int).SetBytes(op.Amount).Cmp(MaxAER) > 0 {
+		return ErrTxInvalidAmount
+	}
+	switch op.Type {
+	case TxType_TRANSFER:
+		if len(op.Payload) != 0 {
+			return ErrTxInvalidPayload
+		}
+	case TxType_CALL, TxType_GOVERNANCE:
+		if len(op.Payload) == 0 {
+			return ErrTxFormatInvalid
+		}
+	default:
+		return ErrTxInvalidType
+	}
+	return nil
+}
+
+// Body returns the tx body with which op is executed as a part of the batch
+// tx body.
+func (op *BatchOp) Body(batch *TxBody) *TxBody {
+	return &TxBody{
+		Nonce:       batch.GetNonce(),
+		Account:     batch.GetAccount(),
+		Recipient:   op.Recipient,
+		Amount:      op.Amount,
+		Payload:     op.Payload,
+		GasLimit:    batch.GetGasLimit(),
+		GasPrice:    batch.GetGasPrice(),
+		Type:        op.Type,
+		ChainIdHash: batch.GetChainIdHash(),
+	}
+}
+
+// GetBatchAmount returns the sum of the amounts of the operations of the
+// batch tx body.
+func (b *TxBody) GetBatchAmount() *big.Int {
+	sum := new(big.Int)
+	ops, err := ParseBatch(b.GetPayload())
+	if err != nil {
+		return sum
+	}
+	for _, op := range ops {
+		sum.Add(sum, new(big.Int).SetBytes(op.Amount))
+	}
+	return sum
+}
+
+func validateBatch(body *TxBody) error {
+	if body.GetRecipient() != nil || len(body.GetAmount()) != 0 {
+		return ErrTxFormatInvalid
+	}
+	ops, err := ParseBatch(body.GetPayload())
+	if err != nil {
+		return err
+	}
+	for _, op := range ops {
+		if op.Type == TxType_GOVERNANCE {
+			if err := validate(op.Body(body)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
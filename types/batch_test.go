@@ -0,0 +1,52 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseBatch(t *testing.T) {
+	const addr = "AmNfacq5A3orqn3MhgkHSncufXEP8gVJgqDy8jTgBphXQeuuaHHF"
+	payload := []byte(`[{"type":"TRANSFER","recipient":"` + addr + `","amount":"1000"},
+		{"type":"CALL","recipient":"` + addr + `","amount":"5","payload":{"Name":"inc","Args":[]}}]`)
+	ops, err := ParseBatch(payload)
+	assert.NoError(t, err)
+	assert.Len(t, ops, 2)
+	assert.Equal(t, TxType_TRANSFER, ops[0].Type)
+	assert.Equal(t, TxType_CALL, ops[1].Type)
+	assert.Equal(t, `{"Name":"inc","Args":[]}`, string(ops[1].Payload))
+
+	encoded, err := EncodeBatch(ops)
+	assert.NoError(t, err)
+	decoded, err := ParseBatch(encoded)
+	assert.NoError(t, err)
+	assert.Equal(t, ops, decoded)
+
+	body := &TxBody{Type: TxType_BATCH, Payload: encoded}
+	assert.Equal(t, "1005", body.GetBatchAmount().String())
+	assert.NoError(t, validateBatch(body))
+	body.Amount = []byte{1}
+	assert.Equal(t, ErrTxFormatInvalid, validateBatch(body))
+
+	for _, bad := range []string{
+		`[]`,
+		`{}`,
+		`[{"type":"DEPLOY","recipient":"` + addr + `","payload":"x"}]`,
+		`[{"type":"TRANSFER","recipient":"` + addr + `","amount":"-1"}]`,
+		`[{"type":"TRANSFER","recipient":"` + addr + `","payload":{}}]`,
+		`[{"type":"CALL","recipient":"` + addr + `"}]`,
+		`[{"type":"TRANSFER","recipient":""}]`,
+	} {
+		_, err := ParseBatch([]byte(bad))
+		assert.Error(t, err, bad)
+	}
+
+	var many []*BatchOp
+	for i := 0; i <= BatchMaxOps; i++ {
+		many = append(many, ops[0])
+	}
+	encoded, _ = EncodeBatch(many)
+	_, err = ParseBatch(encoded)
+	assert.Equal(t, ErrTxInvalidPayload, err)
+}
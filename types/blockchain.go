@@ -16,6 +16,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/aergoio/aergo/fee"
 	"github.com/aergoio/aergo/internal/common"
 	"github.com/aergoio/aergo/internal/enc"
 	"github.com/aergoio/aergo/internal/merkle"
@@ -116,6 +117,10 @@ const (
 	StakingMin
 	GasPrice
 	NamePrice
+	MaxBlockSize
+	StakingDelay
+	UnbondingPeriod
+	VotingRewardAmount
 )
 
 /*
@@ -213,7 +218,7 @@ func BlockNoFromBytes(raw []byte) BlockNo {
 
 type BlockVersionner interface {
 	Version(no BlockNo) int32
-	IsV2Fork(BlockNo) bool
+	IsFeatureActive(f Feature, no BlockNo) bool
 }
 
 type DummyBlockVersionner int32
@@ -222,13 +227,15 @@ func (v DummyBlockVersionner) Version(BlockNo) int32 {
 	return int32(v)
 }
 
-func (v DummyBlockVersionner) IsV2Fork(BlockNo) bool {
+// IsFeatureActive always returns true so that the tests use the latest
+// formats regardless of the dummy version.
+func (v DummyBlockVersionner) IsFeatureActive(Feature, BlockNo) bool {
 	return true
 }
 
 // NewBlock represents to create a block to store transactions.
 func NewBlock(bi *BlockHeaderInfo, blockRoot []byte, receipts *Receipts, txs []*Tx, coinbaseAcc []byte, consensus []byte) *Block {
-	return &Block{
+	block := &Block{
 		Header: &BlockHeader{
 			ChainID:          bi.ChainId,
 			PrevBlockHash:    bi.PrevBlockHash,
@@ -244,6 +251,10 @@ func NewBlock(bi *BlockHeaderInfo, blockRoot []byte, receipts *Receipts, txs []*
 			Txs: txs,
 		},
 	}
+	if bi.BaseFee != nil {
+		block.Header.BaseFee = bi.BaseFee.Bytes()
+	}
+	return block
 }
 
 // Localtime retrurns a time.Time object, which is coverted from block
@@ -290,11 +301,33 @@ func serializeStruct(w io.Writer, s interface{}, stopIndex int) error {
 }
 
 func serializeBH(w io.Writer, bh *BlockHeader) error {
-	return serializeStruct(w, bh, lastIndexOfBH)
+	if err := serializeStruct(w, bh, lastIndexOfBH); err != nil {
+		return err
+	}
+	return writeBaseFee(w, bh)
 }
 
 func serializeBhForDigest(w io.Writer, bh *BlockHeader) error {
-	return serializeStructOmit(w, bh, lastIndexOfBH, "Sign")
+	if err := serializeStructOmit(w, bh, lastIndexOfBH, "Sign"); err != nil {
+		return err
+	}
+	return writeBaseFee(w, bh)
+}
+
+// writeBaseFee adds the base fee to a block header hash. Nothing is written
+// for a block without base fee so that the hashes of the former blocks stay
+// the same. The base fee is prefixed by its length since it follows the
+// variable length consensus field, whose bytes could otherwise be moved to the
+// base fee without changing the hash.
+func writeBaseFee(w io.Writer, bh *BlockHeader) error {
+	if len(bh.BaseFee) == 0 {
+		return nil
+	}
+	if err := binary.Write(w, binary.LittleEndian, uint32(len(bh.BaseFee))); err != nil {
+		return err
+	}
+	_, err := w.Write(bh.BaseFee)
+	return err
 }
 
 func writeBlockHeaderOld(w io.Writer, bh *BlockHeader) error {
@@ -413,6 +446,37 @@ func (block *Block) Sign(privKey crypto.PrivKey) error {
 	return nil
 }
 
+// BlockSigner signs block headers on behalf of a block producer.
+type BlockSigner interface {
+	// PubKey returns the public key of the block producer.
+	PubKey() crypto.PubKey
+	// SignBlockHeader returns the signature of header which is produced for
+	// slot.
+	SignBlockHeader(header *BlockHeader, slot int64) ([]byte, error)
+}
+
+// SignWith sets the public key of signer to block and signs it by using
+// signer.
+func (block *Block) SignWith(signer BlockSigner, slot int64) error {
+	if err := block.setPubKey(signer.PubKey()); err != nil {
+		return err
+	}
+
+	sig, err := signer.SignBlockHeader(block.Header, slot)
+	if err != nil {
+		return err
+	}
+	block.Header.Sign = sig
+
+	if valid, err := block.VerifySign(); err != nil {
+		return err
+	} else if !valid {
+		return ErrInvalidBlockSign
+	}
+
+	return nil
+}
+
 func (bh *BlockHeader) bytesForDigest() ([]byte, error) {
 	var buf bytes.Buffer
 
@@ -545,6 +609,8 @@ func (tx *Tx) CalculateTxHash() []byte {
 	digest.Write(txBody.GasPrice)
 	binary.Write(digest, binary.LittleEndian, txBody.Type)
 	digest.Write(txBody.ChainIdHash)
+	txBody.WriteExpiry(digest)
+	txBody.WriteGasTip(digest)
 	digest.Write(txBody.Sign)
 	return digest.Sum(nil)
 }
@@ -579,6 +645,10 @@ func (tx *Tx) Clone() *Tx {
 		Type:        tx.Body.Type,
 		ChainIdHash: Clone(tx.Body.ChainIdHash).([]byte),
 		Sign:        Clone(tx.Body.Sign).([]byte),
+
+		ValidUntilNo:   tx.Body.ValidUntilNo,
+		ValidUntilTime: tx.Body.ValidUntilTime,
+		GasTip:         Clone(tx.Body.GasTip).([]byte),
 	}
 	res := &Tx{
 		Body: body,
@@ -595,6 +665,73 @@ func (b *TxBody) GetGasPriceBigInt() *big.Int {
 	return new(big.Int).SetBytes(b.GetGasPrice())
 }
 
+// HasExpiry reports whether the tx is valid only up to a block number or a
+// block time.
+func (b *TxBody) HasExpiry() bool {
+	return b.GetValidUntilNo() != 0 || b.GetValidUntilTime() != 0
+}
+
+// IsExpired reports whether the tx can no longer be included in the block
+// numbered no and made at ts (unix nanoseconds). ValidUntilTime is in unix
+// seconds.
+func (b *TxBody) IsExpired(no BlockNo, ts int64) bool {
+	if until := b.GetValidUntilNo(); until != 0 && no > until {
+		return true
+	}
+	if until := b.GetValidUntilTime(); until != 0 && ts/int64(time.Second) > until {
+		return true
+	}
+	return false
+}
+
+// ValidateExpiry checks the expiry of the tx against the block in which it
+// is executed.
+func (b *TxBody) ValidateExpiry(bi *BlockHeaderInfo) error {
+	if !b.HasExpiry() {
+		return nil
+	}
+	if !bi.IsFeatureActive(FeatureTxExpiry) {
+		return ErrTxFormatInvalid
+	}
+	if b.IsExpired(bi.No, bi.Ts) {
+		return ErrTxExpired
+	}
+	return nil
+}
+
+// WriteExpiry adds the expiry to a tx hash. Nothing is written for a tx
+// without expiry so that the hashes of the former txs stay the same.
+func (b *TxBody) WriteExpiry(w io.Writer) {
+	if !b.HasExpiry() {
+		return
+	}
+	binary.Write(w, binary.LittleEndian, b.ValidUntilNo)
+	binary.Write(w, binary.LittleEndian, b.ValidUntilTime)
+}
+
+// GetGasTipBigInt returns the tip per gas paid to the block producer.
+func (b *TxBody) GetGasTipBigInt() *big.Int {
+	return new(big.Int).SetBytes(b.GetGasTip())
+}
+
+// ValidateGasTip checks that the tx has no tip before the base fee is
+// activated.
+func (b *TxBody) ValidateGasTip(version int32) error {
+	if len(b.GetGasTip()) != 0 && !FeatureBaseFee.ActiveAt(version) {
+		return ErrTxFormatInvalid
+	}
+	return nil
+}
+
+// WriteGasTip adds the tip to a tx hash. Nothing is written for a tx without
+// tip so that the hashes of the former txs stay the same.
+func (b *TxBody) WriteGasTip(w io.Writer) {
+	if len(b.GetGasTip()) == 0 {
+		return
+	}
+	w.Write(b.GasTip)
+}
+
 type MovingAverage struct {
 	values []int64
 	size   int
@@ -647,19 +784,31 @@ type BlockHeaderInfo struct {
 	PrevBlockHash []byte
 	ChainId       []byte
 	Version       int32
+	// BaseFee is the gas price of the block once the base fee is activated.
+	BaseFee *big.Int
 }
 
 var EmptyBlockHeaderInfo = &BlockHeaderInfo{}
 
+// IsFeatureActive reports whether the feature is active in the block.
+func (b *BlockHeaderInfo) IsFeatureActive(f Feature) bool {
+	return f.ActiveAt(b.Version)
+}
+
 func NewBlockHeaderInfo(b *Block) *BlockHeaderInfo {
 	cid := b.GetHeader().GetChainID()
 	v := DecodeChainIdVersion(cid)
+	var baseFee *big.Int
+	if bf := b.GetHeader().GetBaseFee(); len(bf) != 0 {
+		baseFee = new(big.Int).SetBytes(bf)
+	}
 	return &BlockHeaderInfo{
 		b.BlockNo(),
 		b.GetHeader().GetTimestamp(),
 		b.GetHeader().GetPrevBlockHash(),
 		cid,
 		v,
+		baseFee,
 	}
 }
 
@@ -678,13 +827,30 @@ func NewBlockHeaderInfoFromPrevBlock(prev *Block, ts int64, bv BlockVersionner)
 	no := prev.GetHeader().GetBlockNo() + 1
 	cid := prev.GetHeader().GetChainID()
 	v := bv.Version(no)
+	var baseFee *big.Int
+	if FeatureBaseFee.ActiveAt(v) {
+		baseFee = NextBaseFee(prev, nil)
+	}
 	return &BlockHeaderInfo{
 		no,
 		ts,
 		prev.GetHash(),
 		MakeChainId(cid, v),
 		v,
+		baseFee,
+	}
+}
+
+// NextBaseFee returns the base fee of the block following prev. It is
+// adjusted by the size of the body of prev and may fall below the gas price
+// down to fee.MinBaseFee. The first block with a base fee starts from
+// gasPrice, the gas price decided by the governance.
+func NextBaseFee(prev *Block, gasPrice *big.Int) *big.Int {
+	bf := prev.GetHeader().GetBaseFee()
+	if len(bf) == 0 {
+		return gasPrice
 	}
+	return fee.NextBaseFee(new(big.Int).SetBytes(bf), int64(proto.Size(prev.GetBody())))
 }
 
 func (b *BlockHeaderInfo) ChainIdHash() []byte {
@@ -28,6 +28,8 @@ const (
 	TxType_TRANSFER      TxType = 4
 	TxType_CALL          TxType = 5
 	TxType_DEPLOY        TxType = 6
+	TxType_MULTISIG      TxType = 7
+	TxType_BATCH         TxType = 8
 )
 
 var TxType_name = map[int32]string{
@@ -38,6 +40,8 @@ var TxType_name = map[int32]string{
 	4: "TRANSFER",
 	5: "CALL",
 	6: "DEPLOY",
+	7: "MULTISIG",
+	8: "BATCH",
 }
 var TxType_value = map[string]int32{
 	"NORMAL":        0,
@@ -47,6 +51,8 @@ var TxType_value = map[string]int32{
 	"TRANSFER":      4,
 	"CALL":          5,
 	"DEPLOY":        6,
+	"MULTISIG":      7,
+	"BATCH":         8,
 }
 
 func (x TxType) String() string {
@@ -123,6 +129,7 @@ type BlockHeader struct {
 	CoinbaseAccount      []byte   `protobuf:"bytes,10,opt,name=coinbaseAccount,proto3" json:"coinbaseAccount,omitempty"`
 	Sign                 []byte   `protobuf:"bytes,11,opt,name=sign,proto3" json:"sign,omitempty"`
 	Consensus            []byte   `protobuf:"bytes,12,opt,name=consensus,proto3" json:"consensus,omitempty"`
+	BaseFee              []byte   `protobuf:"bytes,13,opt,name=baseFee,proto3" json:"baseFee,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -236,6 +243,13 @@ func (m *BlockHeader) GetConsensus() []byte {
 	return nil
 }
 
+func (m *BlockHeader) GetBaseFee() []byte {
+	if m != nil {
+		return m.BaseFee
+	}
+	return nil
+}
+
 type BlockBody struct {
 	Txs                  []*Tx    `protobuf:"bytes,1,rep,name=txs,proto3" json:"txs,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -369,6 +383,9 @@ type TxBody struct {
 	Type                 TxType   `protobuf:"varint,8,opt,name=type,proto3,enum=types.TxType" json:"type,omitempty"`
 	ChainIdHash          []byte   `protobuf:"bytes,9,opt,name=chainIdHash,proto3" json:"chainIdHash,omitempty"`
 	Sign                 []byte   `protobuf:"bytes,10,opt,name=sign,proto3" json:"sign,omitempty"`
+	ValidUntilNo         uint64   `protobuf:"varint,11,opt,name=validUntilNo,proto3" json:"validUntilNo,omitempty"`
+	ValidUntilTime       int64    `protobuf:"varint,12,opt,name=validUntilTime,proto3" json:"validUntilTime,omitempty"`
+	GasTip               []byte   `protobuf:"bytes,13,opt,name=gasTip,proto3" json:"gasTip,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -468,6 +485,27 @@ func (m *TxBody) GetSign() []byte {
 	return nil
 }
 
+func (m *TxBody) GetValidUntilNo() uint64 {
+	if m != nil {
+		return m.ValidUntilNo
+	}
+	return 0
+}
+
+func (m *TxBody) GetValidUntilTime() int64 {
+	if m != nil {
+		return m.ValidUntilTime
+	}
+	return 0
+}
+
+func (m *TxBody) GetGasTip() []byte {
+	if m != nil {
+		return m.GasTip
+	}
+	return nil
+}
+
 // TxIdx specifies a transaction's block hash and index within the block body
 type TxIdx struct {
 	BlockHash            []byte   `protobuf:"bytes,1,opt,name=blockHash,proto3" json:"blockHash,omitempty"`
@@ -1276,6 +1314,7 @@ type ABI struct {
 	Language             string      `protobuf:"bytes,2,opt,name=language,proto3" json:"language,omitempty"`
 	Functions            []*Function `protobuf:"bytes,3,rep,name=functions,proto3" json:"functions,omitempty"`
 	StateVariables       []*StateVar `protobuf:"bytes,4,rep,name=state_variables,json=stateVariables,proto3" json:"state_variables,omitempty"`
+	SourceHash           string      `protobuf:"bytes,5,opt,name=source_hash,json=sourceHash,proto3" json:"source_hash,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
 	XXX_unrecognized     []byte      `json:"-"`
 	XXX_sizecache        int32       `json:"-"`
@@ -1333,6 +1372,13 @@ func (m *ABI) GetStateVariables() []*StateVar {
 	return nil
 }
 
+func (m *ABI) GetSourceHash() string {
+	if m != nil {
+		return m.SourceHash
+	}
+	return ""
+}
+
 type Query struct {
 	ContractAddress      []byte   `protobuf:"bytes,1,opt,name=contractAddress,proto3" json:"contractAddress,omitempty"`
 	Queryinfo            []byte   `protobuf:"bytes,2,opt,name=queryinfo,proto3" json:"queryinfo,omitempty"`
@@ -1581,6 +1627,100 @@ func (m *Proposal) GetMultipleChoice() uint32 {
 	return 0
 }
 
+type DoubleSignEvidence struct {
+	First                *BlockHeader `protobuf:"bytes,1,opt,name=first,proto3" json:"first,omitempty"`
+	Second               *BlockHeader `protobuf:"bytes,2,opt,name=second,proto3" json:"second,omitempty"`
+	Slot                 int64        `protobuf:"varint,3,opt,name=slot,proto3" json:"slot,omitempty"`
+	DetectedAt           int64        `protobuf:"varint,4,opt,name=detectedAt,proto3" json:"detectedAt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *DoubleSignEvidence) Reset()         { *m = DoubleSignEvidence{} }
+func (m *DoubleSignEvidence) String() string { return proto.CompactTextString(m) }
+func (*DoubleSignEvidence) ProtoMessage()    {}
+func (m *DoubleSignEvidence) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DoubleSignEvidence.Unmarshal(m, b)
+}
+func (m *DoubleSignEvidence) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DoubleSignEvidence.Marshal(b, m, deterministic)
+}
+func (dst *DoubleSignEvidence) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DoubleSignEvidence.Merge(dst, src)
+}
+func (m *DoubleSignEvidence) XXX_Size() int {
+	return xxx_messageInfo_DoubleSignEvidence.Size(m)
+}
+func (m *DoubleSignEvidence) XXX_DiscardUnknown() {
+	xxx_messageInfo_DoubleSignEvidence.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DoubleSignEvidence proto.InternalMessageInfo
+
+func (m *DoubleSignEvidence) GetFirst() *BlockHeader {
+	if m != nil {
+		return m.First
+	}
+	return nil
+}
+
+func (m *DoubleSignEvidence) GetSecond() *BlockHeader {
+	if m != nil {
+		return m.Second
+	}
+	return nil
+}
+
+func (m *DoubleSignEvidence) GetSlot() int64 {
+	if m != nil {
+		return m.Slot
+	}
+	return 0
+}
+
+func (m *DoubleSignEvidence) GetDetectedAt() int64 {
+	if m != nil {
+		return m.DetectedAt
+	}
+	return 0
+}
+
+type DoubleSignEvidenceList struct {
+	Evidences            []*DoubleSignEvidence `protobuf:"bytes,1,rep,name=evidences,proto3" json:"evidences,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *DoubleSignEvidenceList) Reset()         { *m = DoubleSignEvidenceList{} }
+func (m *DoubleSignEvidenceList) String() string { return proto.CompactTextString(m) }
+func (*DoubleSignEvidenceList) ProtoMessage()    {}
+func (m *DoubleSignEvidenceList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DoubleSignEvidenceList.Unmarshal(m, b)
+}
+func (m *DoubleSignEvidenceList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DoubleSignEvidenceList.Marshal(b, m, deterministic)
+}
+func (dst *DoubleSignEvidenceList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DoubleSignEvidenceList.Merge(dst, src)
+}
+func (m *DoubleSignEvidenceList) XXX_Size() int {
+	return xxx_messageInfo_DoubleSignEvidenceList.Size(m)
+}
+func (m *DoubleSignEvidenceList) XXX_DiscardUnknown() {
+	xxx_messageInfo_DoubleSignEvidenceList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DoubleSignEvidenceList proto.InternalMessageInfo
+
+func (m *DoubleSignEvidenceList) GetEvidences() []*DoubleSignEvidence {
+	if m != nil {
+		return m.Evidences
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Block)(nil), "types.Block")
 	proto.RegisterType((*BlockHeader)(nil), "types.BlockHeader")
@@ -1604,6 +1744,8 @@ func init() {
 	proto.RegisterType((*StateQuery)(nil), "types.StateQuery")
 	proto.RegisterType((*FilterInfo)(nil), "types.FilterInfo")
 	proto.RegisterType((*Proposal)(nil), "types.Proposal")
+	proto.RegisterType((*DoubleSignEvidence)(nil), "types.DoubleSignEvidence")
+	proto.RegisterType((*DoubleSignEvidenceList)(nil), "types.DoubleSignEvidenceList")
 	proto.RegisterEnum("types.TxType", TxType_name, TxType_value)
 }
 
@@ -242,3 +242,61 @@ func TestBlockLimit(t *testing.T) {
 	a.True(block.Size() <= txSize*i+hdrSize, "block size violation")
 	a.True(block.Size() <= limit, "block size violation")
 }
+
+func TestTxExpiry(t *testing.T) {
+	body := &TxBody{Nonce: 1, Account: []byte("sender"), Amount: []byte{1}}
+	tx := &Tx{Body: body}
+	hash := tx.CalculateTxHash()
+
+	bi := &BlockHeaderInfo{No: 10, Ts: 1000 * int64(time.Second), Version: FeatureTxExpiry.Version()}
+	assert.False(t, body.HasExpiry())
+	assert.NoError(t, body.ValidateExpiry(bi))
+
+	body.ValidUntilNo = 10
+	assert.NotEqual(t, hash, tx.CalculateTxHash(), "expiry must be signed")
+	assert.NoError(t, body.ValidateExpiry(bi))
+	assert.True(t, body.IsExpired(11, bi.Ts))
+	assert.Equal(t, ErrTxExpired, body.ValidateExpiry(&BlockHeaderInfo{No: 11, Ts: bi.Ts, Version: FeatureTxExpiry.Version()}))
+
+	body.ValidUntilNo = 0
+	body.ValidUntilTime = 1000
+	assert.NoError(t, body.ValidateExpiry(bi))
+	assert.True(t, body.IsExpired(bi.No, 1001*int64(time.Second)))
+
+	bi.Version = FeatureTxExpiry.Version() - 1
+	assert.Equal(t, ErrTxFormatInvalid, body.ValidateExpiry(bi))
+}
+
+func TestBaseFee(t *testing.T) {
+	prev := &Block{Header: &BlockHeader{BlockNo: 1}, Body: &BlockBody{}}
+	hash := prev.calculateBlockHash()
+	gasPrice := big.NewInt(1000)
+
+	// the first block from the version starts from the gas price
+	assert.Equal(t, gasPrice, NextBaseFee(prev, gasPrice))
+
+	prev.Header.BaseFee = gasPrice.Bytes()
+	assert.NotEqual(t, hash, prev.calculateBlockHash(), "base fee must be hashed")
+
+	// the bytes moved from the consensus field to the base fee
+	moved := &Block{Header: &BlockHeader{BlockNo: 1, Consensus: []byte{1, 2}, BaseFee: []byte{3}}}
+	hash = moved.calculateBlockHash()
+	moved.Header.Consensus, moved.Header.BaseFee = []byte{1}, []byte{2, 3}
+	assert.NotEqual(t, hash, moved.calculateBlockHash(), "base fee must be separated from the consensus field")
+	// the base fee falls below the gas price after the empty blocks
+	assert.Equal(t, big.NewInt(875), NextBaseFee(prev, gasPrice), "empty block")
+	prev.Header.BaseFee = big.NewInt(875).Bytes()
+	assert.Equal(t, big.NewInt(766), NextBaseFee(prev, gasPrice), "empty block")
+
+	bi := &BlockHeaderInfo{No: 2, BaseFee: big.NewInt(875)}
+	block := NewBlock(bi, nil, &Receipts{}, nil, nil, nil)
+	assert.Equal(t, bi.BaseFee, NewBlockHeaderInfo(block).BaseFee)
+
+	body := &TxBody{Nonce: 1, Account: []byte("sender")}
+	tx := &Tx{Body: body}
+	txHash := tx.CalculateTxHash()
+	body.GasTip = big.NewInt(10).Bytes()
+	assert.NotEqual(t, txHash, tx.CalculateTxHash(), "tip must be signed")
+	assert.NoError(t, body.ValidateGasTip(FeatureBaseFee.Version()))
+	assert.Equal(t, ErrTxFormatInvalid, body.ValidateGasTip(FeatureBaseFee.Version()-1))
+}
@@ -50,6 +50,14 @@ var (
 
 	ErrSignNotMatch = errors.New("signature not matched")
 
+	ErrTxInvalidMultisig = errors.New("tx invalid multisig")
+
+	ErrTxExpired = errors.New("tx expired")
+
+	ErrMultisigNotRegistered = errors.New("multisig account is not registered")
+
+	ErrMultisigAlreadyRegistered = errors.New("multisig account is already registered")
+
 	ErrCouldNotRecoverPubKey = errors.New("could not recover pubkey from sign")
 
 	ErrShouldUnlockAccount = errors.New("should unlock account first")
@@ -78,4 +86,7 @@ var (
 	ErrNotAllowedFeeDelegation = errors.New("fee delegation is not allowed")
 
 	ErrNotEnoughGas = errors.New("not enough gas")
+
+	//ErrInvalidBlockSign
+	ErrInvalidBlockSign = errors.New("invalid block signature")
 )
@@ -0,0 +1,100 @@
+package types
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/mr-tron/base58"
+)
+
+var (
+	ErrEvidenceMissingHeader   = errors.New("evidence has no block header")
+	ErrEvidenceDifferentChain  = errors.New("blocks of evidence belong to different chains")
+	ErrEvidenceOtherChain      = errors.New("blocks of evidence belong to another chain")
+	ErrEvidenceDifferentSigner = errors.New("blocks of evidence are signed by different producers")
+	ErrEvidenceSameBlock       = errors.New("blocks of evidence are identical")
+	ErrEvidenceDifferentSlot   = errors.New("blocks of evidence are produced at different slots")
+	ErrEvidenceInvalidSign     = errors.New("block of evidence has invalid signature")
+)
+
+// DposSlotIndex returns the index of the DPoS slot which a block of timestamp ts (in nanoseconds) belongs to. It must
+// give the same result as the slot of dpos consensus, since the evidence is verified with it.
+func DposSlotIndex(ts int64, blockIntervalSec int64) int64 {
+	intervalMs := blockIntervalSec * 1000
+	ms := ts / 1000000
+	return (ms + intervalMs - 1) / intervalMs
+}
+
+// NewDoubleSignEvidence makes an evidence that a block producer signed two different blocks for the same slot.
+func NewDoubleSignEvidence(first, second *BlockHeader, slot int64, detectedAt int64) *DoubleSignEvidence {
+	return &DoubleSignEvidence{First: first, Second: second, Slot: slot, DetectedAt: detectedAt}
+}
+
+// Producer returns the id of the block producer who signed the blocks.
+func (e *DoubleSignEvidence) Producer() (PeerID, error) {
+	if e.GetFirst() == nil {
+		return PeerID(""), ErrEvidenceMissingHeader
+	}
+	return (&Block{Header: e.First}).BPID()
+}
+
+// Verify checks that the evidence really proves double signing in the chain of chainID. It only needs the evidence
+// itself, the chain ID and the block interval of the chain, so that any node can verify it regardless of its chain
+// state. The version of the chain ID is ignored since the blocks may be produced before a hardfork.
+func (e *DoubleSignEvidence) Verify(chainID []byte, blockIntervalSec int64) error {
+	if e.GetFirst() == nil || e.GetSecond() == nil {
+		return ErrEvidenceMissingHeader
+	}
+	first, second := &Block{Header: e.First}, &Block{Header: e.Second}
+	if !bytes.Equal(e.First.ChainID, e.Second.ChainID) {
+		return ErrEvidenceDifferentChain
+	}
+	if !ChainIdEqualWithoutVersion(e.First.ChainID, chainID) {
+		return ErrEvidenceOtherChain
+	}
+	if len(e.First.PubKey) == 0 || !bytes.Equal(e.First.PubKey, e.Second.PubKey) {
+		return ErrEvidenceDifferentSigner
+	}
+	if bytes.Equal(first.calculateBlockHash(), second.calculateBlockHash()) {
+		return ErrEvidenceSameBlock
+	}
+	if DposSlotIndex(e.First.Timestamp, blockIntervalSec) != e.Slot ||
+		DposSlotIndex(e.Second.Timestamp, blockIntervalSec) != e.Slot {
+		return ErrEvidenceDifferentSlot
+	}
+	for _, b := range []*Block{first, second} {
+		if valid, err := b.VerifySign(); err != nil || !valid {
+			return ErrEvidenceInvalidSign
+		}
+	}
+	return nil
+}
+
+// Key returns an identifier of the misbehavior, which is the same for the evidences of the same producer and slot.
+func (e *DoubleSignEvidence) Key() []byte {
+	return []byte(fmt.Sprintf("%s/%d", base58.Encode(e.GetFirst().GetPubKey()), e.Slot))
+}
+
+// Encode returns base58 encoded string of evidence, which is used as an argument of system transaction.
+func (e *DoubleSignEvidence) Encode() (string, error) {
+	b, err := proto.Marshal(e)
+	if err != nil {
+		return "", err
+	}
+	return base58.Encode(b), nil
+}
+
+// DecodeDoubleSignEvidence decodes base58 encoded string made by DoubleSignEvidence.Encode
+func DecodeDoubleSignEvidence(encoded string) (*DoubleSignEvidence, error) {
+	b, err := base58.Decode(encoded)
+	if err != nil {
+		return nil, err
+	}
+	e := &DoubleSignEvidence{}
+	if err := proto.Unmarshal(b, e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
@@ -0,0 +1,96 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDposSlotIndex(t *testing.T) {
+	base := time.Unix(1000, 0).UnixNano()
+	tests := []struct {
+		name     string
+		ts       int64
+		interval int64
+		want     int64
+	}{
+		{"TExact", base, 1, 1000},
+		{"TInSlot", base + int64(time.Millisecond), 1, 1001},
+		{"TSlotEnd", base + int64(time.Second), 1, 1001},
+		{"TInterval2", base + int64(time.Millisecond), 2, 501},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, DposSlotIndex(tt.ts, tt.interval))
+		})
+	}
+}
+
+func TestDoubleSignEvidence_Verify(t *testing.T) {
+	privKey, _, _ := crypto.GenerateKeyPair(crypto.Secp256k1, 256)
+	otherKey, _, _ := crypto.GenerateKeyPair(crypto.Secp256k1, 256)
+	ts := time.Unix(1000, 0).UnixNano() + int64(time.Millisecond*100)
+	slot := DposSlotIndex(ts, 1)
+
+	chainID := MakeChainId([]byte("0000chain"), 2)
+	newChainHeader := func(cid []byte, key crypto.PrivKey, ts int64, prev []byte) *BlockHeader {
+		block := NewBlock(&BlockHeaderInfo{No: 10, Ts: ts, PrevBlockHash: prev, ChainId: cid}, nil, nil, nil, nil, nil)
+		assert.Nil(t, block.Sign(key))
+		return block.Header
+	}
+	newHeader := func(key crypto.PrivKey, ts int64, prev []byte) *BlockHeader {
+		return newChainHeader(chainID, key, ts, prev)
+	}
+	otherChain := []byte("0000other")
+	first := newHeader(privKey, ts, []byte("prev1"))
+	second := newHeader(privKey, ts+int64(time.Millisecond), []byte("prev2"))
+	forged := newHeader(privKey, ts, []byte("prev3"))
+	forged.Sign = first.Sign
+
+	tests := []struct {
+		name    string
+		e       *DoubleSignEvidence
+		wantErr error
+	}{
+		{"TValid", NewDoubleSignEvidence(first, second, slot, 0), nil},
+		{"TMissing", NewDoubleSignEvidence(first, nil, slot, 0), ErrEvidenceMissingHeader},
+		{"TSameBlock", NewDoubleSignEvidence(first, first, slot, 0), ErrEvidenceSameBlock},
+		{"TOtherSigner", NewDoubleSignEvidence(first, newHeader(otherKey, ts, []byte("prev2")), slot, 0), ErrEvidenceDifferentSigner},
+		{"TOtherSlot", NewDoubleSignEvidence(first, newHeader(privKey, ts+int64(time.Second), []byte("prev2")), slot, 0), ErrEvidenceDifferentSlot},
+		{"TWrongSlot", NewDoubleSignEvidence(first, second, slot+1, 0), ErrEvidenceDifferentSlot},
+		{"TForged", NewDoubleSignEvidence(first, forged, slot, 0), ErrEvidenceInvalidSign},
+		{"TOtherVersion", NewDoubleSignEvidence(
+			newChainHeader(MakeChainId(chainID, 3), privKey, ts, []byte("prev1")),
+			newChainHeader(MakeChainId(chainID, 3), privKey, ts, []byte("prev2")), slot, 0), nil},
+		{"TOtherChain", NewDoubleSignEvidence(
+			newChainHeader(otherChain, privKey, ts, []byte("prev1")),
+			newChainHeader(otherChain, privKey, ts, []byte("prev2")), slot, 0), ErrEvidenceOtherChain},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantErr, tt.e.Verify(chainID, 1))
+		})
+	}
+}
+
+func TestDoubleSignEvidence_EncodeDecode(t *testing.T) {
+	privKey, _, _ := crypto.GenerateKeyPair(crypto.Secp256k1, 256)
+	block := NewBlock(&BlockHeaderInfo{No: 10, Ts: time.Now().UnixNano()}, nil, nil, nil, nil, nil)
+	assert.Nil(t, block.Sign(privKey))
+
+	e := NewDoubleSignEvidence(block.Header, block.Header, 3, 4)
+	encoded, err := e.Encode()
+	assert.Nil(t, err)
+	decoded, err := DecodeDoubleSignEvidence(encoded)
+	assert.Nil(t, err)
+	assert.Equal(t, e.Slot, decoded.Slot)
+	assert.Equal(t, e.Key(), decoded.Key())
+	assert.Equal(t, block.Header.Sign, decoded.First.Sign)
+
+	producer, err := decoded.Producer()
+	assert.Nil(t, err)
+	expected, _ := block.BPID()
+	assert.Equal(t, expected, producer)
+}
@@ -0,0 +1,29 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package types
+
+// Feature is a named protocol change activated by a hardfork version. The
+// features and their versions are declared in config/hardfork.json, from
+// which feature_gen.go is generated.
+type Feature string
+
+// Version returns the hardfork version activating the feature. It returns 0
+// if the feature is unknown to the node.
+func (f Feature) Version() int32 {
+	return featureVersions[f]
+}
+
+// ActiveAt reports whether the feature is active in a block of the version.
+func (f Feature) ActiveAt(version int32) bool {
+	v, exist := featureVersions[f]
+	return exist && version >= v
+}
+
+// Features returns all the features known to the node in the order of their
+// activation.
+func Features() []Feature {
+	return append([]Feature(nil), features...)
+}
@@ -5,12 +5,16 @@ import (
 	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io/ioutil"
 	"math"
 	"math/big"
+	"path/filepath"
 	"strings"
 	"time"
 
+	"github.com/aergoio/aergo/cmd/aergoluac/encoding"
 	"github.com/aergoio/aergo/internal/common"
 )
 
@@ -209,13 +213,117 @@ type EnterpriseBP struct {
 	PeerID  string `json:"peerid"`
 }
 
+// GenesisContract is a Lua contract deployed in the genesis block.
+type GenesisContract struct {
+	// Address is the base58 address of the contract or a name with dots like
+	// "sys.token", which is resolved to itself like aergo.system.
+	Address string `json:"address"`
+	// Code is the payload printed by aergoluac --payload. CodeFile is the
+	// file containing it, which is read by ReadContractFiles.
+	Code     string `json:"code,omitempty"`
+	CodeFile string `json:"code_file,omitempty"`
+	// Args is the JSON array of the constructor arguments. The constructor
+	// is called only if Args is set.
+	Args json.RawMessage `json:"args,omitempty"`
+	// Storage is the initial values of the state variables keyed by the
+	// ids used by the state module, like "name" for a state.value or
+	// "balances-alice" for an entry of a state.map.
+	Storage map[string]json.RawMessage `json:"storage,omitempty"`
+	Balance string                     `json:"balance,omitempty"`
+}
+
+// stateVarKeyPrefix is the prefix of the storage keys of the state variables
+// of a Lua contract.
+const stateVarKeyPrefix = "_sv_"
+
+// ID returns the account address of c.
+func (c *GenesisContract) ID() ([]byte, error) {
+	if strings.Contains(c.Address, ".") {
+		if strings.HasPrefix(c.Address, "aergo.") {
+			return nil, fmt.Errorf("reserved name: %s", c.Address)
+		}
+		if _, _, isSubname := ParseSubname(c.Address); isSubname {
+			return nil, fmt.Errorf("subname is not allowed: %s", c.Address)
+		}
+		if len(c.Address) > AddressLength {
+			return nil, fmt.Errorf("too long name: %s", c.Address)
+		}
+	}
+	id, err := DecodeAddress(c.Address)
+	if err != nil {
+		return nil, err
+	}
+	if len(id) <= NameLength && !strings.Contains(c.Address, ".") {
+		return nil, fmt.Errorf("name without a dot must be registered by aergo.name: %s", c.Address)
+	}
+	return id, nil
+}
+
+// LuaCode returns the bytecode and ABI of c.
+func (c *GenesisContract) LuaCode() ([]byte, error) {
+	if len(c.Code) == 0 {
+		return nil, errors.New("code is empty")
+	}
+	return encoding.DecodeCode(c.Code)
+}
+
+// BalanceBigInt returns the initial balance of c.
+func (c *GenesisContract) BalanceBigInt() (*big.Int, error) {
+	if len(c.Balance) == 0 {
+		return big.NewInt(0), nil
+	}
+	v, ok := new(big.Int).SetString(c.Balance, 10)
+	if !ok || v.Sign() < 0 {
+		return nil, fmt.Errorf("invalid balance: %s", c.Balance)
+	}
+	return v, nil
+}
+
+// StorageData returns the storage keys and values of the state variables of
+// c.
+func (c *GenesisContract) StorageData() (map[string][]byte, error) {
+	data := make(map[string][]byte, len(c.Storage))
+	for id, value := range c.Storage {
+		var b bytes.Buffer
+		if err := json.Compact(&b, value); err != nil {
+			return nil, fmt.Errorf("invalid value of %s: %s", id, err.Error())
+		}
+		data[stateVarKeyPrefix+id] = b.Bytes()
+	}
+	return data, nil
+}
+
+func (c *GenesisContract) validate() error {
+	if _, err := c.ID(); err != nil {
+		return err
+	}
+	if len(c.CodeFile) > 0 {
+		return fmt.Errorf("code file %s is not read", c.CodeFile)
+	}
+	if _, err := c.LuaCode(); err != nil {
+		return fmt.Errorf("invalid code: %s", err.Error())
+	}
+	if len(c.Args) > 0 {
+		var args []interface{}
+		if err := json.Unmarshal(c.Args, &args); err != nil {
+			return fmt.Errorf("args must be a JSON array: %s", err.Error())
+		}
+	}
+	if _, err := c.StorageData(); err != nil {
+		return err
+	}
+	_, err := c.BalanceBigInt()
+	return err
+}
+
 // Genesis represents genesis block
 type Genesis struct {
-	ID            ChainID           `json:"chain_id,omitempty"`
-	Timestamp     int64             `json:"timestamp,omitempty"`
-	Balance       map[string]string `json:"balance"`
-	BPs           []string          `json:"bps"`
-	EnterpriseBPs []EnterpriseBP    `json:"enterprise_bps,omitempty"`
+	ID            ChainID            `json:"chain_id,omitempty"`
+	Timestamp     int64              `json:"timestamp,omitempty"`
+	Balance       map[string]string  `json:"balance"`
+	BPs           []string           `json:"bps"`
+	EnterpriseBPs []EnterpriseBP     `json:"enterprise_bps,omitempty"`
+	Contracts     []*GenesisContract `json:"contracts,omitempty"`
 
 	// followings are for internal use only
 	totalBalance *big.Int
@@ -230,6 +338,49 @@ func (g *Genesis) Validate() error {
 		return err
 	}
 	//TODO check BP count
+	return g.validateContracts()
+}
+
+func (g *Genesis) validateContracts() error {
+	ids := make(map[string]bool)
+	for address := range g.Balance {
+		ids[string(ToAddress(address))] = true
+	}
+	for _, c := range g.Contracts {
+		if err := c.validate(); err != nil {
+			return fmt.Errorf("genesis contract %s: %s", c.Address, err.Error())
+		}
+		id, _ := c.ID()
+		if ids[string(id)] {
+			return fmt.Errorf("genesis contract %s: duplicated account", c.Address)
+		}
+		ids[string(id)] = true
+	}
+	return nil
+}
+
+// ReadContractFiles reads the code files of the genesis contracts. A relative
+// path is relative to baseDir, which is usually the directory of the genesis
+// file.
+func (g *Genesis) ReadContractFiles(baseDir string) error {
+	for _, c := range g.Contracts {
+		if len(c.CodeFile) == 0 {
+			continue
+		}
+		if len(c.Code) > 0 {
+			return fmt.Errorf("genesis contract %s: both code and code file are set", c.Address)
+		}
+		path := c.CodeFile
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(baseDir, path)
+		}
+		code, err := ioutil.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("genesis contract %s: %s", c.Address, err.Error())
+		}
+		c.Code = strings.TrimSpace(string(code))
+		c.CodeFile = ""
+	}
 	return nil
 }
 
@@ -279,8 +430,9 @@ func (g *Genesis) ChainID() ([]byte, error) {
 
 // Bytes returns byte-encoded BPs from g.
 func (g Genesis) Bytes() []byte {
-	// Omit the Balance to reduce the resulting data size.
+	// Omit the Balance and Contracts to reduce the resulting data size.
 	g.Balance = nil
+	g.Contracts = nil
 	if b, err := common.GobEncode(g); err == nil {
 		return b
 	}
@@ -4,9 +4,13 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/aergoio/aergo/cmd/aergoluac/encoding"
 	"github.com/aergoio/aergo/internal/enc"
 	"github.com/davecgh/go-spew/spew"
 	"github.com/stretchr/testify/assert"
@@ -52,6 +56,77 @@ func TestGenesisBytes(t *testing.T) {
 	a.Nil(g2.Balance)
 }
 
+func TestGenesisContractValidate(t *testing.T) {
+	code := encoding.EncodeCode([]byte("bytecode and abi"))
+	address := "AmNpn7K9wg6wsn6oMkTirQSUNdqtDm94iCrrpP5ZpwCAAxxPrsU2"
+	tests := []struct {
+		name    string
+		c       GenesisContract
+		wantErr bool
+	}{
+		{"address", GenesisContract{Address: address, Code: code}, false},
+		{"dotted name", GenesisContract{Address: "sys.token", Code: code, Args: json.RawMessage(`["a", 1]`),
+			Storage: map[string]json.RawMessage{"name": json.RawMessage(`"token"`)}, Balance: "100"}, false},
+		{"plain name", GenesisContract{Address: "systoken", Code: code}, true},
+		{"reserved name", GenesisContract{Address: "aergo.token", Code: code}, true},
+		{"subname", GenesisContract{Address: "sub.systemtoken1", Code: code}, true},
+		{"invalid address", GenesisContract{Address: "AmNpn7K9wg6wsn6oMkTirQSUNdqtDm94iCrrpP5ZpwCAAxxPrsU3", Code: code}, true},
+		{"invalid name", GenesisContract{Address: "sys-token.", Code: code}, true},
+		{"no code", GenesisContract{Address: "sys.token"}, true},
+		{"invalid code", GenesisContract{Address: "sys.token", Code: "0OIl"}, true},
+		{"code file not read", GenesisContract{Address: "sys.token", CodeFile: "token.payload"}, true},
+		{"args not array", GenesisContract{Address: "sys.token", Code: code, Args: json.RawMessage(`{}`)}, true},
+		{"invalid balance", GenesisContract{Address: "sys.token", Code: code, Balance: "-1"}, true},
+	}
+	for _, tt := range tests {
+		g := GetDefaultGenesis()
+		g.Contracts = []*GenesisContract{&tt.c}
+		if err := g.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("%s: Validate() error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+
+	g := GetDefaultGenesis()
+	g.Balance = map[string]string{address: "1"}
+	g.Contracts = []*GenesisContract{{Address: address, Code: code}}
+	assert.Error(t, g.Validate(), "balance and contract of the same account")
+}
+
+func TestGenesisContractStorage(t *testing.T) {
+	c := &GenesisContract{Storage: map[string]json.RawMessage{
+		"name":           json.RawMessage(`"token"`),
+		"balances-alice": json.RawMessage(`{ "_bignum": "100" }`),
+	}}
+	data, err := c.StorageData()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]byte{
+		"_sv_name":           []byte(`"token"`),
+		"_sv_balances-alice": []byte(`{"_bignum":"100"}`),
+	}, data)
+}
+
+func TestReadContractFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "genesis")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	code := encoding.EncodeCode([]byte("bytecode and abi"))
+	if err := ioutil.WriteFile(filepath.Join(dir, "token.payload"), []byte(code+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	g := GetDefaultGenesis()
+	g.Contracts = []*GenesisContract{{Address: "sys.token", CodeFile: "token.payload"}}
+	assert.NoError(t, g.ReadContractFiles(dir))
+	assert.Equal(t, code, g.Contracts[0].Code)
+	assert.Empty(t, g.Contracts[0].CodeFile)
+	assert.NoError(t, g.Validate())
+
+	g.Contracts[0].CodeFile = "token.payload"
+	assert.Error(t, g.ReadContractFiles(dir), "both code and code file")
+}
+
 func TestCodecChainID(t *testing.T) {
 	a := assert.New(t)
 	id1 := NewChainID()
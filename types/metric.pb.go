@@ -125,6 +125,8 @@ type PeerMetric struct {
 	AvrIn                int64    `protobuf:"varint,3,opt,name=avrIn,proto3" json:"avrIn,omitempty"`
 	SumOut               int64    `protobuf:"varint,4,opt,name=sumOut,proto3" json:"sumOut,omitempty"`
 	AvrOut               int64    `protobuf:"varint,5,opt,name=avrOut,proto3" json:"avrOut,omitempty"`
+	DelayedReqs          int64    `protobuf:"varint,6,opt,name=delayedReqs,proto3" json:"delayedReqs,omitempty"`
+	DroppedReqs          int64    `protobuf:"varint,7,opt,name=droppedReqs,proto3" json:"droppedReqs,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -189,6 +191,20 @@ func (m *PeerMetric) GetAvrOut() int64 {
 	return 0
 }
 
+func (m *PeerMetric) GetDelayedReqs() int64 {
+	if m != nil {
+		return m.DelayedReqs
+	}
+	return 0
+}
+
+func (m *PeerMetric) GetDroppedReqs() int64 {
+	if m != nil {
+		return m.DroppedReqs
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*MetricsRequest)(nil), "types.MetricsRequest")
 	proto.RegisterType((*Metrics)(nil), "types.Metrics")
@@ -0,0 +1,204 @@
+/**
+ *  @file
+ *  @copyright defined in aergo/LICENSE.txt
+ */
+
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+
+	"github.com/minio/sha256-simd"
+)
+
+const (
+	// MultisigAddressPrefix is the first byte of a multisig account address.
+	// It never collides with a compressed public key, which begins with 0x02
+	// or 0x03.
+	MultisigAddressPrefix byte = 0x10
+	// MultisigMaxKeys is the maximum number of keys of a multisig account.
+	MultisigMaxKeys = 16
+
+	// multisigSignMarker is the first byte of a multisig witness. A DER
+	// encoded signature always begins with 0x30.
+	multisigSignMarker byte = 0x4d
+)
+
+// MultisigConfig is the key set and the threshold of an m-of-n multisig
+// account. It is the payload of a MULTISIG tx, with the keys given as
+// encoded addresses.
+type MultisigConfig struct {
+	Threshold int
+	Keys      [][]byte
+}
+
+type multisigConfigJSON struct {
+	Threshold int      `json:"threshold"`
+	Keys      []string `json:"keys"`
+}
+
+// ParseMultisigConfig decodes and validates the JSON encoded config.
+func ParseMultisigConfig(payload []byte) (*MultisigConfig, error) {
+	var in multisigConfigJSON
+	if err := json.Unmarshal(payload, &in); err != nil {
+		return nil, ErrTxInvalidPayload
+	}
+	config := &MultisigConfig{Threshold: in.Threshold}
+	for _, k := range in.Keys {
+		key, err := DecodeAddress(k)
+		if err != nil {
+			return nil, err
+		}
+		config.Keys = append(config.Keys, key)
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// Validate checks the threshold and the keys of the config.
+func (c *MultisigConfig) Validate() error {
+	if len(c.Keys) == 0 || len(c.Keys) > MultisigMaxKeys {
+		return errors.New("invalid number of multisig keys")
+	}
+	if c.Threshold < 1 || c.Threshold > len(c.Keys) {
+		return errors.New("invalid multisig threshold")
+	}
+	for i, k := range c.Keys {
+		if len(k) != AddressLength || (k[0] != 0x02 && k[0] != 0x03) {
+			return errors.New("multisig key must be a single key account")
+		}
+		for _, o := range c.Keys[:i] {
+			if bytes.Equal(k, o) {
+				return errors.New("duplicated multisig key")
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalJSON encodes the keys of the config as addresses.
+func (c *MultisigConfig) MarshalJSON() ([]byte, error) {
+	out := multisigConfigJSON{Threshold: c.Threshold}
+	for _, k := range c.Keys {
+		out.Keys = append(out.Keys, EncodeAddress(k))
+	}
+	return json.Marshal(out)
+}
+
+// Address returns the multisig account address derived from the config.
+func (c *MultisigConfig) Address() Address {
+	h := sha256.New()
+	h.Write([]byte{byte(c.Threshold)})
+	for _, k := range c.Keys {
+		h.Write(k)
+	}
+	return append([]byte{MultisigAddressPrefix}, h.Sum(nil)...)
+}
+
+// KeyIndex returns the index of key in the config, or -1.
+func (c *MultisigConfig) KeyIndex(key []byte) int {
+	for i, k := range c.Keys {
+		if bytes.Equal(k, key) {
+			return i
+		}
+	}
+	return -1
+}
+
+// IsMultisigAddress reports whether addr is a multisig account address.
+func IsMultisigAddress(addr []byte) bool {
+	return len(addr) == AddressLength && addr[0] == MultisigAddressPrefix
+}
+
+// MultisigSignature is the signature made by the key at Index of the config.
+type MultisigSignature struct {
+	Index int
+	Sign  []byte
+}
+
+// MultisigSign is the witness of a tx sent by a multisig account. It is
+// stored in the sign field of the tx body, and carries the config so that
+// the signatures can be verified without the state.
+type MultisigSign struct {
+	Config     *MultisigConfig
+	Signatures []*MultisigSignature
+}
+
+// IsMultisigSign reports whether sign is a multisig witness.
+func IsMultisigSign(sign []byte) bool {
+	return len(sign) > 0 && sign[0] == multisigSignMarker
+}
+
+// AddSignature adds or replaces the signature of the key at index.
+func (ms *MultisigSign) AddSignature(index int, sign []byte) {
+	for _, s := range ms.Signatures {
+		if s.Index == index {
+			s.Sign = sign
+			return
+		}
+	}
+	ms.Signatures = append(ms.Signatures, &MultisigSignature{Index: index, Sign: sign})
+}
+
+// Serialize encodes the witness as the marker, the threshold, the keys and
+// the signatures, each prefixed with its key index and length.
+func (ms *MultisigSign) Serialize() []byte {
+	var buf bytes.Buffer
+	buf.WriteByte(multisigSignMarker)
+	buf.WriteByte(byte(ms.Config.Threshold))
+	buf.WriteByte(byte(len(ms.Config.Keys)))
+	for _, k := range ms.Config.Keys {
+		buf.Write(k)
+	}
+	buf.WriteByte(byte(len(ms.Signatures)))
+	for _, s := range ms.Signatures {
+		buf.WriteByte(byte(s.Index))
+		buf.WriteByte(byte(len(s.Sign)))
+		buf.Write(s.Sign)
+	}
+	return buf.Bytes()
+}
+
+// ParseMultisigSign decodes and validates the multisig witness.
+func ParseMultisigSign(sign []byte) (*MultisigSign, error) {
+	if !IsMultisigSign(sign) || len(sign) < 3 {
+		return nil, ErrTxInvalidMultisig
+	}
+	config := &MultisigConfig{Threshold: int(sign[1])}
+	n, pos := int(sign[2]), 3
+	if len(sign) < pos+n*AddressLength+1 {
+		return nil, ErrTxInvalidMultisig
+	}
+	for i := 0; i < n; i++ {
+		config.Keys = append(config.Keys, sign[pos:pos+AddressLength])
+		pos += AddressLength
+	}
+	if config.Validate() != nil {
+		return nil, ErrTxInvalidMultisig
+	}
+	ms := &MultisigSign{Config: config}
+	count := int(sign[pos])
+	pos++
+	seen := make(map[int]bool, count)
+	for i := 0; i < count; i++ {
+		if len(sign) < pos+2 {
+			return nil, ErrTxInvalidMultisig
+		}
+		index, size := int(sign[pos]), int(sign[pos+1])
+		pos += 2
+		if index >= n || seen[index] || len(sign) < pos+size {
+			return nil, ErrTxInvalidMultisig
+		}
+		seen[index] = true
+		ms.Signatures = append(ms.Signatures, &MultisigSignature{Index: index, Sign: sign[pos : pos+size]})
+		pos += size
+	}
+	if pos != len(sign) {
+		return nil, ErrTxInvalidMultisig
+	}
+	return ms, nil
+}
@@ -0,0 +1,51 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultisigConfig(t *testing.T) {
+	key1, _ := DecodeAddress("AmPNYHyzyh9zweLwDyuoiUuTVCdrdksxkRWDjVJS76WQLExa2Jr4")
+	key2, _ := DecodeAddress("AmLt7Z3y2XTu7YS8KHNuyKM2QAszpFHSX77FLKEt7FAuRW7GEhj7")
+	config := &MultisigConfig{Threshold: 2, Keys: [][]byte{key1, key2}}
+	assert.NoError(t, config.Validate())
+
+	payload, err := config.MarshalJSON()
+	assert.NoError(t, err)
+	parsed, err := ParseMultisigConfig(payload)
+	assert.NoError(t, err)
+	assert.Equal(t, config.Address(), parsed.Address())
+	assert.Equal(t, AddressLength, len(config.Address()))
+	assert.True(t, IsMultisigAddress(config.Address()))
+	assert.False(t, IsMultisigAddress(key1))
+
+	for _, c := range []*MultisigConfig{
+		{Threshold: 0, Keys: [][]byte{key1}},
+		{Threshold: 3, Keys: [][]byte{key1, key2}},
+		{Threshold: 1, Keys: [][]byte{key1, key1}},
+		{Threshold: 1, Keys: [][]byte{config.Address()}},
+	} {
+		assert.Error(t, c.Validate())
+	}
+}
+
+func TestMultisigSign(t *testing.T) {
+	key1, _ := DecodeAddress("AmPNYHyzyh9zweLwDyuoiUuTVCdrdksxkRWDjVJS76WQLExa2Jr4")
+	key2, _ := DecodeAddress("AmLt7Z3y2XTu7YS8KHNuyKM2QAszpFHSX77FLKEt7FAuRW7GEhj7")
+	ms := &MultisigSign{Config: &MultisigConfig{Threshold: 1, Keys: [][]byte{key1, key2}}}
+	ms.AddSignature(1, []byte{0x30, 1, 2})
+	ms.AddSignature(1, []byte{0x30, 3})
+
+	sign := ms.Serialize()
+	assert.True(t, IsMultisigSign(sign))
+	parsed, err := ParseMultisigSign(sign)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(parsed.Signatures))
+	assert.Equal(t, []byte{0x30, 3}, parsed.Signatures[0].Sign)
+
+	_, err = ParseMultisigSign(sign[:len(sign)-1])
+	assert.Equal(t, ErrTxInvalidMultisig, err)
+	assert.False(t, IsMultisigSign([]byte{0x30}))
+}
@@ -12,12 +12,17 @@ func _() {
 	_ = x[OpvoteDAO-1]
 	_ = x[Opstake-2]
 	_ = x[Opunstake-3]
-	_ = x[OpSysTxMax-4]
+	_ = x[OpsubmitEvidence-4]
+	_ = x[Opwithdraw-5]
+	_ = x[OpclaimReward-6]
+	_ = x[Opdelegate-7]
+	_ = x[Opundelegate-8]
+	_ = x[OpSysTxMax-9]
 }
 
-const _OpSysTx_name = "OpvoteBPOpvoteDAOOpstakeOpunstakeOpSysTxMax"
+const _OpSysTx_name = "OpvoteBPOpvoteDAOOpstakeOpunstakeOpsubmitEvidenceOpwithdrawOpclaimRewardOpdelegateOpundelegateOpSysTxMax"
 
-var _OpSysTx_index = [...]uint8{0, 8, 17, 24, 33, 43}
+var _OpSysTx_index = [...]uint8{0, 8, 17, 24, 33, 49, 59, 72, 82, 94, 104}
 
 func (i OpSysTx) String() string {
 	if i < 0 || i >= OpSysTx(len(_OpSysTx_index)-1) {
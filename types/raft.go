@@ -47,15 +47,17 @@ func (mc *MembershipChange) ToString() string {
 
 func (mattr *MemberAttr) MarshalJSON() ([]byte, error) {
 	return json.Marshal(&struct {
-		ID      string `json:"id,omitempty"`
-		Name    string `json:"name,omitempty"`
-		Address string `json:"address,omitempty"`
-		PeerID  string `json:"peerid,omitempty"`
+		ID        string `json:"id,omitempty"`
+		Name      string `json:"name,omitempty"`
+		Address   string `json:"address,omitempty"`
+		PeerID    string `json:"peerid,omitempty"`
+		IsLearner bool   `json:"learner,omitempty"`
 	}{
-		ID:      Uint64ToHexaString(mattr.ID),
-		Name:    mattr.Name,
-		Address: mattr.Address,
-		PeerID:  IDB58Encode(PeerID(mattr.PeerID)),
+		ID:        Uint64ToHexaString(mattr.ID),
+		Name:      mattr.Name,
+		Address:   mattr.Address,
+		PeerID:    IDB58Encode(PeerID(mattr.PeerID)),
+		IsLearner: mattr.IsLearner,
 	})
 }
 
@@ -66,10 +68,11 @@ func (mattr *MemberAttr) UnmarshalJSON(data []byte) error {
 	)
 
 	aux := &struct {
-		ID      string `json:"id,omitempty"`
-		Name    string `json:"name,omitempty"`
-		Address string `json:"address,omitempty"`
-		PeerID  string `json:"peerid,omitempty"`
+		ID        string `json:"id,omitempty"`
+		Name      string `json:"name,omitempty"`
+		Address   string `json:"address,omitempty"`
+		PeerID    string `json:"peerid,omitempty"`
+		IsLearner bool   `json:"learner,omitempty"`
 	}{}
 
 	if err = json.Unmarshal(data, aux); err != nil {
@@ -93,6 +96,7 @@ func (mattr *MemberAttr) UnmarshalJSON(data []byte) error {
 	}
 	mattr.Name = aux.Name
 	mattr.Address = aux.Address
+	mattr.IsLearner = aux.IsLearner
 
 	return nil
 }
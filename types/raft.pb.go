@@ -22,17 +22,26 @@ const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 type MembershipChangeType int32
 
 const (
-	MembershipChangeType_ADD_MEMBER    MembershipChangeType = 0
-	MembershipChangeType_REMOVE_MEMBER MembershipChangeType = 1
+	MembershipChangeType_ADD_MEMBER      MembershipChangeType = 0
+	MembershipChangeType_REMOVE_MEMBER   MembershipChangeType = 1
+	MembershipChangeType_ADD_LEARNER     MembershipChangeType = 2
+	MembershipChangeType_PROMOTE_LEARNER MembershipChangeType = 3
+	MembershipChangeType_TRANSFER_LEADER MembershipChangeType = 4
 )
 
 var MembershipChangeType_name = map[int32]string{
 	0: "ADD_MEMBER",
 	1: "REMOVE_MEMBER",
+	2: "ADD_LEARNER",
+	3: "PROMOTE_LEARNER",
+	4: "TRANSFER_LEADER",
 }
 var MembershipChangeType_value = map[string]int32{
-	"ADD_MEMBER":    0,
-	"REMOVE_MEMBER": 1,
+	"ADD_MEMBER":      0,
+	"REMOVE_MEMBER":   1,
+	"ADD_LEARNER":     2,
+	"PROMOTE_LEARNER": 3,
+	"TRANSFER_LEADER": 4,
 }
 
 func (x MembershipChangeType) String() string {
@@ -73,6 +82,7 @@ type MemberAttr struct {
 	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
 	Address              string   `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
 	PeerID               []byte   `protobuf:"bytes,4,opt,name=peerID,proto3" json:"peerID,omitempty"`
+	IsLearner            bool     `protobuf:"varint,5,opt,name=isLearner,proto3" json:"isLearner,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -130,6 +140,13 @@ func (m *MemberAttr) GetPeerID() []byte {
 	return nil
 }
 
+func (m *MemberAttr) GetIsLearner() bool {
+	if m != nil {
+		return m.IsLearner
+	}
+	return false
+}
+
 type MembershipChange struct {
 	Type                 MembershipChangeType `protobuf:"varint,1,opt,name=type,proto3,enum=types.MembershipChangeType" json:"type,omitempty"`
 	RequestID            uint64               `protobuf:"varint,2,opt,name=requestID,proto3" json:"requestID,omitempty"`
@@ -462,7 +462,7 @@ type ReceiptMerkle struct {
 func (rm *ReceiptMerkle) GetHash() []byte {
 	h := sha256.New()
 	var b []byte
-	if rm.hardForkConfig.IsV2Fork(rm.blockNo) {
+	if rm.hardForkConfig.IsFeatureActive(FeatureReceiptV2, rm.blockNo) {
 		b, _ = rm.receipt.MarshalMerkleBinaryV2()
 	} else {
 		b, _ = rm.receipt.MarshalMerkleBinary()
@@ -554,7 +554,7 @@ func (rs *Receipts) MarshalBinary() ([]byte, error) {
 	var rB []byte
 	var err error
 	for _, r := range rs.receipts {
-		if rs.hardForkConfig.IsV2Fork(rs.blockNo) {
+		if rs.hardForkConfig.IsFeatureActive(FeatureReceiptV2, rs.blockNo) {
 			rB, err = r.marshalStoreBinaryV2()
 		} else {
 			rB, err = r.marshalStoreBinary()
@@ -596,7 +596,7 @@ func (rs *Receipts) UnmarshalBinary(data []byte) error {
 	var err error
 	for i := uint32(0); i < rCount; i++ {
 		var r Receipt
-		if rs.hardForkConfig.IsV2Fork(rs.blockNo) {
+		if rs.hardForkConfig.IsFeatureActive(FeatureReceiptV2, rs.blockNo) {
 			unread, err = r.unmarshalStoreBinaryV2(unread)
 		} else {
 			unread, err = r.unmarshalStoreBinary(unread)
@@ -700,6 +700,11 @@ type Peer struct {
 	Version              string          `protobuf:"bytes,7,opt,name=version,proto3" json:"version,omitempty"`
 	Certificates         []*AgentCertificate `protobuf:"bytes,8,rep,name=certificates,proto3" json:"certificates,omitempty"`
 	AcceptedRole         PeerRole            `protobuf:"varint,9,opt,name=acceptedRole,proto3,enum=types.PeerRole" json:"acceptedRole,omitempty"`
+	LastSuccess          int64               `protobuf:"varint,10,opt,name=lastSuccess,proto3" json:"lastSuccess,omitempty"`
+	LastFailure          int64               `protobuf:"varint,11,opt,name=lastFailure,proto3" json:"lastFailure,omitempty"`
+	SuccessCnt           int32               `protobuf:"varint,12,opt,name=successCnt,proto3" json:"successCnt,omitempty"`
+	FailCnt              int32               `protobuf:"varint,13,opt,name=failCnt,proto3" json:"failCnt,omitempty"`
+	Latency              int64               `protobuf:"varint,14,opt,name=latency,proto3" json:"latency,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
 	XXX_unrecognized     []byte          `json:"-"`
 	XXX_sizecache        int32           `json:"-"`
@@ -792,6 +797,41 @@ func (m *Peer) GetAcceptedRole() PeerRole {
 	return PeerRole_LegacyVersion
 }
 
+func (m *Peer) GetLastSuccess() int64 {
+	if m != nil {
+		return m.LastSuccess
+	}
+	return 0
+}
+
+func (m *Peer) GetLastFailure() int64 {
+	if m != nil {
+		return m.LastFailure
+	}
+	return 0
+}
+
+func (m *Peer) GetSuccessCnt() int32 {
+	if m != nil {
+		return m.SuccessCnt
+	}
+	return 0
+}
+
+func (m *Peer) GetFailCnt() int32 {
+	if m != nil {
+		return m.FailCnt
+	}
+	return 0
+}
+
+func (m *Peer) GetLatency() int64 {
+	if m != nil {
+		return m.Latency
+	}
+	return 0
+}
+
 type PeerList struct {
 	Peers                []*Peer  `protobuf:"bytes,1,rep,name=peers,proto3" json:"peers,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -1431,11 +1471,12 @@ func (m *ImportFormat) GetNewpass() string {
 }
 
 type Staking struct {
-	Amount               []byte   `protobuf:"bytes,1,opt,name=amount,proto3" json:"amount,omitempty"`
-	When                 uint64   `protobuf:"varint,2,opt,name=when,proto3" json:"when,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Amount               []byte       `protobuf:"bytes,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	When                 uint64       `protobuf:"varint,2,opt,name=when,proto3" json:"when,omitempty"`
+	Unbondings           []*Unbonding `protobuf:"bytes,3,rep,name=unbondings,proto3" json:"unbondings,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
 }
 
 func (m *Staking) Reset()         { *m = Staking{} }
@@ -1476,6 +1517,13 @@ func (m *Staking) GetWhen() uint64 {
 	return 0
 }
 
+func (m *Staking) GetUnbondings() []*Unbonding {
+	if m != nil {
+		return m.Unbondings
+	}
+	return nil
+}
+
 type Vote struct {
 	Candidate            []byte   `protobuf:"bytes,1,opt,name=candidate,proto3" json:"candidate,omitempty"`
 	Amount               []byte   `protobuf:"bytes,2,opt,name=amount,proto3" json:"amount,omitempty"`
@@ -1571,6 +1619,8 @@ func (m *VoteParams) GetCount() uint32 {
 type AccountVoteInfo struct {
 	Staking              *Staking    `protobuf:"bytes,1,opt,name=staking,proto3" json:"staking,omitempty"`
 	Voting               []*VoteInfo `protobuf:"bytes,2,rep,name=voting,proto3" json:"voting,omitempty"`
+	Delegate             string      `protobuf:"bytes,3,opt,name=delegate,proto3" json:"delegate,omitempty"`
+	Delegated            string      `protobuf:"bytes,4,opt,name=delegated,proto3" json:"delegated,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
 	XXX_unrecognized     []byte      `json:"-"`
 	XXX_sizecache        int32       `json:"-"`
@@ -1614,6 +1664,20 @@ func (m *AccountVoteInfo) GetVoting() []*VoteInfo {
 	return nil
 }
 
+func (m *AccountVoteInfo) GetDelegate() string {
+	if m != nil {
+		return m.Delegate
+	}
+	return ""
+}
+
+func (m *AccountVoteInfo) GetDelegated() string {
+	if m != nil {
+		return m.Delegated
+	}
+	return ""
+}
+
 type VoteInfo struct {
 	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	Candidates           []string `protobuf:"bytes,2,rep,name=candidates,proto3" json:"candidates,omitempty"`
@@ -1810,6 +1874,8 @@ type NameInfo struct {
 	Name                 *Name    `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	Owner                []byte   `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`
 	Destination          []byte   `protobuf:"bytes,3,opt,name=destination,proto3" json:"destination,omitempty"`
+	ExpireNo             uint64   `protobuf:"varint,4,opt,name=expireNo,proto3" json:"expireNo,omitempty"`
+	Status               string   `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1860,9 +1926,24 @@ func (m *NameInfo) GetDestination() []byte {
 	return nil
 }
 
+func (m *NameInfo) GetExpireNo() uint64 {
+	if m != nil {
+		return m.ExpireNo
+	}
+	return 0
+}
+
+func (m *NameInfo) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
 type PeersParams struct {
 	NoHidden             bool     `protobuf:"varint,1,opt,name=noHidden,proto3" json:"noHidden,omitempty"`
 	ShowSelf             bool     `protobuf:"varint,2,opt,name=showSelf,proto3" json:"showSelf,omitempty"`
+	Known                bool     `protobuf:"varint,3,opt,name=known,proto3" json:"known,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1906,6 +1987,13 @@ func (m *PeersParams) GetShowSelf() bool {
 	return false
 }
 
+func (m *PeersParams) GetKnown() bool {
+	if m != nil {
+		return m.Known
+	}
+	return false
+}
+
 type KeyParams struct {
 	Key                  []string `protobuf:"bytes,1,rep,name=key,proto3" json:"key,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -2150,69 +2238,568 @@ func (m *EnterpriseConfigKey) XXX_DiscardUnknown() {
 	xxx_messageInfo_EnterpriseConfigKey.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_EnterpriseConfigKey proto.InternalMessageInfo
-
-func (m *EnterpriseConfigKey) GetKey() string {
+var xxx_messageInfo_EnterpriseConfigKey proto.InternalMessageInfo
+
+func (m *EnterpriseConfigKey) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+type EnterpriseConfig struct {
+	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	On                   bool     `protobuf:"varint,2,opt,name=on,proto3" json:"on,omitempty"`
+	Values               []string `protobuf:"bytes,3,rep,name=values,proto3" json:"values,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EnterpriseConfig) Reset()         { *m = EnterpriseConfig{} }
+func (m *EnterpriseConfig) String() string { return proto.CompactTextString(m) }
+func (*EnterpriseConfig) ProtoMessage()    {}
+func (*EnterpriseConfig) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_6be6c88022a0cf1f, []int{41}
+}
+func (m *EnterpriseConfig) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EnterpriseConfig.Unmarshal(m, b)
+}
+func (m *EnterpriseConfig) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EnterpriseConfig.Marshal(b, m, deterministic)
+}
+func (dst *EnterpriseConfig) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EnterpriseConfig.Merge(dst, src)
+}
+func (m *EnterpriseConfig) XXX_Size() int {
+	return xxx_messageInfo_EnterpriseConfig.Size(m)
+}
+func (m *EnterpriseConfig) XXX_DiscardUnknown() {
+	xxx_messageInfo_EnterpriseConfig.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EnterpriseConfig proto.InternalMessageInfo
+
+func (m *EnterpriseConfig) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *EnterpriseConfig) GetOn() bool {
+	if m != nil {
+		return m.On
+	}
+	return false
+}
+
+func (m *EnterpriseConfig) GetValues() []string {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
+type BPStatParams struct {
+	BlockNo              uint64   `protobuf:"varint,1,opt,name=blockNo,proto3" json:"blockNo,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BPStatParams) Reset()         { *m = BPStatParams{} }
+func (m *BPStatParams) String() string { return proto.CompactTextString(m) }
+func (*BPStatParams) ProtoMessage()    {}
+func (m *BPStatParams) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BPStatParams.Unmarshal(m, b)
+}
+func (m *BPStatParams) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BPStatParams.Marshal(b, m, deterministic)
+}
+func (dst *BPStatParams) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BPStatParams.Merge(dst, src)
+}
+func (m *BPStatParams) XXX_Size() int {
+	return xxx_messageInfo_BPStatParams.Size(m)
+}
+func (m *BPStatParams) XXX_DiscardUnknown() {
+	xxx_messageInfo_BPStatParams.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BPStatParams proto.InternalMessageInfo
+
+func (m *BPStatParams) GetBlockNo() uint64 {
+	if m != nil {
+		return m.BlockNo
+	}
+	return 0
+}
+
+type BPStat struct {
+	PeerID               string   `protobuf:"bytes,1,opt,name=peerID,proto3" json:"peerID,omitempty"`
+	Assigned             uint64   `protobuf:"varint,2,opt,name=assigned,proto3" json:"assigned,omitempty"`
+	Produced             uint64   `protobuf:"varint,3,opt,name=produced,proto3" json:"produced,omitempty"`
+	Missed               uint64   `protobuf:"varint,4,opt,name=missed,proto3" json:"missed,omitempty"`
+	Orphaned             uint64   `protobuf:"varint,5,opt,name=orphaned,proto3" json:"orphaned,omitempty"`
+	AvgLatency           int64    `protobuf:"varint,6,opt,name=avgLatency,proto3" json:"avgLatency,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BPStat) Reset()         { *m = BPStat{} }
+func (m *BPStat) String() string { return proto.CompactTextString(m) }
+func (*BPStat) ProtoMessage()    {}
+func (m *BPStat) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BPStat.Unmarshal(m, b)
+}
+func (m *BPStat) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BPStat.Marshal(b, m, deterministic)
+}
+func (dst *BPStat) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BPStat.Merge(dst, src)
+}
+func (m *BPStat) XXX_Size() int {
+	return xxx_messageInfo_BPStat.Size(m)
+}
+func (m *BPStat) XXX_DiscardUnknown() {
+	xxx_messageInfo_BPStat.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BPStat proto.InternalMessageInfo
+
+func (m *BPStat) GetPeerID() string {
+	if m != nil {
+		return m.PeerID
+	}
+	return ""
+}
+
+func (m *BPStat) GetAssigned() uint64 {
+	if m != nil {
+		return m.Assigned
+	}
+	return 0
+}
+
+func (m *BPStat) GetProduced() uint64 {
+	if m != nil {
+		return m.Produced
+	}
+	return 0
+}
+
+func (m *BPStat) GetMissed() uint64 {
+	if m != nil {
+		return m.Missed
+	}
+	return 0
+}
+
+func (m *BPStat) GetOrphaned() uint64 {
+	if m != nil {
+		return m.Orphaned
+	}
+	return 0
+}
+
+func (m *BPStat) GetAvgLatency() int64 {
+	if m != nil {
+		return m.AvgLatency
+	}
+	return 0
+}
+
+type BPStatList struct {
+	StartNo              uint64    `protobuf:"varint,1,opt,name=startNo,proto3" json:"startNo,omitempty"`
+	EndNo                uint64    `protobuf:"varint,2,opt,name=endNo,proto3" json:"endNo,omitempty"`
+	Stats                []*BPStat `protobuf:"bytes,3,rep,name=stats,proto3" json:"stats,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *BPStatList) Reset()         { *m = BPStatList{} }
+func (m *BPStatList) String() string { return proto.CompactTextString(m) }
+func (*BPStatList) ProtoMessage()    {}
+func (m *BPStatList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BPStatList.Unmarshal(m, b)
+}
+func (m *BPStatList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BPStatList.Marshal(b, m, deterministic)
+}
+func (dst *BPStatList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BPStatList.Merge(dst, src)
+}
+func (m *BPStatList) XXX_Size() int {
+	return xxx_messageInfo_BPStatList.Size(m)
+}
+func (m *BPStatList) XXX_DiscardUnknown() {
+	xxx_messageInfo_BPStatList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BPStatList proto.InternalMessageInfo
+
+func (m *BPStatList) GetStartNo() uint64 {
+	if m != nil {
+		return m.StartNo
+	}
+	return 0
+}
+
+func (m *BPStatList) GetEndNo() uint64 {
+	if m != nil {
+		return m.EndNo
+	}
+	return 0
+}
+
+func (m *BPStatList) GetStats() []*BPStat {
+	if m != nil {
+		return m.Stats
+	}
+	return nil
+}
+
+type Unbonding struct {
+	Amount               []byte   `protobuf:"bytes,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	Release              uint64   `protobuf:"varint,2,opt,name=release,proto3" json:"release,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Unbonding) Reset()         { *m = Unbonding{} }
+func (m *Unbonding) String() string { return proto.CompactTextString(m) }
+func (*Unbonding) ProtoMessage()    {}
+func (m *Unbonding) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Unbonding.Unmarshal(m, b)
+}
+func (m *Unbonding) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Unbonding.Marshal(b, m, deterministic)
+}
+func (dst *Unbonding) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Unbonding.Merge(dst, src)
+}
+func (m *Unbonding) XXX_Size() int {
+	return xxx_messageInfo_Unbonding.Size(m)
+}
+func (m *Unbonding) XXX_DiscardUnknown() {
+	xxx_messageInfo_Unbonding.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Unbonding proto.InternalMessageInfo
+
+func (m *Unbonding) GetAmount() []byte {
+	if m != nil {
+		return m.Amount
+	}
+	return nil
+}
+
+func (m *Unbonding) GetRelease() uint64 {
+	if m != nil {
+		return m.Release
+	}
+	return 0
+}
+
+type VotingReward struct {
+	Address              []byte   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Power                []byte   `protobuf:"bytes,2,opt,name=power,proto3" json:"power,omitempty"`
+	Accrued              []byte   `protobuf:"bytes,3,opt,name=accrued,proto3" json:"accrued,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *VotingReward) Reset()         { *m = VotingReward{} }
+func (m *VotingReward) String() string { return proto.CompactTextString(m) }
+func (*VotingReward) ProtoMessage()    {}
+func (m *VotingReward) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VotingReward.Unmarshal(m, b)
+}
+func (m *VotingReward) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VotingReward.Marshal(b, m, deterministic)
+}
+func (dst *VotingReward) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VotingReward.Merge(dst, src)
+}
+func (m *VotingReward) XXX_Size() int {
+	return xxx_messageInfo_VotingReward.Size(m)
+}
+func (m *VotingReward) XXX_DiscardUnknown() {
+	xxx_messageInfo_VotingReward.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VotingReward proto.InternalMessageInfo
+
+func (m *VotingReward) GetAddress() []byte {
+	if m != nil {
+		return m.Address
+	}
+	return nil
+}
+
+func (m *VotingReward) GetPower() []byte {
+	if m != nil {
+		return m.Power
+	}
+	return nil
+}
+
+func (m *VotingReward) GetAccrued() []byte {
+	if m != nil {
+		return m.Accrued
+	}
+	return nil
+}
+
+type HDWallet struct {
+	Passphrase           string   `protobuf:"bytes,1,opt,name=passphrase,proto3" json:"passphrase,omitempty"`
+	Mnemonic             string   `protobuf:"bytes,2,opt,name=mnemonic,proto3" json:"mnemonic,omitempty"`
+	Path                 string   `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HDWallet) Reset()         { *m = HDWallet{} }
+func (m *HDWallet) String() string { return proto.CompactTextString(m) }
+func (*HDWallet) ProtoMessage()    {}
+func (m *HDWallet) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HDWallet.Unmarshal(m, b)
+}
+func (m *HDWallet) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HDWallet.Marshal(b, m, deterministic)
+}
+func (dst *HDWallet) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HDWallet.Merge(dst, src)
+}
+func (m *HDWallet) XXX_Size() int {
+	return xxx_messageInfo_HDWallet.Size(m)
+}
+func (m *HDWallet) XXX_DiscardUnknown() {
+	xxx_messageInfo_HDWallet.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HDWallet proto.InternalMessageInfo
+
+func (m *HDWallet) GetPassphrase() string {
+	if m != nil {
+		return m.Passphrase
+	}
+	return ""
+}
+
+func (m *HDWallet) GetMnemonic() string {
+	if m != nil {
+		return m.Mnemonic
+	}
+	return ""
+}
+
+func (m *HDWallet) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+type HDAccount struct {
+	Passphrase           string   `protobuf:"bytes,1,opt,name=passphrase,proto3" json:"passphrase,omitempty"`
+	Index                uint32   `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HDAccount) Reset()         { *m = HDAccount{} }
+func (m *HDAccount) String() string { return proto.CompactTextString(m) }
+func (*HDAccount) ProtoMessage()    {}
+func (m *HDAccount) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HDAccount.Unmarshal(m, b)
+}
+func (m *HDAccount) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HDAccount.Marshal(b, m, deterministic)
+}
+func (dst *HDAccount) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HDAccount.Merge(dst, src)
+}
+func (m *HDAccount) XXX_Size() int {
+	return xxx_messageInfo_HDAccount.Size(m)
+}
+func (m *HDAccount) XXX_DiscardUnknown() {
+	xxx_messageInfo_HDAccount.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HDAccount proto.InternalMessageInfo
+
+func (m *HDAccount) GetPassphrase() string {
+	if m != nil {
+		return m.Passphrase
+	}
+	return ""
+}
+
+func (m *HDAccount) GetIndex() uint32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+type ContractSource struct {
+	ContractAddress      []byte   `protobuf:"bytes,1,opt,name=contractAddress,proto3" json:"contractAddress,omitempty"`
+	Source               string   `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	CompilerVersion      string   `protobuf:"bytes,3,opt,name=compilerVersion,proto3" json:"compilerVersion,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ContractSource) Reset()         { *m = ContractSource{} }
+func (m *ContractSource) String() string { return proto.CompactTextString(m) }
+func (*ContractSource) ProtoMessage()    {}
+func (m *ContractSource) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ContractSource.Unmarshal(m, b)
+}
+func (m *ContractSource) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ContractSource.Marshal(b, m, deterministic)
+}
+func (dst *ContractSource) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ContractSource.Merge(dst, src)
+}
+func (m *ContractSource) XXX_Size() int {
+	return xxx_messageInfo_ContractSource.Size(m)
+}
+func (m *ContractSource) XXX_DiscardUnknown() {
+	xxx_messageInfo_ContractSource.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ContractSource proto.InternalMessageInfo
+
+func (m *ContractSource) GetContractAddress() []byte {
+	if m != nil {
+		return m.ContractAddress
+	}
+	return nil
+}
+
+func (m *ContractSource) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+func (m *ContractSource) GetCompilerVersion() string {
 	if m != nil {
-		return m.Key
+		return m.CompilerVersion
 	}
 	return ""
 }
 
-type EnterpriseConfig struct {
-	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
-	On                   bool     `protobuf:"varint,2,opt,name=on,proto3" json:"on,omitempty"`
-	Values               []string `protobuf:"bytes,3,rep,name=values,proto3" json:"values,omitempty"`
+type SourceVerification struct {
+	Verified             bool     `protobuf:"varint,1,opt,name=verified,proto3" json:"verified,omitempty"`
+	CompilerVersion      string   `protobuf:"bytes,2,opt,name=compilerVersion,proto3" json:"compilerVersion,omitempty"`
+	ChunkName            string   `protobuf:"bytes,3,opt,name=chunkName,proto3" json:"chunkName,omitempty"`
+	DeployedHash         []byte   `protobuf:"bytes,4,opt,name=deployedHash,proto3" json:"deployedHash,omitempty"`
+	CompiledHash         []byte   `protobuf:"bytes,5,opt,name=compiledHash,proto3" json:"compiledHash,omitempty"`
+	AbiMatch             bool     `protobuf:"varint,6,opt,name=abiMatch,proto3" json:"abiMatch,omitempty"`
+	SourceHash           string   `protobuf:"bytes,7,opt,name=sourceHash,proto3" json:"sourceHash,omitempty"`
+	SourceHashMatch      bool     `protobuf:"varint,8,opt,name=sourceHashMatch,proto3" json:"sourceHashMatch,omitempty"`
+	Reason               string   `protobuf:"bytes,9,opt,name=reason,proto3" json:"reason,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *EnterpriseConfig) Reset()         { *m = EnterpriseConfig{} }
-func (m *EnterpriseConfig) String() string { return proto.CompactTextString(m) }
-func (*EnterpriseConfig) ProtoMessage()    {}
-func (*EnterpriseConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rpc_6be6c88022a0cf1f, []int{41}
+func (m *SourceVerification) Reset()         { *m = SourceVerification{} }
+func (m *SourceVerification) String() string { return proto.CompactTextString(m) }
+func (*SourceVerification) ProtoMessage()    {}
+func (m *SourceVerification) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SourceVerification.Unmarshal(m, b)
 }
-func (m *EnterpriseConfig) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_EnterpriseConfig.Unmarshal(m, b)
+func (m *SourceVerification) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SourceVerification.Marshal(b, m, deterministic)
 }
-func (m *EnterpriseConfig) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_EnterpriseConfig.Marshal(b, m, deterministic)
+func (dst *SourceVerification) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SourceVerification.Merge(dst, src)
 }
-func (dst *EnterpriseConfig) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_EnterpriseConfig.Merge(dst, src)
+func (m *SourceVerification) XXX_Size() int {
+	return xxx_messageInfo_SourceVerification.Size(m)
 }
-func (m *EnterpriseConfig) XXX_Size() int {
-	return xxx_messageInfo_EnterpriseConfig.Size(m)
+func (m *SourceVerification) XXX_DiscardUnknown() {
+	xxx_messageInfo_SourceVerification.DiscardUnknown(m)
 }
-func (m *EnterpriseConfig) XXX_DiscardUnknown() {
-	xxx_messageInfo_EnterpriseConfig.DiscardUnknown(m)
+
+var xxx_messageInfo_SourceVerification proto.InternalMessageInfo
+
+func (m *SourceVerification) GetVerified() bool {
+	if m != nil {
+		return m.Verified
+	}
+	return false
 }
 
-var xxx_messageInfo_EnterpriseConfig proto.InternalMessageInfo
+func (m *SourceVerification) GetCompilerVersion() string {
+	if m != nil {
+		return m.CompilerVersion
+	}
+	return ""
+}
 
-func (m *EnterpriseConfig) GetKey() string {
+func (m *SourceVerification) GetChunkName() string {
 	if m != nil {
-		return m.Key
+		return m.ChunkName
 	}
 	return ""
 }
 
-func (m *EnterpriseConfig) GetOn() bool {
+func (m *SourceVerification) GetDeployedHash() []byte {
 	if m != nil {
-		return m.On
+		return m.DeployedHash
 	}
-	return false
+	return nil
 }
 
-func (m *EnterpriseConfig) GetValues() []string {
+func (m *SourceVerification) GetCompiledHash() []byte {
 	if m != nil {
-		return m.Values
+		return m.CompiledHash
 	}
 	return nil
 }
 
+func (m *SourceVerification) GetAbiMatch() bool {
+	if m != nil {
+		return m.AbiMatch
+	}
+	return false
+}
+
+func (m *SourceVerification) GetSourceHash() string {
+	if m != nil {
+		return m.SourceHash
+	}
+	return ""
+}
+
+func (m *SourceVerification) GetSourceHashMatch() bool {
+	if m != nil {
+		return m.SourceHashMatch
+	}
+	return false
+}
+
+func (m *SourceVerification) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*BlockchainStatus)(nil), "types.BlockchainStatus")
 	proto.RegisterType((*ChainId)(nil), "types.ChainId")
@@ -2259,6 +2846,15 @@ func init() {
 	proto.RegisterType((*ConsensusInfo)(nil), "types.ConsensusInfo")
 	proto.RegisterType((*EnterpriseConfigKey)(nil), "types.EnterpriseConfigKey")
 	proto.RegisterType((*EnterpriseConfig)(nil), "types.EnterpriseConfig")
+	proto.RegisterType((*BPStatParams)(nil), "types.BPStatParams")
+	proto.RegisterType((*BPStat)(nil), "types.BPStat")
+	proto.RegisterType((*BPStatList)(nil), "types.BPStatList")
+	proto.RegisterType((*Unbonding)(nil), "types.Unbonding")
+	proto.RegisterType((*VotingReward)(nil), "types.VotingReward")
+	proto.RegisterType((*HDWallet)(nil), "types.HDWallet")
+	proto.RegisterType((*HDAccount)(nil), "types.HDAccount")
+	proto.RegisterType((*ContractSource)(nil), "types.ContractSource")
+	proto.RegisterType((*SourceVerification)(nil), "types.SourceVerification")
 	proto.RegisterEnum("types.CommitStatus", CommitStatus_name, CommitStatus_value)
 	proto.RegisterEnum("types.VerifyStatus", VerifyStatus_name, VerifyStatus_value)
 }
@@ -2359,6 +2955,24 @@ type AergoRPCServiceClient interface {
 	GetEnterpriseConfig(ctx context.Context, in *EnterpriseConfigKey, opts ...grpc.CallOption) (*EnterpriseConfig, error)
 	// Return a status of changeCluster enterprise tx,  queried by requestID
 	GetConfChangeProgress(ctx context.Context, in *SingleBytes, opts ...grpc.CallOption) (*ConfChangeProgress, error)
+	// Returns evidences of block producers which signed two different blocks for the same slot
+	ListEvidences(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*DoubleSignEvidenceList, error)
+	// Returns block production statistics of each block producer for an election period
+	GetBPStats(ctx context.Context, in *BPStatParams, opts ...grpc.CallOption) (*BPStatList, error)
+	// Return the voting reward accrued to an account
+	GetVotingReward(ctx context.Context, in *AccountAddress, opts ...grpc.CallOption) (*VotingReward, error)
+	// Create the HD wallet of the node key store from a new mnemonic
+	CreateHDWallet(ctx context.Context, in *HDWallet, opts ...grpc.CallOption) (*HDWallet, error)
+	// Import the HD wallet of the node key store from a mnemonic
+	ImportHDWallet(ctx context.Context, in *HDWallet, opts ...grpc.CallOption) (*HDWallet, error)
+	// Derive the accounts of the HD wallet up to the index
+	DeriveHDAccount(ctx context.Context, in *HDAccount, opts ...grpc.CallOption) (*Account, error)
+	// Return the derived accounts in the order of their index
+	GetHDAccounts(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*AccountList, error)
+	// Unlock the derived account at the index
+	UnlockHDAccount(ctx context.Context, in *HDAccount, opts ...grpc.CallOption) (*Account, error)
+	// Recompile the source of a contract and compare it with the deployed code
+	VerifyContractSource(ctx context.Context, in *ContractSource, opts ...grpc.CallOption) (*SourceVerification, error)
 }
 
 type aergoRPCServiceClient struct {
@@ -2816,6 +3430,87 @@ func (c *aergoRPCServiceClient) GetConfChangeProgress(ctx context.Context, in *S
 	return out, nil
 }
 
+func (c *aergoRPCServiceClient) ListEvidences(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*DoubleSignEvidenceList, error) {
+	out := new(DoubleSignEvidenceList)
+	err := c.cc.Invoke(ctx, "/types.AergoRPCService/ListEvidences", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aergoRPCServiceClient) GetBPStats(ctx context.Context, in *BPStatParams, opts ...grpc.CallOption) (*BPStatList, error) {
+	out := new(BPStatList)
+	err := c.cc.Invoke(ctx, "/types.AergoRPCService/GetBPStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aergoRPCServiceClient) GetVotingReward(ctx context.Context, in *AccountAddress, opts ...grpc.CallOption) (*VotingReward, error) {
+	out := new(VotingReward)
+	err := c.cc.Invoke(ctx, "/types.AergoRPCService/GetVotingReward", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aergoRPCServiceClient) CreateHDWallet(ctx context.Context, in *HDWallet, opts ...grpc.CallOption) (*HDWallet, error) {
+	out := new(HDWallet)
+	err := c.cc.Invoke(ctx, "/types.AergoRPCService/CreateHDWallet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aergoRPCServiceClient) ImportHDWallet(ctx context.Context, in *HDWallet, opts ...grpc.CallOption) (*HDWallet, error) {
+	out := new(HDWallet)
+	err := c.cc.Invoke(ctx, "/types.AergoRPCService/ImportHDWallet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aergoRPCServiceClient) DeriveHDAccount(ctx context.Context, in *HDAccount, opts ...grpc.CallOption) (*Account, error) {
+	out := new(Account)
+	err := c.cc.Invoke(ctx, "/types.AergoRPCService/DeriveHDAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aergoRPCServiceClient) GetHDAccounts(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*AccountList, error) {
+	out := new(AccountList)
+	err := c.cc.Invoke(ctx, "/types.AergoRPCService/GetHDAccounts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aergoRPCServiceClient) UnlockHDAccount(ctx context.Context, in *HDAccount, opts ...grpc.CallOption) (*Account, error) {
+	out := new(Account)
+	err := c.cc.Invoke(ctx, "/types.AergoRPCService/UnlockHDAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aergoRPCServiceClient) VerifyContractSource(ctx context.Context, in *ContractSource, opts ...grpc.CallOption) (*SourceVerification, error) {
+	out := new(SourceVerification)
+	err := c.cc.Invoke(ctx, "/types.AergoRPCService/VerifyContractSource", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AergoRPCServiceServer is the server API for AergoRPCService service.
 type AergoRPCServiceServer interface {
 	// Returns the current state of this node
@@ -2902,6 +3597,24 @@ type AergoRPCServiceServer interface {
 	GetEnterpriseConfig(context.Context, *EnterpriseConfigKey) (*EnterpriseConfig, error)
 	// Return a status of changeCluster enterprise tx,  queried by requestID
 	GetConfChangeProgress(context.Context, *SingleBytes) (*ConfChangeProgress, error)
+	// Returns evidences of block producers which signed two different blocks for the same slot
+	ListEvidences(context.Context, *Empty) (*DoubleSignEvidenceList, error)
+	// Returns block production statistics of each block producer for an election period
+	GetBPStats(context.Context, *BPStatParams) (*BPStatList, error)
+	// Return the voting reward accrued to an account
+	GetVotingReward(context.Context, *AccountAddress) (*VotingReward, error)
+	// Create the HD wallet of the node key store from a new mnemonic
+	CreateHDWallet(context.Context, *HDWallet) (*HDWallet, error)
+	// Import the HD wallet of the node key store from a mnemonic
+	ImportHDWallet(context.Context, *HDWallet) (*HDWallet, error)
+	// Derive the accounts of the HD wallet up to the index
+	DeriveHDAccount(context.Context, *HDAccount) (*Account, error)
+	// Return the derived accounts in the order of their index
+	GetHDAccounts(context.Context, *Empty) (*AccountList, error)
+	// Unlock the derived account at the index
+	UnlockHDAccount(context.Context, *HDAccount) (*Account, error)
+	// Recompile the source of a contract and compare it with the deployed code
+	VerifyContractSource(context.Context, *ContractSource) (*SourceVerification, error)
 }
 
 func RegisterAergoRPCServiceServer(s *grpc.Server, srv AergoRPCServiceServer) {
@@ -3673,6 +4386,168 @@ func _AergoRPCService_GetConfChangeProgress_Handler(srv interface{}, ctx context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AergoRPCService_ListEvidences_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AergoRPCServiceServer).ListEvidences(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.AergoRPCService/ListEvidences",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AergoRPCServiceServer).ListEvidences(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AergoRPCService_GetBPStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BPStatParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AergoRPCServiceServer).GetBPStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.AergoRPCService/GetBPStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AergoRPCServiceServer).GetBPStats(ctx, req.(*BPStatParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AergoRPCService_GetVotingReward_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountAddress)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AergoRPCServiceServer).GetVotingReward(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.AergoRPCService/GetVotingReward",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AergoRPCServiceServer).GetVotingReward(ctx, req.(*AccountAddress))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AergoRPCService_CreateHDWallet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HDWallet)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AergoRPCServiceServer).CreateHDWallet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.AergoRPCService/CreateHDWallet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AergoRPCServiceServer).CreateHDWallet(ctx, req.(*HDWallet))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AergoRPCService_ImportHDWallet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HDWallet)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AergoRPCServiceServer).ImportHDWallet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.AergoRPCService/ImportHDWallet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AergoRPCServiceServer).ImportHDWallet(ctx, req.(*HDWallet))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AergoRPCService_DeriveHDAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HDAccount)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AergoRPCServiceServer).DeriveHDAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.AergoRPCService/DeriveHDAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AergoRPCServiceServer).DeriveHDAccount(ctx, req.(*HDAccount))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AergoRPCService_GetHDAccounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AergoRPCServiceServer).GetHDAccounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.AergoRPCService/GetHDAccounts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AergoRPCServiceServer).GetHDAccounts(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AergoRPCService_UnlockHDAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HDAccount)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AergoRPCServiceServer).UnlockHDAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.AergoRPCService/UnlockHDAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AergoRPCServiceServer).UnlockHDAccount(ctx, req.(*HDAccount))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AergoRPCService_VerifyContractSource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ContractSource)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AergoRPCServiceServer).VerifyContractSource(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.AergoRPCService/VerifyContractSource",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AergoRPCServiceServer).VerifyContractSource(ctx, req.(*ContractSource))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _AergoRPCService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "types.AergoRPCService",
 	HandlerType: (*AergoRPCServiceServer)(nil),
@@ -3833,6 +4708,42 @@ var _AergoRPCService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetConfChangeProgress",
 			Handler:    _AergoRPCService_GetConfChangeProgress_Handler,
 		},
+		{
+			MethodName: "ListEvidences",
+			Handler:    _AergoRPCService_ListEvidences_Handler,
+		},
+		{
+			MethodName: "GetBPStats",
+			Handler:    _AergoRPCService_GetBPStats_Handler,
+		},
+		{
+			MethodName: "GetVotingReward",
+			Handler:    _AergoRPCService_GetVotingReward_Handler,
+		},
+		{
+			MethodName: "CreateHDWallet",
+			Handler:    _AergoRPCService_CreateHDWallet_Handler,
+		},
+		{
+			MethodName: "ImportHDWallet",
+			Handler:    _AergoRPCService_ImportHDWallet_Handler,
+		},
+		{
+			MethodName: "DeriveHDAccount",
+			Handler:    _AergoRPCService_DeriveHDAccount_Handler,
+		},
+		{
+			MethodName: "GetHDAccounts",
+			Handler:    _AergoRPCService_GetHDAccounts_Handler,
+		},
+		{
+			MethodName: "UnlockHDAccount",
+			Handler:    _AergoRPCService_UnlockHDAccount_Handler,
+		},
+		{
+			MethodName: "VerifyContractSource",
+			Handler:    _AergoRPCService_VerifyContractSource_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -12,11 +12,15 @@ func _() {
 	_ = x[StakingMin-1]
 	_ = x[GasPrice-2]
 	_ = x[NamePrice-3]
+	_ = x[MaxBlockSize-4]
+	_ = x[StakingDelay-5]
+	_ = x[UnbondingPeriod-6]
+	_ = x[VotingRewardAmount-7]
 }
 
-const _SystemValue_name = "StakingTotalStakingMinGasPriceNamePrice"
+const _SystemValue_name = "StakingTotalStakingMinGasPriceNamePriceMaxBlockSizeStakingDelayUnbondingPeriodVotingRewardAmount"
 
-var _SystemValue_index = [...]uint8{0, 12, 22, 30, 39}
+var _SystemValue_index = [...]uint8{0, 12, 22, 30, 39, 51, 63, 78, 96}
 
 func (i SystemValue) String() string {
 	if i < 0 || i >= SystemValue(len(_SystemValue_index)-1) {
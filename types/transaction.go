@@ -18,6 +18,9 @@ import (
 const SetContractOwner = "v1setOwner"
 const NameCreate = "v1createName"
 const NameUpdate = "v1updateName"
+const NameRenew = "v1renewName"
+const NameSetSubname = "v1setSubname"
+const NameSetPrimary = "v1setPrimaryName"
 
 const TxMaxSize = 200 * 1024
 
@@ -118,6 +121,9 @@ func (tx *transaction) Validate(chainidhash []byte, isPublic bool) error {
 	if gasprice.Cmp(MaxAER) > 0 {
 		return ErrTxInvalidPrice
 	}
+	if tx.GetBody().GetGasTipBigInt().Cmp(MaxAER) > 0 {
+		return ErrTxInvalidPrice
+	}
 
 	if len(tx.GetBody().GetAccount()) > AddressLength {
 		return ErrTxInvalidAccount
@@ -127,6 +133,14 @@ func (tx *transaction) Validate(chainidhash []byte, isPublic bool) error {
 		return ErrTxInvalidRecipient
 	}
 
+	if tx.GetBody().GetValidUntilTime() < 0 {
+		return ErrTxFormatInvalid
+	}
+
+	if err := validateMultisigSign(tx.GetBody()); err != nil {
+		return err
+	}
+
 	switch tx.GetBody().Type {
 	case TxType_REDEPLOY:
 		if isPublic {
@@ -167,12 +181,44 @@ func (tx *transaction) Validate(chainidhash []byte, isPublic bool) error {
 		if len(tx.GetBody().GetPayload()) == 0 {
 			return ErrTxFormatInvalid
 		}
+	case TxType_MULTISIG:
+		config, err := ParseMultisigConfig(tx.GetBody().GetPayload())
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(config.Address(), tx.GetBody().GetRecipient()) {
+			return ErrTxInvalidRecipient
+		}
+	case TxType_BATCH:
+		if err := validateBatch(tx.GetBody()); err != nil {
+			return err
+		}
 	default:
 		return ErrTxInvalidType
 	}
 	return nil
 }
 
+// validateMultisigSign checks the structure of a multisig witness. The
+// signatures are verified with the sign of the tx.
+func validateMultisigSign(body *TxBody) error {
+	if !IsMultisigSign(body.GetSign()) {
+		return nil
+	}
+	ms, err := ParseMultisigSign(body.GetSign())
+	if err != nil {
+		return err
+	}
+	account := body.GetAccount()
+	if len(account) == AddressLength && !bytes.Equal(ms.Config.Address(), account) {
+		return ErrTxInvalidMultisig
+	}
+	if len(ms.Signatures) < ms.Config.Threshold {
+		return ErrTxInvalidMultisig
+	}
+	return nil
+}
+
 func validate(tx *TxBody) error {
 	if val, exist := govValidators[string(tx.GetRecipient())]; exist {
 		return val(tx)
@@ -190,6 +236,23 @@ func ValidateSystemTx(tx *TxBody) error {
 	switch op {
 	case Opstake,
 		Opunstake:
+	case Opwithdraw,
+		OpclaimReward,
+		Opundelegate:
+		if len(ci.Args) != 0 {
+			return ErrTxInvalidPayload
+		}
+	case Opdelegate:
+		if len(ci.Args) != 1 {
+			return ErrTxInvalidPayload
+		}
+		encoded, ok := ci.Args[0].(string)
+		if !ok {
+			return ErrTxInvalidPayload
+		}
+		if delegate, err := DecodeAddress(encoded); err != nil || len(delegate) != AddressLength {
+			return ErrTxInvalidPayload
+		}
 	case OpvoteBP:
 		unique := map[string]int{}
 		for i, v := range ci.Args {
@@ -213,6 +276,17 @@ func ValidateSystemTx(tx *TxBody) error {
 				return ErrTxInvalidPayload
 			}
 		}
+	case OpsubmitEvidence:
+		if len(ci.Args) != 1 {
+			return ErrTxInvalidPayload
+		}
+		encoded, ok := ci.Args[0].(string)
+		if !ok {
+			return ErrTxInvalidPayload
+		}
+		if _, err := DecodeDoubleSignEvidence(encoded); err != nil {
+			return ErrTxInvalidPayload
+		}
 	case OpvoteDAO:
 		if len(ci.Args) < 1 {
 			return fmt.Errorf("the number of args less then 1")
@@ -261,6 +335,57 @@ func validateNameTx(tx *TxBody) error {
 		if len(to) > AddressLength {
 			return fmt.Errorf("too long name %s", string(tx.GetPayload()))
 		}
+	case NameRenew:
+		if err := _validateNameTx(tx, &ci); err != nil {
+			return err
+		}
+		if len(ci.Args) != 1 {
+			return fmt.Errorf("invalid arguments in %s", ci)
+		}
+	case NameSetSubname:
+		if len(ci.Args) != 2 {
+			return fmt.Errorf("invalid arguments in %s", ci)
+		}
+		subname, ok := ci.Args[0].(string)
+		if !ok {
+			return fmt.Errorf("invalid arguments in %s", ci)
+		}
+		if _, _, ok := ParseSubname(subname); !ok {
+			return fmt.Errorf("invalid subname %s", subname)
+		}
+		to, ok := ci.Args[1].(string)
+		if !ok {
+			return fmt.Errorf("invalid arguments in %s", ci)
+		}
+		// an empty destination removes the subname
+		if to != "" {
+			if addr, err := DecodeAddress(to); err != nil || len(addr) != AddressLength {
+				return fmt.Errorf("invalid receiver in %s", ci)
+			}
+		}
+		if len(tx.GetAmount()) != 0 {
+			return ErrTxInvalidAmount
+		}
+	case NameSetPrimary:
+		if len(ci.Args) != 1 {
+			return fmt.Errorf("invalid arguments in %s", ci)
+		}
+		primary, ok := ci.Args[0].(string)
+		if !ok {
+			return fmt.Errorf("invalid arguments in %s", ci)
+		}
+		// an empty name removes the primary name
+		if _, _, isSubname := ParseSubname(primary); primary != "" && !isSubname {
+			if len(primary) != NameLength {
+				return fmt.Errorf("invalid name %s", primary)
+			}
+			if err := validateAllowedChar([]byte(primary)); err != nil {
+				return err
+			}
+		}
+		if len(tx.GetAmount()) != 0 {
+			return ErrTxInvalidAmount
+		}
 	case SetContractOwner:
 		owner, ok := ci.Args[0].(string)
 		if !ok {
@@ -306,9 +431,12 @@ func (tx *transaction) ValidateWithSenderState(senderState *State, gasPrice *big
 		return ErrTxNonceTooLow
 	}
 	amount := tx.GetBody().GetAmountBigInt()
+	if tx.GetBody().GetType() == TxType_BATCH {
+		amount = tx.GetBody().GetBatchAmount()
+	}
 	balance := senderState.GetBalanceBigInt()
 	switch tx.GetBody().GetType() {
-	case TxType_NORMAL, TxType_REDEPLOY, TxType_TRANSFER, TxType_CALL, TxType_DEPLOY:
+	case TxType_NORMAL, TxType_REDEPLOY, TxType_TRANSFER, TxType_CALL, TxType_DEPLOY, TxType_MULTISIG, TxType_BATCH:
 		fee, err := tx.GetMaxFee(new(big.Int).Sub(balance, amount), gasPrice, version)
 		if err != nil {
 			return err
@@ -397,7 +525,11 @@ func (tx *transaction) GetMaxFee(balance, gasPrice *big.Int, version int32) (*bi
 	if fee.IsZeroFee() {
 		return fee.NewZeroFee(), nil
 	}
-	if version >= 2 {
+	if FeatureBaseFee.ActiveAt(version) {
+		// the tip is paid for every gas in addition to the base fee
+		gasPrice = new(big.Int).Add(gasPrice, tx.GetBody().GetGasTipBigInt())
+	}
+	if FeatureGasFee.ActiveAt(version) {
 		minGasLimit := fee.TxGas(len(tx.GetBody().GetPayload()))
 		gasLimit := tx.GetBody().GasLimit
 		if gasLimit == 0 {
@@ -36,6 +36,16 @@ const (
 	Opstake
 	// Opunstake represents a unstaking tranaction.
 	Opunstake
+	// OpsubmitEvidence represents a transaction submitting an evidence of double signing by a block producer.
+	OpsubmitEvidence
+	// Opwithdraw represents a transaction claiming the unstaked amounts whose unbonding period has passed.
+	Opwithdraw
+	// OpclaimReward represents a transaction claiming the accrued voting reward.
+	OpclaimReward
+	// Opdelegate represents a transaction delegating the voting power of the sender to another account.
+	Opdelegate
+	// Opundelegate represents a transaction revoking the delegation of the voting power.
+	Opundelegate
 	// OpSysTxMax is the maximum of system tx OP numbers.
 	OpSysTxMax
 